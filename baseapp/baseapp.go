@@ -1,16 +1,18 @@
 package baseapp
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
 	"runtime/debug"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"errors"
 
-	"github.com/gogo/protobuf/proto"
-
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -18,13 +20,11 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/version"
 )
 
-// Key to store the consensus params in the main store.
-var mainConsensusParamsKey = []byte("consensus_params")
-
 // Enum mode for app.runTx
 type runTxMode uint8
 
@@ -35,11 +35,33 @@ const (
 	runTxModeSimulate runTxMode = iota
 	// Deliver a transaction
 	runTxModeDeliver runTxMode = iota
+	// Recheck a transaction left in the mempool after a block
+	runTxModeReCheck runTxMode = iota
 
 	// MainStoreKey is the string representation of the main store
 	MainStoreKey = "main"
+
+	// maxCheckedTxs is the maximum number of txs tracked as passing CheckTx,
+	// a bit larger than the default Tendermint mempool size.
+	maxCheckedTxs = 10000
 )
 
+// String returns the name of the mode, used as a label of the metrics.
+func (mode runTxMode) String() string {
+	switch mode {
+	case runTxModeCheck:
+		return "check"
+	case runTxModeSimulate:
+		return "simulate"
+	case runTxModeDeliver:
+		return "deliver"
+	case runTxModeReCheck:
+		return "recheck"
+	default:
+		return "unknown"
+	}
+}
+
 // BaseApp reflects the ABCI application implementation.
 type BaseApp struct {
 	// initialized on creation
@@ -48,19 +70,26 @@ type BaseApp struct {
 	db          dbm.DB               // common DB backend
 	cms         sdk.CommitMultiStore // Main (uncached) state
 	router      Router               // handle any kind of message
+	msgRouter   MsgRouter            // handle messages by concrete type
 	queryRouter QueryRouter          // router for redirecting query calls
 	txDecoder   sdk.TxDecoder        // unmarshal []byte into sdk.Tx
+	storeLoader StoreLoader          // loads the latest version of cms
+	storeDBs    map[string]dbm.DB    // DBs of the stores kept apart from db, by store name
 
 	// set upon LoadVersion or LoadLatestVersion.
 	baseKey *sdk.KVStoreKey // Main KVStore in cms
 
-	anteHandler    sdk.AnteHandler  // ante handler for fee and auth
-	initChainer    sdk.InitChainer  // initialize state with validators and state blob
-	beginBlocker   sdk.BeginBlocker // logic to run before any txs
-	endBlocker     sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
-	addrPeerFilter sdk.PeerFilter   // filter peers by address and port
-	idPeerFilter   sdk.PeerFilter   // filter peers by node ID
-	fauxMerkleMode bool             // if true, IAVL MountStores uses MountStoresDB for simulation speed.
+	anteHandler    sdk.AnteHandler   // ante handler for fee and auth
+	postHandler    sdk.PostHandler   // post handler run after the messages of a tx
+	msgAuthorizer  sdk.MsgAuthorizer // authorizes messages executed on behalf of other accounts
+	initChainer    sdk.InitChainer   // initialize state with validators and state blob
+	beginBlocker   sdk.BeginBlocker  // logic to run before any txs
+	endBlocker     sdk.EndBlocker    // logic to run after all txs, and to determine valset changes
+	gasScheduler   sdk.GasScheduler  // gas schedule of the stores, read at the start of each block
+	paramStore     ParamStore        // stores the consensus params, changeable by governance
+	addrPeerFilter sdk.PeerFilter    // filter peers by address and port
+	idPeerFilter   sdk.PeerFilter    // filter peers by node ID
+	fauxMerkleMode bool              // if true, IAVL MountStores uses MountStoresDB for simulation speed.
 
 	// --------------------
 	// Volatile state
@@ -70,15 +99,32 @@ type BaseApp struct {
 	checkState   *state          // for CheckTx
 	deliverState *state          // for DeliverTx
 	voteInfos    []abci.VoteInfo // absent validators from begin block
-
-	// consensus params
-	// TODO: Move this in the future to baseapp param store on main store.
+	txIndex      int64           // index in the block of the next delivered tx
+
+	// hashes of the txs which passed CheckTx since the last commit, and of the
+	// txs which passed it before and were not rechecked nor delivered yet. As
+	// Tendermint rechecks the txs left in its mempool after every block, a
+	// CheckTx of one of the latter is a recheck. The txs evicted from the
+	// mempool are not rechecked, so they are forgotten at the next commit.
+	checkedTxs map[string]struct{}
+	recheckTxs map[string]struct{}
+
+	// consensus params, memoized from the param store on load and at the
+	// start of each block
 	consensusParams *abci.ConsensusParams
 
 	// The minimum gas prices a validator is willing to accept for processing a
 	// transaction. This is mainly used for DoS and spam prevention.
 	minGasPrices sdk.DecCoins
 
+	// The gas limit of each custom query, or 0 for no limit.
+	queryGasLimit uint64
+
+	// header of the last committed block, read by the custom queries which
+	// are served concurrently with the other ABCI calls
+	queryMtx         sync.RWMutex
+	lastCommitHeader abci.Header
+
 	// flag for sealing options and parameters to a BaseApp
 	sealed bool
 }
@@ -100,8 +146,12 @@ func NewBaseApp(
 		db:             db,
 		cms:            store.NewCommitMultiStore(db),
 		router:         NewRouter(),
+		msgRouter:      sdk.NewMsgRouter(),
 		queryRouter:    NewQueryRouter(),
 		txDecoder:      txDecoder,
+		storeLoader:    DefaultStoreLoader,
+		checkedTxs:     make(map[string]struct{}),
+		recheckTxs:     make(map[string]struct{}),
 		fauxMerkleMode: false,
 	}
 	for _, option := range options {
@@ -155,15 +205,44 @@ func (app *BaseApp) MountStoreWithDB(key sdk.StoreKey, typ sdk.StoreType, db dbm
 }
 
 // MountStore mounts a store to the provided key in the BaseApp multistore,
-// using the default DB.
+// using the default DB unless the store has its own DB.
 func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
-	app.cms.MountStoreWithDB(key, typ, nil)
+	app.cms.MountStoreWithDB(key, typ, app.storeDBs[key.Name()])
+}
+
+// StoreLoader loads the latest version of a multistore, possibly upgrading its
+// set of stores.
+type StoreLoader func(ms sdk.CommitMultiStore) error
+
+// DefaultStoreLoader loads the latest version of the multistore as is.
+func DefaultStoreLoader(ms sdk.CommitMultiStore) error {
+	return ms.LoadLatestVersion()
+}
+
+// UpgradeStoreLoader returns a StoreLoader applying the given store upgrades
+// when the upgrade height is the next height to be committed, so that the
+// stores of the new binary can be loaded at the upgrade height.
+func UpgradeStoreLoader(upgradeHeight int64, upgrades *sdk.StoreUpgrades) StoreLoader {
+	return func(ms sdk.CommitMultiStore) error {
+		if ms.LatestVersion()+1 != upgradeHeight {
+			return ms.LoadLatestVersion()
+		}
+		return ms.LoadLatestVersionAndUpgrade(upgrades)
+	}
 }
 
-// LoadLatestVersion loads the latest application version. It will panic if
-// called more than once on a running BaseApp.
+// AddListeners registers listeners notified of the state changes committed
+// to the store with the given key by DeliverTx, BeginBlock, EndBlock and
+// InitChain.
+func (app *BaseApp) AddListeners(key sdk.StoreKey, listeners ...sdk.WriteListener) {
+	app.cms.AddListeners(key, listeners...)
+}
+
+// LoadLatestVersion loads the latest application version with the store
+// loader of the BaseApp. It will panic if called more than once on a running
+// BaseApp.
 func (app *BaseApp) LoadLatestVersion(baseKey *sdk.KVStoreKey) error {
-	err := app.cms.LoadLatestVersion()
+	err := app.storeLoader(app.cms)
 	if err != nil {
 		return err
 	}
@@ -203,24 +282,26 @@ func (app *BaseApp) initFromMainStore(baseKey *sdk.KVStoreKey) error {
 	}
 	app.baseKey = baseKey
 
-	// Load the consensus params from the main store. If the consensus params are
-	// nil, it will be saved later during InitChain.
-	//
-	// TODO: assert that InitChain hasn't yet been called.
-	consensusParamsBz := mainStore.Get(mainConsensusParamsKey)
-	if consensusParamsBz != nil {
-		var consensusParams = &abci.ConsensusParams{}
-
-		err := proto.Unmarshal(consensusParamsBz, consensusParams)
-		if err != nil {
-			panic(err)
-		}
-
-		app.setConsensusParams(consensusParams)
+	// Load the consensus params from the param store. If the consensus params
+	// are not set yet, they will be stored during InitChain.
+	if app.paramStore != nil {
+		ctx := sdk.NewContext(app.cms.CacheMultiStore(), abci.Header{}, false, app.logger)
+		app.setConsensusParams(app.GetConsensusParams(ctx))
 	}
 
 	// needed for `gaiad export`, which inits from store but never calls initchain
 	app.setCheckState(abci.Header{})
+
+	// reload the header of the last committed block for the queries, which
+	// only holds its height if it was committed before the headers were
+	// persisted
+	header := abci.Header{Height: app.LastBlockHeight()}
+	if header.Height > 0 {
+		if committed, err := app.loadCommitHeader(header.Height); err == nil {
+			header = committed
+		}
+	}
+	app.setLastCommitHeader(header)
 	app.Seal()
 
 	return nil
@@ -230,6 +311,52 @@ func (app *BaseApp) setMinGasPrices(gasPrices sdk.DecCoins) {
 	app.minGasPrices = gasPrices
 }
 
+func (app *BaseApp) setQueryGasLimit(gasLimit uint64) {
+	app.queryGasLimit = gasLimit
+}
+
+// setLastCommitHeader sets the header of the last committed block.
+func (app *BaseApp) setLastCommitHeader(header abci.Header) {
+	app.queryMtx.Lock()
+	defer app.queryMtx.Unlock()
+	app.lastCommitHeader = header
+}
+
+// getLastCommitHeader returns the header of the last committed block.
+func (app *BaseApp) getLastCommitHeader() abci.Header {
+	app.queryMtx.RLock()
+	defer app.queryMtx.RUnlock()
+	return app.lastCommitHeader
+}
+
+// commitHeaderKey returns the key of the header of the block committed at the
+// given height in the common DB, which doesn't overlap the keys of the stores.
+func commitHeaderKey(height int64) []byte {
+	return []byte(fmt.Sprintf("baseapp/header/%d", height))
+}
+
+// saveCommitHeader persists the header of a block being committed, so that
+// the queries at its height read the same header after a restart.
+func (app *BaseApp) saveCommitHeader(header abci.Header) error {
+	bz, err := header.Marshal()
+	if err != nil {
+		return err
+	}
+	app.db.Set(commitHeaderKey(header.Height), bz)
+	return nil
+}
+
+// loadCommitHeader returns the persisted header of the block committed at the
+// given height.
+func (app *BaseApp) loadCommitHeader(height int64) (header abci.Header, err error) {
+	bz := app.db.Get(commitHeaderKey(height))
+	if bz == nil {
+		return header, fmt.Errorf("no header persisted at height %d", height)
+	}
+	err = header.Unmarshal(bz)
+	return header, err
+}
+
 // Router returns the router of the BaseApp.
 func (app *BaseApp) Router() Router {
 	if app.sealed {
@@ -240,6 +367,15 @@ func (app *BaseApp) Router() Router {
 	return app.router
 }
 
+// MsgRouter returns the router of the BaseApp for the messages routed by
+// concrete type, which take precedence over the routes of the Router.
+func (app *BaseApp) MsgRouter() MsgRouter {
+	if app.sealed {
+		panic("MsgRouter() on sealed BaseApp")
+	}
+	return app.msgRouter
+}
+
 // QueryRouter returns the QueryRouter of a BaseApp.
 func (app *BaseApp) QueryRouter() QueryRouter { return app.queryRouter }
 
@@ -258,6 +394,7 @@ func (app *BaseApp) setCheckState(header abci.Header) {
 		ms:  ms,
 		ctx: sdk.NewContext(ms, header, true, app.logger).WithMinGasPrices(app.minGasPrices),
 	}
+	app.checkState.ctx = app.withGasSchedule(app.checkState.ctx)
 }
 
 // setCheckState sets checkState with the cached multistore and
@@ -272,21 +409,20 @@ func (app *BaseApp) setDeliverState(header abci.Header) {
 	}
 }
 
+// withGasSchedule returns the context charging the gas schedule read from its
+// state, if the app has a gas scheduler.
+func (app *BaseApp) withGasSchedule(ctx sdk.Context) sdk.Context {
+	if app.gasScheduler == nil {
+		return ctx
+	}
+	return ctx.WithGasSchedule(app.gasScheduler(ctx))
+}
+
 // setConsensusParams memoizes the consensus params.
 func (app *BaseApp) setConsensusParams(consensusParams *abci.ConsensusParams) {
 	app.consensusParams = consensusParams
 }
 
-// setConsensusParams stores the consensus params to the main store.
-func (app *BaseApp) storeConsensusParams(consensusParams *abci.ConsensusParams) {
-	consensusParamsBz, err := proto.Marshal(consensusParams)
-	if err != nil {
-		panic(err)
-	}
-	mainStore := app.cms.GetKVStore(app.baseKey)
-	mainStore.Set(mainConsensusParamsKey, consensusParamsBz)
-}
-
 // getMaximumBlockGas gets the maximum gas from the consensus params. It panics
 // if maximum block gas is less than negative one and returns zero if negative
 // one.
@@ -331,18 +467,18 @@ func (app *BaseApp) SetOption(req abci.RequestSetOption) (res abci.ResponseSetOp
 // InitChain implements the ABCI interface. It runs the initialization logic
 // directly on the CommitMultiStore.
 func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitChain) {
-	// stash the consensus params in the cms main store and memoize
-	if req.ConsensusParams != nil {
-		app.setConsensusParams(req.ConsensusParams)
-		app.storeConsensusParams(req.ConsensusParams)
-	}
-
 	initHeader := abci.Header{ChainID: req.ChainId, Time: req.Time}
 
 	// initialize the deliver state and check state with a correct header
 	app.setDeliverState(initHeader)
 	app.setCheckState(initHeader)
 
+	// store the consensus params in the param store and memoize
+	if req.ConsensusParams != nil {
+		app.setConsensusParams(req.ConsensusParams)
+		app.StoreConsensusParams(app.deliverState.ctx, req.ConsensusParams)
+	}
+
 	if app.initChainer == nil {
 		return
 	}
@@ -423,10 +559,30 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 			tx, err := app.txDecoder(txBytes)
 			if err != nil {
 				result = err.Result()
+			} else if len(path) >= 3 && path[2] == "diff" {
+				// "/app/simulate/diff" also returns the state diff of the tx
+				return abci.ResponseQuery{
+					Code:      uint32(sdk.CodeOK),
+					Codespace: string(sdk.CodespaceRoot),
+					Value:     codec.Cdc.MustMarshalBinaryLengthPrefixed(app.SimulateWithDiff(txBytes, tx)),
+				}
 			} else {
 				result = app.Simulate(txBytes, tx)
 			}
 
+		case "msgs":
+			// the messages routed by concrete type, with their JSON schema
+			value, err := json.Marshal(app.msgRouter.Msgs())
+			if err != nil {
+				result = sdk.ErrInternal(err.Error()).Result()
+				break
+			}
+			return abci.ResponseQuery{
+				Code:      uint32(sdk.CodeOK),
+				Codespace: string(sdk.CodespaceRoot),
+				Value:     value,
+			}
+
 		case "version":
 			return abci.ResponseQuery{
 				Code:      uint32(sdk.CodeOK),
@@ -446,7 +602,7 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 		}
 	}
 
-	msg := "Expected second parameter to be either simulate or version, neither was present"
+	msg := "Expected second parameter to be one of simulate, msgs or version, none was present"
 	return sdk.ErrUnknownRequest(msg).QueryResult()
 }
 
@@ -499,10 +655,27 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 		return sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", path[1])).QueryResult()
 	}
 
-	// cache wrap the commit-multistore for safety
-	ctx := sdk.NewContext(
-		app.cms.CacheMultiStore(), app.checkState.ctx.BlockHeader(), true, app.logger,
-	).WithMinGasPrices(app.minGasPrices)
+	ctx, release, err := app.createQueryContext(req.Height)
+	if err != nil {
+		return err.QueryResult()
+	}
+	defer release()
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				msg := fmt.Sprintf(
+					"query out of gas in location: %v; gasLimit: %d, gasUsed: %d",
+					rType.Descriptor, ctx.GasMeter().Limit(), ctx.GasMeter().GasConsumed(),
+				)
+				res = sdk.ErrOutOfGas(msg).QueryResult()
+			default:
+				msg := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
+				res = sdk.ErrInternal(msg).QueryResult()
+			}
+		}
+	}()
 
 	// Passes the rest of the path as an argument to the querier.
 	//
@@ -523,6 +696,74 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 	}
 }
 
+// createQueryContext returns the context of a custom query at the given
+// height, or at the last committed height if it is 0. The context reads an
+// immutable snapshot of the committed state, so that queries can be served
+// concurrently with the other ABCI calls, and its gas meter is limited by the
+// query gas limit of the app. The returned function must be called once the
+// query is done, as the version read is not pruned until then.
+func (app *BaseApp) createQueryContext(height int64) (sdk.Context, func(), sdk.Error) {
+	header := app.getLastCommitHeader()
+	if header.Height == 0 {
+		if height > 0 {
+			return sdk.Context{}, nil, sdk.ErrUnknownRequest(
+				fmt.Sprintf("cannot query height %d, no height is committed", height),
+			)
+		}
+
+		// nothing is committed yet, e.g. before the first block, so the check
+		// state is read, which is safe as IsConcurrentQuery returns false
+		ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.checkState.ctx.BlockHeader(), true, app.logger)
+		return app.withQueryLimits(ctx), func() {}, nil
+	}
+	if height > header.Height {
+		return sdk.Context{}, nil, sdk.ErrUnknownRequest(
+			fmt.Sprintf("cannot query height %d, the last committed height is %d", height, header.Height),
+		)
+	}
+	if height > 0 && height < header.Height {
+		var err error
+		header, err = app.loadCommitHeader(height)
+		if err != nil {
+			return sdk.Context{}, nil, sdk.ErrUnknownRequest(
+				fmt.Sprintf("failed to load header at height %d: %v", height, err),
+			)
+		}
+	}
+
+	// the version is pinned before it is loaded, as the commits prune the
+	// old versions concurrently with the query
+	unpin := app.cms.PinVersion(header.Height)
+	cacheMS, err := app.cms.CacheMultiStoreWithVersion(header.Height)
+	if err != nil {
+		unpin()
+		return sdk.Context{}, nil, sdk.ErrUnknownRequest(
+			fmt.Sprintf("failed to load state at height %d: %v", header.Height, err),
+		)
+	}
+
+	ctx := sdk.NewContext(cacheMS, header, true, app.logger)
+	return app.withQueryLimits(ctx), unpin, nil
+}
+
+// withQueryLimits sets the minimum gas prices, the gas schedule and the query
+// gas limit of the app on the context of a query.
+func (app *BaseApp) withQueryLimits(ctx sdk.Context) sdk.Context {
+	ctx = app.withGasSchedule(ctx.WithMinGasPrices(app.minGasPrices))
+	if app.queryGasLimit > 0 {
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(app.queryGasLimit))
+	}
+	return ctx
+}
+
+// IsConcurrentQuery returns true if the query can be served concurrently with
+// the other ABCI calls, which is the case of the custom queries as they read
+// an immutable snapshot of the committed state once a block is committed.
+func (app *BaseApp) IsConcurrentQuery(req abci.RequestQuery) bool {
+	path := splitPath(req.Path)
+	return len(path) > 0 && path[0] == "custom" && app.getLastCommitHeader().Height > 0
+}
+
 func (app *BaseApp) validateHeight(req abci.RequestBeginBlock) error {
 	if req.Header.Height < 1 {
 		return fmt.Errorf("invalid height: %d", req.Header.Height)
@@ -561,6 +802,11 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 			WithBlockHeight(req.Header.Height)
 	}
 
+	// the consensus params changed in the previous block apply from this block
+	if app.paramStore != nil {
+		app.setConsensusParams(app.GetConsensusParams(app.deliverState.ctx))
+	}
+
 	// add block gas meter
 	var gasMeter sdk.GasMeter
 	if maxGas := app.getMaximumBlockGas(); maxGas > 0 {
@@ -569,7 +815,8 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		gasMeter = sdk.NewInfiniteGasMeter()
 	}
 
-	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(gasMeter)
+	app.deliverState.ctx = app.withGasSchedule(app.deliverState.ctx.WithBlockGasMeter(gasMeter))
+	app.txIndex = 0
 
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.deliverState.ctx, req)
@@ -585,15 +832,43 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 // the ante handler (which checks signatures/fees/ValidateBasic), then finally
 // the route match to see whether a handler exists.
 //
+// The priority and the sender of a passing transaction, as set by the ante
+// handler, are returned in the tags of a tx event, since the ResponseCheckTx of
+// Tendermint has no fields for them yet.
+//
 // NOTE:CheckTx does not run the actual Msg handler function(s).
 func (app *BaseApp) CheckTx(txBytes []byte) (res abci.ResponseCheckTx) {
 	var result sdk.Result
 
+	txHash := string(tmhash.Sum(txBytes))
+	mode := runTxModeCheck
+	if _, ok := app.recheckTxs[txHash]; ok {
+		mode = runTxModeReCheck
+	}
+
 	tx, err := app.txDecoder(txBytes)
 	if err != nil {
 		result = err.Result()
 	} else {
-		result = app.runTx(runTxModeCheck, txBytes, tx)
+		result = app.runTx(mode, txBytes, tx)
+	}
+
+	if result.IsOK() {
+		if len(app.checkedTxs) < maxCheckedTxs {
+			app.checkedTxs[txHash] = struct{}{}
+		}
+
+		attrs := []sdk.Attribute{
+			sdk.NewAttribute(sdk.AttributeKeyPriority, strconv.FormatInt(result.Priority, 10)),
+		}
+		if result.Sender != "" {
+			attrs = append(attrs, sdk.NewAttribute(sdk.AttributeKeySender, result.Sender))
+		}
+		result.Events = result.Events.AppendEvent(sdk.NewEvent(sdk.EventTypeTx, attrs...))
+	} else {
+		// Tendermint removes failing txs from its mempool
+		delete(app.checkedTxs, txHash)
+		delete(app.recheckTxs, txHash)
 	}
 
 	return abci.ResponseCheckTx{
@@ -602,7 +877,7 @@ func (app *BaseApp) CheckTx(txBytes []byte) (res abci.ResponseCheckTx) {
 		Log:       result.Log,
 		GasWanted: int64(result.GasWanted), // TODO: Should type accept unsigned ints?
 		GasUsed:   int64(result.GasUsed),   // TODO: Should type accept unsigned ints?
-		Tags:      result.Tags,
+		Tags:      result.Events.ToTags(),
 	}
 }
 
@@ -610,6 +885,16 @@ func (app *BaseApp) CheckTx(txBytes []byte) (res abci.ResponseCheckTx) {
 func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
 	var result sdk.Result
 
+	// tag the state changes of the tx for the store listeners
+	app.cms.SetListeningTxIndex(app.txIndex)
+	defer app.cms.SetListeningTxIndex(-1)
+	app.txIndex++
+
+	// the tx leaves the mempool once included in a block
+	txHash := string(tmhash.Sum(txBytes))
+	delete(app.checkedTxs, txHash)
+	delete(app.recheckTxs, txHash)
+
 	tx, err := app.txDecoder(txBytes)
 	if err != nil {
 		result = err.Result()
@@ -624,7 +909,7 @@ func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
 		Log:       result.Log,
 		GasWanted: int64(result.GasWanted), // TODO: Should type accept unsigned ints?
 		GasUsed:   int64(result.GasUsed),   // TODO: Should type accept unsigned ints?
-		Tags:      result.Tags,
+		Tags:      result.Events.ToTags(),
 	}
 }
 
@@ -650,9 +935,14 @@ func (app *BaseApp) getContextForTx(mode runTxMode, txBytes []byte) (ctx sdk.Con
 	ctx = app.getState(mode).ctx.
 		WithTxBytes(txBytes).
 		WithVoteInfos(app.voteInfos).
-		WithConsensusParams(app.consensusParams)
+		WithConsensusParams(app.consensusParams).
+		WithEventManager(sdk.NewEventManager())
+
+	switch mode {
+	case runTxModeReCheck:
+		ctx = ctx.WithIsReCheckTx(true)
 
-	if mode == runTxModeSimulate {
+	case runTxModeSimulate:
 		ctx, _ = ctx.CacheContext()
 	}
 
@@ -663,34 +953,49 @@ func (app *BaseApp) getContextForTx(mode runTxMode, txBytes []byte) (ctx sdk.Con
 func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (result sdk.Result) {
 	idxLogs := make([]sdk.ABCIMessageLog, 0, len(msgs)) // a list of JSON-encoded logs with msg index
 
-	var data []byte   // NOTE: we just append them all (?!)
-	var tags sdk.Tags // also just append them all
+	var data []byte // NOTE: we just append them all (?!)
+	events := sdk.EmptyEvents()
 	var code sdk.CodeType
 	var codespace sdk.CodespaceType
 
 	for msgIdx, msg := range msgs {
-		// match message route
-		msgRoute := msg.Route()
-		handler := app.router.Route(msgRoute)
-		if handler == nil {
-			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgRoute).Result()
-		}
-
 		var msgResult sdk.Result
 
-		// skip actual execution for CheckTx mode
-		if mode != runTxModeCheck {
-			msgResult = handler(ctx, msg)
+		// each message gets its own event manager, so that its events can be
+		// told apart from the events of the other messages
+		msgCtx := ctx.WithEventManager(sdk.NewEventManager())
+		startingGas := ctx.GasMeter().GasConsumed()
+
+		if execMsg, ok := msg.(sdk.ExecMsg); ok {
+			// skip actual execution for CheckTx mode
+			if mode != runTxModeCheck {
+				msgResult = app.runExecMsg(msgCtx, execMsg)
+			}
+		} else {
+			handler := app.msgHandler(msg)
+			if handler == nil {
+				return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msg.Route()).Result()
+			}
+
+			// skip actual execution for CheckTx mode
+			if mode != runTxModeCheck {
+				msgResult = handler(msgCtx, msg)
+			}
 		}
 
 		// NOTE: GasWanted is determined by ante handler and GasUsed by the GasMeter.
 
 		// Result.Data must be length prefixed in order to separate each result
 		data = append(data, msgResult.Data...)
-		tags = append(tags, sdk.MakeTag(sdk.TagAction, msg.Type()))
-		tags = append(tags, msgResult.Tags...)
 
-		idxLog := sdk.ABCIMessageLog{MsgIndex: msgIdx, Log: msgResult.Log}
+		// each message is prefixed with a message event holding its action
+		msgEvents := sdk.Events{
+			sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyAction, msg.Type())),
+		}
+		msgEvents = msgEvents.AppendEvents(msgResult.Events)
+		events = events.AppendEvents(msgEvents)
+
+		idxLog := sdk.ABCIMessageLog{MsgIndex: msgIdx, Log: msgResult.Log, Events: msgEvents}
 
 		// stop execution and return on first failed message
 		if !msgResult.IsOK() {
@@ -704,6 +1009,12 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 
 		idxLog.Success = true
 		idxLogs = append(idxLogs, idxLog)
+
+		if mode == runTxModeDeliver {
+			metrics := telemetry.Default()
+			metrics.Msgs.With("route", msg.Route(), "type", msg.Type()).Add(1)
+			metrics.MsgGasUsed.With("route", msg.Route()).Add(float64(ctx.GasMeter().GasConsumed() - startingGas))
+		}
 	}
 
 	logJSON := codec.Cdc.MustMarshalJSON(idxLogs)
@@ -713,16 +1024,68 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 		Data:      data,
 		Log:       strings.TrimSpace(string(logJSON)),
 		GasUsed:   ctx.GasMeter().GasConsumed(),
-		Tags:      tags,
+		Events:    events,
 	}
 
 	return result
 }
 
+// msgHandler returns the handler of the concrete type of msg, or else the
+// handler of its route.
+func (app *BaseApp) msgHandler(msg sdk.Msg) sdk.Handler {
+	if handler := app.msgRouter.MsgHandler(msg); handler != nil {
+		return handler
+	}
+	return app.router.Route(msg.Route())
+}
+
+// runExecMsg dispatches the messages wrapped by msg to their handlers on behalf
+// of their signers, once the msgAuthorizer has checked that each signer
+// authorized the signer of msg to do so.
+func (app *BaseApp) runExecMsg(ctx sdk.Context, msg sdk.ExecMsg) sdk.Result {
+	if app.msgAuthorizer == nil {
+		return sdk.ErrUnauthorized("executing messages on behalf of other accounts is not supported").Result()
+	}
+
+	var data []byte
+	events := sdk.EmptyEvents()
+
+	grantee := msg.GetSigners()[0]
+	for _, execMsg := range msg.GetExecMsgs() {
+		handler := app.msgHandler(execMsg)
+		if handler == nil {
+			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + execMsg.Route()).Result()
+		}
+
+		for _, granter := range execMsg.GetSigners() {
+			if granter.Equals(grantee) {
+				continue
+			}
+
+			if err := app.msgAuthorizer(ctx, granter, grantee, execMsg); err != nil {
+				return err.Result()
+			}
+		}
+
+		msgResult := handler(ctx.WithEventManager(sdk.NewEventManager()), execMsg)
+		if !msgResult.IsOK() {
+			return msgResult
+		}
+
+		data = append(data, msgResult.Data...)
+		events = events.AppendEvent(
+			sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyAction, execMsg.Type())),
+		)
+		events = events.AppendEvents(msgResult.Events)
+	}
+
+	return sdk.Result{Data: data, Events: events}
+}
+
 // Returns the applications's deliverState if app is in runTxModeDeliver,
 // otherwise it returns the application's checkstate.
 func (app *BaseApp) getState(mode runTxMode) *state {
-	if mode == runTxModeCheck || mode == runTxModeSimulate {
+	if mode == runTxModeCheck || mode == runTxModeReCheck || mode == runTxModeSimulate {
 		return app.checkState
 	}
 
@@ -755,12 +1118,23 @@ func (app *BaseApp) cacheTxContext(ctx sdk.Context, txBytes []byte) (
 // further details on transaction execution, reference the BaseApp SDK
 // documentation.
 func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk.Result) {
+	return app.runTxWithContext(app.getContextForTx(mode, txBytes), mode, txBytes, tx)
+}
+
+// runTxWithContext processes a transaction in the given context, as returned
+// by getContextForTx for the mode.
+func (app *BaseApp) runTxWithContext(ctx sdk.Context, mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 	// NOTE: GasWanted should be returned by the AnteHandler. GasUsed is
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter so we initialize upfront.
 	var gasWanted uint64
 
-	ctx := app.getContextForTx(mode, txBytes)
+	// the mempool priority and the sender of the tx, set by the AnteHandler
+	var (
+		priority int64
+		sender   string
+	)
+
 	ms := ctx.MultiStore()
 
 	// only run the tx if there is block gas remaining
@@ -791,6 +1165,12 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 		result.GasWanted = gasWanted
 		result.GasUsed = ctx.GasMeter().GasConsumed()
+
+		txResult := "ok"
+		if !result.IsOK() {
+			txResult = "error"
+		}
+		telemetry.Default().Txs.With("mode", mode.String(), "result", txResult).Add(1)
 	}()
 
 	// If BlockGasMeter() panics it will be caught by the above recover and will
@@ -842,15 +1222,22 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		}
 
 		gasWanted = result.GasWanted
+		priority, sender = result.Priority, result.Sender
 
 		if abort {
+			telemetry.Default().AnteRejections.With(
+				"mode", mode.String(),
+				"codespace", string(result.Codespace),
+				"code", strconv.FormatUint(uint64(result.Code), 10),
+			).Add(1)
 			return result
 		}
 
 		msCache.Write()
 	}
 
-	if mode == runTxModeCheck {
+	if mode == runTxModeCheck || mode == runTxModeReCheck {
+		result.Priority, result.Sender = priority, sender
 		return
 	}
 
@@ -860,11 +1247,19 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	result = app.runMsgs(runMsgCtx, msgs, mode)
 	result.GasWanted = gasWanted
 
-	if mode == runTxModeSimulate {
-		return
+	// the post handler runs in the same cached state as the messages, so that
+	// their state changes are reverted if it fails
+	if result.IsOK() && app.postHandler != nil {
+		postCtx := runMsgCtx.WithEventManager(sdk.NewEventManager())
+		result = app.postHandler(postCtx, tx, mode == runTxModeSimulate, result)
+		result.GasWanted = gasWanted
+		if result.IsOK() {
+			result.Events = result.Events.AppendEvents(postCtx.EventManager().Events())
+		}
 	}
 
-	// only update state if all messages pass
+	// only update state if all messages pass, which for a simulation is a
+	// cache of the check state that is discarded
 	if result.IsOK() {
 		msCache.Write()
 	}
@@ -882,17 +1277,33 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 		res = app.endBlocker(app.deliverState.ctx, req)
 	}
 
+	// Tendermint applies the updated consensus params from the next block
+	if app.consensusParamsModified(app.deliverState.ctx) {
+		res.ConsensusParamUpdates = app.GetConsensusParams(app.deliverState.ctx)
+	}
+
 	return
 }
 
 // Commit implements the ABCI interface.
 func (app *BaseApp) Commit() (res abci.ResponseCommit) {
+	defer func(start time.Time) {
+		telemetry.Default().CommitDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	header := app.deliverState.ctx.BlockHeader()
 
+	// persist the header before the commit, so that a committed height
+	// always has its header
+	if err := app.saveCommitHeader(header); err != nil {
+		panic(err)
+	}
+
 	// write the Deliver state and commit the MultiStore
 	app.deliverState.ms.Write()
 	commitID := app.cms.Commit()
 	app.logger.Debug("Commit synced", "commit", fmt.Sprintf("%X", commitID))
+	app.setLastCommitHeader(header)
 
 	// Reset the Check state to the latest committed.
 	//
@@ -900,6 +1311,11 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// Use the header from this latest block.
 	app.setCheckState(header)
 
+	// the txs left in the mempool are rechecked after the commit, and the ones
+	// passing are tracked again
+	app.recheckTxs = app.checkedTxs
+	app.checkedTxs = make(map[string]struct{})
+
 	// empty/reset the deliver state
 	app.deliverState = nil
 
@@ -3,9 +3,12 @@ package baseapp
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"testing"
+	"time"
 
 	store "github.com/cosmos/cosmos-sdk/store/types"
 
@@ -18,6 +21,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 var (
@@ -34,9 +38,39 @@ func newBaseApp(name string, options ...func(*BaseApp)) *BaseApp {
 	db := dbm.NewMemDB()
 	codec := codec.New()
 	registerTestCodec(codec)
+	options = append([]func(*BaseApp){func(app *BaseApp) { app.SetParamStore(newParamStore()) }}, options...)
 	return NewBaseApp(name, logger, db, testTxDecoder(codec), options...)
 }
 
+// paramStore is an in-memory ParamStore, it records every parameter ever set
+// as modified.
+type paramStore struct {
+	params   map[string][]byte
+	modified map[string]bool
+}
+
+func newParamStore() *paramStore {
+	return &paramStore{params: make(map[string][]byte), modified: make(map[string]bool)}
+}
+
+func (ps *paramStore) Get(_ sdk.Context, key []byte, ptr interface{}) {
+	codec.Cdc.MustUnmarshalJSON(ps.params[string(key)], ptr)
+}
+
+func (ps *paramStore) Has(_ sdk.Context, key []byte) bool {
+	_, ok := ps.params[string(key)]
+	return ok
+}
+
+func (ps *paramStore) Set(_ sdk.Context, key []byte, param interface{}) {
+	ps.params[string(key)] = codec.Cdc.MustMarshalJSON(param)
+	ps.modified[string(key)] = true
+}
+
+func (ps *paramStore) Modified(_ sdk.Context, key []byte) bool {
+	return ps.modified[string(key)]
+}
+
 func registerTestCodec(cdc *codec.Codec) {
 	// register Tx, Msg
 	sdk.RegisterCodec(cdc)
@@ -130,6 +164,47 @@ func TestLoadVersion(t *testing.T) {
 	testLoadVersionHelper(t, app, int64(2), commitID2)
 }
 
+func TestLoadVersionWithUpgradeStoreLoader(t *testing.T) {
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	name := t.Name()
+	capKey := sdk.NewKVStoreKey(MainStoreKey)
+	newKey := sdk.NewKVStoreKey("new")
+
+	app := NewBaseApp(name, logger, db, nil)
+	app.MountStores(capKey)
+	require.NoError(t, app.LoadLatestVersion(capKey))
+	for height := int64(1); height <= 2; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.Commit()
+	}
+
+	upgrades := &sdk.StoreUpgrades{Added: []string{"new"}}
+
+	// the upgrades are only applied at the upgrade height
+	app = NewBaseApp(name, logger, db, nil)
+	app.SetStoreLoader(UpgradeStoreLoader(4, upgrades))
+	app.MountStores(capKey, newKey)
+	require.Error(t, app.LoadLatestVersion(capKey))
+
+	app = NewBaseApp(name, logger, db, nil)
+	app.SetStoreLoader(UpgradeStoreLoader(3, upgrades))
+	app.MountStores(capKey, newKey)
+	require.NoError(t, app.LoadLatestVersion(capKey))
+	require.Equal(t, int64(2), app.LastBlockHeight())
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	res := app.Commit()
+
+	// the new store is committed with the upgrade height
+	app = NewBaseApp(name, logger, db, nil)
+	app.SetStoreLoader(UpgradeStoreLoader(3, upgrades))
+	app.MountStores(capKey, newKey)
+	require.NoError(t, app.LoadLatestVersion(capKey))
+	testLoadVersionHelper(t, app, int64(3), sdk.CommitID{Version: 3, Hash: res.Data})
+	require.Equal(t, int64(3), app.cms.GetCommitStore(newKey).LastCommitID().Version)
+}
+
 func TestLoadVersionInvalid(t *testing.T) {
 	logger := log.NewNopLogger()
 	pruningOpt := SetPruning(store.PruneSyncable)
@@ -244,6 +319,9 @@ func TestBaseAppOptionSeal(t *testing.T) {
 	require.Panics(t, func() {
 		app.SetAnteHandler(nil)
 	})
+	require.Panics(t, func() {
+		app.SetMsgAuthorizer(nil)
+	})
 	require.Panics(t, func() {
 		app.SetAddrPeerFilter(nil)
 	})
@@ -496,6 +574,21 @@ func incrementingCounter(t *testing.T, store sdk.KVStore, counterKey []byte, cou
 	return
 }
 
+type recordingListener struct {
+	pairs   []sdk.StoreKVPair
+	commits []int64
+}
+
+func (l *recordingListener) OnWrite(pair sdk.StoreKVPair) error {
+	l.pairs = append(l.pairs, pair)
+	return nil
+}
+
+func (l *recordingListener) OnCommit(height int64) error {
+	l.commits = append(l.commits, height)
+	return nil
+}
+
 //---------------------------------------------------------------------
 // Tx processing - CheckTx, DeliverTx, SimulateTx.
 // These tests use the serialized tx as input, while most others will use the
@@ -550,6 +643,128 @@ func TestCheckTx(t *testing.T) {
 	require.Nil(t, storedBytes)
 }
 
+func TestCheckTxPriorityAndReCheck(t *testing.T) {
+	// the ante handler sets a priority from the tx counter and records the
+	// recheck mode of each counter
+	reChecked := make(map[int64]bool)
+	failAnte := false
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+			txTest := tx.(txTest)
+			if ctx.IsCheckTx() {
+				reChecked[txTest.Counter] = ctx.IsReCheckTx()
+			}
+
+			if failAnte {
+				return ctx, sdk.ErrInternal("ante handler failure").Result(), true
+			}
+			return ctx, sdk.Result{Priority: txTest.Counter * 10, Sender: "sender"}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+
+	txBytes := make([][]byte, 3)
+	for i := range txBytes {
+		txBytes[i] = cdc.MustMarshalBinaryLengthPrefixed(newTxCounter(int64(i), 0))
+	}
+
+	for i, tx := range txBytes {
+		res := app.CheckTx(tx)
+		require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+		require.False(t, reChecked[int64(i)])
+
+		tags := sdk.TagsToStringTags(res.Tags)
+		require.Contains(t, tags, sdk.StringTag{Key: "tx.priority", Value: fmt.Sprintf("%d", i*10)})
+		require.Contains(t, tags, sdk.StringTag{Key: "tx.sender", Value: "sender"})
+	}
+
+	// the first tx is included in the block
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.DeliverTx(txBytes[0])
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// the txs left in the mempool are rechecked
+	for i, tx := range txBytes[1:] {
+		res := app.CheckTx(tx)
+		require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+		require.True(t, reChecked[int64(i+1)])
+	}
+
+	// a delivered tx is not in the mempool anymore
+	require.True(t, app.CheckTx(txBytes[0]).IsOK())
+	require.False(t, reChecked[0])
+
+	// a tx failing its recheck is removed from the mempool
+	failAnte = true
+	require.False(t, app.CheckTx(txBytes[1]).IsOK())
+	require.True(t, reChecked[1])
+
+	failAnte = false
+	require.True(t, app.CheckTx(txBytes[1]).IsOK())
+	require.False(t, reChecked[1])
+}
+
+// Test that the txs evicted from the mempool or untracked once the tracked txs
+// are saturated are not checked as rechecks.
+func TestCheckTxReCheckSaturated(t *testing.T) {
+	reChecked := make(map[int64]bool)
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+			reChecked[tx.(txTest).Counter] = ctx.IsReCheckTx()
+			return ctx, sdk.Result{}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+
+	// one tx more than the tracked ones passes CheckTx
+	txBytes := make([][]byte, maxCheckedTxs+1)
+	for i := range txBytes {
+		txBytes[i] = cdc.MustMarshalBinaryLengthPrefixed(newTxCounter(int64(i), 0))
+		require.True(t, app.CheckTx(txBytes[i]).IsOK())
+		require.False(t, reChecked[int64(i)])
+	}
+	commitBlock := func(height int64) {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+
+	// only the first tx is left in the mempool, the untracked one is checked
+	// as a new tx
+	commitBlock(1)
+	require.True(t, app.CheckTx(txBytes[0]).IsOK())
+	require.True(t, reChecked[0])
+	require.True(t, app.CheckTx(txBytes[maxCheckedTxs]).IsOK())
+	require.False(t, reChecked[maxCheckedTxs])
+
+	// the evicted txs are checked as new txs once submitted again, and the
+	// tracked txs keep being rechecked
+	commitBlock(2)
+	require.True(t, app.CheckTx(txBytes[1]).IsOK())
+	require.False(t, reChecked[1])
+	require.True(t, app.CheckTx(txBytes[0]).IsOK())
+	require.True(t, reChecked[0])
+	require.True(t, app.CheckTx(txBytes[maxCheckedTxs]).IsOK())
+	require.True(t, reChecked[maxCheckedTxs])
+}
+
 // Test that successive DeliverTx can see each others' effects
 // on the store, both within and across blocks.
 func TestDeliverTx(t *testing.T) {
@@ -593,6 +808,52 @@ func TestDeliverTx(t *testing.T) {
 	}
 }
 
+// Test that the state changes of DeliverTx are reported to the store listeners
+// with the tx index, unlike the ones of CheckTx and of failed messages.
+func TestDeliverTxListeners(t *testing.T) {
+	anteKey := []byte("ante-key")
+	anteOpt := func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) }
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey))
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	listener := &recordingListener{}
+	app.AddListeners(capKey1, listener)
+	app.InitChain(abci.RequestInitChain{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	header := abci.Header{Height: 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+	require.True(t, app.CheckTx(txBytes).IsOK())
+	require.True(t, app.DeliverTx(txBytes).IsOK())
+
+	failingTx := &txTest{[]sdk.Msg{msgCounter{1, true}}, 1, false}
+	txBytes, err = codec.MarshalBinaryLengthPrefixed(failingTx)
+	require.NoError(t, err)
+	require.False(t, app.DeliverTx(txBytes).IsOK())
+
+	app.EndBlock(abci.RequestEndBlock{})
+	require.Empty(t, listener.pairs)
+	app.Commit()
+
+	// varint encodings of the counters 1 and 2
+	one, two := []byte{2}, []byte{4}
+	expected := []sdk.StoreKVPair{
+		{Height: 1, TxIndex: 0, StoreKey: capKey1.Name(), Key: anteKey, Value: one},
+		{Height: 1, TxIndex: 0, StoreKey: capKey1.Name(), Key: deliverKey, Value: one},
+		{Height: 1, TxIndex: 1, StoreKey: capKey1.Name(), Key: anteKey, Value: two},
+	}
+	require.Equal(t, expected, listener.pairs)
+	require.Equal(t, []int64{1}, listener.commits)
+}
+
 // Number of messages doesn't matter to CheckTx.
 func TestMultiMsgCheckTx(t *testing.T) {
 	// TODO: ensure we get the same results
@@ -630,6 +891,17 @@ func TestMultiMsgDeliverTx(t *testing.T) {
 	res := app.DeliverTx(txBytes)
 	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
 
+	// every message emits its own message event, flattened into the tags and
+	// kept apart in the logs
+	require.Equal(t, 3, len(res.Tags))
+	require.Equal(t, sdk.MakeTag("message.action", "counter1"), res.Tags[0])
+	var logs sdk.ABCIMessageLogs
+	require.NoError(t, codec.UnmarshalJSON([]byte(res.Log), &logs))
+	require.Len(t, logs, 3)
+	for _, msgLog := range logs {
+		require.Equal(t, sdk.Events{sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyAction, "counter1"))}, msgLog.Events)
+	}
+
 	store := app.deliverState.ctx.KVStore(capKey1)
 
 	// tx counter only incremented once
@@ -664,6 +936,51 @@ func TestMultiMsgDeliverTx(t *testing.T) {
 	require.Equal(t, int64(2), msgCounter2)
 }
 
+func TestMsgRouter(t *testing.T) {
+	deliverKey := []byte("deliver-key")
+	deliverKey2 := []byte("deliver-key2")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey))
+		bapp.Router().AddRoute(routeMsgCounter2, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return sdk.ErrInternal("routed by route").Result()
+		})
+		bapp.MsgRouter().AddMsgHandler(&msgCounter2{}, handlerMsgCounter(t, capKey1, deliverKey2))
+	}
+
+	app := setupBaseApp(t, routerOpt)
+	require.Panics(t, func() { app.MsgRouter() })
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	// the messages with a handler for their type aren't routed by route
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	tx := newTxCounter(0, 0)
+	tx.Msgs = append(tx.Msgs, msgCounter2{0})
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(tx)
+	require.NoError(t, err)
+	res := app.DeliverTx(txBytes)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+
+	store := app.deliverState.ctx.KVStore(capKey1)
+	require.Equal(t, int64(1), getIntFromStore(store, deliverKey))
+	require.Equal(t, int64(1), getIntFromStore(store, deliverKey2))
+
+	// the messages routed by type are listed with their schema
+	query := app.Query(abci.RequestQuery{Path: "/app/msgs"})
+	require.True(t, query.IsOK(), query.Log)
+	var msgs []sdk.MsgInfo
+	require.NoError(t, json.Unmarshal(query.Value, &msgs))
+	require.Len(t, msgs, 1)
+	require.Equal(t, routeMsgCounter2, msgs[0].Route)
+	require.Equal(t, "counter2", msgs[0].Type)
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {"Counter": {"type": "string", "format": "int64"}},
+		"required": ["Counter"]
+	}`, string(msgs[0].Schema))
+}
+
 // Interleave calls to Check and Deliver and ensure
 // that there is no cross-talk. Check sees results of the previous Check calls
 // and Deliver sees that of the previous Deliver calls, but they don't see eachother.
@@ -737,6 +1054,73 @@ func TestSimulateTx(t *testing.T) {
 	}
 }
 
+// Simulate a transaction and check the state diff and the events it returns.
+func TestSimulateTxWithDiff(t *testing.T) {
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			ctx.KVStore(capKey1).Set([]byte("ante"), []byte("fee"))
+			return
+		})
+	}
+
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			store := ctx.KVStore(capKey2)
+			store.Set([]byte("msg"), []byte("first"))
+			store.Set([]byte("msg"), []byte("second"))
+			store.Delete([]byte("genesis"))
+			ctx.KVStore(capKey1).Set([]byte("ante"), []byte("refund"))
+			ctx.EventManager().EmitEvent(sdk.NewEvent("counter", sdk.NewAttribute("key", "msg")))
+			return sdk.Result{Events: ctx.EventManager().Events()}
+		})
+	}
+
+	initOpt := func(bapp *BaseApp) {
+		bapp.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+			ctx.KVStore(capKey2).Set([]byte("genesis"), []byte("value"))
+			return abci.ResponseInitChain{}
+		})
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt, initOpt)
+	app.InitChain(abci.RequestInitChain{})
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	tx := newTxCounter(0, 0)
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	require.NoError(t, err)
+
+	expected := []sdk.StoreDiff{
+		{StoreKey: capKey1.Name(), Key: []byte("ante"), Before: nil, After: []byte("refund")},
+		{StoreKey: capKey2.Name(), Key: []byte("genesis"), Before: []byte("value"), After: nil},
+		{StoreKey: capKey2.Name(), Key: []byte("msg"), Before: nil, After: []byte("second")},
+	}
+
+	res := app.SimulateWithDiff(txBytes, tx)
+	require.Zero(t, res.Code, res.RawLog)
+	require.Equal(t, expected, res.Diff)
+	require.Len(t, res.Events, 2)
+	require.Equal(t, sdk.NewEvent("counter", sdk.NewAttribute("key", "msg")), res.Events[1])
+
+	// the simulation is discarded
+	ctx := app.NewContext(true, abci.Header{})
+	require.Nil(t, ctx.KVStore(capKey1).Get([]byte("ante")))
+	require.Equal(t, []byte("value"), ctx.KVStore(capKey2).Get([]byte("genesis")))
+
+	// simulate by calling Query with encoded tx
+	queryResult := app.Query(abci.RequestQuery{Path: "/app/simulate/diff", Data: txBytes})
+	require.True(t, queryResult.IsOK(), queryResult.Log)
+
+	var queryRes sdk.SimulationResponse
+	codec.Cdc.MustUnmarshalBinaryLengthPrefixed(queryResult.Value, &queryRes)
+	require.Zero(t, queryRes.Code, queryRes.RawLog)
+	require.Equal(t, expected, queryRes.Diff)
+}
+
 func TestRunInvalidTransaction(t *testing.T) {
 	anteOpt := func(bapp *BaseApp) {
 		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
@@ -1004,6 +1388,57 @@ func TestMaxBlockGasLimits(t *testing.T) {
 	}
 }
 
+// Test that the gas schedule is read from the state at the start of each block
+// and charged for the store accesses of the block.
+func TestGasScheduler(t *testing.T) {
+	costKey := []byte("cost")
+	schedulerOpt := func(bapp *BaseApp) {
+		bapp.SetGasScheduler(func(ctx sdk.Context) sdk.GasSchedule {
+			cost := getIntFromStore(ctx.MultiStore().GetKVStore(capKey1), costKey)
+			return sdk.GasSchedule{
+				KV:     sdk.GasConfig{WriteCostFlat: uint64(cost)},
+				Stores: map[string]sdk.GasConfig{capKey2.Name(): {WriteCostFlat: 1}},
+			}
+		})
+	}
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			return ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), sdk.Result{}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			setIntOnStore(ctx.KVStore(capKey1), costKey, msg.(*msgCounter).Counter)
+			ctx.KVStore(capKey2).Set([]byte("key"), []byte("value"))
+			return sdk.Result{}
+		})
+	}
+
+	app := setupBaseApp(t, schedulerOpt, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 10))
+	require.NoError(t, err)
+
+	// the cost set by the tx only applies from the next block
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	res := app.DeliverTx(txBytes)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, int64(0+1), res.GasUsed)
+	res = app.DeliverTx(txBytes)
+	require.Equal(t, int64(0+1), res.GasUsed)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	require.Equal(t, sdk.Gas(10), app.checkState.ctx.GasSchedule().KVStoreGasConfig(capKey1.Name()).WriteCostFlat)
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	res = app.DeliverTx(txBytes)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, int64(10+1), res.GasUsed)
+}
+
 func TestBaseAppAnteHandler(t *testing.T) {
 	anteKey := []byte("ante-key")
 	anteOpt := func(bapp *BaseApp) {
@@ -1075,6 +1510,157 @@ func TestBaseAppAnteHandler(t *testing.T) {
 	app.Commit()
 }
 
+func TestBaseAppPostHandler(t *testing.T) {
+	postKey := []byte("post-key")
+	failPost, postRuns := false, 0
+	postOpt := func(bapp *BaseApp) {
+		bapp.SetPostHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool, res sdk.Result) sdk.Result {
+			postRuns++
+			store := ctx.KVStore(capKey1)
+			setIntOnStore(store, postKey, getIntFromStore(store, postKey)+1)
+			ctx.EventManager().EmitEvent(sdk.NewEvent("post", sdk.NewAttribute("run", "true")))
+
+			if failPost {
+				return sdk.ErrInternal("post handler failure").Result()
+			}
+			return res
+		})
+	}
+
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey))
+	}
+
+	cdc := codec.New()
+	app := setupBaseApp(t, postOpt, routerOpt)
+
+	app.InitChain(abci.RequestInitChain{})
+	registerTestCodec(cdc)
+
+	// the post handler is not run during CheckTx
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+	require.True(t, app.CheckTx(txBytes).IsOK())
+	require.Equal(t, 0, postRuns)
+
+	header := abci.Header{Height: app.LastBlockHeight() + 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	// a successful post handler keeps the state changes of the messages and
+	// appends its events
+	res := app.DeliverTx(txBytes)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, 1, postRuns)
+	require.Equal(t, "post.run", string(res.Tags[len(res.Tags)-1].Key))
+
+	store := app.getState(runTxModeDeliver).ctx.KVStore(capKey1)
+	require.Equal(t, int64(1), getIntFromStore(store, deliverKey))
+	require.Equal(t, int64(1), getIntFromStore(store, postKey))
+
+	// a failing post handler reverts the state changes of the messages
+	failPost = true
+	txBytes, err = cdc.MarshalBinaryLengthPrefixed(newTxCounter(1, 1))
+	require.NoError(t, err)
+	res = app.DeliverTx(txBytes)
+	require.False(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, 2, postRuns)
+
+	store = app.getState(runTxModeDeliver).ctx.KVStore(capKey1)
+	require.Equal(t, int64(1), getIntFromStore(store, deliverKey))
+	require.Equal(t, int64(1), getIntFromStore(store, postKey))
+
+	// a failing message skips the post handler
+	tx := newTxCounter(2, 1)
+	tx.setFailOnHandler(true)
+	txBytes, err = cdc.MarshalBinaryLengthPrefixed(tx)
+	require.NoError(t, err)
+	res = app.DeliverTx(txBytes)
+	require.False(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, 2, postRuns)
+}
+
+// a msg signed by Signer, to be executed on its behalf by a msgExec
+type msgSigned struct {
+	Signer sdk.AccAddress
+}
+
+func (msg msgSigned) Route() string                { return routeMsgCounter }
+func (msg msgSigned) Type() string                 { return "signed" }
+func (msg msgSigned) GetSignBytes() []byte         { return nil }
+func (msg msgSigned) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Signer} }
+func (msg msgSigned) ValidateBasic() sdk.Error     { return nil }
+
+// a msg executing Msgs on behalf of their signers
+type msgExec struct {
+	Grantee sdk.AccAddress
+	Msgs    []sdk.Msg
+}
+
+func (msg msgExec) Route() string                { return "exec" }
+func (msg msgExec) Type() string                 { return "exec" }
+func (msg msgExec) GetSignBytes() []byte         { return nil }
+func (msg msgExec) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Grantee} }
+func (msg msgExec) ValidateBasic() sdk.Error     { return nil }
+func (msg msgExec) GetExecMsgs() []sdk.Msg       { return msg.Msgs }
+
+func TestExecMsg(t *testing.T) {
+	grantee := sdk.AccAddress([]byte("grantee"))
+	granter := sdk.AccAddress([]byte("granter"))
+	stranger := sdk.AccAddress([]byte("stranger"))
+
+	var executed []sdk.AccAddress
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			executed = append(executed, msg.GetSigners()[0])
+			return sdk.Result{}
+		})
+	}
+
+	var authorized int
+	authorizerOpt := func(bapp *BaseApp) {
+		bapp.SetMsgAuthorizer(func(ctx sdk.Context, from, to sdk.AccAddress, msg sdk.Msg) sdk.Error {
+			require.Equal(t, grantee, to)
+			if !from.Equals(granter) {
+				return sdk.ErrUnauthorized("no authorization")
+			}
+			authorized++
+			return nil
+		})
+	}
+
+	app := setupBaseApp(t, routerOpt, authorizerOpt)
+	app.InitChain(abci.RequestInitChain{})
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	// the grantee's own messages do not need an authorization
+	tx := &txTest{Msgs: []sdk.Msg{msgExec{grantee, []sdk.Msg{msgSigned{granter}, msgSigned{grantee}}}}}
+	res := app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, []sdk.AccAddress{granter, grantee}, executed)
+	require.Equal(t, 1, authorized)
+
+	// the tx fails if any signer did not authorize the grantee
+	executed = nil
+	tx = &txTest{Msgs: []sdk.Msg{msgExec{grantee, []sdk.Msg{msgSigned{granter}, msgSigned{stranger}}}}}
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.CodeUnauthorized, res.Code, fmt.Sprintf("%v", res))
+
+	// messages are not executed in CheckTx
+	res = app.Check(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, 2, authorized)
+
+	// without a MsgAuthorizer messages cannot be executed on behalf of others
+	app = setupBaseApp(t, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	tx = &txTest{Msgs: []sdk.Msg{msgExec{grantee, []sdk.Msg{msgSigned{granter}}}}}
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.CodeUnauthorized, res.Code, fmt.Sprintf("%v", res))
+}
+
 func TestGasConsumptionBadTx(t *testing.T) {
 	gasWanted := uint64(5)
 	anteOpt := func(bapp *BaseApp) {
@@ -1207,6 +1793,210 @@ func TestQuery(t *testing.T) {
 	require.Equal(t, value, res.Value)
 }
 
+// Test that custom queries read the committed state at the requested height.
+func TestCustomQueryHeight(t *testing.T) {
+	key := []byte("key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			ctx.KVStore(capKey1).Set(key, []byte(strconv.FormatInt(ctx.BlockHeight(), 10)))
+			return sdk.Result{}
+		})
+		bapp.QueryRouter().AddRoute("height", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			// the state is readable but the writes are discarded
+			value := ctx.KVStore(capKey1).Get(key)
+			ctx.KVStore(capKey1).Set(key, []byte("query"))
+			return []byte(fmt.Sprintf("%d:%s", ctx.BlockHeight(), value)), nil
+		})
+	}
+
+	app := setupBaseApp(t, routerOpt, SetPruning(store.PruneNothing))
+	app.InitChain(abci.RequestInitChain{})
+
+	// nothing is committed yet, so the check state is read and the queries
+	// aren't served concurrently
+	require.False(t, app.IsConcurrentQuery(abci.RequestQuery{Path: "/custom/height"}))
+	res := app.Query(abci.RequestQuery{Path: "/custom/height"})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "0:", string(res.Value))
+
+	res = app.Query(abci.RequestQuery{Path: "/custom/height", Height: 1})
+	require.Equal(t, uint32(sdk.CodeUnknownRequest), res.Code)
+
+	for height := int64(1); height <= 3; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		resTx := app.Deliver(newTxCounter(height, 0))
+		require.True(t, resTx.IsOK(), fmt.Sprintf("%v", resTx))
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+
+	res = app.Query(abci.RequestQuery{Path: "/custom/height"})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "3:3", string(res.Value))
+
+	res = app.Query(abci.RequestQuery{Path: "/custom/height", Height: 2})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "2:2", string(res.Value))
+
+	res = app.Query(abci.RequestQuery{Path: "/custom/height", Height: 4})
+	require.Equal(t, uint32(sdk.CodeUnknownRequest), res.Code)
+
+	// the queries can be served concurrently with the blocks
+	require.True(t, app.IsConcurrentQuery(abci.RequestQuery{Path: "/custom/height"}))
+	require.False(t, app.IsConcurrentQuery(abci.RequestQuery{Path: "/store/key1/key"}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			res := app.Query(abci.RequestQuery{Path: "/custom/height", Height: 3})
+			require.True(t, res.IsOK(), res.Log)
+			require.Equal(t, "3:3", string(res.Value))
+		}
+	}()
+	for height := int64(4); height <= 8; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.Deliver(newTxCounter(height, 0))
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+	<-done
+}
+
+// Test that historical custom queries read the header of the block committed
+// at their height, including after a restart.
+func TestCustomQueryHeader(t *testing.T) {
+	queryOpt := func(bapp *BaseApp) {
+		bapp.QueryRouter().AddRoute("time", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			header := ctx.BlockHeader()
+			return []byte(fmt.Sprintf("%s:%d:%d", header.ChainID, header.Height, header.Time.Unix())), nil
+		})
+	}
+
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	loadApp := func() *BaseApp {
+		app := NewBaseApp(t.Name(), logger, db, nil, queryOpt, SetPruning(store.PruneNothing))
+		app.MountStores(capKey1)
+		require.NoError(t, app.LoadLatestVersion(capKey1))
+		return app
+	}
+
+	app := loadApp()
+	for height := int64(1); height <= 3; height++ {
+		header := abci.Header{ChainID: "test-chain", Height: height, Time: time.Unix(height*100, 0)}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+
+	checkHeaders := func(app *BaseApp) {
+		for height := int64(1); height <= 3; height++ {
+			res := app.Query(abci.RequestQuery{Path: "/custom/time", Height: height})
+			require.True(t, res.IsOK(), res.Log)
+			require.Equal(t, fmt.Sprintf("test-chain:%d:%d", height, height*100), string(res.Value))
+		}
+		res := app.Query(abci.RequestQuery{Path: "/custom/time"})
+		require.True(t, res.IsOK(), res.Log)
+		require.Equal(t, "test-chain:3:300", string(res.Value))
+	}
+	checkHeaders(app)
+
+	// the headers are reloaded from the db
+	checkHeaders(loadApp())
+}
+
+// Test that the version read by a custom query isn't pruned by the commits
+// until the query is done.
+func TestCustomQueryPruning(t *testing.T) {
+	started := make(chan struct{})
+	pruned := make(chan struct{})
+	opts := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			// the whole tree is rewritten at each height
+			for i := 0; i < 20; i++ {
+				ctx.KVStore(capKey1).Set([]byte{byte(i)}, []byte(strconv.FormatInt(ctx.BlockHeight(), 10)))
+			}
+			return sdk.Result{}
+		})
+		bapp.QueryRouter().AddRoute("values", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			if len(path) > 0 && path[0] == "wait" {
+				close(started)
+				<-pruned
+			}
+			var values []string
+			it := ctx.KVStore(capKey1).Iterator(nil, nil)
+			defer it.Close()
+			for ; it.Valid(); it.Next() {
+				values = append(values, string(it.Value()))
+			}
+			return []byte(fmt.Sprint(values)), nil
+		})
+	}
+
+	// only the latest version and the one before it are kept
+	app := setupBaseApp(t, opts, SetPruning(store.NewPruningOptions(1, 0)))
+	app.InitChain(abci.RequestInitChain{})
+	commitBlock := func(height int64) {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		resTx := app.Deliver(newTxCounter(height, 0))
+		require.True(t, resTx.IsOK(), fmt.Sprintf("%v", resTx))
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+	commitBlock(1)
+	commitBlock(2)
+
+	expected := make([]string, 20)
+	for i := range expected {
+		expected[i] = "2"
+	}
+	done := make(chan abci.ResponseQuery)
+	go func() {
+		done <- app.Query(abci.RequestQuery{Path: "/custom/values/wait", Height: 2})
+	}()
+
+	// the version is read after the commits which would have pruned it
+	<-started
+	for height := int64(3); height <= 5; height++ {
+		commitBlock(height)
+	}
+	close(pruned)
+	res := <-done
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, fmt.Sprint(expected), string(res.Value))
+
+	// the version is pruned by the next commit once unpinned
+	commitBlock(6)
+	res = app.Query(abci.RequestQuery{Path: "/custom/values", Height: 2})
+	require.Equal(t, uint32(sdk.CodeUnknownRequest), res.Code)
+}
+
+// Test that custom queries fail once they exceed the query gas limit.
+func TestCustomQueryGasLimit(t *testing.T) {
+	queryOpt := func(bapp *BaseApp) {
+		bapp.QueryRouter().AddRoute("gas", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			gas, err := strconv.ParseUint(path[0], 10, 64)
+			require.NoError(t, err)
+			ctx.GasMeter().ConsumeGas(gas, "test")
+			return nil, nil
+		})
+	}
+
+	app := setupBaseApp(t, queryOpt, SetQueryGasLimit(1000))
+	app.InitChain(abci.RequestInitChain{})
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	res := app.Query(abci.RequestQuery{Path: "/custom/gas/1000"})
+	require.True(t, res.IsOK(), res.Log)
+
+	res = app.Query(abci.RequestQuery{Path: "/custom/gas/1001"})
+	require.Equal(t, uint32(sdk.CodeOutOfGas), res.Code)
+	require.Equal(t, string(sdk.CodespaceRoot), res.Codespace)
+}
+
 // Test p2p filter queries
 func TestP2PQuery(t *testing.T) {
 	addrPeerFilterOpt := func(bapp *BaseApp) {
@@ -1253,3 +2043,68 @@ func TestGetMaximumBlockGas(t *testing.T) {
 	app.setConsensusParams(&abci.ConsensusParams{Block: &abci.BlockParams{MaxGas: -5000000}})
 	require.Panics(t, func() { app.getMaximumBlockGas() })
 }
+
+func TestConsensusParamsUpdates(t *testing.T) {
+	db := dbm.NewMemDB()
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	paramSpace := params.NewKeeper(codec.New(), keyParams, tkeyParams).
+		Subspace(Paramspace).WithKeyTable(ConsensusParamsKeyTable())
+
+	newApp := func() *BaseApp {
+		app := NewBaseApp(t.Name(), defaultLogger(), db, nil)
+		app.SetParamStore(paramSpace)
+		app.MountStores(capKey1, keyParams, tkeyParams)
+		require.NoError(t, app.LoadLatestVersion(capKey1))
+		return app
+	}
+	app := newApp()
+
+	genesisParams := &abci.ConsensusParams{
+		Block:     &abci.BlockParams{MaxBytes: 1000, MaxGas: 100},
+		Evidence:  &abci.EvidenceParams{MaxAge: 100},
+		Validator: &abci.ValidatorParams{PubKeyTypes: []string{"ed25519"}},
+	}
+	app.InitChain(abci.RequestInitChain{ConsensusParams: genesisParams})
+	require.Equal(t, genesisParams, app.GetConsensusParams(app.deliverState.ctx))
+
+	// the genesis params are returned at the end of the first block
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	res := app.EndBlock(abci.RequestEndBlock{Height: 1})
+	require.Equal(t, genesisParams, res.ConsensusParamUpdates)
+	app.Commit()
+
+	// the params are only returned in the block they change in
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	app.StoreConsensusParams(app.deliverState.ctx, &abci.ConsensusParams{
+		Block: &abci.BlockParams{MaxBytes: 1000, MaxGas: 200},
+	})
+	res = app.EndBlock(abci.RequestEndBlock{Height: 2})
+	require.Equal(t, int64(200), res.ConsensusParamUpdates.Block.MaxGas)
+	require.Equal(t, genesisParams.Evidence, res.ConsensusParamUpdates.Evidence)
+	require.Equal(t, uint64(100), app.getMaximumBlockGas())
+	app.Commit()
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	res = app.EndBlock(abci.RequestEndBlock{Height: 3})
+	require.Nil(t, res.ConsensusParamUpdates)
+	require.Equal(t, uint64(200), app.getMaximumBlockGas())
+	app.Commit()
+
+	// the params are loaded on restart
+	app = newApp()
+	require.Equal(t, uint64(200), app.getMaximumBlockGas())
+}
+
+func TestValidateConsensusParams(t *testing.T) {
+	require.NoError(t, ValidateBlockParams(abci.BlockParams{MaxBytes: 1, MaxGas: -1}))
+	require.Error(t, ValidateBlockParams(abci.BlockParams{MaxBytes: 0, MaxGas: -1}))
+	require.Error(t, ValidateBlockParams(abci.BlockParams{MaxBytes: 1, MaxGas: -2}))
+	require.Error(t, ValidateBlockParams(&abci.BlockParams{MaxBytes: 1}))
+
+	require.NoError(t, ValidateEvidenceParams(abci.EvidenceParams{MaxAge: 1}))
+	require.Error(t, ValidateEvidenceParams(abci.EvidenceParams{MaxAge: 0}))
+
+	require.NoError(t, ValidateValidatorParams(abci.ValidatorParams{PubKeyTypes: []string{"ed25519"}}))
+	require.Error(t, ValidateValidatorParams(abci.ValidatorParams{}))
+}
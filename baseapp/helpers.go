@@ -1,10 +1,12 @@
 package baseapp
 
 import (
+	"fmt"
 	"regexp"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/cosmos/cosmos-sdk/store/cachemulti"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -20,6 +22,21 @@ func (app *BaseApp) Simulate(txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 	return app.runTx(runTxModeSimulate, txBytes, tx)
 }
 
+// SimulateWithDiff simulates a tx like Simulate and also returns the changes
+// it made to the state, which are discarded.
+func (app *BaseApp) SimulateWithDiff(txBytes []byte, tx sdk.Tx) sdk.SimulationResponse {
+	ctx := app.getContextForTx(runTxModeSimulate, txBytes)
+	cms, ok := ctx.MultiStore().(cachemulti.Store)
+	if !ok {
+		msg := fmt.Sprintf("state diffs are not supported by the multistore %T", ctx.MultiStore())
+		return sdk.NewSimulationResponse(sdk.ErrInternal(msg).Result(), nil)
+	}
+
+	cache, diff := cms.CacheMultiStoreWithDiff()
+	result := app.runTxWithContext(ctx.WithMultiStore(cache), runTxModeSimulate, txBytes, tx)
+	return sdk.NewSimulationResponse(result, diff())
+}
+
 // nolint
 func (app *BaseApp) Deliver(tx sdk.Tx) (result sdk.Result) {
 	return app.runTx(runTxModeDeliver, nil, tx)
@@ -7,6 +7,7 @@ import (
 	dbm "github.com/tendermint/tendermint/libs/db"
 
 	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -18,6 +19,41 @@ func SetPruning(opts sdk.PruningOptions) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.cms.SetPruning(opts) }
 }
 
+// SetSnapshotOptions returns an option that enables periodic state sync
+// snapshots of the multistore associated with the app.
+func SetSnapshotOptions(opts store.SnapshotOptions) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		rs, ok := bap.cms.(*rootmulti.Store)
+		if !ok {
+			panic(fmt.Sprintf("snapshots are not supported by the multistore %T", bap.cms))
+		}
+		if opts.Logger == nil {
+			opts.Logger = bap.logger
+		}
+		rs.SetSnapshotOptions(opts)
+	}
+}
+
+// SetInterBlockCache returns an option that caches the reads of the IAVL
+// stores of the multistore associated with the app across blocks. It must be
+// set before the stores are loaded.
+func SetInterBlockCache(cache store.MultiStorePersistentCache) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		rs, ok := bap.cms.(*rootmulti.Store)
+		if !ok {
+			panic(fmt.Sprintf("inter-block caching is not supported by the multistore %T", bap.cms))
+		}
+		rs.SetInterBlockCache(cache)
+	}
+}
+
+// SetStoreDBs returns an option that keeps the stores with the given names in
+// their own DB instead of the DB of the app. It must be set before the stores
+// are mounted.
+func SetStoreDBs(dbs map[string]dbm.DB) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.storeDBs = dbs }
+}
+
 // SetMinGasPrices returns an option that sets the minimum gas prices on the app.
 func SetMinGasPrices(gasPricesStr string) func(*BaseApp) {
 	gasPrices, err := sdk.ParseDecCoins(gasPricesStr)
@@ -28,6 +64,12 @@ func SetMinGasPrices(gasPricesStr string) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.setMinGasPrices(gasPrices) }
 }
 
+// SetQueryGasLimit returns an option that sets the gas limit of each custom
+// query on the app, with 0 for no limit.
+func SetQueryGasLimit(gasLimit uint64) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.setQueryGasLimit(gasLimit) }
+}
+
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
 		panic("SetName() on sealed BaseApp")
@@ -49,6 +91,13 @@ func (app *BaseApp) SetCMS(cms store.CommitMultiStore) {
 	app.cms = cms
 }
 
+func (app *BaseApp) SetStoreLoader(loader StoreLoader) {
+	if app.sealed {
+		panic("SetStoreLoader() on sealed BaseApp")
+	}
+	app.storeLoader = loader
+}
+
 func (app *BaseApp) SetInitChainer(initChainer sdk.InitChainer) {
 	if app.sealed {
 		panic("SetInitChainer() on sealed BaseApp")
@@ -77,6 +126,36 @@ func (app *BaseApp) SetAnteHandler(ah sdk.AnteHandler) {
 	app.anteHandler = ah
 }
 
+func (app *BaseApp) SetPostHandler(ph sdk.PostHandler) {
+	if app.sealed {
+		panic("SetPostHandler() on sealed BaseApp")
+	}
+	app.postHandler = ph
+}
+
+func (app *BaseApp) SetMsgAuthorizer(authorizer sdk.MsgAuthorizer) {
+	if app.sealed {
+		panic("SetMsgAuthorizer() on sealed BaseApp")
+	}
+	app.msgAuthorizer = authorizer
+}
+
+func (app *BaseApp) SetGasScheduler(scheduler sdk.GasScheduler) {
+	if app.sealed {
+		panic("SetGasScheduler() on sealed BaseApp")
+	}
+	app.gasScheduler = scheduler
+}
+
+// SetParamStore sets a parameter store on the BaseApp, holding the consensus
+// params.
+func (app *BaseApp) SetParamStore(ps ParamStore) {
+	if app.sealed {
+		panic("SetParamStore() on sealed BaseApp")
+	}
+	app.paramStore = ps
+}
+
 func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 	if app.sealed {
 		panic("SetAddrPeerFilter() on sealed BaseApp")
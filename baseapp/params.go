@@ -0,0 +1,151 @@
+package baseapp
+
+import (
+	"errors"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+)
+
+// Paramspace defines the parameter subspace to be used for the paramstore.
+const Paramspace = "baseapp"
+
+// Parameter store keys for all the consensus parameter types.
+var (
+	ParamStoreKeyBlockParams     = []byte("BlockParams")
+	ParamStoreKeyEvidenceParams  = []byte("EvidenceParams")
+	ParamStoreKeyValidatorParams = []byte("ValidatorParams")
+)
+
+// ParamStore defines the interface the parameter store used by the BaseApp must
+// fulfill.
+type ParamStore interface {
+	Get(ctx sdk.Context, key []byte, ptr interface{})
+	Has(ctx sdk.Context, key []byte) bool
+	Set(ctx sdk.Context, key []byte, param interface{})
+	Modified(ctx sdk.Context, key []byte) bool
+}
+
+// ConsensusParamsKeyTable returns a KeyTable for the consensus parameters, with
+// the validators applied to the values changed by governance.
+func ConsensusParamsKeyTable() subspace.KeyTable {
+	return subspace.NewKeyTable().
+		RegisterTypeWithValidator(ParamStoreKeyBlockParams, abci.BlockParams{}, ValidateBlockParams).
+		RegisterTypeWithValidator(ParamStoreKeyEvidenceParams, abci.EvidenceParams{}, ValidateEvidenceParams).
+		RegisterTypeWithValidator(ParamStoreKeyValidatorParams, abci.ValidatorParams{}, ValidateValidatorParams)
+}
+
+// ValidateBlockParams defines a stateless validation on BlockParams. It is run
+// on the values updated through the param store, e.g. by governance.
+func ValidateBlockParams(i interface{}) error {
+	v, ok := i.(abci.BlockParams)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.MaxBytes <= 0 {
+		return fmt.Errorf("block maximum bytes must be positive: %d", v.MaxBytes)
+	}
+	if v.MaxGas < -1 {
+		return fmt.Errorf("block maximum gas must be greater than or equal to -1: %d", v.MaxGas)
+	}
+
+	return nil
+}
+
+// ValidateEvidenceParams defines a stateless validation on EvidenceParams. It is
+// run on the values updated through the param store, e.g. by governance.
+func ValidateEvidenceParams(i interface{}) error {
+	v, ok := i.(abci.EvidenceParams)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.MaxAge <= 0 {
+		return fmt.Errorf("evidence maximum age must be positive: %d", v.MaxAge)
+	}
+
+	return nil
+}
+
+// ValidateValidatorParams defines a stateless validation on ValidatorParams. It
+// is run on the values updated through the param store, e.g. by governance.
+func ValidateValidatorParams(i interface{}) error {
+	v, ok := i.(abci.ValidatorParams)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if len(v.PubKeyTypes) == 0 {
+		return errors.New("validator allowed pubkey types must not be empty")
+	}
+
+	return nil
+}
+
+// GetConsensusParams returns the current consensus parameters from the BaseApp's
+// ParamStore. If the BaseApp has no ParamStore defined, nil is returned.
+func (app *BaseApp) GetConsensusParams(ctx sdk.Context) *abci.ConsensusParams {
+	if app.paramStore == nil {
+		return nil
+	}
+
+	cp := new(abci.ConsensusParams)
+
+	if app.paramStore.Has(ctx, ParamStoreKeyBlockParams) {
+		var bp abci.BlockParams
+		app.paramStore.Get(ctx, ParamStoreKeyBlockParams, &bp)
+		cp.Block = &bp
+	}
+
+	if app.paramStore.Has(ctx, ParamStoreKeyEvidenceParams) {
+		var ep abci.EvidenceParams
+		app.paramStore.Get(ctx, ParamStoreKeyEvidenceParams, &ep)
+		cp.Evidence = &ep
+	}
+
+	if app.paramStore.Has(ctx, ParamStoreKeyValidatorParams) {
+		var vp abci.ValidatorParams
+		app.paramStore.Get(ctx, ParamStoreKeyValidatorParams, &vp)
+		cp.Validator = &vp
+	}
+
+	return cp
+}
+
+// StoreConsensusParams sets the consensus parameters to the BaseApp's param
+// store. It panics if the BaseApp has no ParamStore.
+func (app *BaseApp) StoreConsensusParams(ctx sdk.Context, cp *abci.ConsensusParams) {
+	if app.paramStore == nil {
+		panic("cannot store consensus params with no params store set")
+	}
+
+	if cp == nil {
+		return
+	}
+
+	if cp.Block != nil {
+		app.paramStore.Set(ctx, ParamStoreKeyBlockParams, *cp.Block)
+	}
+	if cp.Evidence != nil {
+		app.paramStore.Set(ctx, ParamStoreKeyEvidenceParams, *cp.Evidence)
+	}
+	if cp.Validator != nil {
+		app.paramStore.Set(ctx, ParamStoreKeyValidatorParams, *cp.Validator)
+	}
+}
+
+// consensusParamsModified returns true if any of the consensus parameters was
+// changed in the block of the context.
+func (app *BaseApp) consensusParamsModified(ctx sdk.Context) bool {
+	if app.paramStore == nil {
+		return false
+	}
+
+	return app.paramStore.Modified(ctx, ParamStoreKeyBlockParams) ||
+		app.paramStore.Modified(ctx, ParamStoreKeyEvidenceParams) ||
+		app.paramStore.Modified(ctx, ParamStoreKeyValidatorParams)
+}
@@ -7,10 +7,7 @@ import (
 )
 
 // QueryRouter provides queryables for each query path.
-type QueryRouter interface {
-	AddRoute(r string, h sdk.Querier) (rtr QueryRouter)
-	Route(path string) (h sdk.Querier)
-}
+type QueryRouter = sdk.QueryRouter
 
 type queryRouter struct {
 	routes map[string]sdk.Querier
@@ -7,10 +7,10 @@ import (
 )
 
 // Router provides handlers for each transaction type.
-type Router interface {
-	AddRoute(r string, h sdk.Handler) (rtr Router)
-	Route(path string) (h sdk.Handler)
-}
+type Router = sdk.Router
+
+// MsgRouter provides handlers for each concrete message type.
+type MsgRouter = sdk.MsgRouter
 
 type router struct {
 	routes map[string]sdk.Handler
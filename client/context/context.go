@@ -49,6 +49,7 @@ type CLIContext struct {
 	Verifier      tmlite.Verifier
 	VerifierHome  string
 	Simulate      bool
+	ShowDiff      bool
 	GenerateOnly  bool
 	FromAddress   sdk.AccAddress
 	FromName      string
@@ -94,6 +95,7 @@ func NewCLIContext() CLIContext {
 		PrintResponse: viper.GetBool(client.FlagPrintResponse),
 		Verifier:      verifier,
 		Simulate:      viper.GetBool(client.FlagDryRun),
+		ShowDiff:      viper.GetBool(client.FlagShowDiff),
 		GenerateOnly:  genOnly,
 		FromAddress:   fromAddress,
 		FromName:      fromName,
@@ -235,6 +237,12 @@ func (ctx CLIContext) WithSimulation(simulate bool) CLIContext {
 	return ctx
 }
 
+// WithShowDiff returns a copy of the context with updated ShowDiff value
+func (ctx CLIContext) WithShowDiff(showDiff bool) CLIContext {
+	ctx.ShowDiff = showDiff
+	return ctx
+}
+
 // WithFromName returns a copy of the context with an updated from account name.
 func (ctx CLIContext) WithFromName(name string) CLIContext {
 	ctx.FromName = name
@@ -59,6 +59,34 @@ func (ctx CLIContext) QuerySubspace(subspace []byte, storeName string) (res []sd
 	return
 }
 
+// QueryStoreKeys performs a query from a Tendermint node for a list of keys of
+// the store with the provided name, returning the key/value pairs of the keys
+// that exist. From a distrusted node, all the values and absences are checked
+// against the app hash with a single proof.
+func (ctx CLIContext) QueryStoreKeys(keys [][]byte, storeName string) (res []sdk.KVPair, err error) {
+	resRaw, err := ctx.queryStore(ctx.Codec.MustMarshalBinaryLengthPrefixed(keys), storeName, "keys")
+	if err != nil {
+		return res, err
+	}
+
+	ctx.Codec.MustUnmarshalBinaryLengthPrefixed(resRaw, &res)
+	return
+}
+
+// QueryStoreRange performs a query from a Tendermint node for all the keys with
+// the provided prefix in the store with the provided name. From a distrusted
+// node, the key/value pairs are checked against the app hash with a single
+// proof, which also proves that no key is missing.
+func (ctx CLIContext) QueryStoreRange(prefix []byte, storeName string) (res []sdk.KVPair, err error) {
+	resRaw, err := ctx.queryStore(prefix, storeName, "range")
+	if err != nil {
+		return res, err
+	}
+
+	ctx.Codec.MustUnmarshalBinaryLengthPrefixed(resRaw, &res)
+	return
+}
+
 // GetAccount queries for an account given an address and a block height. An
 // error is returned if the query or decoding fails.
 func (ctx CLIContext) GetAccount(address []byte) (auth.Account, error) {
@@ -172,7 +200,7 @@ func (ctx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err erro
 		return resp.Value, nil
 	}
 
-	err = ctx.verifyProof(path, resp)
+	err = ctx.verifyProof(path, key, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -193,8 +221,9 @@ func (ctx CLIContext) Verify(height int64) (tmtypes.SignedHeader, error) {
 	return check, nil
 }
 
-// verifyProof perform response proof verification.
-func (ctx CLIContext) verifyProof(queryPath string, resp abci.ResponseQuery) error {
+// verifyProof perform response proof verification of a query with the given
+// data.
+func (ctx CLIContext) verifyProof(queryPath string, data []byte, resp abci.ResponseQuery) error {
 	if ctx.Verifier == nil {
 		return fmt.Errorf("missing valid certifier to verify data from distrusted node")
 	}
@@ -205,15 +234,37 @@ func (ctx CLIContext) verifyProof(queryPath string, resp abci.ResponseQuery) err
 		return err
 	}
 
-	// TODO: Instead of reconstructing, stash on CLIContext field?
-	prt := rootmulti.DefaultProofRuntime()
-
 	// TODO: Better convention for path?
-	storeName, err := parseQueryStorePath(queryPath)
+	storeName, subpath, err := parseQueryStorePath(queryPath)
 	if err != nil {
 		return err
 	}
 
+	switch subpath {
+	case "keys":
+		var keys [][]byte
+		var kvs []sdk.KVPair
+		if err := ctx.Codec.UnmarshalBinaryLengthPrefixed(data, &keys); err != nil {
+			return err
+		}
+		if err := ctx.Codec.UnmarshalBinaryLengthPrefixed(resp.Value, &kvs); err != nil {
+			return err
+		}
+		err = rootmulti.VerifyKeysProof(resp.Proof, commit.Header.AppHash, storeName, keys, kvs)
+		return errors.Wrap(err, "failed to prove merkle proof")
+
+	case "range":
+		var kvs []sdk.KVPair
+		if err := ctx.Codec.UnmarshalBinaryLengthPrefixed(resp.Value, &kvs); err != nil {
+			return err
+		}
+		err = rootmulti.VerifyRangeProof(resp.Proof, commit.Header.AppHash, storeName, data, kvs)
+		return errors.Wrap(err, "failed to prove merkle proof")
+	}
+
+	// TODO: Instead of reconstructing, stash on CLIContext field?
+	prt := rootmulti.DefaultProofRuntime()
+
 	kp := merkle.KeyPath{}
 	kp = kp.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
 	kp = kp.AppendKey(resp.Key, merkle.KeyEncodingURL)
@@ -241,7 +292,8 @@ func (ctx CLIContext) queryStore(key cmn.HexBytes, storeName, endPath string) ([
 }
 
 // isQueryStoreWithProof expects a format like /<queryType>/<storeName>/<subpath>
-// queryType must be "store" and subpath must be "key" to require a proof.
+// queryType must be "store" and subpath must be "key", "keys" or "range" to
+// require a proof.
 func isQueryStoreWithProof(path string) bool {
 	if !strings.HasPrefix(path, "/") {
 		return false
@@ -260,21 +312,22 @@ func isQueryStoreWithProof(path string) bool {
 	return false
 }
 
-// parseQueryStorePath expects a format like /store/<storeName>/key.
-func parseQueryStorePath(path string) (storeName string, err error) {
+// parseQueryStorePath expects a format like /store/<storeName>/<subpath>, the
+// subpath being "key", "keys" or "range".
+func parseQueryStorePath(path string) (storeName, subpath string, err error) {
 	if !strings.HasPrefix(path, "/") {
-		return "", errors.New("expected path to start with /")
+		return "", "", errors.New("expected path to start with /")
 	}
 
 	paths := strings.SplitN(path[1:], "/", 3)
 	switch {
 	case len(paths) != 3:
-		return "", errors.New("expected format like /store/<storeName>/key")
+		return "", "", errors.New("expected format like /store/<storeName>/key")
 	case paths[0] != "store":
-		return "", errors.New("expected format like /store/<storeName>/key")
-	case paths[2] != "key":
-		return "", errors.New("expected format like /store/<storeName>/key")
+		return "", "", errors.New("expected format like /store/<storeName>/key")
+	case !rootmulti.RequireProof("/" + paths[2]):
+		return "", "", errors.New("expected format like /store/<storeName>/key")
 	}
 
-	return paths[1], nil
+	return paths[1], paths[2], nil
 }
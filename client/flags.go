@@ -41,9 +41,13 @@ const (
 	FlagMemo               = "memo"
 	FlagFees               = "fees"
 	FlagGasPrices          = "gas-prices"
+	FlagFeeGranter         = "fee-granter"
+	FlagTimeoutHeight      = "timeout-height"
+	FlagUnordered          = "unordered"
 	FlagBroadcastMode      = "broadcast-mode"
 	FlagPrintResponse      = "print-response"
 	FlagDryRun             = "dry-run"
+	FlagShowDiff           = "show-diff"
 	FlagGenerateOnly       = "generate-only"
 	FlagIndentResponse     = "indent"
 	FlagListenAddr         = "laddr"
@@ -86,6 +90,9 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().String(FlagMemo, "", "Memo to send along with transaction")
 		c.Flags().String(FlagFees, "", "Fees to pay along with transaction; eg: 10uatom")
 		c.Flags().String(FlagGasPrices, "", "Gas prices to determine the transaction fee (e.g. 10uatom)")
+		c.Flags().String(FlagFeeGranter, "", "Address of an account that granted the signer a fee allowance to pay the fees")
+		c.Flags().Uint64(FlagTimeoutHeight, 0, "Block height after which the transaction is rejected")
+		c.Flags().Bool(FlagUnordered, false, "Do not bind the transaction to the signer's sequence; requires --timeout-height")
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
 		c.Flags().Bool(FlagUseLedger, false, "Use a connected Ledger device")
 		c.Flags().Float64(FlagGasAdjustment, DefaultGasAdjustment, "adjustment factor to be multiplied against the estimate returned by the tx simulation; if the gas limit is set manually this flag is ignored ")
@@ -93,6 +100,7 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().Bool(FlagPrintResponse, true, "return tx response (only works with async = false)")
 		c.Flags().Bool(FlagTrustNode, true, "Trust connected full node (don't verify proofs for responses)")
 		c.Flags().Bool(FlagDryRun, false, "ignore the --gas flag and perform a simulation of a transaction, but don't broadcast it")
+		c.Flags().Bool(FlagShowDiff, false, "with --dry-run, print the result, events and state changes of the simulated transaction")
 		c.Flags().Bool(FlagGenerateOnly, false, "build an unsigned transaction and write it to STDOUT")
 		c.Flags().BoolP(FlagSkipConfirmation, "y", false, "Skip tx broadcasting prompt confirmation")
 
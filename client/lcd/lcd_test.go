@@ -3,6 +3,7 @@ package lcd
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -83,6 +84,25 @@ func TestNodeStatus(t *testing.T) {
 	getSyncStatus(t, port, false)
 }
 
+func TestMsgs(t *testing.T) {
+	cleanup, _, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{}, true)
+	defer cleanup()
+
+	res, body := Request(t, port, "GET", "/msgs", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	var msgs []sdk.MsgInfo
+	require.NoError(t, json.Unmarshal([]byte(body), &msgs))
+	require.NotEmpty(t, msgs)
+	for _, msg := range msgs {
+		if msg.Route == "bank" && msg.Type == "send" {
+			require.Contains(t, string(msg.Schema), `"from_address"`)
+			return
+		}
+	}
+	t.Fatal("bank send message not listed")
+}
+
 func TestBlock(t *testing.T) {
 	cleanup, _, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{}, true)
 	defer cleanup()
@@ -176,6 +196,20 @@ func TestCoinSend(t *testing.T) {
 	acc = getAccount(t, port, addr)
 	require.Equal(t, expectedBalance.Amount, acc.GetCoins().AmountOf(sdk.DefaultBondDenom))
 
+	// run simulation returning the state changes, which are not applied
+	res, body = doTransferSimulationWithDiff(t, port, addr, fees)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	var simResp rest.SimulationDiffResponse
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &simResp))
+	require.NotZero(t, simResp.GasEstimate)
+	require.Zero(t, simResp.Simulation.Code, simResp.Simulation.RawLog)
+	require.NotEmpty(t, simResp.Simulation.Events)
+	require.NotEmpty(t, simResp.Simulation.Diff)
+
+	acc = getAccount(t, port, addr)
+	require.Equal(t, expectedBalance.Amount, acc.GetCoins().AmountOf(sdk.DefaultBondDenom))
+
 	// run successful tx
 	gas := fmt.Sprintf("%d", gasEstResp.GasEstimate)
 	res, body, _ = doTransferWithGas(t, port, seed, name1, memo, pw, addr, gas, 1.0, false, true, fees)
@@ -329,14 +363,14 @@ func TestTxs(t *testing.T) {
 	require.Equal(t, emptyTxs, txs)
 
 	// query empty
-	txs = getTransactions(t, port, fmt.Sprintf("sender=%s", addr.String()))
+	txs = getTransactions(t, port, fmt.Sprintf("message.sender=%s", addr.String()))
 	require.Equal(t, emptyTxs, txs)
 
 	// also tests url decoding
-	txs = getTransactions(t, port, fmt.Sprintf("sender=%s", addr.String()))
+	txs = getTransactions(t, port, fmt.Sprintf("message.sender=%s", addr.String()))
 	require.Equal(t, emptyTxs, txs)
 
-	txs = getTransactions(t, port, fmt.Sprintf("action=submit%%20proposal&sender=%s", addr.String()))
+	txs = getTransactions(t, port, fmt.Sprintf("message.action=submit%%20proposal&message.sender=%s", addr.String()))
 	require.Equal(t, emptyTxs, txs)
 
 	// create tx
@@ -348,12 +382,12 @@ func TestTxs(t *testing.T) {
 	require.Equal(t, resultTx.TxHash, tx.TxHash)
 
 	// query sender
-	txs = getTransactions(t, port, fmt.Sprintf("sender=%s", addr.String()))
+	txs = getTransactions(t, port, fmt.Sprintf("message.sender=%s", addr.String()))
 	require.Len(t, txs, 1)
 	require.Equal(t, resultTx.Height, txs[0].Height)
 
 	// query recipient
-	txs = getTransactions(t, port, fmt.Sprintf("recipient=%s", receiveAddr.String()))
+	txs = getTransactions(t, port, fmt.Sprintf("transfer.recipient=%s", receiveAddr.String()))
 	require.Len(t, txs, 1)
 	require.Equal(t, resultTx.Height, txs[0].Height)
 
@@ -455,8 +489,8 @@ func TestBonding(t *testing.T) {
 
 	// query tx
 	txs := getTransactions(t, port,
-		fmt.Sprintf("action=delegate&sender=%s", addr),
-		fmt.Sprintf("destination-validator=%s", operAddrs[0]),
+		fmt.Sprintf("message.action=delegate&message.sender=%s", addr),
+		fmt.Sprintf("delegate.validator=%s", operAddrs[0]),
 	)
 	require.Len(t, txs, 1)
 	require.Equal(t, resultTx.Height, txs[0].Height)
@@ -508,8 +542,8 @@ func TestBonding(t *testing.T) {
 
 	// query tx
 	txs = getTransactions(t, port,
-		fmt.Sprintf("action=begin_unbonding&sender=%s", addr),
-		fmt.Sprintf("source-validator=%s", operAddrs[0]),
+		fmt.Sprintf("message.action=begin_unbonding&message.sender=%s", addr),
+		fmt.Sprintf("unbond.validator=%s", operAddrs[0]),
 	)
 	require.Len(t, txs, 1)
 	require.Equal(t, resultTx.Height, txs[0].Height)
@@ -545,9 +579,9 @@ func TestBonding(t *testing.T) {
 
 	// query tx
 	txs = getTransactions(t, port,
-		fmt.Sprintf("action=begin_redelegate&sender=%s", addr),
-		fmt.Sprintf("source-validator=%s", operAddrs[0]),
-		fmt.Sprintf("destination-validator=%s", operAddrs[1]),
+		fmt.Sprintf("message.action=begin_redelegate&message.sender=%s", addr),
+		fmt.Sprintf("redelegate.source_validator=%s", operAddrs[0]),
+		fmt.Sprintf("redelegate.destination_validator=%s", operAddrs[1]),
 	)
 	require.Len(t, txs, 1)
 	require.Equal(t, resultTx.Height, txs[0].Height)
@@ -669,7 +703,7 @@ func TestDeposit(t *testing.T) {
 	require.Equal(t, expectedBalance.Amount.Sub(depositTokens), acc.GetCoins().AmountOf(sdk.DefaultBondDenom))
 
 	// query tx
-	txs := getTransactions(t, port, fmt.Sprintf("action=deposit&sender=%s", addr))
+	txs := getTransactions(t, port, fmt.Sprintf("message.action=deposit&message.sender=%s", addr))
 	require.Len(t, txs, 1)
 	require.Equal(t, resultTx.Height, txs[0].Height)
 
@@ -728,7 +762,7 @@ func TestVote(t *testing.T) {
 	expectedBalance = coins[0]
 
 	// query tx
-	txs := getTransactions(t, port, fmt.Sprintf("action=vote&sender=%s", addr))
+	txs := getTransactions(t, port, fmt.Sprintf("message.action=vote&message.sender=%s", addr))
 	require.Len(t, txs, 1)
 	require.Equal(t, resultTx.Height, txs[0].Height)
 
@@ -729,6 +729,29 @@ func doTransferWithGas(
 	return resp, body, receiveAddr
 }
 
+// doTransferSimulationWithDiff simulates a transfer from the given address and
+// returns the simulation along with its result and state changes.
+func doTransferSimulationWithDiff(
+	t *testing.T, port string, addr sdk.AccAddress, fees sdk.Coins,
+) (resp *http.Response, body string) {
+
+	acc := getAccount(t, port, addr)
+	baseReq := rest.NewBaseReq(
+		addr.String(), "", viper.GetString(client.FlagChainID), "10000", "1.0",
+		acc.GetAccountNumber(), acc.GetSequence(), fees, nil, true,
+	)
+	baseReq.ShowDiff = true
+
+	sr := bankrest.SendReq{
+		Amount:  sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)},
+		BaseReq: baseReq,
+	}
+	req, err := cdc.MarshalJSON(sr)
+	require.NoError(t, err)
+
+	return Request(t, port, "POST", fmt.Sprintf("/bank/accounts/%s/transfers", addr), req)
+}
+
 // doTransferWithGasAccAuto is similar to doTransferWithGas except that it
 // automatically determines the account's number and sequence when generating the
 // tx.
@@ -49,6 +49,16 @@ func WriteGenerateStdTxResponse(w http.ResponseWriter, cdc *codec.Codec,
 			return
 		}
 
+		if br.Simulate && br.ShowDiff {
+			res, err := utils.SimulateMsgsWithDiff(txBldr, cliCtx, msgs)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			rest.WriteSimulationDiffResponse(w, cdc, txBldr.Gas(), res)
+			return
+		}
+
 		if br.Simulate {
 			rest.WriteSimulationResponse(w, cdc, txBldr.Gas())
 			return
@@ -0,0 +1,92 @@
+package tx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+)
+
+// QueryMsgs returns the messages routed by concrete type by the node, with the
+// JSON schema of each of them.
+func QueryMsgs(cliCtx context.CLIContext) ([]sdk.MsgInfo, error) {
+	res, err := cliCtx.Query("/app/msgs", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []sdk.MsgInfo
+	if err := json.Unmarshal(res, &msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// QueryMsgsCmd returns a command listing the messages the chain accepts.
+func QueryMsgsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "msgs",
+		Short: "List the messages accepted by the chain with their JSON schema",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext()
+
+			msgs, err := QueryMsgs(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			// the schemas are raw JSON, which Amino would encode as base64
+			var output []byte
+			if cliCtx.Indent {
+				output, err = json.MarshalIndent(msgs, "", "  ")
+			} else {
+				output, err = json.Marshal(msgs)
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(client.FlagNode, "n", "tcp://localhost:26657", "Node to connect to")
+	viper.BindPFlag(client.FlagNode, cmd.Flags().Lookup(client.FlagNode))
+	cmd.Flags().Bool(client.FlagTrustNode, false, "Trust connected full node (don't verify proofs for responses)")
+	viper.BindPFlag(client.FlagTrustNode, cmd.Flags().Lookup(client.FlagTrustNode))
+
+	return cmd
+}
+
+// QueryMsgsRequestHandlerFn implements a REST handler listing the messages the
+// chain accepts with their JSON schema.
+func QueryMsgsRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := cliCtx.Query("/app/msgs", nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if cliCtx.Indent {
+			var out bytes.Buffer
+			if err := json.Indent(&out, res, "", "  "); err != nil {
+				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			res = out.Bytes()
+		}
+
+		rest.PostProcessResponse(w, cliCtx.Codec, res, cliCtx.Indent)
+	}
+}
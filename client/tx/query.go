@@ -19,59 +19,62 @@ import (
 )
 
 const (
-	flagTags  = "tags"
-	flagPage  = "page"
-	flagLimit = "limit"
+	flagEvents = "events"
+	flagPage   = "page"
+	flagLimit  = "limit"
 )
 
 // ----------------------------------------------------------------------------
 // CLI
 // ----------------------------------------------------------------------------
 
-// SearchTxCmd returns a command to search through tagged transactions.
+// SearchTxCmd returns a command to search through transactions by events.
 func SearchTxCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "txs",
-		Short: "Search for paginated transactions that match a set of tags",
+		Short: "Search for paginated transactions that match a set of events",
 		Long: strings.TrimSpace(`
-Search for transactions that match the exact given tags where results are paginated.
+Search for transactions that match the exact given events where results are paginated.
+Each event takes the form of '<type>.<attribute>=<value>'.
 
 Example:
-$ gaiacli query txs --tags '<tag1>:<value1>&<tag2>:<value2>' --page 1 --limit 30
+$ gaiacli query txs --events 'message.sender=cosmos1...&message.action=withdraw_delegator_reward' --page 1 --limit 30
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			tagsStr := viper.GetString(flagTags)
-			tagsStr = strings.Trim(tagsStr, "'")
+			eventsStr := viper.GetString(flagEvents)
+			eventsStr = strings.Trim(eventsStr, "'")
 
-			var tags []string
-			if strings.Contains(tagsStr, "&") {
-				tags = strings.Split(tagsStr, "&")
+			var events []string
+			if strings.Contains(eventsStr, "&") {
+				events = strings.Split(eventsStr, "&")
 			} else {
-				tags = append(tags, tagsStr)
+				events = append(events, eventsStr)
 			}
 
-			var tmTags []string
-			for _, tag := range tags {
-				if !strings.Contains(tag, ":") {
-					return fmt.Errorf("%s should be of the format <key>:<value>", tagsStr)
-				} else if strings.Count(tag, ":") > 1 {
-					return fmt.Errorf("%s should only contain one <key>:<value> pair", tagsStr)
+			var tmEvents []string
+			for _, event := range events {
+				if !strings.Contains(event, "=") {
+					return fmt.Errorf("invalid event; event %s should be of the format: <type>.<attribute>=<value>", event)
+				} else if strings.Count(event, "=") > 1 {
+					return fmt.Errorf("invalid event; event %s should only contain one <type>.<attribute>=<value> pair", event)
 				}
 
-				keyValue := strings.Split(tag, ":")
+				keyValue := strings.Split(event, "=")
 				if keyValue[0] == types.TxHeightKey {
-					tag = fmt.Sprintf("%s=%s", keyValue[0], keyValue[1])
+					event = fmt.Sprintf("%s=%s", keyValue[0], keyValue[1])
+				} else if !strings.Contains(keyValue[0], ".") {
+					return fmt.Errorf("invalid event; event %s should be of the format: <type>.<attribute>=<value>", event)
 				} else {
-					tag = fmt.Sprintf("%s='%s'", keyValue[0], keyValue[1])
+					event = fmt.Sprintf("%s='%s'", keyValue[0], keyValue[1])
 				}
-				tmTags = append(tmTags, tag)
+				tmEvents = append(tmEvents, event)
 			}
 
 			page := viper.GetInt(flagPage)
 			limit := viper.GetInt(flagLimit)
 
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			txs, err := SearchTxs(cliCtx, cdc, tmTags, page, limit)
+			txs, err := SearchTxs(cliCtx, cdc, tmEvents, page, limit)
 			if err != nil {
 				return err
 			}
@@ -97,10 +100,10 @@ $ gaiacli query txs --tags '<tag1>:<value1>&<tag2>:<value2>' --page 1 --limit 30
 	cmd.Flags().Bool(client.FlagTrustNode, false, "Trust connected full node (don't verify proofs for responses)")
 	viper.BindPFlag(client.FlagTrustNode, cmd.Flags().Lookup(client.FlagTrustNode))
 
-	cmd.Flags().String(flagTags, "", "tag:value list of tags that must match")
+	cmd.Flags().String(flagEvents, "", "list of transaction events in the form of <type>.<attribute>=<value>")
 	cmd.Flags().Uint32(flagPage, rest.DefaultPage, "Query a specific page of paginated results")
 	cmd.Flags().Uint32(flagLimit, rest.DefaultLimit, "Query number of transactions results per page returned")
-	cmd.MarkFlagRequired(flagTags)
+	cmd.MarkFlagRequired(flagEvents)
 
 	return cmd
 }
@@ -139,12 +142,12 @@ func QueryTxCmd(cdc *codec.Codec) *cobra.Command {
 // REST
 // ----------------------------------------------------------------------------
 
-// QueryTxsByTagsRequestHandlerFn implements a REST handler that searches for
-// transactions by tags.
-func QueryTxsByTagsRequestHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+// QueryTxsRequestHandlerFn implements a REST handler that searches for
+// transactions by events.
+func QueryTxsRequestHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var (
-			tags        []string
+			events      []string
 			txs         []sdk.TxResponse
 			page, limit int
 		)
@@ -161,14 +164,14 @@ func QueryTxsByTagsRequestHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec)
 			return
 		}
 
-		tags, page, limit, err = rest.ParseHTTPArgs(r)
+		events, page, limit, err = rest.ParseHTTPArgs(r)
 
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		txs, err = SearchTxs(cliCtx, cdc, tags, page, limit)
+		txs, err = SearchTxs(cliCtx, cdc, events, page, limit)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
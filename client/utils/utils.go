@@ -62,6 +62,13 @@ func CompleteAndBroadcastTxCLI(txBldr authtxb.TxBuilder, cliCtx context.CLIConte
 	}
 
 	if cliCtx.Simulate {
+		if cliCtx.ShowDiff {
+			res, err := SimulateMsgsWithDiff(txBldr, cliCtx, msgs)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(res)
+		}
 		return nil
 	}
 
@@ -140,6 +147,31 @@ func CalculateGas(queryFunc func(string, common.HexBytes) ([]byte, error),
 	return
 }
 
+// SimulateMsgsWithDiff simulates a transaction of the given messages and
+// returns its result along with the changes it made to the state, which are
+// discarded.
+func SimulateMsgsWithDiff(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, msgs []sdk.Msg) (sdk.SimulationResponse, error) {
+	txBytes, err := txBldr.BuildTxForSim(msgs)
+	if err != nil {
+		return sdk.SimulationResponse{}, err
+	}
+	return QuerySimulationWithDiff(cliCtx.Query, cliCtx.Codec, txBytes)
+}
+
+// QuerySimulationWithDiff simulates the execution of a transaction via the
+// /app/simulate/diff query and returns its result along with the changes it
+// made to the state.
+func QuerySimulationWithDiff(queryFunc func(string, common.HexBytes) ([]byte, error),
+	cdc *amino.Codec, txBytes []byte) (res sdk.SimulationResponse, err error) {
+
+	rawRes, err := queryFunc("/app/simulate/diff", txBytes)
+	if err != nil {
+		return
+	}
+	err = cdc.UnmarshalBinaryLengthPrefixed(rawRes, &res)
+	return
+}
+
 // PrintUnsignedStdTx builds an unsigned StdTx and prints it to os.Stdout.
 // Don't perform online validation or lookups if offline is true.
 func PrintUnsignedStdTx(
@@ -352,7 +384,8 @@ func buildUnsignedStdTxOffline(txBldr authtxb.TxBuilder, cliCtx context.CLIConte
 		return
 	}
 
-	return auth.NewStdTx(stdSignMsg.Msgs, stdSignMsg.Fee, nil, stdSignMsg.Memo), nil
+	stdTx = auth.NewStdTx(stdSignMsg.Msgs, stdSignMsg.Fee, nil, stdSignMsg.Memo)
+	return stdTx.WithTimeout(stdSignMsg.TimeoutHeight, stdSignMsg.Unordered), nil
 }
 
 func isTxSigner(user sdk.AccAddress, signers []sdk.AccAddress) bool {
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -15,9 +16,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/x/gas"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/params"
@@ -35,6 +39,22 @@ var (
 	DefaultNodeHome = os.ExpandEnv("$HOME/.gaiad")
 )
 
+// ModuleBasics holds the basic elements of the modules of gaia, which build
+// the codec and the default genesis state.
+var ModuleBasics = sdk.NewModuleBasicManager(
+	auth.AppModuleBasic{},
+	bank.AppModuleBasic{},
+	crisis.AppModuleBasic{},
+	distr.AppModuleBasic{},
+	gov.AppModuleBasic{},
+	mint.AppModuleBasic{},
+	slashing.AppModuleBasic{},
+	staking.AppModuleBasic{},
+	feegrant.AppModuleBasic{},
+	authz.AppModuleBasic{},
+	gas.AppModuleBasic{},
+)
+
 // Extended ABCI application
 type GaiaApp struct {
 	*bam.BaseApp
@@ -53,6 +73,8 @@ type GaiaApp struct {
 	tkeyDistr        *sdk.TransientStoreKey
 	keyGov           *sdk.KVStoreKey
 	keyFeeCollection *sdk.KVStoreKey
+	keyFeeGrant      *sdk.KVStoreKey
+	keyAuthz         *sdk.KVStoreKey
 	keyParams        *sdk.KVStoreKey
 	tkeyParams       *sdk.TransientStoreKey
 
@@ -66,7 +88,13 @@ type GaiaApp struct {
 	distrKeeper         distr.Keeper
 	govKeeper           gov.Keeper
 	crisisKeeper        crisis.Keeper
+	feeGrantKeeper      feegrant.Keeper
+	authzKeeper         authz.Keeper
+	gasKeeper           gas.Keeper
 	paramsKeeper        params.Keeper
+
+	// the module manager
+	mm *sdk.ModuleManager
 }
 
 // NewGaiaApp returns a reference to an initialized GaiaApp.
@@ -93,6 +121,8 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		keySlashing:      sdk.NewKVStoreKey(slashing.StoreKey),
 		keyGov:           sdk.NewKVStoreKey(gov.StoreKey),
 		keyFeeCollection: sdk.NewKVStoreKey(auth.FeeStoreKey),
+		keyFeeGrant:      sdk.NewKVStoreKey(feegrant.StoreKey),
+		keyAuthz:         sdk.NewKVStoreKey(authz.StoreKey),
 		keyParams:        sdk.NewKVStoreKey(params.StoreKey),
 		tkeyParams:       sdk.NewTransientStoreKey(params.TStoreKey),
 	}
@@ -112,6 +142,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		app.accountKeeper,
 		app.paramsKeeper.Subspace(bank.DefaultParamspace),
 		bank.DefaultCodespace,
+		BlockedAddrs(),
 	)
 	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(
 		app.cdc,
@@ -146,12 +177,23 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		app.paramsKeeper, app.paramsKeeper.Subspace(gov.DefaultParamspace), app.bankKeeper, &stakingKeeper,
 		gov.DefaultCodespace,
 	)
+	app.feeGrantKeeper = feegrant.NewKeeper(
+		app.cdc,
+		app.keyFeeGrant,
+	)
+	app.authzKeeper = authz.NewKeeper(
+		app.cdc,
+		app.keyAuthz,
+	)
 	app.crisisKeeper = crisis.NewKeeper(
 		app.paramsKeeper.Subspace(crisis.DefaultParamspace),
 		app.distrKeeper,
 		app.bankKeeper,
 		app.feeCollectionKeeper,
 	)
+	app.gasKeeper = gas.NewKeeper(
+		app.paramsKeeper.Subspace(gas.DefaultParamspace),
+	)
 
 	// register the staking hooks
 	// NOTE: The stakingKeeper above is passed by reference, so that it can be
@@ -160,37 +202,57 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		NewStakingHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()),
 	)
 
-	// register the crisis routes
-	bank.RegisterInvariants(&app.crisisKeeper, app.accountKeeper)
-	distr.RegisterInvariants(&app.crisisKeeper, app.distrKeeper, app.stakingKeeper)
-	staking.RegisterInvariants(&app.crisisKeeper, app.stakingKeeper, app.feeCollectionKeeper, app.distrKeeper, app.accountKeeper)
-
-	// register message routes
-	app.Router().
-		AddRoute(bank.RouterKey, bank.NewHandler(app.bankKeeper)).
-		AddRoute(staking.RouterKey, staking.NewHandler(app.stakingKeeper)).
-		AddRoute(distr.RouterKey, distr.NewHandler(app.distrKeeper)).
-		AddRoute(slashing.RouterKey, slashing.NewHandler(app.slashingKeeper)).
-		AddRoute(gov.RouterKey, gov.NewHandler(app.govKeeper)).
-		AddRoute(crisis.RouterKey, crisis.NewHandler(app.crisisKeeper))
-
-	app.QueryRouter().
-		AddRoute(auth.QuerierRoute, auth.NewQuerier(app.accountKeeper)).
-		AddRoute(distr.QuerierRoute, distr.NewQuerier(app.distrKeeper)).
-		AddRoute(gov.QuerierRoute, gov.NewQuerier(app.govKeeper)).
-		AddRoute(slashing.QuerierRoute, slashing.NewQuerier(app.slashingKeeper, app.cdc)).
-		AddRoute(staking.QuerierRoute, staking.NewQuerier(app.stakingKeeper, app.cdc)).
-		AddRoute(mint.QuerierRoute, mint.NewQuerier(app.mintKeeper))
+	// NOTE: Any module instantiated in the module manager that is later modified
+	// must be passed by reference here.
+	app.mm = sdk.NewModuleManager(
+		auth.NewAppModule(app.accountKeeper, app.feeCollectionKeeper),
+		bank.NewAppModule(app.bankKeeper, app.accountKeeper),
+		crisis.NewAppModule(&app.crisisKeeper),
+		distr.NewAppModule(app.distrKeeper, app.stakingKeeper),
+		gov.NewAppModule(app.govKeeper),
+		mint.NewAppModule(app.mintKeeper),
+		slashing.NewAppModule(app.slashingKeeper),
+		staking.NewAppModule(app.stakingKeeper, app.feeCollectionKeeper, app.distrKeeper, app.accountKeeper),
+		feegrant.NewAppModule(app.feeGrantKeeper),
+		authz.NewAppModule(app.authzKeeper),
+		gas.NewAppModule(app.gasKeeper),
+	)
+
+	// slash anyone who double signed after the rewards of the previous block
+	// are distributed, so that there is nothing left over in the validator fee
+	// pool, so as to keep the CanWithdrawInvariant invariant.
+	// TODO: slashing should really happen at EndBlocker.
+	app.mm.SetOrderBeginBlockers(mint.ModuleName, distr.ModuleName, slashing.ModuleName,
+		auth.ModuleName, bank.ModuleName, crisis.ModuleName, gov.ModuleName, staking.ModuleName,
+		feegrant.ModuleName, authz.ModuleName, gas.ModuleName)
+	app.mm.SetOrderEndBlockers(auth.ModuleName, gov.ModuleName, staking.ModuleName,
+		bank.ModuleName, crisis.ModuleName, distr.ModuleName, mint.ModuleName, slashing.ModuleName,
+		feegrant.ModuleName, authz.ModuleName, gas.ModuleName)
+
+	// distribution must be initialized before staking, whose hooks update it,
+	// and slashing after staking, as it needs the genesis validators.
+	app.mm.SetOrderInitGenesis(distr.ModuleName, staking.ModuleName, auth.ModuleName,
+		bank.ModuleName, slashing.ModuleName, gov.ModuleName, crisis.ModuleName, mint.ModuleName,
+		feegrant.ModuleName, authz.ModuleName, gas.ModuleName)
+
+	// the invariants are registered before the routes, so that the crisis
+	// handler can verify all of them
+	app.mm.RegisterInvariants(&app.crisisKeeper)
+	app.mm.RegisterRoutes(app.Router(), app.MsgRouter(), app.QueryRouter())
 
 	// initialize BaseApp
 	app.MountStores(app.keyMain, app.keyAccount, app.keyStaking, app.keyMint, app.keyDistr,
-		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams,
-		app.tkeyParams, app.tkeyStaking, app.tkeyDistr,
+		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyFeeGrant, app.keyAuthz,
+		app.keyParams, app.tkeyParams, app.tkeyStaking, app.tkeyDistr,
 	)
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper, app.feeCollectionKeeper))
+	app.SetAnteHandler(auth.NewAnteHandlerWithFeeGrants(app.accountKeeper, app.feeCollectionKeeper, app.feeGrantKeeper))
+	app.SetMsgAuthorizer(app.authzKeeper.Authorize)
 	app.SetEndBlocker(app.EndBlocker)
+	app.SetGasScheduler(app.gasKeeper.GasSchedule)
+	app.mm.SetBlockGasCapper(app.gasKeeper.BlockGasCaps)
+	app.SetParamStore(app.paramsKeeper.Subspace(bam.Paramspace).WithKeyTable(bam.ConsensusParamsKeyTable()))
 
 	if loadLatest {
 		err := app.LoadLatestVersion(app.keyMain)
@@ -202,62 +264,57 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 	return app
 }
 
+// BlockedAddrs returns the set of module-managed addresses that users are not
+// allowed to send funds to through bank messages.
+func BlockedAddrs() map[string]bool {
+	return map[string]bool{
+		gov.DepositedCoinsAccAddr.String():     true,
+		gov.BurnedDepositCoinsAccAddr.String(): true,
+	}
+}
+
 // custom tx codec
 func MakeCodec() *codec.Codec {
 	var cdc = codec.New()
-	bank.RegisterCodec(cdc)
-	staking.RegisterCodec(cdc)
-	distr.RegisterCodec(cdc)
-	slashing.RegisterCodec(cdc)
-	gov.RegisterCodec(cdc)
-	auth.RegisterCodec(cdc)
-	crisis.RegisterCodec(cdc)
+	ModuleBasics.RegisterCodecs(cdc)
 	sdk.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
 	return cdc
 }
 
-// application updates every end block
+// application updates every begin block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
-	// mint new tokens for the previous block
-	mint.BeginBlocker(ctx, app.mintKeeper)
-
-	// distribute rewards for the previous block
-	distr.BeginBlocker(ctx, req, app.distrKeeper)
-
-	// slash anyone who double signed.
-	// NOTE: This should happen after distr.BeginBlocker so that
-	// there is nothing left over in the validator fee pool,
-	// so as to keep the CanWithdrawInvariant invariant.
-	// TODO: This should really happen at EndBlocker.
-	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
-
-	return abci.ResponseBeginBlock{
-		Tags: tags.ToKVPairs(),
-	}
+	return app.mm.BeginBlock(ctx, req)
 }
 
 // application updates every end block
 // nolint: unparam
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-	tags := gov.EndBlocker(ctx, app.govKeeper)
-	validatorUpdates, endBlockerTags := staking.EndBlocker(ctx, app.stakingKeeper)
-	tags = append(tags, endBlockerTags...)
+	res := app.mm.EndBlock(ctx, req)
 
 	if app.invCheckPeriod != 0 && ctx.BlockHeight()%int64(app.invCheckPeriod) == 0 {
 		app.assertRuntimeInvariants()
 	}
 
-	return abci.ResponseEndBlock{
-		ValidatorUpdates: validatorUpdates,
-		Tags:             tags,
-	}
+	return res
 }
 
 // initialize store from a genesis state
-func (app *GaiaApp) initFromGenesisState(ctx sdk.Context, genesisState GenesisState) []abci.ValidatorUpdate {
+func (app *GaiaApp) initFromGenesisState(ctx sdk.Context, stateJSON []byte) []abci.ValidatorUpdate {
+	var genesisState GenesisState
+	err := app.cdc.UnmarshalJSON(stateJSON, &genesisState)
+	if err != nil {
+		panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+		// return sdk.ErrGenesisParse("").TraceCause(err, "")
+	}
 	genesisState.Sanitize()
 
+	// the genesis states of the modules are kept under their module name
+	var moduleGenesis map[string]json.RawMessage
+	if err := json.Unmarshal(stateJSON, &moduleGenesis); err != nil {
+		panic(err)
+	}
+
 	// load the accounts
 	for _, gacc := range genesisState.Accounts {
 		acc := gacc.ToAccount()
@@ -265,22 +322,8 @@ func (app *GaiaApp) initFromGenesisState(ctx sdk.Context, genesisState GenesisSt
 		app.accountKeeper.SetAccount(ctx, acc)
 	}
 
-	// initialize distribution (must happen before staking)
-	distr.InitGenesis(ctx, app.distrKeeper, genesisState.DistrData)
-
-	// load the initial staking information
-	validators, err := staking.InitGenesis(ctx, app.stakingKeeper, genesisState.StakingData)
-	if err != nil {
-		panic(err) // TODO find a way to do this w/o panics
-	}
-
-	// initialize module-specific stores
-	auth.InitGenesis(ctx, app.accountKeeper, app.feeCollectionKeeper, genesisState.AuthData)
-	bank.InitGenesis(ctx, app.bankKeeper, genesisState.BankData)
-	slashing.InitGenesis(ctx, app.slashingKeeper, genesisState.SlashingData, genesisState.StakingData.Validators.ToSDKValidators())
-	gov.InitGenesis(ctx, app.govKeeper, genesisState.GovData)
-	crisis.InitGenesis(ctx, app.crisisKeeper, genesisState.CrisisData)
-	mint.InitGenesis(ctx, app.mintKeeper, genesisState.MintData)
+	// initialize the modules
+	validators := app.mm.InitGenesis(ctx, app.cdc, moduleGenesis)
 
 	// validate genesis state
 	if err := GaiaValidateGenesisState(genesisState); err != nil {
@@ -311,14 +354,7 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	stateJSON := req.AppStateBytes
 	// TODO is this now the whole genesis file?
 
-	var genesisState GenesisState
-	err := app.cdc.UnmarshalJSON(stateJSON, &genesisState)
-	if err != nil {
-		panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
-		// return sdk.ErrGenesisParse("").TraceCause(err, "")
-	}
-
-	validators := app.initFromGenesisState(ctx, genesisState)
+	validators := app.initFromGenesisState(ctx, stateJSON)
 
 	// sanity check
 	if len(req.Validators) > 0 {
@@ -1,9 +1,11 @@
 package app
 
 import (
+	"encoding/json"
 	"os"
 	"testing"
 
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
 
@@ -12,8 +14,12 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/x/gas"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
@@ -38,6 +44,9 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 		gov.DefaultGenesisState(),
 		crisis.DefaultGenesisState(),
 		slashing.DefaultGenesisState(),
+		feegrant.DefaultGenesisState(),
+		authz.DefaultGenesisState(),
+		gas.DefaultGenesisState(),
 	)
 
 	stateBytes, err := codec.MarshalJSONIndent(gapp.cdc, genesisState)
@@ -63,3 +72,62 @@ func TestGaiadExport(t *testing.T) {
 	_, _, err := newGapp.ExportAppStateAndValidators(false, []string{})
 	require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
 }
+
+func TestConsensusParamsChange(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), db.NewMemDB(), nil, true, 0)
+	stateBytes, err := codec.MarshalJSONIndent(gapp.cdc, NewDefaultGenesisState())
+	require.NoError(t, err)
+
+	gapp.InitChain(abci.RequestInitChain{
+		AppStateBytes: stateBytes,
+		ConsensusParams: &abci.ConsensusParams{
+			Block: &abci.BlockParams{MaxBytes: 1000, MaxGas: 100},
+		},
+	})
+	gapp.Commit()
+
+	header := abci.Header{Height: gapp.LastBlockHeight() + 1}
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := gapp.NewContext(false, header)
+
+	// the consensus params can be changed through governance, with their
+	// values validated
+	change := func(value string) gov.ParamChange {
+		return gov.NewParamChange(bam.Paramspace, string(bam.ParamStoreKeyBlockParams), value)
+	}
+	require.NotNil(t, gapp.govKeeper.ApplyParamChanges(ctx, []gov.ParamChange{change(`{"max_bytes":"0","max_gas":"200"}`)}))
+	require.Nil(t, gapp.govKeeper.ApplyParamChanges(ctx, []gov.ParamChange{change(`{"max_bytes":"1000","max_gas":"200"}`)}))
+
+	res := gapp.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	require.Equal(t, &abci.BlockParams{MaxBytes: 1000, MaxGas: 200}, res.ConsensusParamUpdates.Block)
+}
+
+func TestGaiaAppMsgs(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), db.NewMemDB(), nil, true, 0)
+
+	// every module with messages routes them by type, so that all of them are
+	// listed
+	var declared int
+	for name, module := range gapp.mm.Modules {
+		msgModule, ok := module.(sdk.AppModuleMsgHandlers)
+		if module.Route() != "" {
+			require.True(t, ok, name)
+		}
+		if ok {
+			declared += len(msgModule.Msgs())
+		}
+	}
+
+	res := gapp.Query(abci.RequestQuery{Path: "/app/msgs"})
+	require.True(t, res.IsOK(), res.Log)
+	var msgs []sdk.MsgInfo
+	require.NoError(t, json.Unmarshal(res.Value, &msgs))
+	require.Len(t, msgs, declared)
+
+	types := make(map[string]bool)
+	for _, msg := range msgs {
+		types[msg.Route+"/"+msg.Type] = true
+	}
+	require.True(t, types["gov/vote"])
+	require.True(t, types["staking/create_validator"])
+}
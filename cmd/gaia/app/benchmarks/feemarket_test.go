@@ -0,0 +1,135 @@
+package app
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+const (
+	feeMarketGas         = 100000 // gas wanted by every tx
+	feeMarketMaxGasPrice = 10     // maximum random gas price paid, in stake
+)
+
+// mempoolTx is a tx accepted by CheckTx along with its priority.
+type mempoolTx struct {
+	bz       []byte
+	from     int
+	priority int64
+}
+
+// feeMarket runs blocks of a gaia app under congestion: every account without
+// a pending tx submits a bank send paying a random gas price, while only the
+// benchTxs txs of the highest priorities are included in each block. The txs
+// left in the mempool are rechecked after every block.
+type feeMarket struct {
+	*blockBenchmark
+	r       *rand.Rand
+	mempool []mempoolTx
+	pending map[int]bool // accounts with a tx in the mempool
+}
+
+// submitTxs checks a new tx from every account without a pending tx.
+func (fm *feeMarket) submitTxs(b *testing.B) {
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1))
+	for from := range fm.addrs {
+		if fm.pending[from] {
+			continue
+		}
+
+		gasPrice := fm.r.Int63n(feeMarketMaxGasPrice) + 1
+		fee := auth.NewStdFee(feeMarketGas, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, gasPrice*feeMarketGas)))
+		msgs := []sdk.Msg{bank.NewMsgSend(fm.addrs[from], fm.addrs[(from+1)%benchAccounts], coins)}
+		sig, err := fm.privs[from].Sign(auth.StdSignBytes("", uint64(from), fm.seqs[from], 0, false, fee, msgs, ""))
+		if err != nil {
+			b.Fatal(err)
+		}
+		tx := auth.NewStdTx(msgs, fee, []auth.StdSignature{{PubKey: fm.privs[from].PubKey(), Signature: sig}}, "")
+		fm.seqs[from]++
+
+		bz := fm.cdc.MustMarshalBinaryLengthPrefixed(tx)
+		fm.mempool = append(fm.mempool, mempoolTx{bz: bz, from: from, priority: fm.checkTx(b, bz)})
+		fm.pending[from] = true
+	}
+}
+
+// checkTx checks a tx, returning its priority.
+func (fm *feeMarket) checkTx(b *testing.B, bz []byte) int64 {
+	res := fm.app.CheckTx(bz)
+	if !res.IsOK() {
+		b.Fatalf("check tx failed at height %d: %s", fm.height, res.Log)
+	}
+
+	for _, tag := range res.Tags {
+		if string(tag.Key) == sdk.EventTypeTx+"."+sdk.AttributeKeyPriority {
+			priority, err := strconv.ParseInt(string(tag.Value), 10, 64)
+			if err != nil {
+				b.Fatal(err)
+			}
+			return priority
+		}
+	}
+
+	b.Fatalf("no priority returned by check tx")
+	return 0
+}
+
+// runBlock delivers the txs of the highest priorities in a new block, then
+// commits it and rechecks the txs left in the mempool.
+func (fm *feeMarket) runBlock(b *testing.B) {
+	sort.SliceStable(fm.mempool, func(i, j int) bool {
+		return fm.mempool[i].priority > fm.mempool[j].priority
+	})
+
+	n := benchTxs
+	if n > len(fm.mempool) {
+		n = len(fm.mempool)
+	}
+
+	txs := make([][]byte, n)
+	for i, tx := range fm.mempool[:n] {
+		txs[i] = tx.bz
+		delete(fm.pending, tx.from)
+	}
+	fm.mempool = fm.mempool[n:]
+	fm.blockBenchmark.runBlock(b, txs)
+
+	for _, tx := range fm.mempool {
+		fm.checkTx(b, tx.bz)
+	}
+}
+
+// Every op is a block including benchTxs txs out of a backlog of twice as many,
+// along with the checks of the submitted txs and the rechecks of the others.
+func BenchmarkFeeMarketBlocks(b *testing.B) {
+	bb, cleanup := newBlockBenchmark(b, baseapp.SetMinGasPrices("1.0"+sdk.DefaultBondDenom))
+	defer cleanup()
+
+	fm := &feeMarket{
+		blockBenchmark: bb,
+		r:              rand.New(rand.NewSource(42)),
+		pending:        make(map[int]bool),
+	}
+
+	// commit a first block, as the txs are checked against the genesis
+	// block otherwise
+	bb.app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: bb.height + 1}})
+	bb.app.EndBlock(abci.RequestEndBlock{})
+	bb.app.Commit()
+	bb.height++
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fm.submitTxs(b)
+		fm.runBlock(b)
+	}
+}
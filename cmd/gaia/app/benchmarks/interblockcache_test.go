@@ -0,0 +1,150 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/cache"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+)
+
+const (
+	benchAccounts = 100
+	benchTxs      = 50 // per block
+	benchCoins    = 1000000000
+)
+
+// blockBenchmark runs blocks of a gaia app persisted in a goleveldb database.
+type blockBenchmark struct {
+	app    *app.GaiaApp
+	cdc    *codec.Codec
+	height int64
+	privs  []crypto.PrivKey
+	addrs  []sdk.AccAddress
+	seqs   []uint64
+}
+
+func newBlockBenchmark(b *testing.B, options ...func(*baseapp.BaseApp)) (*blockBenchmark, func()) {
+	dir, err := ioutil.TempDir("", "goleveldb-gaia-block-bench")
+	require.NoError(b, err)
+	db, err := sdk.NewLevelDB("application", dir)
+	require.NoError(b, err)
+	cleanup := func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+
+	bb := &blockBenchmark{
+		app:  app.NewGaiaApp(log.NewNopLogger(), db, nil, true, 0, options...),
+		cdc:  app.MakeCodec(),
+		seqs: make([]uint64, benchAccounts),
+	}
+
+	genesisState := app.NewDefaultGenesisState()
+	for i := 0; i < benchAccounts; i++ {
+		priv := secp256k1.GenPrivKeySecp256k1([]byte{byte(i)})
+		addr := sdk.AccAddress(priv.PubKey().Address())
+		bb.privs = append(bb.privs, priv)
+		bb.addrs = append(bb.addrs, addr)
+
+		acc := auth.NewBaseAccountWithAddress(addr)
+		acc.Coins = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, benchCoins))
+		genesisState.Accounts = append(genesisState.Accounts, app.NewGenesisAccount(&acc))
+	}
+	genesisState.StakingData.Pool.NotBondedTokens = sdk.NewInt(benchAccounts * benchCoins)
+	stateBytes, err := codec.MarshalJSONIndent(bb.cdc, genesisState)
+	require.NoError(b, err)
+
+	bb.app.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+	bb.app.Commit()
+	bb.height = 1
+	return bb, cleanup
+}
+
+// sendTxs returns n signed bank sends between the accounts, round robin.
+func (bb *blockBenchmark) sendTxs(n int) [][]byte {
+	txs := make([][]byte, n)
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1))
+	for i := range txs {
+		from := (int(bb.height)*n + i) % benchAccounts
+		to := (from + 1) % benchAccounts
+		msg := bank.NewMsgSend(bb.addrs[from], bb.addrs[to], coins)
+		tx := mock.GenTx([]sdk.Msg{msg}, []uint64{uint64(from)}, []uint64{bb.seqs[from]}, bb.privs[from])
+		bb.seqs[from]++
+		txs[i] = bb.cdc.MustMarshalBinaryLengthPrefixed(tx)
+	}
+	return txs
+}
+
+// runBlock delivers the given txs in a new block and commits it, the commit
+// not being timed.
+func (bb *blockBenchmark) runBlock(b *testing.B, txs [][]byte) {
+	bb.height++
+	bb.app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: bb.height}})
+	for _, tx := range txs {
+		res := bb.app.DeliverTx(tx)
+		if !res.IsOK() {
+			b.Fatalf("tx failed at height %d: %s", bb.height, res.Log)
+		}
+	}
+	bb.app.EndBlock(abci.RequestEndBlock{Height: bb.height})
+
+	b.StopTimer()
+	bb.app.Commit()
+	b.StartTimer()
+}
+
+func interBlockCacheOpt() func(*baseapp.BaseApp) {
+	return baseapp.SetInterBlockCache(cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize))
+}
+
+func benchmarkEmptyBlocks(b *testing.B, options ...func(*baseapp.BaseApp)) {
+	bb, cleanup := newBlockBenchmark(b, options...)
+	defer cleanup()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bb.runBlock(b, nil)
+	}
+}
+
+func benchmarkSendBlocks(b *testing.B, options ...func(*baseapp.BaseApp)) {
+	bb, cleanup := newBlockBenchmark(b, options...)
+	defer cleanup()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		txs := bb.sendTxs(benchTxs)
+		b.StartTimer()
+		bb.runBlock(b, txs)
+	}
+}
+
+// The blocks without transactions only run the begin and end blockers of the
+// modules, which read the same parameters and pool entries at every height.
+func BenchmarkEmptyBlocks(b *testing.B) {
+	b.Run("no-cache", func(b *testing.B) { benchmarkEmptyBlocks(b) })
+	b.Run("inter-block-cache", func(b *testing.B) { benchmarkEmptyBlocks(b, interBlockCacheOpt()) })
+}
+
+func BenchmarkSendBlocks(b *testing.B) {
+	b.Run("no-cache", func(b *testing.B) { benchmarkSendBlocks(b) })
+	b.Run("inter-block-cache", func(b *testing.B) { benchmarkSendBlocks(b, interBlockCacheOpt()) })
+}
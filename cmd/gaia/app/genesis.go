@@ -16,9 +16,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/x/gas"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
@@ -42,6 +45,9 @@ type GenesisState struct {
 	GovData      gov.GenesisState      `json:"gov"`
 	CrisisData   crisis.GenesisState   `json:"crisis"`
 	SlashingData slashing.GenesisState `json:"slashing"`
+	FeeGrantData feegrant.GenesisState `json:"feegrant"`
+	AuthzData    authz.GenesisState    `json:"authz"`
+	GasData      gas.GenesisState      `json:"gas"`
 	GenTxs       []json.RawMessage     `json:"gentxs"`
 }
 
@@ -49,7 +55,8 @@ func NewGenesisState(accounts []GenesisAccount, authData auth.GenesisState,
 	bankData bank.GenesisState,
 	stakingData staking.GenesisState, mintData mint.GenesisState,
 	distrData distr.GenesisState, govData gov.GenesisState, crisisData crisis.GenesisState,
-	slashingData slashing.GenesisState) GenesisState {
+	slashingData slashing.GenesisState, feeGrantData feegrant.GenesisState,
+	authzData authz.GenesisState, gasData gas.GenesisState) GenesisState {
 
 	return GenesisState{
 		Accounts:     accounts,
@@ -61,6 +68,9 @@ func NewGenesisState(accounts []GenesisAccount, authData auth.GenesisState,
 		GovData:      govData,
 		CrisisData:   crisisData,
 		SlashingData: slashingData,
+		FeeGrantData: feeGrantData,
+		AuthzData:    authzData,
+		GasData:      gasData,
 	}
 }
 
@@ -214,6 +224,9 @@ func NewDefaultGenesisState() GenesisState {
 		GovData:      gov.DefaultGenesisState(),
 		CrisisData:   crisis.DefaultGenesisState(),
 		SlashingData: slashing.DefaultGenesisState(),
+		FeeGrantData: feegrant.DefaultGenesisState(),
+		AuthzData:    authz.DefaultGenesisState(),
+		GasData:      gas.DefaultGenesisState(),
 		GenTxs:       nil,
 	}
 }
@@ -253,6 +266,15 @@ func GaiaValidateGenesisState(genesisState GenesisState) error {
 	if err := crisis.ValidateGenesis(genesisState.CrisisData); err != nil {
 		return err
 	}
+	if err := feegrant.ValidateGenesis(genesisState.FeeGrantData); err != nil {
+		return err
+	}
+	if err := authz.ValidateGenesis(genesisState.AuthzData); err != nil {
+		return err
+	}
+	if err := gas.ValidateGenesis(genesisState.GasData); err != nil {
+		return err
+	}
 
 	return slashing.ValidateGenesis(genesisState.SlashingData)
 }
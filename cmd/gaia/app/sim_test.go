@@ -27,6 +27,7 @@ import (
 	banksim "github.com/cosmos/cosmos-sdk/x/bank/simulation"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	distrsim "github.com/cosmos/cosmos-sdk/x/distribution/simulation"
+	"github.com/cosmos/cosmos-sdk/x/gas"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	govsim "github.com/cosmos/cosmos-sdk/x/gov/simulation"
 	"github.com/cosmos/cosmos-sdk/x/mint"
@@ -154,6 +155,7 @@ func appStateRandomizedFn(r *rand.Rand, accs []simulation.Account, genesisTimest
 			TxSizeCostPerByte:      uint64(randIntBetween(r, 5, 15)),
 			SigVerifyCostED25519:   uint64(randIntBetween(r, 500, 1000)),
 			SigVerifyCostSecp256k1: uint64(randIntBetween(r, 500, 1000)),
+			MinGasPrice:            sdk.ZeroDec(),
 		},
 	}
 	fmt.Printf("Selected randomly generated auth parameters:\n\t%+v\n", authGenesis)
@@ -252,6 +254,7 @@ func appStateRandomizedFn(r *rand.Rand, accs []simulation.Account, genesisTimest
 		DistrData:    distrGenesis,
 		SlashingData: slashingGenesis,
 		GovData:      govGenesis,
+		GasData:      gas.DefaultGenesisState(),
 	}
 
 	// Marshal genesis
@@ -413,13 +416,8 @@ func TestGaiaImportExport(t *testing.T) {
 	}()
 	newApp := NewGaiaApp(log.NewNopLogger(), newDB, nil, true, 0, fauxMerkleModeOpt)
 	require.Equal(t, "GaiaApp", newApp.Name())
-	var genesisState GenesisState
-	err = app.cdc.UnmarshalJSON(appState, &genesisState)
-	if err != nil {
-		panic(err)
-	}
 	ctxB := newApp.NewContext(true, abci.Header{})
-	newApp.initFromGenesisState(ctxB, genesisState)
+	newApp.initFromGenesisState(ctxB, appState)
 
 	fmt.Printf("Comparing stores...\n")
 	ctxA := app.NewContext(true, abci.Header{})
@@ -430,13 +428,15 @@ func TestGaiaImportExport(t *testing.T) {
 	}
 	storeKeysPrefixes := []StoreKeysPrefixes{
 		{app.keyMain, newApp.keyMain, [][]byte{}},
-		{app.keyAccount, newApp.keyAccount, [][]byte{}},
+		{app.keyAccount, newApp.keyAccount, [][]byte{auth.UnorderedTxKeyPrefix}},
 		{app.keyStaking, newApp.keyStaking, [][]byte{staking.UnbondingQueueKey,
 			staking.RedelegationQueueKey, staking.ValidatorQueueKey}}, // ordering may change but it doesn't matter
 		{app.keySlashing, newApp.keySlashing, [][]byte{}},
 		{app.keyMint, newApp.keyMint, [][]byte{}},
 		{app.keyDistr, newApp.keyDistr, [][]byte{}},
 		{app.keyFeeCollection, newApp.keyFeeCollection, [][]byte{}},
+		{app.keyFeeGrant, newApp.keyFeeGrant, [][]byte{}},
+		{app.keyAuthz, newApp.keyAuthz, [][]byte{}},
 		{app.keyParams, newApp.keyParams, [][]byte{}},
 		{app.keyGov, newApp.keyGov, [][]byte{}},
 	}
@@ -397,8 +397,8 @@ func (f *Fixtures) QueryAccount(address sdk.AccAddress, flags ...string) auth.Ba
 // gaiacli query txs
 
 // QueryTxs is gaiacli query txs
-func (f *Fixtures) QueryTxs(page, limit int, tags ...string) []sdk.TxResponse {
-	cmd := fmt.Sprintf("../../../build/gaiacli query txs --page=%d --limit=%d --tags='%s' %v", page, limit, queryTags(tags), f.Flags())
+func (f *Fixtures) QueryTxs(page, limit int, events ...string) []sdk.TxResponse {
+	cmd := fmt.Sprintf("../../../build/gaiacli query txs --page=%d --limit=%d --events='%s' %v", page, limit, queryEvents(events), f.Flags())
 	out, _ := tests.ExecuteT(f.T, cmd, "")
 	var txs []sdk.TxResponse
 	cdc := app.MakeCodec()
@@ -408,8 +408,8 @@ func (f *Fixtures) QueryTxs(page, limit int, tags ...string) []sdk.TxResponse {
 }
 
 // QueryTxsInvalid query txs with wrong parameters and compare expected error
-func (f *Fixtures) QueryTxsInvalid(expectedErr error, page, limit int, tags ...string) {
-	cmd := fmt.Sprintf("../../../build/gaiacli query txs --page=%d --limit=%d --tags='%s' %v", page, limit, queryTags(tags), f.Flags())
+func (f *Fixtures) QueryTxsInvalid(expectedErr error, page, limit int, events ...string) {
+	cmd := fmt.Sprintf("../../../build/gaiacli query txs --page=%d --limit=%d --events='%s' %v", page, limit, queryEvents(events), f.Flags())
 	_, err := tests.ExecuteT(f.T, cmd, "")
 	require.EqualError(f.T, expectedErr, err)
 }
@@ -657,9 +657,9 @@ func addFlags(cmd string, flags []string) string {
 	return strings.TrimSpace(cmd)
 }
 
-func queryTags(tags []string) (out string) {
-	for _, tag := range tags {
-		out += tag + "&"
+func queryEvents(events []string) (out string) {
+	for _, event := range events {
+		out += event + "&"
 	}
 	return strings.TrimSuffix(out, "&")
 }
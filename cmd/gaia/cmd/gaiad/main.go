@@ -19,6 +19,7 @@ import (
 	gaiaInit "github.com/cosmos/cosmos-sdk/cmd/gaia/init"
 	"github.com/cosmos/cosmos-sdk/server"
 	"github.com/cosmos/cosmos-sdk/store"
+	storecache "github.com/cosmos/cosmos-sdk/store/cache"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -66,25 +67,51 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewGaiaApp(
-		logger, db, traceStore, true, invCheckPeriod,
-		baseapp.SetPruning(store.NewPruningOptionsFromString(viper.GetString("pruning"))),
+	pruningOpts, err := server.GetPruningOptionsFromFlags()
+	if err != nil {
+		panic(err)
+	}
+	storeDBs, err := server.OpenStoreDBs(viper.GetString(cli.HomeFlag))
+	if err != nil {
+		panic(err)
+	}
+
+	options := []func(*baseapp.BaseApp){
+		baseapp.SetPruning(pruningOpts),
+		baseapp.SetStoreDBs(storeDBs),
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
-	)
+		baseapp.SetQueryGasLimit(uint64(viper.GetInt64(server.FlagQueryGasLimit))),
+		baseapp.SetSnapshotOptions(store.SnapshotOptions{
+			Dir:        server.SnapshotDir(viper.GetString(cli.HomeFlag)),
+			Interval:   viper.GetInt64(server.FlagSnapshotInterval),
+			KeepRecent: viper.GetInt(server.FlagSnapshotKeepRecent),
+		}),
+	}
+	if viper.GetBool(server.FlagInterBlockCache) {
+		options = append(options, baseapp.SetInterBlockCache(
+			storecache.NewCommitKVStoreCacheManager(storecache.DefaultCommitKVStoreCacheSize)))
+	}
+
+	return app.NewGaiaApp(logger, db, traceStore, true, invCheckPeriod, options...)
 }
 
 func exportAppStateAndTMValidators(
 	logger log.Logger, db dbm.DB, traceStore io.Writer, height int64, forZeroHeight bool, jailWhiteList []string,
 ) (json.RawMessage, []tmtypes.GenesisValidator, error) {
 
+	storeDBs, err := server.OpenStoreDBs(viper.GetString(cli.HomeFlag))
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if height != -1 {
-		gApp := app.NewGaiaApp(logger, db, traceStore, false, uint(1))
+		gApp := app.NewGaiaApp(logger, db, traceStore, false, uint(1), baseapp.SetStoreDBs(storeDBs))
 		err := gApp.LoadHeight(height)
 		if err != nil {
 			return nil, nil, err
 		}
 		return gApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 	}
-	gApp := app.NewGaiaApp(logger, db, traceStore, true, uint(1))
+	gApp := app.NewGaiaApp(logger, db, traceStore, true, uint(1), baseapp.SetStoreDBs(storeDBs))
 	return gApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 }
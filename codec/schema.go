@@ -0,0 +1,168 @@
+package codec
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONRepr is implemented by the types whose custom JSON encoding can't be
+// told from their fields, such as a struct encoding one of its fields as a
+// string. JSONRepr returns a value of the type they are encoded as, from which
+// their JSON schema is derived instead.
+type JSONRepr interface {
+	JSONRepr() interface{}
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonReprType      = reflect.TypeOf((*JSONRepr)(nil)).Elem()
+)
+
+// JSONSchema returns the JSON schema of the Amino JSON encoding of the type of
+// o. As Amino, it encodes the 64 bit integers as strings, the byte slices as
+// base64 strings and the interfaces as a type name and a value.
+//
+// The schema of a type implementing json.Marshaler is inferred from the
+// encoding of its zero value, unless it implements JSONRepr.
+func JSONSchema(o interface{}) json.RawMessage {
+	bz, err := json.Marshal(jsonSchema(reflect.TypeOf(o), make(map[reflect.Type]bool)))
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func jsonSchema(rt reflect.Type, visiting map[reflect.Type]bool) map[string]interface{} {
+	if rt == nil {
+		return map[string]interface{}{}
+	}
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	// recursive types are left open past their first occurrence
+	if visiting[rt] {
+		return map[string]interface{}{}
+	}
+	visiting[rt] = true
+	defer delete(visiting, rt)
+
+	switch {
+	case rt == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+
+	case rt.Implements(jsonReprType), reflect.PtrTo(rt).Implements(jsonReprType):
+		repr := reflect.New(rt).Interface().(JSONRepr).JSONRepr()
+		return jsonSchema(reflect.TypeOf(repr), visiting)
+
+	case rt.Implements(jsonMarshalerType), reflect.PtrTo(rt).Implements(jsonMarshalerType):
+		return marshalerSchema(rt)
+	}
+
+	switch rt.Kind() {
+	case reflect.Interface:
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"type":  map[string]interface{}{"type": "string"},
+				"value": map[string]interface{}{},
+			},
+		}
+
+	case reflect.Array, reflect.Slice:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": "array", "items": jsonSchema(rt.Elem(), visiting)}
+
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchema(rt.Elem(), visiting)}
+
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		var required []string
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			name, omitEmpty := field.Name, false
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				if tag == "-" {
+					continue
+				}
+				opts := strings.Split(tag, ",")
+				if opts[0] != "" {
+					name = opts[0]
+				}
+				for _, opt := range opts[1:] {
+					omitEmpty = omitEmpty || opt == "omitempty"
+				}
+			}
+			properties[name] = jsonSchema(field.Type, visiting)
+			if !omitEmpty {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+
+	case reflect.Int64, reflect.Int:
+		return map[string]interface{}{"type": "string", "format": "int64"}
+
+	case reflect.Uint64, reflect.Uint:
+		return map[string]interface{}{"type": "string", "format": "uint64"}
+
+	case reflect.Int32, reflect.Int16, reflect.Int8,
+		reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		return map[string]interface{}{"type": "integer"}
+
+	case reflect.Float64, reflect.Float32:
+		return map[string]interface{}{"type": "number"}
+
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// marshalerSchema infers the schema of a type implementing json.Marshaler from
+// the encoding of its zero value. It is left open if the encoding fails.
+func marshalerSchema(rt reflect.Type) (schema map[string]interface{}) {
+	schema = map[string]interface{}{}
+	defer func() {
+		if r := recover(); r != nil {
+			schema = map[string]interface{}{}
+		}
+	}()
+
+	bz, err := json.Marshal(reflect.New(rt).Interface())
+	if err != nil || len(bz) == 0 {
+		return schema
+	}
+	switch bz[0] {
+	case '"':
+		schema["type"] = "string"
+	case 't', 'f':
+		schema["type"] = "boolean"
+	case '[':
+		schema["type"] = "array"
+	case '{':
+		schema["type"] = "object"
+	case 'n':
+	default:
+		schema["type"] = "number"
+	}
+	return schema
+}
@@ -0,0 +1,52 @@
+package codec
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+type schemaRepr struct {
+	Key string `json:"key"`
+}
+
+type schemaCustom struct {
+	Key crypto.PubKey
+}
+
+func (schemaCustom) MarshalJSON() ([]byte, error) { return []byte(`{}`), nil }
+func (schemaCustom) JSONRepr() interface{}        { return schemaRepr{} }
+
+type schemaStruct struct {
+	Height   int64           `json:"height"`
+	Count    int32           `json:"count"`
+	Bytes    []byte          `json:"bytes"`
+	Time     time.Time       `json:"time"`
+	Key      crypto.PubKey   `json:"key"`
+	Custom   schemaCustom    `json:"custom"`
+	Values   map[string]bool `json:"values,omitempty"`
+	Children []*schemaStruct `json:"children,omitempty"`
+	Skipped  string          `json:"-"`
+	hidden   string          // nolint: unused, structcheck
+	Untagged *uint64
+}
+
+func TestJSONSchema(t *testing.T) {
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"height": {"type": "string", "format": "int64"},
+			"count": {"type": "integer"},
+			"bytes": {"type": "string", "contentEncoding": "base64"},
+			"time": {"type": "string", "format": "date-time"},
+			"key": {"type": "object", "properties": {"type": {"type": "string"}, "value": {}}},
+			"custom": {"type": "object", "properties": {"key": {"type": "string"}}, "required": ["key"]},
+			"values": {"type": "object", "additionalProperties": {"type": "boolean"}},
+			"children": {"type": "array", "items": {}},
+			"Untagged": {"type": "string", "format": "uint64"}
+		},
+		"required": ["height", "count", "bytes", "time", "key", "custom", "Untagged"]
+	}`, string(JSONSchema(&schemaStruct{})))
+}
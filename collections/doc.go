@@ -0,0 +1,59 @@
+package collections
+
+/*
+Package collections provides typed collections kept in the KVStore of a
+module, so that keepers don't hand-roll key prefixes, key encodings and index
+maintenance.
+
+There are four collections, each stored under its own prefix of the store:
+
+	Map         keys to values
+	KeySet      a set of keys
+	Sequence    a monotonically increasing number
+	IndexedMap  a Map with secondary indexes kept in sync with its values
+
+The keys are encoded by a KeyCodec in the order of the keys, so that the
+collections are iterated over in order and over ranges. Codecs are provided for
+strings, byte slices, uint64, time and addresses, and PairKeyCodec composes two
+of them. A codec only accepts the keys of its type. The values are encoded with
+the amino codec of the module, and decoded into pointers like the store List.
+
+Basic Usage:
+
+Declare the collections in the keeper, with distinct prefixes.
+
+	type Keeper struct {
+		Proposals  collections.IndexedMap
+		ByProposer collections.MultiIndex
+		ProposalID collections.Sequence
+		Votes      collections.Map
+	}
+
+	func NewKeeper(cdc *codec.Codec, key sdk.StoreKey) Keeper {
+		byProposer := collections.NewMultiIndex(key, []byte{0x01}, collections.AccAddressKey, collections.Uint64Key,
+			func(id, p interface{}) interface{} { return p.(Proposal).Proposer })
+		return Keeper{
+			Proposals: collections.NewIndexedMap(key, []byte{0x00}, collections.Uint64Key, cdc,
+				Proposal{}, byProposer),
+			ByProposer: byProposer,
+			ProposalID: collections.NewSequence(key, []byte{0x02}),
+			Votes: collections.NewMap(key, []byte{0x03},
+				collections.PairKeyCodec(collections.Uint64Key, collections.AccAddressKey), cdc),
+		}
+	}
+
+Then use them with the context.
+
+	id := k.ProposalID.Next(ctx)
+	err := k.Proposals.Set(ctx, id, proposal)
+	ids := k.ByProposer.MatchExact(ctx, proposer)
+
+	var (
+		vote  Vote
+		votes []Vote
+	)
+	rng := new(collections.Range).Prefix(collections.PairPrefix(id))
+	page := k.Votes.Paginate(ctx, rng, collections.PageRequest{Limit: 10}, &vote, func(key interface{}) {
+		votes = append(votes, vote)
+	})
+*/
@@ -0,0 +1,264 @@
+package collections
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ErrConflict is the error returned when a value of an IndexedMap has the
+// unique key of another value.
+type ErrConflict struct {
+	UniqueKey  string
+	PrimaryKey string
+}
+
+// Error implements the error interface.
+func (err ErrConflict) Error() string {
+	return fmt.Sprintf("unique index conflict: %s is the unique key of %s", err.UniqueKey, err.PrimaryKey)
+}
+
+// Index is a secondary index of the values of an IndexedMap, which keeps it
+// in sync with the values.
+type Index interface {
+	// Reference indexes the value of a primary key, returning an error if the
+	// value cannot be indexed.
+	Reference(ctx sdk.Context, pk, value interface{}) error
+	// Unreference removes the entries of the value of a primary key.
+	Unreference(ctx sdk.Context, pk, value interface{})
+}
+
+// IndexedMap is a Map of primary keys to values with secondary indexes, which
+// are updated whenever a value is set or removed.
+type IndexedMap struct {
+	m         Map
+	valueType reflect.Type
+	indexes   []Index
+}
+
+// NewIndexedMap returns a new IndexedMap stored under the given prefix of the
+// store, with the given indexes, which must be stored under other prefixes.
+// The values have the type of valueType, which is passed to the indexes.
+func NewIndexedMap(storeKey sdk.StoreKey, prefix []byte, pkCodec KeyCodec, cdc *codec.Codec,
+	valueType interface{}, indexes ...Index) IndexedMap {
+
+	return IndexedMap{
+		m:         NewMap(storeKey, prefix, pkCodec, cdc),
+		valueType: reflect.TypeOf(valueType),
+		indexes:   indexes,
+	}
+}
+
+// Set sets the value of a primary key and updates the indexes. Nothing is
+// written if an index fails to reference the value.
+func (im IndexedMap) Set(ctx sdk.Context, pk, value interface{}) error {
+	if reflect.TypeOf(value) != im.valueType {
+		return fmt.Errorf("invalid value type %T, expected %s", value, im.valueType)
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	if old, found := im.get(cacheCtx, pk); found {
+		for _, index := range im.indexes {
+			index.Unreference(cacheCtx, pk, old)
+		}
+	}
+	for _, index := range im.indexes {
+		if err := index.Reference(cacheCtx, pk, value); err != nil {
+			return err
+		}
+	}
+	im.m.Set(cacheCtx, pk, value)
+
+	write()
+	return nil
+}
+
+// Get decodes the value of a primary key into ptr and returns true if it is
+// set.
+func (im IndexedMap) Get(ctx sdk.Context, pk, ptr interface{}) bool {
+	return im.m.Get(ctx, pk, ptr)
+}
+
+// Has returns true if the primary key is set.
+func (im IndexedMap) Has(ctx sdk.Context, pk interface{}) bool {
+	return im.m.Has(ctx, pk)
+}
+
+// Remove removes a primary key and its index entries, if it is set.
+func (im IndexedMap) Remove(ctx sdk.Context, pk interface{}) {
+	old, found := im.get(ctx, pk)
+	if !found {
+		return
+	}
+	for _, index := range im.indexes {
+		index.Unreference(ctx, pk, old)
+	}
+	im.m.Remove(ctx, pk)
+}
+
+// Iterate returns an iterator over the primary keys in the given range, nil
+// being all the keys. The iterator must be closed.
+func (im IndexedMap) Iterate(ctx sdk.Context, rng *Range) Iterator {
+	return im.m.Iterate(ctx, rng)
+}
+
+// Walk decodes the values of the primary keys in the given range into ptr and
+// calls fn on the keys, in order, until it returns true.
+func (im IndexedMap) Walk(ctx sdk.Context, rng *Range, ptr interface{}, fn func(pk interface{}) (stop bool)) {
+	im.m.Walk(ctx, rng, ptr, fn)
+}
+
+// Paginate decodes the values of a page of the primary keys in the given
+// range into ptr and calls fn on the keys, in order.
+func (im IndexedMap) Paginate(ctx sdk.Context, rng *Range, req PageRequest, ptr interface{}, fn func(pk interface{})) PageResponse {
+	return im.m.Paginate(ctx, rng, req, ptr, fn)
+}
+
+// get returns the value of a primary key, if it is set.
+func (im IndexedMap) get(ctx sdk.Context, pk interface{}) (interface{}, bool) {
+	ptr := reflect.New(im.valueType)
+	if !im.m.Get(ctx, pk, ptr.Interface()) {
+		return nil, false
+	}
+	return ptr.Elem().Interface(), true
+}
+
+// MultiIndex indexes the primary keys of an IndexedMap by a reference key
+// derived from their values, which many values can share.
+type MultiIndex struct {
+	refKeys   KeySet
+	getRefKey func(pk, value interface{}) interface{}
+}
+
+var _ Index = MultiIndex{}
+
+// NewMultiIndex returns a new MultiIndex stored under the given prefix of the
+// store, the reference key of a value being returned by getRefKey.
+func NewMultiIndex(storeKey sdk.StoreKey, prefix []byte, refKeyCodec, pkCodec KeyCodec,
+	getRefKey func(pk, value interface{}) interface{}) MultiIndex {
+
+	return MultiIndex{
+		refKeys:   NewKeySet(storeKey, prefix, PairKeyCodec(refKeyCodec, pkCodec)),
+		getRefKey: getRefKey,
+	}
+}
+
+// Reference implements Index.
+func (mi MultiIndex) Reference(ctx sdk.Context, pk, value interface{}) error {
+	mi.refKeys.Set(ctx, Join(mi.getRefKey(pk, value), pk))
+	return nil
+}
+
+// Unreference implements Index.
+func (mi MultiIndex) Unreference(ctx sdk.Context, pk, value interface{}) {
+	mi.refKeys.Remove(ctx, Join(mi.getRefKey(pk, value), pk))
+}
+
+// Has returns true if the value of the primary key has the reference key.
+func (mi MultiIndex) Has(ctx sdk.Context, refKey, pk interface{}) bool {
+	return mi.refKeys.Has(ctx, Join(refKey, pk))
+}
+
+// Walk calls fn on the primary keys of the values with the reference key, in
+// order, until it returns true.
+func (mi MultiIndex) Walk(ctx sdk.Context, refKey interface{}, fn func(pk interface{}) (stop bool)) {
+	mi.refKeys.Walk(ctx, mi.refKeyRange(refKey), func(key interface{}) bool {
+		return fn(key.(Pair).K2())
+	})
+}
+
+// MatchExact returns the primary keys of the values with the reference key.
+func (mi MultiIndex) MatchExact(ctx sdk.Context, refKey interface{}) (pks []interface{}) {
+	mi.Walk(ctx, refKey, func(pk interface{}) bool {
+		pks = append(pks, pk)
+		return false
+	})
+	return pks
+}
+
+// Paginate calls fn on a page of the primary keys of the values with the
+// reference key, in order.
+func (mi MultiIndex) Paginate(ctx sdk.Context, refKey interface{}, req PageRequest, fn func(pk interface{})) PageResponse {
+	return mi.refKeys.Paginate(ctx, mi.refKeyRange(refKey), req, func(key interface{}) {
+		fn(key.(Pair).K2())
+	})
+}
+
+func (mi MultiIndex) refKeyRange(refKey interface{}) *Range {
+	return new(Range).Prefix(PairPrefix(refKey))
+}
+
+// UniqueIndex indexes the primary keys of an IndexedMap by a unique key
+// derived from their values, setting a value with the unique key of another
+// value failing with ErrConflict.
+type UniqueIndex struct {
+	uniqueKeys   Map
+	pkCodec      KeyCodec
+	getUniqueKey func(pk, value interface{}) interface{}
+}
+
+var _ Index = UniqueIndex{}
+
+// NewUniqueIndex returns a new UniqueIndex stored under the given prefix of
+// the store, the unique key of a value being returned by getUniqueKey.
+func NewUniqueIndex(storeKey sdk.StoreKey, prefix []byte, uniqueKeyCodec, pkCodec KeyCodec,
+	getUniqueKey func(pk, value interface{}) interface{}) UniqueIndex {
+
+	// the primary keys are stored with their encoding rather than with amino
+	return UniqueIndex{
+		uniqueKeys:   NewMap(storeKey, prefix, uniqueKeyCodec, nil),
+		pkCodec:      pkCodec,
+		getUniqueKey: getUniqueKey,
+	}
+}
+
+// Reference implements Index.
+func (ui UniqueIndex) Reference(ctx sdk.Context, pk, value interface{}) error {
+	uniqueKey := ui.getUniqueKey(pk, value)
+	bz := ui.encodePK(pk)
+	if other := ui.uniqueKeys.getBytes(ctx, uniqueKey); other != nil && !bytes.Equal(other, bz) {
+		return ErrConflict{
+			UniqueKey:  ui.uniqueKeys.keyCodec.Stringify(uniqueKey),
+			PrimaryKey: ui.pkCodec.Stringify(ui.decodePK(other)),
+		}
+	}
+	ui.uniqueKeys.setBytes(ctx, uniqueKey, bz)
+	return nil
+}
+
+// Unreference implements Index.
+func (ui UniqueIndex) Unreference(ctx sdk.Context, pk, value interface{}) {
+	ui.uniqueKeys.Remove(ctx, ui.getUniqueKey(pk, value))
+}
+
+// MatchExact returns the primary key of the value with the unique key, if
+// any.
+func (ui UniqueIndex) MatchExact(ctx sdk.Context, uniqueKey interface{}) (interface{}, bool) {
+	bz := ui.uniqueKeys.getBytes(ctx, uniqueKey)
+	if bz == nil {
+		return nil, false
+	}
+	return ui.decodePK(bz), true
+}
+
+func (ui UniqueIndex) encodePK(pk interface{}) []byte {
+	bz, err := ui.pkCodec.Encode(pk)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode key %s: %v", ui.pkCodec.Stringify(pk), err))
+	}
+	if bz == nil {
+		bz = []byte{}
+	}
+	return bz
+}
+
+func (ui UniqueIndex) decodePK(bz []byte) interface{} {
+	pk, err := ui.pkCodec.Decode(bz)
+	if err != nil {
+		panic(fmt.Sprintf("failed to decode key %X: %v", bz, err))
+	}
+	return pk
+}
@@ -0,0 +1,89 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type testAccount struct {
+	Owner sdk.AccAddress
+	Name  string
+}
+
+type testKeeper struct {
+	Accounts IndexedMap
+	ByOwner  MultiIndex
+	ByName   UniqueIndex
+	ID       Sequence
+}
+
+func newTestKeeper(key sdk.StoreKey) testKeeper {
+	cdc := codec.New()
+	byOwner := NewMultiIndex(key, []byte{0x01}, AccAddressKey, Uint64Key,
+		func(id, acc interface{}) interface{} { return acc.(testAccount).Owner })
+	byName := NewUniqueIndex(key, []byte{0x02}, StringKey, Uint64Key,
+		func(id, acc interface{}) interface{} { return acc.(testAccount).Name })
+
+	return testKeeper{
+		Accounts: NewIndexedMap(key, []byte{0x00}, Uint64Key, cdc, testAccount{}, byOwner, byName),
+		ByOwner:  byOwner,
+		ByName:   byName,
+		ID:       NewSequence(key, []byte{0x03}),
+	}
+}
+
+func TestIndexedMap(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	k := newTestKeeper(key)
+	alice, bob := sdk.AccAddress("alice"), sdk.AccAddress("bob")
+
+	for _, acc := range []testAccount{{alice, "a1"}, {bob, "b1"}, {alice, "a2"}} {
+		require.NoError(t, k.Accounts.Set(ctx, k.ID.Next(ctx), acc))
+	}
+	require.Equal(t, []interface{}{uint64(1), uint64(3)}, k.ByOwner.MatchExact(ctx, alice))
+	require.Equal(t, []interface{}{uint64(2)}, k.ByOwner.MatchExact(ctx, bob))
+	id, found := k.ByName.MatchExact(ctx, "a2")
+	require.True(t, found)
+	require.Equal(t, uint64(3), id)
+
+	// updating a value moves its index entries
+	require.NoError(t, k.Accounts.Set(ctx, uint64(3), testAccount{bob, "b2"}))
+	require.Equal(t, []interface{}{uint64(1)}, k.ByOwner.MatchExact(ctx, alice))
+	require.Equal(t, []interface{}{uint64(2), uint64(3)}, k.ByOwner.MatchExact(ctx, bob))
+	_, found = k.ByName.MatchExact(ctx, "a2")
+	require.False(t, found)
+	require.True(t, k.ByOwner.Has(ctx, bob, uint64(3)))
+
+	var pks []interface{}
+	res := k.ByOwner.Paginate(ctx, bob, PageRequest{Limit: 1}, func(pk interface{}) {
+		pks = append(pks, pk)
+	})
+	require.Equal(t, []interface{}{uint64(2)}, pks)
+	require.NotNil(t, res.NextKey)
+
+	// a unique key conflict writes nothing
+	err := k.Accounts.Set(ctx, uint64(1), testAccount{bob, "b1"})
+	require.Error(t, err)
+	require.Equal(t, ErrConflict{UniqueKey: "b1", PrimaryKey: "2"}, err)
+	var acc testAccount
+	require.True(t, k.Accounts.Get(ctx, uint64(1), &acc))
+	require.Equal(t, testAccount{alice, "a1"}, acc)
+	require.Equal(t, []interface{}{uint64(1)}, k.ByOwner.MatchExact(ctx, alice))
+
+	// the values of another type are rejected
+	require.Error(t, k.Accounts.Set(ctx, uint64(4), &testAccount{bob, "b4"}))
+	require.False(t, k.Accounts.Has(ctx, uint64(4)))
+
+	// removing a value removes its index entries
+	k.Accounts.Remove(ctx, uint64(2))
+	require.False(t, k.Accounts.Has(ctx, uint64(2)))
+	require.Equal(t, []interface{}{uint64(3)}, k.ByOwner.MatchExact(ctx, bob))
+	_, found = k.ByName.MatchExact(ctx, "b1")
+	require.False(t, found)
+	require.NoError(t, k.Accounts.Set(ctx, uint64(1), testAccount{bob, "b1"}))
+}
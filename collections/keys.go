@@ -0,0 +1,214 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KeyCodec encodes keys of a given type to bytes which sort in the order of
+// the keys, so that collections can be iterated over in order and over
+// ranges. Encoding a key of another type fails.
+//
+// The non-terminal encoding is used when the key is followed by another key,
+// as the first key of a Pair. It must be self-delimiting, so that the end of
+// the key can be found, and keep the order of the keys of the same length.
+type KeyCodec interface {
+	// Encode returns the encoding of the key.
+	Encode(key interface{}) ([]byte, error)
+	// Decode decodes a key from all the given bytes.
+	Decode(b []byte) (interface{}, error)
+	// EncodeNonTerminal returns the self-delimiting encoding of the key.
+	EncodeNonTerminal(key interface{}) ([]byte, error)
+	// DecodeNonTerminal decodes a key from the start of the given bytes and
+	// returns the number of bytes read.
+	DecodeNonTerminal(b []byte) (int, interface{}, error)
+	// Stringify returns a human readable representation of the key.
+	Stringify(key interface{}) string
+}
+
+// The key codecs of the common key types.
+var (
+	// StringKey encodes strings as is, and as null-terminated strings when
+	// they are not the last key. Non-terminal strings cannot contain a null
+	// byte.
+	StringKey KeyCodec = stringKey{}
+
+	// BytesKey encodes byte slices as is, and prefixed with their length when
+	// they are not the last key, so that they are sorted by length first.
+	// Non-terminal slices are limited to 255 bytes.
+	BytesKey KeyCodec = bytesKey{reflect.TypeOf([]byte{})}
+
+	// Uint64Key encodes integers in 8 bytes, big-endian.
+	Uint64Key KeyCodec = uint64Key{}
+
+	// TimeKey encodes times in UTC with sdk.FormatTimeBytes, which has a fixed
+	// length.
+	TimeKey KeyCodec = timeKey{}
+
+	// AccAddressKey, ValAddressKey and ConsAddressKey encode addresses like
+	// BytesKey.
+	AccAddressKey  KeyCodec = bytesKey{reflect.TypeOf(sdk.AccAddress{})}
+	ValAddressKey  KeyCodec = bytesKey{reflect.TypeOf(sdk.ValAddress{})}
+	ConsAddressKey KeyCodec = bytesKey{reflect.TypeOf(sdk.ConsAddress{})}
+)
+
+func errKeyType(key interface{}, expected string) error {
+	return fmt.Errorf("invalid key type %T, expected %s", key, expected)
+}
+
+type stringKey struct{}
+
+func (stringKey) Encode(key interface{}) ([]byte, error) {
+	s, ok := key.(string)
+	if !ok {
+		return nil, errKeyType(key, "string")
+	}
+	return []byte(s), nil
+}
+
+func (stringKey) Decode(b []byte) (interface{}, error) {
+	return string(b), nil
+}
+
+func (k stringKey) EncodeNonTerminal(key interface{}) ([]byte, error) {
+	bz, err := k.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.IndexByte(bz, 0) >= 0 {
+		return nil, fmt.Errorf("non-terminal string key %q contains a null byte", bz)
+	}
+	return append(bz, 0), nil
+}
+
+func (stringKey) DecodeNonTerminal(b []byte) (int, interface{}, error) {
+	i := bytes.IndexByte(b, 0)
+	if i < 0 {
+		return 0, nil, fmt.Errorf("non-terminal string key %X is not null-terminated", b)
+	}
+	return i + 1, string(b[:i]), nil
+}
+
+func (stringKey) Stringify(key interface{}) string {
+	return fmt.Sprintf("%v", key)
+}
+
+// bytesKey encodes the keys of a byte slice type.
+type bytesKey struct {
+	typ reflect.Type
+}
+
+func (k bytesKey) Encode(key interface{}) ([]byte, error) {
+	if reflect.TypeOf(key) != k.typ {
+		return nil, errKeyType(key, k.typ.String())
+	}
+	return reflect.ValueOf(key).Bytes(), nil
+}
+
+func (k bytesKey) Decode(b []byte) (interface{}, error) {
+	return reflect.ValueOf(append([]byte{}, b...)).Convert(k.typ).Interface(), nil
+}
+
+func (k bytesKey) EncodeNonTerminal(key interface{}) ([]byte, error) {
+	bz, err := k.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) > 255 {
+		return nil, fmt.Errorf("non-terminal key of %d bytes is longer than 255 bytes", len(bz))
+	}
+	return append([]byte{byte(len(bz))}, bz...), nil
+}
+
+func (k bytesKey) DecodeNonTerminal(b []byte) (int, interface{}, error) {
+	if len(b) == 0 || len(b) < 1+int(b[0]) {
+		return 0, nil, fmt.Errorf("invalid non-terminal key %X", b)
+	}
+	n := 1 + int(b[0])
+	key, err := k.Decode(b[1:n])
+	return n, key, err
+}
+
+func (k bytesKey) Stringify(key interface{}) string {
+	if s, ok := key.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%X", key)
+}
+
+type uint64Key struct{}
+
+func (uint64Key) Encode(key interface{}) ([]byte, error) {
+	i, ok := key.(uint64)
+	if !ok {
+		return nil, errKeyType(key, "uint64")
+	}
+	return sdk.Uint64ToBigEndian(i), nil
+}
+
+func (uint64Key) Decode(b []byte) (interface{}, error) {
+	if len(b) != 8 {
+		return nil, fmt.Errorf("invalid uint64 key %X", b)
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+func (k uint64Key) EncodeNonTerminal(key interface{}) ([]byte, error) {
+	return k.Encode(key)
+}
+
+func (k uint64Key) DecodeNonTerminal(b []byte) (int, interface{}, error) {
+	if len(b) < 8 {
+		return 0, nil, fmt.Errorf("invalid uint64 key %X", b)
+	}
+	key, err := k.Decode(b[:8])
+	return 8, key, err
+}
+
+func (uint64Key) Stringify(key interface{}) string {
+	return fmt.Sprintf("%d", key)
+}
+
+type timeKey struct{}
+
+var timeKeyLen = len(sdk.SortableTimeFormat)
+
+func (timeKey) Encode(key interface{}) ([]byte, error) {
+	t, ok := key.(time.Time)
+	if !ok {
+		return nil, errKeyType(key, "time.Time")
+	}
+	bz := sdk.FormatTimeBytes(t)
+	if len(bz) != timeKeyLen {
+		return nil, fmt.Errorf("time key %v is out of range", t)
+	}
+	return bz, nil
+}
+
+func (timeKey) Decode(b []byte) (interface{}, error) {
+	return sdk.ParseTimeBytes(b)
+}
+
+func (k timeKey) EncodeNonTerminal(key interface{}) ([]byte, error) {
+	return k.Encode(key)
+}
+
+func (k timeKey) DecodeNonTerminal(b []byte) (int, interface{}, error) {
+	if len(b) < timeKeyLen {
+		return 0, nil, fmt.Errorf("invalid time key %X", b)
+	}
+	key, err := k.Decode(b[:timeKeyLen])
+	return timeKeyLen, key, err
+}
+
+func (timeKey) Stringify(key interface{}) string {
+	if t, ok := key.(time.Time); ok {
+		return t.UTC().Format(sdk.SortableTimeFormat)
+	}
+	return fmt.Sprintf("%v", key)
+}
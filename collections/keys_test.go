@@ -0,0 +1,92 @@
+package collections
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// checkKeyCodec checks that the keys, given in ascending order, round trip
+// and that their encodings keep their order. Keys of different lengths are
+// only ordered in their terminal encoding.
+func checkKeyCodec(t *testing.T, keyCodec KeyCodec, keys ...interface{}) {
+	var prev, prevNonTerminal []byte
+	for i, key := range keys {
+		bz, err := keyCodec.Encode(key)
+		require.NoError(t, err)
+		decoded, err := keyCodec.Decode(bz)
+		require.NoError(t, err)
+		require.Equal(t, keyCodec.Stringify(key), keyCodec.Stringify(decoded))
+
+		nonTerminal, err := keyCodec.EncodeNonTerminal(key)
+		require.NoError(t, err)
+		n, decoded, err := keyCodec.DecodeNonTerminal(append(nonTerminal, 0xff, 0x00))
+		require.NoError(t, err)
+		require.Equal(t, len(nonTerminal), n)
+		require.Equal(t, keyCodec.Stringify(key), keyCodec.Stringify(decoded))
+
+		if i > 0 {
+			require.True(t, bytes.Compare(prev, bz) < 0, "key %d", i)
+			require.True(t, bytes.Compare(prevNonTerminal, nonTerminal) < 0, "key %d", i)
+		}
+		prev, prevNonTerminal = bz, nonTerminal
+	}
+}
+
+func TestKeyCodecs(t *testing.T) {
+	checkKeyCodec(t, StringKey, "", "a", "ab", "b")
+	checkKeyCodec(t, BytesKey, []byte{0}, []byte{1}, []byte{2})
+	checkKeyCodec(t, Uint64Key, uint64(0), uint64(1), uint64(255), uint64(256), uint64(1<<63))
+	checkKeyCodec(t, TimeKey,
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 4, 1, 12, 0, 0, 1, time.UTC),
+		time.Date(2019, 4, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600)).Add(time.Second))
+	checkKeyCodec(t, AccAddressKey, sdk.AccAddress{1, 2}, sdk.AccAddress{2, 0})
+	checkKeyCodec(t, ValAddressKey, sdk.ValAddress{1, 0}, sdk.ValAddress{1, 1})
+	checkKeyCodec(t, PairKeyCodec(StringKey, Uint64Key), Join("a", uint64(2)), Join("ab", uint64(1)), Join("b", uint64(0)))
+	checkKeyCodec(t, PairKeyCodec(Uint64Key, StringKey), Join(uint64(1), "b"), Join(uint64(2), "a"))
+
+	// the keys are decoded to their type
+	key, err := AccAddressKey.Decode([]byte{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, sdk.AccAddress{1, 2}, key)
+}
+
+func TestKeyCodecErrors(t *testing.T) {
+	_, err := StringKey.EncodeNonTerminal("a\x00b")
+	require.Error(t, err)
+	_, _, err = StringKey.DecodeNonTerminal([]byte("ab"))
+	require.Error(t, err)
+
+	_, err = BytesKey.EncodeNonTerminal(make([]byte, 256))
+	require.Error(t, err)
+	_, _, err = BytesKey.DecodeNonTerminal([]byte{2, 0})
+	require.Error(t, err)
+
+	_, err = Uint64Key.Decode([]byte{1})
+	require.Error(t, err)
+	_, err = TimeKey.Encode(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Error(t, err)
+
+	// the keys of other types are rejected
+	_, err = Uint64Key.Encode(1)
+	require.Error(t, err)
+	_, err = AccAddressKey.Encode(sdk.ValAddress{1})
+	require.Error(t, err)
+	_, err = StringKey.Encode([]byte("a"))
+	require.Error(t, err)
+
+	// a pair prefix only encodes its first key
+	pairCodec := PairKeyCodec(StringKey, Uint64Key)
+	bz, err := pairCodec.Encode(PairPrefix("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("a\x00"), bz)
+	_, err = pairCodec.Encode(Pair{})
+	require.Error(t, err)
+	_, err = pairCodec.EncodeNonTerminal(PairPrefix("a"))
+	require.Error(t, err)
+}
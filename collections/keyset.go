@@ -0,0 +1,66 @@
+package collections
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KeySet is a typed set of keys kept under a prefix of a store, in the order
+// of the keys. The keys are stored with an empty value.
+type KeySet struct {
+	m Map
+}
+
+// NewKeySet returns a new KeySet stored under the given prefix of the store,
+// which must not be the prefix of any other collection or key of the store.
+func NewKeySet(storeKey sdk.StoreKey, prefix []byte, keyCodec KeyCodec) KeySet {
+	return KeySet{NewMap(storeKey, prefix, keyCodec, nil)}
+}
+
+// KeyCodec returns the codec of the keys of the set.
+func (s KeySet) KeyCodec() KeyCodec {
+	return s.m.keyCodec
+}
+
+// Set adds a key to the set.
+func (s KeySet) Set(ctx sdk.Context, key interface{}) {
+	s.m.setBytes(ctx, key, []byte{})
+}
+
+// Has returns true if the key is in the set.
+func (s KeySet) Has(ctx sdk.Context, key interface{}) bool {
+	return s.m.Has(ctx, key)
+}
+
+// Remove removes a key from the set, if it is in it.
+func (s KeySet) Remove(ctx sdk.Context, key interface{}) {
+	s.m.Remove(ctx, key)
+}
+
+// Iterate returns an iterator over the keys in the given range, nil being
+// all the keys. The iterator must be closed, and its values are not to be
+// decoded.
+func (s KeySet) Iterate(ctx sdk.Context, rng *Range) Iterator {
+	return s.m.Iterate(ctx, rng)
+}
+
+// Walk calls fn on the keys in the given range, in order, until it returns
+// true.
+//
+// CONTRACT: No writes may happen within the range while walking over it.
+func (s KeySet) Walk(ctx sdk.Context, rng *Range, fn func(key interface{}) (stop bool)) {
+	it := s.Iterate(ctx, rng)
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		if fn(it.Key()) {
+			return
+		}
+	}
+}
+
+// Paginate calls fn on a page of the keys in the given range, in order.
+func (s KeySet) Paginate(ctx sdk.Context, rng *Range, req PageRequest, fn func(key interface{})) PageResponse {
+	return paginate(ctx.KVStore(s.m.storeKey), s.m.prefix, s.m.keyCodec, rng, req, func(key, _ []byte) {
+		fn(s.m.decodeKey(key))
+	})
+}
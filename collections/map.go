@@ -0,0 +1,181 @@
+package collections
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Map is a typed map of keys to values kept under a prefix of a store. The
+// keys are stored with their encoding, so that the map is iterated over in
+// the order of its keys, and the values with the length-prefixed amino binary
+// encoding of the codec, in which their types must be registered.
+//
+// It panics when a key or value cannot be encoded or decoded, like the
+// keepers do with the codec.
+type Map struct {
+	storeKey sdk.StoreKey
+	prefix   []byte
+	keyCodec KeyCodec
+	cdc      *codec.Codec
+}
+
+// NewMap returns a new Map stored under the given prefix of the store, which
+// must not be the prefix of any other collection or key of the store.
+func NewMap(storeKey sdk.StoreKey, prefix []byte, keyCodec KeyCodec, cdc *codec.Codec) Map {
+	if len(prefix) == 0 {
+		panic("collection prefix cannot be empty")
+	}
+	return Map{
+		storeKey: storeKey,
+		prefix:   prefix,
+		keyCodec: keyCodec,
+		cdc:      cdc,
+	}
+}
+
+// KeyCodec returns the codec of the keys of the map.
+func (m Map) KeyCodec() KeyCodec {
+	return m.keyCodec
+}
+
+// Set sets the value of a key.
+func (m Map) Set(ctx sdk.Context, key, value interface{}) {
+	bz, err := m.cdc.MarshalBinaryLengthPrefixed(value)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode value of key %s: %v", m.keyCodec.Stringify(key), err))
+	}
+	m.setBytes(ctx, key, bz)
+}
+
+// Get decodes the value of a key into ptr and returns true if it is set.
+func (m Map) Get(ctx sdk.Context, key, ptr interface{}) (found bool) {
+	bz := m.getBytes(ctx, key)
+	if bz == nil {
+		return false
+	}
+	m.decodeValue(bz, ptr)
+	return true
+}
+
+// Has returns true if the key is set.
+func (m Map) Has(ctx sdk.Context, key interface{}) bool {
+	return ctx.KVStore(m.storeKey).Has(m.storeKeyBytes(key))
+}
+
+// Remove removes a key, if it is set.
+func (m Map) Remove(ctx sdk.Context, key interface{}) {
+	ctx.KVStore(m.storeKey).Delete(m.storeKeyBytes(key))
+}
+
+// Iterate returns an iterator over the keys in the given range, nil being
+// all the keys. The iterator must be closed.
+func (m Map) Iterate(ctx sdk.Context, rng *Range) Iterator {
+	it, err := rng.iterator(ctx.KVStore(m.storeKey), m.prefix, m.keyCodec, nil)
+	if err != nil {
+		panic(fmt.Sprintf("invalid range: %v", err))
+	}
+	return Iterator{it, m}
+}
+
+// Walk decodes the values of the keys in the given range into ptr and calls
+// fn on their keys, in order, until it returns true.
+//
+// CONTRACT: No writes may happen within the range while walking over it.
+func (m Map) Walk(ctx sdk.Context, rng *Range, ptr interface{}, fn func(key interface{}) (stop bool)) {
+	it := m.Iterate(ctx, rng)
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		it.Value(ptr)
+		if fn(it.Key()) {
+			return
+		}
+	}
+}
+
+// Paginate decodes the values of a page of the keys in the given range into
+// ptr and calls fn on their keys, in order.
+func (m Map) Paginate(ctx sdk.Context, rng *Range, req PageRequest, ptr interface{}, fn func(key interface{})) PageResponse {
+	return paginate(ctx.KVStore(m.storeKey), m.prefix, m.keyCodec, rng, req, func(key, value []byte) {
+		m.decodeValue(value, ptr)
+		fn(m.decodeKey(key))
+	})
+}
+
+// setBytes sets the encoded value of a key.
+func (m Map) setBytes(ctx sdk.Context, key interface{}, bz []byte) {
+	ctx.KVStore(m.storeKey).Set(m.storeKeyBytes(key), bz)
+}
+
+// getBytes returns the encoded value of a key, nil if it is not set.
+func (m Map) getBytes(ctx sdk.Context, key interface{}) []byte {
+	return ctx.KVStore(m.storeKey).Get(m.storeKeyBytes(key))
+}
+
+// storeKeyBytes returns the key in the store of a key of the map.
+func (m Map) storeKeyBytes(key interface{}) []byte {
+	bz, err := m.keyCodec.Encode(key)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode key %s: %v", m.keyCodec.Stringify(key), err))
+	}
+	return concat(m.prefix, bz)
+}
+
+// decodeKey decodes a key in the store.
+func (m Map) decodeKey(bz []byte) interface{} {
+	key, err := m.keyCodec.Decode(bz[len(m.prefix):])
+	if err != nil {
+		panic(fmt.Sprintf("failed to decode key %X: %v", bz, err))
+	}
+	return key
+}
+
+func (m Map) decodeValue(bz []byte, ptr interface{}) {
+	if err := m.cdc.UnmarshalBinaryLengthPrefixed(bz, ptr); err != nil {
+		panic(fmt.Sprintf("failed to decode value %X: %v", bz, err))
+	}
+}
+
+// Iterator iterates over the key/value pairs of a map.
+type Iterator struct {
+	it sdk.Iterator
+	m  Map
+}
+
+// Valid returns false once the iterator is exhausted.
+func (it Iterator) Valid() bool {
+	return it.it.Valid()
+}
+
+// Next moves the iterator to the next pair.
+func (it Iterator) Next() {
+	it.it.Next()
+}
+
+// Key returns the current key.
+func (it Iterator) Key() interface{} {
+	return it.m.decodeKey(it.it.Key())
+}
+
+// Value decodes the current value into ptr.
+func (it Iterator) Value(ptr interface{}) {
+	it.m.decodeValue(it.it.Value(), ptr)
+}
+
+// Close releases the iterator.
+func (it Iterator) Close() {
+	it.it.Close()
+}
+
+// Keys returns the remaining keys and closes the iterator.
+func (it Iterator) Keys() []interface{} {
+	defer it.Close()
+
+	var keys []interface{}
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
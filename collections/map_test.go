@@ -0,0 +1,195 @@
+package collections
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func defaultContext(key sdk.StoreKey) sdk.Context {
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	cms.LoadLatestVersion()
+	return sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
+}
+
+func TestMap(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	cdc := codec.New()
+	m := NewMap(key, []byte{0x01}, StringKey, cdc)
+
+	var value uint64
+	require.False(t, m.Get(ctx, "a", &value))
+	require.False(t, m.Has(ctx, "a"))
+
+	m.Set(ctx, "a", uint64(1))
+	require.True(t, m.Get(ctx, "a", &value))
+	require.Equal(t, uint64(1), value)
+	require.True(t, m.Has(ctx, "a"))
+
+	// the map is kept under its prefix
+	require.Equal(t, cdc.MustMarshalBinaryLengthPrefixed(uint64(1)), ctx.KVStore(key).Get([]byte("\x01a")))
+
+	m.Remove(ctx, "a")
+	require.False(t, m.Has(ctx, "a"))
+	require.Panics(t, func() { m.Set(ctx, uint64(1), uint64(1)) })
+	require.Panics(t, func() { NewMap(key, nil, StringKey, cdc) })
+}
+
+func TestMapIterate(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	m := NewMap(key, []byte{0x01}, Uint64Key, codec.New())
+	for i := uint64(0); i < 10; i++ {
+		m.Set(ctx, i, fmt.Sprintf("%d", i))
+	}
+	// a key of another collection
+	ctx.KVStore(key).Set([]byte{0x02}, []byte("other"))
+
+	values := func(rng *Range) (values []string) {
+		var value string
+		m.Walk(ctx, rng, &value, func(interface{}) bool {
+			values = append(values, value)
+			return false
+		})
+		return values
+	}
+
+	require.Equal(t, []interface{}{uint64(0), uint64(1), uint64(2), uint64(3), uint64(4),
+		uint64(5), uint64(6), uint64(7), uint64(8), uint64(9)}, m.Iterate(ctx, nil).Keys())
+	require.Equal(t, []string{"2", "3", "4"},
+		values(new(Range).StartInclusive(uint64(2)).EndExclusive(uint64(5))))
+	require.Equal(t, []interface{}{uint64(3), uint64(4), uint64(5)},
+		m.Iterate(ctx, new(Range).StartExclusive(uint64(2)).EndInclusive(uint64(5))).Keys())
+	require.Equal(t, []interface{}{uint64(9), uint64(8), uint64(7)},
+		m.Iterate(ctx, new(Range).StartInclusive(uint64(7)).Descending()).Keys())
+	require.Equal(t, []string{"1", "0"}, values(new(Range).EndExclusive(uint64(2)).Descending()))
+
+	var (
+		walked []interface{}
+		value  string
+	)
+	m.Walk(ctx, nil, &value, func(key interface{}) bool {
+		walked = append(walked, key)
+		return key == uint64(2)
+	})
+	require.Equal(t, []interface{}{uint64(0), uint64(1), uint64(2)}, walked)
+	require.Equal(t, "2", value)
+
+	require.Panics(t, func() { m.Iterate(ctx, new(Range).StartInclusive("a")) })
+}
+
+func TestMapPairPrefix(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	m := NewMap(key, []byte{0x01}, PairKeyCodec(AccAddressKey, Uint64Key), codec.New())
+	addr1, addr2 := sdk.AccAddress{1}, sdk.AccAddress{1, 1}
+	for i := uint64(0); i < 3; i++ {
+		m.Set(ctx, Join(addr1, i), i)
+		m.Set(ctx, Join(addr2, i), 10+i)
+	}
+
+	var (
+		value  uint64
+		values []uint64
+	)
+	m.Walk(ctx, new(Range).Prefix(PairPrefix(addr2)), &value, func(interface{}) bool {
+		values = append(values, value)
+		return false
+	})
+	require.Equal(t, []uint64{10, 11, 12}, values)
+
+	keys := m.Iterate(ctx, new(Range).Prefix(PairPrefix(addr1)).Descending()).Keys()
+	require.Len(t, keys, 3)
+	require.Equal(t, addr1, keys[0].(Pair).K1())
+	require.Equal(t, uint64(2), keys[0].(Pair).K2())
+}
+
+func TestMapPaginate(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	m := NewMap(key, []byte{0x01}, Uint64Key, codec.New())
+	for i := uint64(0); i < 10; i++ {
+		m.Set(ctx, i, i)
+	}
+	paginate := func(rng *Range, req PageRequest) (values []uint64, res PageResponse) {
+		var value uint64
+		res = m.Paginate(ctx, rng, req, &value, func(key interface{}) {
+			require.Equal(t, key, value)
+			values = append(values, value)
+		})
+		return values, res
+	}
+
+	// by offset
+	values, res := paginate(nil, PageRequest{Offset: 2, Limit: 3, CountTotal: true})
+	require.Equal(t, []uint64{2, 3, 4}, values)
+	require.Equal(t, uint64(10), res.Total)
+	next, _ := Uint64Key.Encode(uint64(5))
+	require.Equal(t, next, res.NextKey)
+
+	// by key
+	values, res = paginate(nil, PageRequest{Key: res.NextKey, Limit: 3})
+	require.Equal(t, []uint64{5, 6, 7}, values)
+	values, res = paginate(nil, PageRequest{Key: res.NextKey, Limit: 3})
+	require.Equal(t, []uint64{8, 9}, values)
+	require.Nil(t, res.NextKey)
+
+	// within a descending range
+	rng := new(Range).EndExclusive(uint64(8)).Descending()
+	values, res = paginate(rng, PageRequest{Limit: 5})
+	require.Equal(t, []uint64{7, 6, 5, 4, 3}, values)
+	values, res = paginate(rng, PageRequest{Key: res.NextKey})
+	require.Equal(t, []uint64{2, 1, 0}, values)
+	require.Nil(t, res.NextKey)
+
+	values, res = paginate(nil, PageRequest{Offset: 20, CountTotal: true})
+	require.Empty(t, values)
+	require.Equal(t, uint64(10), res.Total)
+}
+
+func TestKeySet(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	s := NewKeySet(key, []byte{0x01}, StringKey)
+
+	s.Set(ctx, "b")
+	s.Set(ctx, "a")
+	s.Set(ctx, "c")
+	require.True(t, s.Has(ctx, "a"))
+	s.Remove(ctx, "a")
+	require.False(t, s.Has(ctx, "a"))
+
+	require.Equal(t, []interface{}{"b", "c"}, s.Iterate(ctx, nil).Keys())
+	var keys []interface{}
+	res := s.Paginate(ctx, nil, PageRequest{Limit: 1}, func(key interface{}) {
+		keys = append(keys, key)
+	})
+	require.Equal(t, []interface{}{"b"}, keys)
+	require.Equal(t, []byte("c"), res.NextKey)
+}
+
+func TestSequence(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	seq := NewSequence(key, []byte{0x01})
+
+	require.Equal(t, DefaultSequenceStart, seq.Peek(ctx))
+	require.Equal(t, DefaultSequenceStart, seq.Next(ctx))
+	require.Equal(t, DefaultSequenceStart+1, seq.Next(ctx))
+	require.Equal(t, DefaultSequenceStart+2, seq.Peek(ctx))
+
+	seq.Set(ctx, 10)
+	require.Equal(t, uint64(10), seq.Next(ctx))
+}
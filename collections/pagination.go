@@ -0,0 +1,79 @@
+package collections
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultLimit is the number of results of a page when no limit is given.
+const DefaultLimit = 100
+
+// PageRequest selects a page of the results of a range, either by the key
+// of its first result, which the previous page returns, or by offset.
+type PageRequest struct {
+	// Key is the NextKey of the previous page, the offset being ignored
+	// when it is set.
+	Key []byte `json:"key"`
+	// Offset is the number of results skipped before the page.
+	Offset uint64 `json:"offset"`
+	// Limit is the maximum number of results of the page, DefaultLimit if 0.
+	Limit uint64 `json:"limit"`
+	// CountTotal counts the results of the whole range when the page is
+	// selected by offset.
+	CountTotal bool `json:"count_total"`
+}
+
+// PageResponse describes the page returned for a request.
+type PageResponse struct {
+	// NextKey is the key of the first result of the next page, nil on the
+	// last page.
+	NextKey []byte `json:"next_key"`
+	// Total is the number of results of the range, if it was counted.
+	Total uint64 `json:"total"`
+}
+
+// paginate calls onResult with the key and value of each result in the page,
+// within the range of the keys of the collection under the given prefix. The
+// page keys are the keys of the collection.
+func paginate(store sdk.KVStore, prefix []byte, keyCodec KeyCodec, rng *Range, req PageRequest,
+	onResult func(key, value []byte)) PageResponse {
+
+	it, err := rng.iterator(store, prefix, keyCodec, req.Key)
+	if err != nil {
+		panic(fmt.Sprintf("invalid range: %v", err))
+	}
+	defer it.Close()
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	var count uint64
+	if req.Key == nil {
+		for ; count < req.Offset && it.Valid(); it.Next() {
+			count++
+		}
+	}
+
+	var res PageResponse
+	for n := uint64(0); it.Valid(); it.Next() {
+		if n == limit && res.NextKey == nil {
+			res.NextKey = append([]byte{}, it.Key()[len(prefix):]...)
+			if !req.CountTotal || req.Key != nil {
+				break
+			}
+		}
+		if n < limit {
+			onResult(it.Key(), it.Value())
+			n++
+		}
+		count++
+	}
+
+	if req.CountTotal && req.Key == nil {
+		res.Total = count
+	}
+	return res
+}
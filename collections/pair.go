@@ -0,0 +1,129 @@
+package collections
+
+import (
+	"fmt"
+)
+
+// Pair is a key made of two keys, which sorts by its first key then by its
+// second key. A pair with only its first key set is the prefix of all the
+// pairs with that key, to be used in ranges.
+type Pair struct {
+	key1 interface{}
+	key2 interface{}
+}
+
+// Join returns the pair of the given keys.
+func Join(key1, key2 interface{}) Pair {
+	return Pair{key1: key1, key2: key2}
+}
+
+// PairPrefix returns the pair prefix with the given first key.
+func PairPrefix(key1 interface{}) Pair {
+	return Pair{key1: key1}
+}
+
+// K1 returns the first key of the pair, or nil if it is not set.
+func (p Pair) K1() interface{} {
+	return p.key1
+}
+
+// K2 returns the second key of the pair, or nil if it is not set.
+func (p Pair) K2() interface{} {
+	return p.key2
+}
+
+// PairKeyCodec returns the codec of the pairs of the keys of the given
+// codecs, the first key being encoded as non-terminal.
+func PairKeyCodec(keyCodec1, keyCodec2 KeyCodec) KeyCodec {
+	return pairKeyCodec{keyCodec1, keyCodec2}
+}
+
+type pairKeyCodec struct {
+	keyCodec1 KeyCodec
+	keyCodec2 KeyCodec
+}
+
+func (c pairKeyCodec) pair(key interface{}) (Pair, error) {
+	pair, ok := key.(Pair)
+	if !ok {
+		return Pair{}, errKeyType(key, "Pair")
+	}
+	if pair.key1 == nil {
+		return Pair{}, fmt.Errorf("pair key has no first key")
+	}
+	return pair, nil
+}
+
+func (c pairKeyCodec) Encode(key interface{}) ([]byte, error) {
+	pair, err := c.pair(key)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := c.keyCodec1.EncodeNonTerminal(pair.key1)
+	if err != nil || pair.key2 == nil {
+		return bz, err
+	}
+	bz2, err := c.keyCodec2.Encode(pair.key2)
+	if err != nil {
+		return nil, err
+	}
+	return append(bz, bz2...), nil
+}
+
+func (c pairKeyCodec) Decode(b []byte) (interface{}, error) {
+	n, key1, err := c.keyCodec1.DecodeNonTerminal(b)
+	if err != nil {
+		return nil, err
+	}
+	key2, err := c.keyCodec2.Decode(b[n:])
+	if err != nil {
+		return nil, err
+	}
+	return Join(key1, key2), nil
+}
+
+func (c pairKeyCodec) EncodeNonTerminal(key interface{}) ([]byte, error) {
+	pair, err := c.pair(key)
+	if err != nil {
+		return nil, err
+	}
+	if pair.key2 == nil {
+		return nil, fmt.Errorf("non-terminal pair key must have both keys")
+	}
+	bz, err := c.keyCodec1.EncodeNonTerminal(pair.key1)
+	if err != nil {
+		return nil, err
+	}
+	bz2, err := c.keyCodec2.EncodeNonTerminal(pair.key2)
+	if err != nil {
+		return nil, err
+	}
+	return append(bz, bz2...), nil
+}
+
+func (c pairKeyCodec) DecodeNonTerminal(b []byte) (int, interface{}, error) {
+	n1, key1, err := c.keyCodec1.DecodeNonTerminal(b)
+	if err != nil {
+		return 0, nil, err
+	}
+	n2, key2, err := c.keyCodec2.DecodeNonTerminal(b[n1:])
+	if err != nil {
+		return 0, nil, err
+	}
+	return n1 + n2, Join(key1, key2), nil
+}
+
+func (c pairKeyCodec) Stringify(key interface{}) string {
+	pair, ok := key.(Pair)
+	if !ok {
+		return fmt.Sprintf("%v", key)
+	}
+	key1, key2 := "<nil>", "<nil>"
+	if pair.key1 != nil {
+		key1 = c.keyCodec1.Stringify(pair.key1)
+	}
+	if pair.key2 != nil {
+		key2 = c.keyCodec2.Stringify(pair.key2)
+	}
+	return fmt.Sprintf("(%s, %s)", key1, key2)
+}
@@ -0,0 +1,131 @@
+package collections
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Range is a range of keys to iterate over, all the keys by default. The
+// bounds are set with the builder methods:
+//
+//	new(Range).StartInclusive(uint64(10)).EndExclusive(uint64(20)).Descending()
+//
+// A prefix range iterates over the pairs with a given first key:
+//
+//	new(Range).Prefix(PairPrefix(addr))
+type Range struct {
+	prefix     interface{}
+	start      *bound
+	end        *bound
+	descending bool
+}
+
+type bound struct {
+	key       interface{}
+	inclusive bool
+}
+
+// Prefix restricts the range to the keys starting with the encoding of the
+// given key, usually a pair prefix.
+func (r *Range) Prefix(key interface{}) *Range {
+	r.prefix = key
+	return r
+}
+
+// StartInclusive starts the range at the given key.
+func (r *Range) StartInclusive(key interface{}) *Range {
+	r.start = &bound{key, true}
+	return r
+}
+
+// StartExclusive starts the range after the given key.
+func (r *Range) StartExclusive(key interface{}) *Range {
+	r.start = &bound{key, false}
+	return r
+}
+
+// EndInclusive ends the range at the given key.
+func (r *Range) EndInclusive(key interface{}) *Range {
+	r.end = &bound{key, true}
+	return r
+}
+
+// EndExclusive ends the range before the given key.
+func (r *Range) EndExclusive(key interface{}) *Range {
+	r.end = &bound{key, false}
+	return r
+}
+
+// Descending iterates over the range in descending order.
+func (r *Range) Descending() *Range {
+	r.descending = true
+	return r
+}
+
+// iterator returns the iterator over the range of the keys of the collection
+// under the given prefix, a nil range being the whole collection. It starts
+// from the given encoded key, if any, instead of the start of the range.
+func (r *Range) iterator(store sdk.KVStore, prefix []byte, keyCodec KeyCodec, from []byte) (sdk.Iterator, error) {
+	start, end := prefix, sdk.PrefixEndBytes(prefix)
+	descending := false
+	if r != nil {
+		var err error
+		if start, end, err = r.bounds(prefix, keyCodec); err != nil {
+			return nil, err
+		}
+		descending = r.descending
+	}
+
+	if descending {
+		if from != nil {
+			end = sdk.InclusiveEndBytes(concat(prefix, from))
+		}
+		return store.ReverseIterator(start, end), nil
+	}
+	if from != nil {
+		start = concat(prefix, from)
+	}
+	return store.Iterator(start, end), nil
+}
+
+// bounds returns the start and exclusive end of the range in the store.
+func (r *Range) bounds(prefix []byte, keyCodec KeyCodec) (start, end []byte, err error) {
+	start, end = prefix, sdk.PrefixEndBytes(prefix)
+	if r.prefix != nil {
+		bz, err := keyCodec.Encode(r.prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		start = concat(prefix, bz)
+		end = sdk.PrefixEndBytes(start)
+	}
+
+	if r.start != nil {
+		bz, err := keyCodec.Encode(r.start.key)
+		if err != nil {
+			return nil, nil, err
+		}
+		bz = concat(prefix, bz)
+		if !r.start.inclusive {
+			bz = sdk.InclusiveEndBytes(bz)
+		}
+		start = bz
+	}
+	if r.end != nil {
+		bz, err := keyCodec.Encode(r.end.key)
+		if err != nil {
+			return nil, nil, err
+		}
+		bz = concat(prefix, bz)
+		if r.end.inclusive {
+			bz = sdk.InclusiveEndBytes(bz)
+		}
+		end = bz
+	}
+	return start, end, nil
+}
+
+// concat returns a new slice with the given prefix and key.
+func concat(prefix, key []byte) []byte {
+	bz := make([]byte, 0, len(prefix)+len(key))
+	return append(append(bz, prefix...), key...)
+}
@@ -0,0 +1,51 @@
+package collections
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultSequenceStart is the first number of a sequence.
+const DefaultSequenceStart uint64 = 1
+
+// Sequence is a monotonically increasing number kept under a key of a store,
+// to number the entries of a collection such as proposals.
+type Sequence struct {
+	storeKey sdk.StoreKey
+	key      []byte
+}
+
+// NewSequence returns a new Sequence stored under the given key of the store,
+// which must not be the prefix of any other collection or key of the store.
+func NewSequence(storeKey sdk.StoreKey, key []byte) Sequence {
+	if len(key) == 0 {
+		panic("sequence key cannot be empty")
+	}
+	return Sequence{storeKey, key}
+}
+
+// Peek returns the next number of the sequence without consuming it.
+func (s Sequence) Peek(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(s.storeKey).Get(s.key)
+	if bz == nil {
+		return DefaultSequenceStart
+	}
+	if len(bz) != 8 {
+		panic(fmt.Sprintf("invalid sequence value %X", bz))
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// Next consumes and returns the next number of the sequence.
+func (s Sequence) Next(ctx sdk.Context) uint64 {
+	value := s.Peek(ctx)
+	s.Set(ctx, value+1)
+	return value
+}
+
+// Set sets the next number of the sequence, for instance at genesis.
+func (s Sequence) Set(ctx sdk.Context, value uint64) {
+	ctx.KVStore(s.storeKey).Set(s.key, sdk.Uint64ToBigEndian(value))
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"sync"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/proxy"
+)
+
+// ConcurrentQuerier is implemented by the applications able to serve some
+// queries concurrently with the other ABCI calls, e.g. the queries reading an
+// immutable snapshot of the committed state.
+type ConcurrentQuerier interface {
+	IsConcurrentQuery(req abci.RequestQuery) bool
+}
+
+// NewLocalClientCreator returns a creator of the clients of an in-process
+// application, which lock a mutex shared by all the ABCI connections like the
+// Tendermint local clients. If the application is a ConcurrentQuerier, its
+// concurrent queries are served without locking the mutex, so that they don't
+// contend with CheckTx and the blocks being executed.
+func NewLocalClientCreator(app abci.Application) proxy.ClientCreator {
+	return &localClientCreator{
+		mtx: new(sync.Mutex),
+		app: app,
+	}
+}
+
+type localClientCreator struct {
+	mtx *sync.Mutex
+	app abci.Application
+}
+
+func (c *localClientCreator) NewABCIClient() (abcicli.Client, error) {
+	client := abcicli.NewLocalClient(c.mtx, c.app)
+	querier, ok := c.app.(ConcurrentQuerier)
+	if !ok {
+		return client, nil
+	}
+	return &concurrentQueryClient{Client: client, app: c.app, querier: querier}, nil
+}
+
+// concurrentQueryClient is a local client serving the concurrent queries of
+// the application without locking the mutex of the client.
+type concurrentQueryClient struct {
+	abcicli.Client
+
+	app     abci.Application
+	querier ConcurrentQuerier
+}
+
+func (cli *concurrentQueryClient) QuerySync(req abci.RequestQuery) (*abci.ResponseQuery, error) {
+	if !cli.querier.IsConcurrentQuery(req) {
+		return cli.Client.QuerySync(req)
+	}
+	res := cli.app.Query(req)
+	return &res, nil
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+type concurrentQueryApp struct {
+	abci.BaseApplication
+}
+
+func (concurrentQueryApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	return abci.ResponseQuery{Value: []byte(req.Path)}
+}
+
+func (concurrentQueryApp) IsConcurrentQuery(req abci.RequestQuery) bool {
+	return req.Path == "/concurrent"
+}
+
+func TestLocalClientCreatorConcurrentQueries(t *testing.T) {
+	creator := NewLocalClientCreator(concurrentQueryApp{}).(*localClientCreator)
+	client, err := creator.NewABCIClient()
+	require.NoError(t, err)
+
+	// the concurrent queries are served while the mutex is held by another
+	// connection
+	creator.mtx.Lock()
+	res, err := client.QuerySync(abci.RequestQuery{Path: "/concurrent"})
+	require.NoError(t, err)
+	require.Equal(t, []byte("/concurrent"), res.Value)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		res, err := client.QuerySync(abci.RequestQuery{Path: "/other"})
+		require.NoError(t, err)
+		require.Equal(t, []byte("/other"), res.Value)
+	}()
+	select {
+	case <-done:
+		t.Fatal("query served without locking the mutex")
+	case <-time.After(50 * time.Millisecond):
+	}
+	creator.mtx.Unlock()
+	<-done
+
+	// the clients of other apps are the Tendermint local clients
+	client, err = NewLocalClientCreator(abci.NewBaseApplication()).NewABCIClient()
+	require.NoError(t, err)
+	_, ok := client.(*concurrentQueryClient)
+	require.False(t, ok)
+}
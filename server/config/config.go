@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -17,11 +18,27 @@ type BaseConfig struct {
 	// transaction. A transaction's fees must meet the minimum of any denomination
 	// specified in this config (e.g. 0.25token1;0.0001token2).
 	MinGasPrices string `mapstructure:"minimum-gas-prices"`
+
+	// The database backend of the application state: goleveldb, memdb or, for
+	// binaries built with the gcc build tag, cleveldb. The default backend of
+	// the binary is used when it is empty.
+	DBBackend string `mapstructure:"db-backend"`
+
+	// The names of the stores kept in their own database in the data
+	// directory instead of the application database (e.g. staking, distr).
+	StoreDBs []string `mapstructure:"store-dbs"`
+
+	// The gas limit of each custom query served by the node, or 0 for no
+	// limit. A query running out of gas fails with an out of gas error.
+	QueryGasLimit uint64 `mapstructure:"query-gas-limit"`
 }
 
 // Config defines the server's top level configuration
 type Config struct {
 	BaseConfig `mapstructure:",squash"`
+
+	// The telemetry configuration of the node
+	Telemetry telemetry.Config `mapstructure:"telemetry"`
 }
 
 // SetMinGasPrices sets the validator's minimum gas prices.
@@ -54,8 +71,9 @@ func (c *Config) GetMinGasPrices() sdk.DecCoins {
 // DefaultConfig returns server's default configuration.
 func DefaultConfig() *Config {
 	return &Config{
-		BaseConfig{
+		BaseConfig: BaseConfig{
 			MinGasPrices: defaultMinGasPrices,
 		},
+		Telemetry: telemetry.DefaultConfig(),
 	}
 }
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -11,6 +15,8 @@ import (
 func TestDefaultConfig(t *testing.T) {
 	cfg := DefaultConfig()
 	require.True(t, cfg.GetMinGasPrices().IsZero())
+	require.False(t, cfg.Telemetry.Enabled)
+	require.Zero(t, cfg.QueryGasLimit)
 }
 
 func TestSetMinimumFees(t *testing.T) {
@@ -18,3 +24,26 @@ func TestSetMinimumFees(t *testing.T) {
 	cfg.SetMinGasPrices(sdk.DecCoins{sdk.NewInt64DecCoin("foo", 5)})
 	require.Equal(t, "5.000000000000000000foo", cfg.MinGasPrices)
 }
+
+func TestWriteAndParseTelemetryConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := DefaultConfig()
+	cfg.Telemetry.Enabled = true
+	cfg.Telemetry.PrometheusListenAddr = "localhost:9100"
+	cfg.Telemetry.Namespace = "gaia"
+
+	path := filepath.Join(dir, "gaiad.toml")
+	WriteConfigFile(path, cfg)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig())
+
+	parsed, err := ParseConfig()
+	require.NoError(t, err)
+	require.Equal(t, cfg.Telemetry, parsed.Telemetry)
+}
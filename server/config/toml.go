@@ -17,6 +17,35 @@ const defaultConfigTemplate = `# This is a TOML config file.
 # transaction. A transaction's fees must meet the minimum of any denomination
 # specified in this config (e.g. 0.25token1;0.0001token2).
 minimum-gas-prices = "{{ .BaseConfig.MinGasPrices }}"
+
+# The database backend of the application state: goleveldb, memdb or, for
+# binaries built with the gcc build tag, cleveldb. The default backend of the
+# binary is used when it is empty. Use "gaiad migrate-db" to change the backend
+# of an existing node.
+db-backend = "{{ .BaseConfig.DBBackend }}"
+
+# The names of the stores kept in their own database in the data directory
+# instead of the application database (e.g. ["staking", "distr"]). Use
+# "gaiad migrate-db" to move the stores of an existing node.
+store-dbs = [{{ range $i, $name := .BaseConfig.StoreDBs }}{{ if $i }}, {{ end }}"{{ $name }}"{{ end }}]
+
+# The gas limit of each custom query served by the node, or 0 for no limit. A
+# query running out of gas fails with an out of gas error.
+query-gas-limit = {{ .BaseConfig.QueryGasLimit }}
+
+##### telemetry config options #####
+[telemetry]
+
+# When true, the node records its metrics (txs and messages processed, ante
+# handler rejections, gas used, store accesses, commit latency, module queue
+# sizes) and serves them on the Prometheus endpoint.
+enabled = {{ .Telemetry.Enabled }}
+
+# The address the Prometheus endpoint listens on.
+prometheus-listen-addr = "{{ .Telemetry.PrometheusListenAddr }}"
+
+# The namespace the metrics are registered under.
+namespace = "{{ .Telemetry.Namespace }}"
 `
 
 var configTemplate *template.Template
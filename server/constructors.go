@@ -10,8 +10,6 @@ import (
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
-
-	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 type (
@@ -25,9 +23,7 @@ type (
 )
 
 func openDB(rootDir string) (dbm.DB, error) {
-	dataDir := filepath.Join(rootDir, "data")
-	db, err := sdk.NewLevelDB("application", dataDir)
-	return db, err
+	return newDB(appDBName, DBBackend(), filepath.Join(rootDir, "data"))
 }
 
 func openTraceWriter(traceWriterFile string) (w io.Writer, err error) {
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	"github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// database options of the server config
+const (
+	configDBBackend = "db-backend"
+	configStoreDBs  = "store-dbs"
+)
+
+// migrate-db flags
+const (
+	flagToBackend  = "to-backend"
+	flagToStoreDBs = "to-store-dbs"
+	flagOutputDir  = "output-dir"
+)
+
+const appDBName = "application"
+
+// DBBackend returns the backend of the application databases set in the server
+// config, or the default backend of the binary.
+func DBBackend() dbm.DBBackendType {
+	if backend := viper.GetString(configDBBackend); backend != "" {
+		return dbm.DBBackendType(backend)
+	}
+	if sdk.DBBackend == string(dbm.CLevelDBBackend) {
+		return dbm.CLevelDBBackend
+	}
+	return dbm.GoLevelDBBackend
+}
+
+// OpenStoreDBs opens the databases of the stores kept apart from the
+// application database, as set in the server config, by store name.
+func OpenStoreDBs(rootDir string) (map[string]dbm.DB, error) {
+	return openStoreDBs(filepath.Join(rootDir, "data"), DBBackend(), viper.GetStringSlice(configStoreDBs))
+}
+
+func openStoreDBs(dir string, backend dbm.DBBackendType, names []string) (map[string]dbm.DB, error) {
+	dbs := make(map[string]dbm.DB, len(names))
+	for _, name := range names {
+		db, err := newDB(appDBName+"-"+name, backend, dir)
+		if err != nil {
+			closeDBs(dbs)
+			return nil, err
+		}
+		dbs[name] = db
+	}
+	return dbs, nil
+}
+
+// newDB opens the database with the given name and backend in dir.
+func newDB(name string, backend dbm.DBBackendType, dir string) (db dbm.DB, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("couldn't create db: %v", r)
+		}
+	}()
+	return dbm.NewDB(name, backend, dir), err
+}
+
+func closeDBs(dbs map[string]dbm.DB) {
+	for _, db := range dbs {
+		db.Close()
+	}
+}
+
+// openMultiStore opens the application state of a stopped node in a
+// multistore. The returned function closes its databases.
+func openMultiStore(rootDir string) (*rootmulti.Store, func(), error) {
+	db, err := openDB(rootDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	storeDBs, err := OpenStoreDBs(rootDir)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	return newMultiStore(db, storeDBs), func() {
+		db.Close()
+		closeDBs(storeDBs)
+	}, nil
+}
+
+// newMultiStore returns a multistore on which the stores with their own
+// database are mounted, so that the committed versions of all the stores can be
+// read without mounting the other ones.
+func newMultiStore(db dbm.DB, storeDBs map[string]dbm.DB) *rootmulti.Store {
+	rs := rootmulti.NewStore(db)
+	for name, storeDB := range storeDBs {
+		rs.MountStoreWithDB(types.NewKVStoreKey(name), types.StoreTypeIAVL, storeDB)
+	}
+	return rs
+}
+
+// MigrateDBCmd copies the application state of a stopped node to databases
+// of another backend, or with other stores kept in their own database.
+func MigrateDBCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-db",
+		Short: "Copy the application state of a stopped node to databases of another backend or layout",
+		Long: `Copy the application databases of a stopped node to --output-dir, using the
+backend given by --to-backend and keeping the stores given by --to-store-dbs in
+their own database, both defaulting to the current configuration. The commit
+hashes of the copy are then verified against the original ones.
+
+To use the copy, replace the application databases of the data directory with
+the ones of --output-dir and set db-backend and store-dbs in gaiad.toml.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			backend := DBBackend()
+			if to := viper.GetString(flagToBackend); to != "" {
+				backend = dbm.DBBackendType(to)
+			}
+			if backend == dbm.MemDBBackend {
+				return fmt.Errorf("cannot migrate to the %s backend, which doesn't persist the state", backend)
+			}
+			storeDBNames := viper.GetStringSlice(configStoreDBs)
+			if cmd.Flags().Changed(flagToStoreDBs) {
+				storeDBNames = viper.GetStringSlice(flagToStoreDBs)
+			}
+
+			outputDir := viper.GetString(flagOutputDir)
+			dataDir := filepath.Join(config.RootDir, "data")
+			if filepath.Clean(outputDir) == filepath.Clean(dataDir) {
+				return fmt.Errorf("--%s must not be the data directory of the node", flagOutputDir)
+			}
+			if err := os.MkdirAll(outputDir, 0755); err != nil {
+				return err
+			}
+
+			db, err := openDB(config.RootDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			storeDBs, err := OpenStoreDBs(config.RootDir)
+			if err != nil {
+				return err
+			}
+			defer closeDBs(storeDBs)
+
+			newAppDB, err := newDB(appDBName, backend, outputDir)
+			if err != nil {
+				return err
+			}
+			defer newAppDB.Close()
+			newStoreDBs, err := openStoreDBs(outputDir, backend, storeDBNames)
+			if err != nil {
+				return err
+			}
+			defer closeDBs(newStoreDBs)
+
+			commitID, err := newMultiStore(db, storeDBs).VerifyLatestVersion()
+			if err != nil {
+				return err
+			}
+			if err := rootmulti.CopyDB(db, storeDBs, newAppDB, newStoreDBs); err != nil {
+				return err
+			}
+			newCommitID, err := newMultiStore(newAppDB, newStoreDBs).VerifyLatestVersion()
+			if err != nil {
+				return fmt.Errorf("failed to verify the copied state: %v", err)
+			}
+			if newCommitID.Version != commitID.Version || !bytes.Equal(newCommitID.Hash, commitID.Hash) {
+				return fmt.Errorf("the copied state has commit ID %v instead of %v", newCommitID, commitID)
+			}
+
+			fmt.Printf("Copied the application state at height %d with app hash %X to %s\n",
+				commitID.Version, commitID.Hash, outputDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagToBackend, "", "Backend of the new databases: goleveldb, or cleveldb for binaries built with the gcc build tag (default: the db-backend config)")
+	cmd.Flags().StringSlice(flagToStoreDBs, nil, "Stores kept in their own database in the new layout (default: the store-dbs config)")
+	cmd.Flags().String(flagOutputDir, "", "Directory of the new databases")
+	cmd.MarkFlagRequired(flagOutputDir)
+	return cmd
+}
@@ -70,6 +70,36 @@ func (ms multiStore) LoadVersion(ver int64) error {
 	panic("not implemented")
 }
 
+func (ms multiStore) AddListeners(key sdk.StoreKey, listeners ...sdk.WriteListener) {
+	panic("not implemented")
+}
+
+func (ms multiStore) ListeningEnabled(key sdk.StoreKey) bool {
+	return false
+}
+
+func (ms multiStore) SetListeningTxIndex(txIndex int64) {}
+
+func (ms multiStore) LatestVersion() int64 {
+	panic("not implemented")
+}
+
+func (ms multiStore) LoadLatestVersionAndUpgrade(upgrades *sdk.StoreUpgrades) error {
+	return nil
+}
+
+func (ms multiStore) LoadVersionAndUpgrade(ver int64, upgrades *sdk.StoreUpgrades) error {
+	panic("not implemented")
+}
+
+func (ms multiStore) CacheMultiStoreWithVersion(_ int64) (sdk.CacheMultiStore, error) {
+	panic("not implemented")
+}
+
+func (ms multiStore) PinVersion(_ int64) func() {
+	panic("not implemented")
+}
+
 func (ms multiStore) GetKVStore(key sdk.StoreKey) sdk.KVStore {
 	return ms.kv[key]
 }
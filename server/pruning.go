@@ -0,0 +1,101 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/store"
+)
+
+// pruning flags
+const (
+	FlagPruning            = "pruning"
+	FlagPruningKeepRecent  = "pruning-keep-recent"
+	FlagPruningKeepEvery   = "pruning-keep-every"
+	FlagPruningInterval    = "pruning-interval"
+	FlagPruningKeepHeights = "pruning-keep-heights"
+)
+
+// AddPruningFlags adds the flags selecting the pruning options to a command.
+func AddPruningFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagPruning, store.PruningStrategyDefault,
+		"Pruning strategy: default, nothing, everything or custom (syncable is an alias of default pruning at every height)")
+	cmd.Flags().Int64(FlagPruningKeepRecent, 0, "Number of recent states to keep with the custom strategy")
+	cmd.Flags().Int64(FlagPruningKeepEvery, 0, "Keep every N-th state with the custom strategy, 0 keeps none")
+	cmd.Flags().Int64(FlagPruningInterval, 10, "Number of heights between pruning runs with the custom strategy")
+	cmd.Flags().String(FlagPruningKeepHeights, "", "Comma separated heights whose state is never pruned")
+}
+
+// GetPruningOptionsFromFlags returns the pruning options selected by the
+// pruning flags.
+func GetPruningOptionsFromFlags() (store.PruningOptions, error) {
+	var opts store.PruningOptions
+
+	switch strategy := viper.GetString(FlagPruning); strategy {
+	case store.PruningStrategyDefault, store.PruningStrategyNothing,
+		store.PruningStrategyEverything, store.PruningStrategySyncable:
+		opts = store.NewPruningOptionsFromString(strategy)
+
+	case store.PruningStrategyCustom:
+		opts = store.NewPruningOptions(
+			viper.GetInt64(FlagPruningKeepRecent), viper.GetInt64(FlagPruningKeepEvery),
+		).WithInterval(viper.GetInt64(FlagPruningInterval))
+
+	default:
+		return opts, fmt.Errorf("unknown pruning strategy %s", strategy)
+	}
+
+	if heights := viper.GetString(FlagPruningKeepHeights); heights != "" {
+		for _, s := range strings.Split(heights, ",") {
+			height, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				return opts, fmt.Errorf("invalid height to keep %s: %v", s, err)
+			}
+			opts = opts.WithKeepHeights(height)
+		}
+	}
+
+	return opts, opts.Validate()
+}
+
+// PruneCmd prunes the application state of a stopped node with the pruning
+// options given by the pruning flags.
+func PruneCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Prune the application state of a stopped node down to a pruning strategy",
+		Long: `Delete the historical states of the application that are not kept by the
+pruning strategy selected by the pruning flags. The node must not be running.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := GetPruningOptionsFromFlags()
+			if err != nil {
+				return err
+			}
+
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+			rs, closeDBs, err := openMultiStore(config.RootDir)
+			if err != nil {
+				return err
+			}
+			defer closeDBs()
+
+			if err := rs.PruneStores(opts); err != nil {
+				return err
+			}
+
+			fmt.Printf("Pruned the application state up to height %d\n", rs.LatestVersion())
+			return nil
+		},
+	}
+
+	AddPruningFlags(cmd)
+	return cmd
+}
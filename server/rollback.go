@@ -0,0 +1,55 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+)
+
+// RollbackCmd reverts the application state of a stopped node to an earlier
+// height.
+func RollbackCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll the application state of a stopped node back to an earlier height",
+		Long: `Delete the application states committed after the given height, which becomes
+the latest height of the application, and print its app hash. The state at the
+height must not have been pruned. The stores renamed by a store upgrade after
+the height get their former name back and the ones added after it are deleted,
+while the stores deleted after it cannot be restored. The node must not be
+running. A rollback interrupted by a crash is finished when the node starts or
+by running the command again.
+
+The blocks stored by Tendermint are left untouched.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height := viper.GetInt64(flagHeight)
+			if height <= 0 {
+				return fmt.Errorf("--%s must be positive", flagHeight)
+			}
+
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+			rs, closeDBs, err := openMultiStore(config.RootDir)
+			if err != nil {
+				return err
+			}
+			defer closeDBs()
+
+			commitID, err := rs.Rollback(height)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Rolled the application state back to height %d, app hash %X\n", commitID.Version, commitID.Hash)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(flagHeight, 0, "Height to roll the application state back to")
+	cmd.MarkFlagRequired(flagHeight)
+	return cmd
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// state sync snapshot flags
+const (
+	FlagSnapshotInterval   = "snapshot-interval"
+	FlagSnapshotKeepRecent = "snapshot-keep-recent"
+	flagSnapshotDir        = "snapshot-dir"
+	flagAppHash            = "app-hash"
+)
+
+// SnapshotDir returns the default directory of the snapshots of the node
+// with the given home directory.
+func SnapshotDir(home string) string {
+	return filepath.Join(home, "data", "snapshots")
+}
+
+// SnapshotCmd returns the commands managing the state sync snapshots of the
+// application state.
+func SnapshotCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshots",
+		Short: "Manage state sync snapshots of the application state",
+	}
+
+	cmd.AddCommand(
+		listSnapshotsCmd(ctx),
+		exportSnapshotCmd(ctx),
+		restoreSnapshotCmd(ctx),
+	)
+	cmd.PersistentFlags().String(flagSnapshotDir, "", "Snapshot directory (default \"<home>/data/snapshots\")")
+	return cmd
+}
+
+func listSnapshotsCmd(ctx *Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots of the node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifests, err := rootmulti.ListSnapshots(snapshotDir(ctx))
+			if err != nil {
+				return err
+			}
+
+			for _, manifest := range manifests {
+				fmt.Printf("height: %d format: %d chunks: %d app hash: %X hash: %X\n",
+					manifest.Height, manifest.Format, len(manifest.ChunkHashes), manifest.AppHash, manifest.Hash())
+			}
+			return nil
+		},
+	}
+}
+
+func exportSnapshotCmd(ctx *Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export [height]",
+		Short: "Take a snapshot of the application state at the given height, the latest one by default",
+		Long: `Take a snapshot of the application state at the given height, which must
+not have been pruned. The node must not be running.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// no store needs to be mounted to read the committed versions
+			rs, closeDBs, err := openMultiStore(snapshotHome(ctx))
+			if err != nil {
+				return err
+			}
+			defer closeDBs()
+
+			height := rs.LatestVersion()
+			if len(args) > 0 {
+				if height, err = strconv.ParseInt(args[0], 10, 64); err != nil {
+					return fmt.Errorf("invalid height %s: %v", args[0], err)
+				}
+			}
+			if height == 0 {
+				return fmt.Errorf("no committed state to take a snapshot of")
+			}
+
+			manifest, err := rs.Snapshot(snapshotDir(ctx), height)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Took snapshot at height %d with app hash %X\n", manifest.Height, manifest.AppHash)
+			return nil
+		},
+	}
+}
+
+func restoreSnapshotCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [height]",
+		Short: "Restore the application state from the snapshot at the given height",
+		Long: `Restore the application state of a node without any state from the snapshot
+at the given height. The restored app hash is checked against --app-hash when
+it is given, which should be taken from a trusted block header.
+
+Only the application state is restored: the node must be provided with the
+Tendermint state and blocks at the snapshot height before it can be started.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height %s: %v", args[0], err)
+			}
+
+			dir := snapshotDir(ctx)
+			manifest, err := rootmulti.LoadSnapshotManifest(dir, height)
+			if err != nil {
+				return err
+			}
+			if appHash := viper.GetString(flagAppHash); appHash != "" {
+				expected, err := hex.DecodeString(appHash)
+				if err != nil {
+					return fmt.Errorf("invalid app hash %s: %v", appHash, err)
+				}
+				if !bytes.Equal(expected, manifest.AppHash) {
+					return fmt.Errorf("snapshot app hash %X does not match %X", manifest.AppHash, expected)
+				}
+			}
+
+			db, err := openDB(snapshotHome(ctx))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			storeDBs, err := OpenStoreDBs(snapshotHome(ctx))
+			if err != nil {
+				return err
+			}
+			defer closeDBs(storeDBs)
+
+			rs := rootmulti.NewStore(db)
+			for _, store := range manifest.Stores {
+				rs.MountStoreWithDB(types.NewKVStoreKey(store.Name), types.StoreTypeIAVL, storeDBs[store.Name])
+			}
+			if _, err := rs.Restore(dir, height); err != nil {
+				return err
+			}
+
+			fmt.Printf("Restored snapshot at height %d with app hash %X\n", manifest.Height, manifest.AppHash)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagAppHash, "", "Expected app hash of the snapshot, in hex")
+	return cmd
+}
+
+func snapshotHome(ctx *Context) string {
+	ctx.Config.SetRoot(viper.GetString(cli.HomeFlag))
+	return ctx.Config.RootDir
+}
+
+func snapshotDir(ctx *Context) string {
+	if dir := viper.GetString(flagSnapshotDir); dir != "" {
+		return dir
+	}
+	return SnapshotDir(snapshotHome(ctx))
+}
@@ -13,16 +13,19 @@ import (
 	"github.com/tendermint/tendermint/node"
 	"github.com/tendermint/tendermint/p2p"
 	pvm "github.com/tendermint/tendermint/privval"
-	"github.com/tendermint/tendermint/proxy"
+
+	"github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 )
 
 // Tendermint full-node start flags
 const (
-	flagWithTendermint = "with-tendermint"
-	flagAddress        = "address"
-	flagTraceStore     = "trace-store"
-	flagPruning        = "pruning"
-	FlagMinGasPrices   = "minimum-gas-prices"
+	flagWithTendermint  = "with-tendermint"
+	flagAddress         = "address"
+	flagTraceStore      = "trace-store"
+	FlagMinGasPrices    = "minimum-gas-prices"
+	FlagInterBlockCache = "inter-block-cache"
+	FlagQueryGasLimit   = "query-gas-limit"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -48,11 +51,15 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().Bool(flagWithTendermint, true, "Run abci app embedded in-process with tendermint")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
-	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
 	cmd.Flags().String(
 		FlagMinGasPrices, "",
 		"Minimum gas prices to accept for transactions; Any fee in a tx must meet this minimum (e.g. 0.01photino;0.0001stake)",
 	)
+	cmd.Flags().Bool(FlagInterBlockCache, false, "Cache the reads of the application state across blocks")
+	cmd.Flags().Uint64(FlagQueryGasLimit, 0, "Gas limit of each custom query, 0 for no limit")
+	AddPruningFlags(cmd)
+	cmd.Flags().Int64(FlagSnapshotInterval, 0, "Take a state sync snapshot every N heights, 0 disables snapshots")
+	cmd.Flags().Int(FlagSnapshotKeepRecent, 2, "Number of recent state sync snapshots to keep, 0 keeps all of them")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
@@ -73,6 +80,10 @@ func startStandAlone(ctx *Context, appCreator AppCreator) error {
 		return err
 	}
 
+	if err := startTelemetry(ctx); err != nil {
+		return err
+	}
+
 	app := appCreator(ctx.Logger, db, traceWriter)
 
 	svr, err := server.NewServer(addr, "socket", app)
@@ -112,6 +123,10 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		return nil, err
 	}
 
+	if err := startTelemetry(ctx); err != nil {
+		return nil, err
+	}
+
 	app := appCreator(ctx.Logger, db, traceWriter)
 
 	nodeKey, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile())
@@ -125,7 +140,7 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		cfg,
 		pvm.LoadOrGenFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile()),
 		nodeKey,
-		proxy.NewLocalClientCreator(app),
+		NewLocalClientCreator(app),
 		node.DefaultGenesisDocProviderFunc(cfg),
 		node.DefaultDBProvider,
 		node.DefaultMetricsProvider(cfg.Instrumentation),
@@ -149,3 +164,22 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	// run forever (the node will not be returned)
 	select {}
 }
+
+// startTelemetry starts serving the metrics of the node on the Prometheus
+// endpoint if telemetry is enabled. It must be called before the application
+// is created so that its metrics are recorded.
+func startTelemetry(ctx *Context) error {
+	conf, err := config.ParseConfig()
+	if err != nil {
+		return err
+	}
+
+	srv, err := telemetry.StartServer(conf.Telemetry)
+	if err != nil {
+		return fmt.Errorf("error starting telemetry: %v", err)
+	}
+	if srv != nil {
+		ctx.Logger.Info("Serving telemetry", "addr", conf.Telemetry.PrometheusListenAddr)
+	}
+	return nil
+}
@@ -152,6 +152,10 @@ func AddCommands(
 		client.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
+		SnapshotCmd(ctx),
+		PruneCmd(ctx),
+		RollbackCmd(ctx),
+		MigrateDBCmd(ctx),
 		client.LineBreak,
 		version.VersionCmd,
 	)
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"container/list"
+	"io"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/tracekv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var (
+	_ types.CommitKVStore             = (*CommitKVStoreCache)(nil)
+	_ types.MultiStorePersistentCache = (*CommitKVStoreCacheManager)(nil)
+)
+
+// DefaultCommitKVStoreCacheSize is the number of entries kept by default in
+// the inter-block cache of each store.
+const DefaultCommitKVStoreCacheSize = 1000
+
+// CommitKVStoreCache is an inter-block (persistent) cache wrapping a
+// CommitKVStore. Reads are served from a LRU cache of the most recently used
+// keys, the misses being read from the wrapped store and cached. Writes and
+// deletes go through to both the cache and the store, so that the cache never
+// holds a value the store doesn't have and survives commits.
+type CommitKVStoreCache struct {
+	types.CommitKVStore
+
+	mtx     sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List // of *cacheEntry, the most recently used first
+}
+
+type cacheEntry struct {
+	key   string
+	value []byte
+}
+
+// NewCommitKVStoreCache returns a cache of at most size entries wrapping the
+// given store.
+func NewCommitKVStoreCache(store types.CommitKVStore, size int) *CommitKVStoreCache {
+	return &CommitKVStoreCache{
+		CommitKVStore: store,
+		size:          size,
+		entries:       make(map[string]*list.Element),
+		lru:           list.New(),
+	}
+}
+
+// Get implements KVStore. A missing key is not cached.
+func (ckv *CommitKVStoreCache) Get(key []byte) []byte {
+	ckv.mtx.Lock()
+	defer ckv.mtx.Unlock()
+
+	if elem, ok := ckv.entries[string(key)]; ok {
+		ckv.lru.MoveToFront(elem)
+		return elem.Value.(*cacheEntry).value
+	}
+
+	value := ckv.CommitKVStore.Get(key)
+	if value != nil {
+		ckv.add(string(key), value)
+	}
+	return value
+}
+
+// Set implements KVStore.
+func (ckv *CommitKVStoreCache) Set(key, value []byte) {
+	ckv.mtx.Lock()
+	defer ckv.mtx.Unlock()
+
+	ckv.CommitKVStore.Set(key, value)
+	ckv.add(string(key), value)
+}
+
+// Delete implements KVStore.
+func (ckv *CommitKVStoreCache) Delete(key []byte) {
+	ckv.mtx.Lock()
+	defer ckv.mtx.Unlock()
+
+	ckv.CommitKVStore.Delete(key)
+	if elem, ok := ckv.entries[string(key)]; ok {
+		ckv.lru.Remove(elem)
+		delete(ckv.entries, string(key))
+	}
+}
+
+// CacheWrap implements CacheWrapper, wrapping the cache rather than the store
+// so that the writes of the cache-wrap go through it.
+func (ckv *CommitKVStoreCache) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(ckv)
+}
+
+// CacheWrapWithTrace implements CacheWrapper.
+func (ckv *CommitKVStoreCache) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(ckv, w, tc))
+}
+
+// add caches the value of a key, evicting the least recently used entry if
+// the cache is full.
+func (ckv *CommitKVStoreCache) add(key string, value []byte) {
+	if elem, ok := ckv.entries[key]; ok {
+		elem.Value.(*cacheEntry).value = value
+		ckv.lru.MoveToFront(elem)
+		return
+	}
+
+	ckv.entries[key] = ckv.lru.PushFront(&cacheEntry{key, value})
+	if ckv.lru.Len() > ckv.size {
+		oldest := ckv.lru.Back()
+		ckv.lru.Remove(oldest)
+		delete(ckv.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// CommitKVStoreCacheManager keeps a CommitKVStoreCache per store key, for the
+// stores of a CommitMultiStore to be cached across blocks.
+type CommitKVStoreCacheManager struct {
+	cacheSize int
+	caches    map[string]*CommitKVStoreCache
+}
+
+// NewCommitKVStoreCacheManager returns a manager of caches of the given size.
+func NewCommitKVStoreCacheManager(size int) *CommitKVStoreCacheManager {
+	return &CommitKVStoreCacheManager{
+		cacheSize: size,
+		caches:    make(map[string]*CommitKVStoreCache),
+	}
+}
+
+// GetStoreCache implements MultiStorePersistentCache.
+func (cmgr *CommitKVStoreCacheManager) GetStoreCache(key types.StoreKey, store types.CommitKVStore) types.CommitKVStore {
+	cache := NewCommitKVStoreCache(store, cmgr.cacheSize)
+	cmgr.caches[key.Name()] = cache
+	return cache
+}
+
+// Unwrap implements MultiStorePersistentCache.
+func (cmgr *CommitKVStoreCacheManager) Unwrap(key types.StoreKey) types.CommitKVStore {
+	if cache, ok := cmgr.caches[key.Name()]; ok {
+		return cache.CommitKVStore
+	}
+	return nil
+}
+
+// Reset implements MultiStorePersistentCache.
+func (cmgr *CommitKVStoreCacheManager) Reset() {
+	cmgr.caches = make(map[string]*CommitKVStoreCache)
+}
@@ -0,0 +1,78 @@
+package cache_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store/cache"
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func newIAVLStore(t *testing.T) types.CommitKVStore {
+	store, err := iavl.LoadStore(dbm.NewMemDB(), types.CommitID{}, types.PruneNothing)
+	require.NoError(t, err)
+	return store.(types.CommitKVStore)
+}
+
+func TestGetOrSetStoreCache(t *testing.T) {
+	mngr := cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize)
+
+	sKey := types.NewKVStoreKey("test")
+	store := newIAVLStore(t)
+	require.Nil(t, mngr.Unwrap(sKey))
+
+	store2 := mngr.GetStoreCache(sKey, store)
+	require.NotNil(t, store2)
+	require.Equal(t, store, mngr.Unwrap(sKey))
+
+	mngr.Reset()
+	require.Nil(t, mngr.Unwrap(sKey))
+}
+
+func TestStoreCacheWriteThrough(t *testing.T) {
+	parent := newIAVLStore(t)
+	kvStore := cache.NewCommitKVStoreCache(parent, 2)
+
+	key, value := []byte("key"), []byte("value")
+	require.Nil(t, kvStore.Get(key))
+	kvStore.Set(key, value)
+	require.Equal(t, value, kvStore.Get(key))
+	require.Equal(t, value, parent.Get(key))
+
+	// the cached value survives commits
+	kvStore.Commit()
+	require.Equal(t, value, kvStore.Get(key))
+
+	kvStore.Delete(key)
+	require.Nil(t, kvStore.Get(key))
+	require.Nil(t, parent.Get(key))
+
+	// the writes of a cache-wrap go through the cache
+	kvStore.Set(key, value)
+	cacheWrap := kvStore.CacheWrap().(types.CacheKVStore)
+	cacheWrap.Set(key, []byte("new value"))
+	require.Equal(t, value, kvStore.Get(key))
+	cacheWrap.Write()
+	require.Equal(t, []byte("new value"), kvStore.Get(key))
+	require.Equal(t, []byte("new value"), parent.Get(key))
+}
+
+func TestStoreCacheEviction(t *testing.T) {
+	parent := newIAVLStore(t)
+	kvStore := cache.NewCommitKVStoreCache(parent, 2)
+
+	for i := 0; i < 3; i++ {
+		kvStore.Set([]byte(fmt.Sprintf("key%d", i)), []byte{byte(i)})
+	}
+
+	// key0 was evicted and is read from the store again
+	parent.Set([]byte("key0"), []byte("changed"))
+	parent.Set([]byte("key2"), []byte("changed"))
+	require.Equal(t, []byte("changed"), kvStore.Get([]byte("key0")))
+	require.Equal(t, []byte{2}, kvStore.Get([]byte("key2")))
+}
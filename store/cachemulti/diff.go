@@ -0,0 +1,63 @@
+package cachemulti
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/store/listenkv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// CacheMultiStoreWithDiff returns a cache of the multistore along with a
+// function returning the changes made to the stores of the cache, sorted by
+// store name and key, with the values of the keys in the multistore before the
+// changes. The writes to the transient stores are not reported.
+//
+// CONTRACT: the returned cache is meant to be discarded, its writes are only
+// recorded until it is written.
+func (cms Store) CacheMultiStoreWithDiff() (types.CacheMultiStore, func() []types.StoreDiff) {
+	stores := make(map[types.StoreKey]types.CacheWrapper, len(cms.stores))
+	listened := make(map[types.StoreKey]bool, len(cms.stores))
+	keysByName := make(map[string]types.StoreKey, len(cms.stores))
+	for key, store := range cms.stores {
+		stores[key] = store
+		if store.(types.Store).GetStoreType() != types.StoreTypeTransient {
+			listened[key] = true
+			keysByName[key.Name()] = key
+		}
+	}
+
+	var pairs []types.StoreKVPair
+	txIndex := int64(-1)
+	buffer := listenkv.NewBuffer(listened, &txIndex, func(written []types.StoreKVPair) {
+		pairs = append(pairs, written...)
+	})
+	cache := NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext, buffer)
+
+	diff := func() []types.StoreDiff {
+		buffer.Write()
+
+		var diffs []types.StoreDiff
+		indexes := make(map[string]int)
+		for _, pair := range pairs {
+			id := pair.StoreKey + "/" + string(pair.Key)
+			i, ok := indexes[id]
+			if !ok {
+				before := cms.GetKVStore(keysByName[pair.StoreKey]).Get(pair.Key)
+				diffs = append(diffs, types.StoreDiff{StoreKey: pair.StoreKey, Key: pair.Key, Before: before})
+				i = len(diffs) - 1
+				indexes[id] = i
+			}
+			diffs[i].After = pair.Value
+		}
+
+		sort.Slice(diffs, func(i, j int) bool {
+			if diffs[i].StoreKey != diffs[j].StoreKey {
+				return diffs[i].StoreKey < diffs[j].StoreKey
+			}
+			return bytes.Compare(diffs[i].Key, diffs[j].Key) < 0
+		})
+		return diffs
+	}
+	return cache, diff
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/store/cachekv"
 	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/listenkv"
 	"github.com/cosmos/cosmos-sdk/store/types"
 )
 
@@ -24,14 +25,19 @@ type Store struct {
 
 	traceWriter  io.Writer
 	traceContext types.TraceContext
+
+	listenBuffer *listenkv.Buffer
 }
 
 var _ types.CacheMultiStore = Store{}
 
+// NewFromKVStore returns a new cache multistore. If listenBuffer is not nil,
+// the writes to the listened stores are recorded in it and reported when the
+// cache multistore is written.
 func NewFromKVStore(
 	store types.KVStore,
 	stores map[types.StoreKey]types.CacheWrapper, keys map[string]types.StoreKey,
-	traceWriter io.Writer, traceContext types.TraceContext,
+	traceWriter io.Writer, traceContext types.TraceContext, listenBuffer *listenkv.Buffer,
 ) Store {
 	cms := Store{
 		db:           cachekv.NewStore(store),
@@ -39,6 +45,7 @@ func NewFromKVStore(
 		keys:         keys,
 		traceWriter:  traceWriter,
 		traceContext: traceContext,
+		listenBuffer: listenBuffer,
 	}
 
 	for key, store := range stores {
@@ -47,6 +54,10 @@ func NewFromKVStore(
 		} else {
 			cms.stores[key] = store.CacheWrap()
 		}
+
+		if listenBuffer != nil && listenBuffer.ListeningEnabled(key) {
+			cms.stores[key] = listenkv.NewStore(cms.stores[key].(types.CacheKVStore), key, listenBuffer)
+		}
 	}
 
 	return cms
@@ -55,17 +66,19 @@ func NewFromKVStore(
 func NewStore(
 	db dbm.DB,
 	stores map[types.StoreKey]types.CacheWrapper, keys map[string]types.StoreKey,
-	traceWriter io.Writer, traceContext types.TraceContext,
+	traceWriter io.Writer, traceContext types.TraceContext, listenBuffer *listenkv.Buffer,
 ) Store {
-	return NewFromKVStore(dbadapter.Store{db}, stores, keys, traceWriter, traceContext)
+	return NewFromKVStore(dbadapter.Store{db}, stores, keys, traceWriter, traceContext, listenBuffer)
 }
 
+// newCacheMultiStoreFromCMS returns a cache of the given cache multistore.
+// Its writes are recorded by the listened stores of the parent once written.
 func newCacheMultiStoreFromCMS(cms Store) Store {
 	stores := make(map[types.StoreKey]types.CacheWrapper)
 	for k, v := range cms.stores {
 		stores[k] = v
 	}
-	return NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext)
+	return NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext, nil)
 }
 
 // SetTracer sets the tracer for the MultiStore that the underlying
@@ -101,12 +114,17 @@ func (cms Store) GetStoreType() types.StoreType {
 	return types.StoreTypeMulti
 }
 
-// Write calls Write on each underlying store.
+// Write calls Write on each underlying store, then reports the recorded
+// writes to the listened stores.
 func (cms Store) Write() {
 	cms.db.Write()
 	for _, store := range cms.stores {
 		store.Write()
 	}
+
+	if cms.listenBuffer != nil {
+		cms.listenBuffer.Write()
+	}
 }
 
 // Implements CacheWrapper.
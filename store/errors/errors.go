@@ -1,6 +1,8 @@
 package errors
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -10,6 +12,7 @@ const (
 	CodeInternal       = sdk.CodeInternal
 	CodeTxDecode       = sdk.CodeTxDecode
 	CodeUnknownRequest = sdk.CodeUnknownRequest
+	CodePrunedHeight   = sdk.CodePrunedHeight
 
 	CodespaceRoot = sdk.CodespaceRoot
 )
@@ -27,3 +30,11 @@ func ErrTxDecode(msg string) Error {
 func ErrUnknownRequest(msg string) Error {
 	return sdk.ErrUnknownRequest(msg)
 }
+
+// ErrPrunedHeight is returned by queries for a height whose state has been
+// pruned, naming the earliest height available.
+func ErrPrunedHeight(height, earliest int64) Error {
+	return sdk.ErrPrunedHeight(fmt.Sprintf(
+		"height %d has been pruned, the earliest available height is %d", height, earliest,
+	))
+}
@@ -3,7 +3,11 @@ package gaskv
 import (
 	"io"
 
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+
 	"github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 )
 
 var _ types.KVStore = &Store{}
@@ -14,6 +18,10 @@ type Store struct {
 	gasMeter  types.GasMeter
 	gasConfig types.GasConfig
 	parent    types.KVStore
+
+	// the telemetry counters of the reads and writes of the store
+	reads  metrics.Counter
+	writes metrics.Counter
 }
 
 // NewStore returns a reference to a new GasKVStore.
@@ -23,6 +31,20 @@ func NewStore(parent types.KVStore, gasMeter types.GasMeter, gasConfig types.Gas
 		gasMeter:  gasMeter,
 		gasConfig: gasConfig,
 		parent:    parent,
+		reads:     discard.NewCounter(),
+		writes:    discard.NewCounter(),
+	}
+	return kvs
+}
+
+// NewNamedStore returns a reference to a new GasKVStore whose reads and writes
+// are recorded by the telemetry under the given store name. Gets, Has calls
+// and the entries seeked by the iterators count as reads, while Sets and
+// Deletes count as writes. Nothing is recorded while telemetry is disabled.
+func NewNamedStore(parent types.KVStore, gasMeter types.GasMeter, gasConfig types.GasConfig, name string) *Store {
+	kvs := NewStore(parent, gasMeter, gasConfig)
+	if telemetry.Enabled() {
+		kvs.reads, kvs.writes = telemetry.Default().StoreCounters(name)
 	}
 	return kvs
 }
@@ -34,6 +56,7 @@ func (gs *Store) GetStoreType() types.StoreType {
 
 // Implements KVStore.
 func (gs *Store) Get(key []byte) (value []byte) {
+	gs.reads.Add(1)
 	gs.gasMeter.ConsumeGas(gs.gasConfig.ReadCostFlat, types.GasReadCostFlatDesc)
 	value = gs.parent.Get(key)
 
@@ -46,6 +69,7 @@ func (gs *Store) Get(key []byte) (value []byte) {
 // Implements KVStore.
 func (gs *Store) Set(key []byte, value []byte) {
 	types.AssertValidValue(value)
+	gs.writes.Add(1)
 	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostFlat, types.GasWriteCostFlatDesc)
 	// TODO overflow-safe math?
 	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostPerByte*types.Gas(len(value)), types.GasWritePerByteDesc)
@@ -54,6 +78,7 @@ func (gs *Store) Set(key []byte, value []byte) {
 
 // Implements KVStore.
 func (gs *Store) Has(key []byte) bool {
+	gs.reads.Add(1)
 	gs.gasMeter.ConsumeGas(gs.gasConfig.HasCost, types.GasHasDesc)
 	return gs.parent.Has(key)
 }
@@ -61,6 +86,7 @@ func (gs *Store) Has(key []byte) bool {
 // Implements KVStore.
 func (gs *Store) Delete(key []byte) {
 	// charge gas to prevent certain attack vectors even though space is being freed
+	gs.writes.Add(1)
 	gs.gasMeter.ConsumeGas(gs.gasConfig.DeleteCost, types.GasDeleteDesc)
 	gs.parent.Delete(key)
 }
@@ -98,7 +124,7 @@ func (gs *Store) iterator(start, end []byte, ascending bool) types.Iterator {
 		parent = gs.parent.ReverseIterator(start, end)
 	}
 
-	gi := newGasIterator(gs.gasMeter, gs.gasConfig, parent)
+	gi := newGasIterator(gs.gasMeter, gs.gasConfig, parent, gs.reads)
 	if gi.Valid() {
 		gi.(*gasIterator).consumeSeekGas()
 	}
@@ -110,13 +136,15 @@ type gasIterator struct {
 	gasMeter  types.GasMeter
 	gasConfig types.GasConfig
 	parent    types.Iterator
+	reads     metrics.Counter
 }
 
-func newGasIterator(gasMeter types.GasMeter, gasConfig types.GasConfig, parent types.Iterator) types.Iterator {
+func newGasIterator(gasMeter types.GasMeter, gasConfig types.GasConfig, parent types.Iterator, reads metrics.Counter) types.Iterator {
 	return &gasIterator{
 		gasMeter:  gasMeter,
 		gasConfig: gasConfig,
 		parent:    parent,
+		reads:     reads,
 	}
 }
 
@@ -163,6 +191,7 @@ func (gi *gasIterator) Close() {
 // consumeSeekGas consumes a flat gas cost for seeking and a variable gas cost
 // based on the current value's length.
 func (gi *gasIterator) consumeSeekGas() {
+	gi.reads.Add(1)
 	value := gi.Value()
 
 	gi.gasMeter.ConsumeGas(gi.gasConfig.ReadCostPerByte*types.Gas(len(value)), types.GasValuePerByteDesc)
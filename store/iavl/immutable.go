@@ -0,0 +1,83 @@
+package iavl
+
+import (
+	"io"
+
+	"github.com/tendermint/iavl"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/tracekv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var _ types.KVStore = (*ImmutableStore)(nil)
+
+// ImmutableStore is a read-only KVStore over a committed version of an IAVL
+// store. It doesn't share any state with the working tree of the store, so
+// that it can be read concurrently with the blocks being executed and
+// committed.
+type ImmutableStore struct {
+	tree *iavl.ImmutableTree
+}
+
+// GetImmutable returns a read-only store over the given committed version.
+func (st *Store) GetImmutable(version int64) (*ImmutableStore, error) {
+	// the working tree isn't read, only the roots saved in the db, so that
+	// this is safe while the store is committed
+	tree, err := st.tree.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	return &ImmutableStore{tree: tree}, nil
+}
+
+// Version returns the version of the store.
+func (st *ImmutableStore) Version() int64 {
+	return st.tree.Version()
+}
+
+// Implements Store.
+func (st *ImmutableStore) GetStoreType() types.StoreType {
+	return types.StoreTypeIAVL
+}
+
+// Implements Store.
+func (st *ImmutableStore) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st *ImmutableStore) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(st, w, tc))
+}
+
+// Implements types.KVStore.
+func (st *ImmutableStore) Get(key []byte) []byte {
+	_, v := st.tree.Get(key)
+	return v
+}
+
+// Implements types.KVStore.
+func (st *ImmutableStore) Has(key []byte) bool {
+	return st.tree.Has(key)
+}
+
+// Implements types.KVStore.
+func (st *ImmutableStore) Set(key, value []byte) {
+	panic("cannot write to an immutable IAVL store")
+}
+
+// Implements types.KVStore.
+func (st *ImmutableStore) Delete(key []byte) {
+	panic("cannot write to an immutable IAVL store")
+}
+
+// Implements types.KVStore.
+func (st *ImmutableStore) Iterator(start, end []byte) types.Iterator {
+	return newIAVLIterator(st.tree, start, end, true)
+}
+
+// Implements types.KVStore.
+func (st *ImmutableStore) ReverseIterator(start, end []byte) types.Iterator {
+	return newIAVLIterator(st.tree, start, end, false)
+}
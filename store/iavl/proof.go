@@ -0,0 +1,233 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// the batch proof operation constant values
+const (
+	ProofOpIAVLKeys  = "iavl:keys"
+	ProofOpIAVLRange = "iavl:range"
+)
+
+var (
+	_ merkle.ProofOperator = KeysOp{}
+	_ merkle.ProofOperator = RangeOp{}
+)
+
+// KeysOp proves the values of a list of keys in a tree with one range proof
+// per key, an empty tree having no proofs. It runs on the amino encoding of the
+// key/value pairs of the keys that exist, in the order of the keys, and returns
+// the root hash of the tree.
+type KeysOp struct {
+	Keys   [][]byte           `json:"keys"`
+	Proofs []*iavl.RangeProof `json:"proofs"`
+}
+
+// NewKeysOp returns a new KeysOp.
+func NewKeysOp(keys [][]byte, proofs []*iavl.RangeProof) KeysOp {
+	return KeysOp{
+		Keys:   keys,
+		Proofs: proofs,
+	}
+}
+
+// KeysOpDecoder returns a KeysOp from a given proof operation.
+func KeysOpDecoder(pop merkle.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpIAVLKeys {
+		return nil, cmn.NewError("unexpected ProofOp.Type; got %v, want %v", pop.Type, ProofOpIAVLKeys)
+	}
+
+	var op KeysOp
+	err := cdc.UnmarshalBinaryLengthPrefixed(pop.Data, &op)
+	if err != nil {
+		return nil, cmn.ErrorWrap(err, "decoding ProofOp.Data into KeysOp")
+	}
+	return op, nil
+}
+
+// ProofOp implements merkle.ProofOperator.
+func (op KeysOp) ProofOp() merkle.ProofOp {
+	return merkle.ProofOp{
+		Type: ProofOpIAVLKeys,
+		Data: cdc.MustMarshalBinaryLengthPrefixed(op),
+	}
+}
+
+// String implements the Stringer interface.
+func (op KeysOp) String() string {
+	return fmt.Sprintf("KeysOp{%d keys}", len(op.Keys))
+}
+
+// GetKey implements merkle.ProofOperator. The keys are part of the operation,
+// so that it doesn't consume any key of the key path.
+func (op KeysOp) GetKey() []byte {
+	return nil
+}
+
+// Run implements merkle.ProofOperator.
+func (op KeysOp) Run(args [][]byte) ([][]byte, error) {
+	kvs, err := decodeKVPairs(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(op.Proofs) == 0 {
+		return emptyTreeRoot(kvs)
+	}
+	if len(op.Proofs) != len(op.Keys) {
+		return nil, cmn.NewError("got %d proofs for %d keys", len(op.Proofs), len(op.Keys))
+	}
+
+	root := op.Proofs[0].ComputeRootHash()
+	next := 0
+	for i, key := range op.Keys {
+		proof := op.Proofs[i]
+		if err := proof.Verify(root); err != nil {
+			return nil, cmn.ErrorWrap(err, "verifying proof of key %X", key)
+		}
+
+		if next < len(kvs) && bytes.Equal(kvs[next].Key, key) {
+			err = proof.VerifyItem(key, kvs[next].Value)
+			next++
+		} else {
+			err = proof.VerifyAbsence(key)
+		}
+		if err != nil {
+			return nil, cmn.ErrorWrap(err, "verifying proof of key %X", key)
+		}
+	}
+	if next != len(kvs) {
+		return nil, cmn.NewError("%d values don't belong to the proven keys", len(kvs)-next)
+	}
+
+	return [][]byte{root}, nil
+}
+
+// RangeOp proves all the key/value pairs of a tree in the range [Start, End)
+// with a single range proof, a nil End being the end of the tree and an empty
+// tree having a nil proof. It runs on the amino encoding of the key/value pairs
+// in the range, and returns the root hash of the tree.
+type RangeOp struct {
+	Start []byte           `json:"start"`
+	End   []byte           `json:"end"`
+	Proof *iavl.RangeProof `json:"proof"`
+}
+
+// NewRangeOp returns a new RangeOp.
+func NewRangeOp(start, end []byte, proof *iavl.RangeProof) RangeOp {
+	return RangeOp{
+		Start: start,
+		End:   end,
+		Proof: proof,
+	}
+}
+
+// RangeOpDecoder returns a RangeOp from a given proof operation.
+func RangeOpDecoder(pop merkle.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpIAVLRange {
+		return nil, cmn.NewError("unexpected ProofOp.Type; got %v, want %v", pop.Type, ProofOpIAVLRange)
+	}
+
+	var op RangeOp
+	err := cdc.UnmarshalBinaryLengthPrefixed(pop.Data, &op)
+	if err != nil {
+		return nil, cmn.ErrorWrap(err, "decoding ProofOp.Data into RangeOp")
+	}
+	return op, nil
+}
+
+// ProofOp implements merkle.ProofOperator.
+func (op RangeOp) ProofOp() merkle.ProofOp {
+	return merkle.ProofOp{
+		Type: ProofOpIAVLRange,
+		Data: cdc.MustMarshalBinaryLengthPrefixed(op),
+	}
+}
+
+// String implements the Stringer interface.
+func (op RangeOp) String() string {
+	return fmt.Sprintf("RangeOp{%X-%X}", op.Start, op.End)
+}
+
+// GetKey implements merkle.ProofOperator. The range is part of the operation,
+// so that it doesn't consume any key of the key path.
+func (op RangeOp) GetKey() []byte {
+	return nil
+}
+
+// Run implements merkle.ProofOperator.
+func (op RangeOp) Run(args [][]byte) ([][]byte, error) {
+	kvs, err := decodeKVPairs(args)
+	if err != nil {
+		return nil, err
+	}
+	if op.Proof == nil {
+		return emptyTreeRoot(kvs)
+	}
+
+	root := op.Proof.ComputeRootHash()
+	if err := op.Proof.Verify(root); err != nil {
+		return nil, err
+	}
+
+	// the leaves of the proof are contiguous, so they must extend from
+	// before the start of the range to after its end for no pair to be missing
+	leaves := op.Proof.Keys()
+	if bytes.Compare(op.Start, leaves[0]) < 0 && op.Proof.VerifyAbsence(op.Start) != nil {
+		return nil, cmn.NewError("proof doesn't cover the start of the range")
+	}
+	last := leaves[len(leaves)-1]
+	if op.End == nil || bytes.Compare(last, op.End) < 0 {
+		// a key right after the last leaf is only absent at the end of the tree
+		if op.Proof.VerifyAbsence(append(append([]byte{}, last...), 0)) != nil {
+			return nil, cmn.NewError("proof doesn't cover the end of the range")
+		}
+	}
+
+	var keys [][]byte
+	for _, key := range leaves {
+		if bytes.Compare(key, op.Start) >= 0 && (op.End == nil || bytes.Compare(key, op.End) < 0) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) != len(kvs) {
+		return nil, cmn.NewError("got %d values for the %d keys of the range", len(kvs), len(keys))
+	}
+	for i, kv := range kvs {
+		if !bytes.Equal(kv.Key, keys[i]) {
+			return nil, cmn.NewError("unexpected key %X in the range", kv.Key)
+		}
+		if err := op.Proof.VerifyItem(kv.Key, kv.Value); err != nil {
+			return nil, cmn.ErrorWrap(err, "verifying proof of key %X", kv.Key)
+		}
+	}
+
+	return [][]byte{root}, nil
+}
+
+func decodeKVPairs(args [][]byte) (kvs []types.KVPair, err error) {
+	if len(args) != 1 {
+		return nil, cmn.NewError("expected 1 arg, got %d", len(args))
+	}
+	err = cdc.UnmarshalBinaryLengthPrefixed(args[0], &kvs)
+	if err != nil {
+		return nil, cmn.ErrorWrap(err, "decoding key/value pairs")
+	}
+	return kvs, nil
+}
+
+// emptyTreeRoot returns the root hash of an empty tree, which has no proof, if
+// no value is proven.
+func emptyTreeRoot(kvs []types.KVPair) ([][]byte, error) {
+	if len(kvs) != 0 {
+		return nil, cmn.NewError("got %d values without a proof", len(kvs))
+	}
+	return [][]byte{nil}, nil
+}
@@ -0,0 +1,99 @@
+package iavl
+
+import (
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// RootHash returns the root hash of the given version of the tree persisted in
+// db, and false if the version does not exist.
+func RootHash(db dbm.DB, version int64) ([]byte, bool) {
+	hash := db.Get(rootKeyFormat.Key(version))
+	if hash == nil {
+		return nil, false
+	}
+	if len(hash) == 0 {
+		// the root of an empty tree
+		return nil, true
+	}
+	return hash, true
+}
+
+// DeleteVersionsAfter rolls the tree persisted in db back to the given version,
+// which must exist, by deleting all the versions after it. The nodes created
+// after the version are deleted, and the nodes of the version orphaned by the
+// later versions become live again.
+func DeleteVersionsAfter(db dbm.DB, version int64) error {
+	if db.Get(rootKeyFormat.Key(version)) == nil {
+		return fmt.Errorf("version %d does not exist", version)
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	// collect the roots of the later versions
+	var roots [][]byte
+	it := dbm.IteratePrefix(db, rootKeyFormat.Key())
+	for ; it.Valid(); it.Next() {
+		var v int64
+		rootKeyFormat.Scan(it.Key(), &v)
+		if v <= version {
+			continue
+		}
+		if len(it.Value()) != 0 {
+			roots = append(roots, it.Value())
+		}
+		batch.Delete(it.Key())
+	}
+	it.Close()
+
+	// delete the nodes reachable from these roots that were created after the
+	// version, the older subtrees being part of the remaining versions
+	deleted := make(map[string]bool)
+	stack := roots
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if deleted[string(hash)] {
+			continue
+		}
+
+		bz := db.Get(nodeKeyFormat.Key(hash))
+		if bz == nil {
+			continue
+		}
+		node, err := decodeNode(bz)
+		if err != nil {
+			return err
+		}
+		if node.version <= version {
+			continue
+		}
+
+		batch.Delete(nodeKeyFormat.Key(hash))
+		deleted[string(hash)] = true
+		if !node.isLeaf() {
+			stack = append(stack, node.leftHash, node.rightHash)
+		}
+	}
+
+	// the nodes orphaned at or after the version are either deleted, if they
+	// were created after it, or part of the latest version again
+	it = dbm.IteratePrefix(db, orphanKeyFormat.Key())
+	for ; it.Valid(); it.Next() {
+		var to, from int64
+		orphanKeyFormat.Scan(it.Key(), &to, &from)
+		if to < version {
+			continue
+		}
+		if from > version {
+			batch.Delete(nodeKeyFormat.Key(it.Value()))
+		}
+		batch.Delete(it.Key())
+	}
+	it.Close()
+
+	batch.WriteSync()
+	return nil
+}
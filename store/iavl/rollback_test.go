@@ -0,0 +1,75 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func saveRollbackVersions(t *testing.T, tree *iavl.MutableTree, from, to int) [][]byte {
+	var hashes [][]byte
+	for v := from; v <= to; v++ {
+		for i := 0; i < 20; i++ {
+			tree.Set([]byte(fmt.Sprintf("key%02d", i*v%30)), []byte(fmt.Sprintf("value%d", v)))
+		}
+		tree.Remove([]byte(fmt.Sprintf("key%02d", v)))
+		hash, _, err := tree.SaveVersion()
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func countKeys(db dbm.DB, prefix byte) int {
+	count := 0
+	it := dbm.IteratePrefix(db, []byte{prefix})
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		count++
+	}
+	return count
+}
+
+func TestDeleteVersionsAfter(t *testing.T) {
+	db := dbm.NewMemDB()
+	hashes := saveRollbackVersions(t, iavl.NewMutableTree(db, cacheSize), 1, 6)
+
+	// the reference tree only ever had the first 3 versions
+	expectedDB := dbm.NewMemDB()
+	saveRollbackVersions(t, iavl.NewMutableTree(expectedDB, cacheSize), 1, 3)
+
+	require.Error(t, DeleteVersionsAfter(db, 7))
+	require.NoError(t, DeleteVersionsAfter(db, 3))
+	for _, prefix := range []byte{'n', 'o', 'r'} {
+		require.Equal(t, countKeys(expectedDB, prefix), countKeys(db, prefix), "prefix %c", prefix)
+	}
+
+	tree := iavl.NewMutableTree(db, cacheSize)
+	latest, err := tree.Load()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), latest)
+	require.Equal(t, hashes[2], tree.Hash())
+
+	// the next versions can be committed again, and the older ones pruned
+	require.Equal(t, hashes[3:5], saveRollbackVersions(t, tree, 4, 5))
+	expectedTree := iavl.NewMutableTree(expectedDB, cacheSize)
+	_, err = expectedTree.Load()
+	require.NoError(t, err)
+	saveRollbackVersions(t, expectedTree, 4, 5)
+	for v := int64(1); v < 5; v++ {
+		require.NoError(t, tree.DeleteVersion(v))
+		require.NoError(t, expectedTree.DeleteVersion(v))
+	}
+	for _, prefix := range []byte{'n', 'o', 'r'} {
+		require.Equal(t, countKeys(expectedDB, prefix), countKeys(db, prefix), "prefix %c", prefix)
+	}
+	expectedTree.Iterate(func(key, expected []byte) bool {
+		_, value := tree.Get(key)
+		require.Equal(t, expected, value)
+		return false
+	})
+}
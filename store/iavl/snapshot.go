@@ -0,0 +1,221 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// The snapshot and rollback functions operate on the nodes persisted by the
+// IAVL tree, so that a restored tree has exactly the same hash as the original
+// one. They depend on the storage layout of the tree:
+//
+//	n<hash>              -> node
+//	o<last><first><hash> -> hash of a node orphaned after its last version
+//	r<version>           -> root hash
+var (
+	nodeKeyFormat   = iavl.NewKeyFormat('n', tmhash.Size)
+	orphanKeyFormat = iavl.NewKeyFormat('o', 8, 8, tmhash.Size)
+	rootKeyFormat   = iavl.NewKeyFormat('r', 8)
+)
+
+// number of imported nodes buffered before they are written to disk
+const importBatchSize = 10000
+
+// ExportNodes calls fn with the encoding of every node of the tree persisted in
+// db at the given version, in pre-order. It returns the root hash of the tree,
+// which is empty if the tree is empty.
+func ExportNodes(db dbm.DB, version int64, fn func(node []byte) error) ([]byte, error) {
+	rootHash := db.Get(rootKeyFormat.Key(version))
+	if rootHash == nil {
+		return nil, fmt.Errorf("version %d does not exist", version)
+	}
+	if len(rootHash) == 0 {
+		return rootHash, nil
+	}
+
+	stack := [][]byte{rootHash}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		bz := db.Get(nodeKeyFormat.Key(hash))
+		if bz == nil {
+			return nil, fmt.Errorf("node %X of version %d is missing, the version may have been pruned", hash, version)
+		}
+
+		node, err := decodeNode(bz)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := fn(bz); err != nil {
+			return nil, err
+		}
+
+		if !node.isLeaf() {
+			stack = append(stack, node.rightHash, node.leftHash)
+		}
+	}
+
+	return rootHash, nil
+}
+
+// NodeImporter writes the nodes of a single tree version, as exported by
+// ExportNodes, to an empty db. Each node is verified against the hash its
+// parent refers to, up to the expected root hash.
+type NodeImporter struct {
+	db      dbm.DB
+	batch   dbm.Batch
+	size    int
+	version int64
+	root    []byte
+	pending map[string]bool
+}
+
+// NewNodeImporter returns a new NodeImporter for the given version of a tree
+// with the given root hash.
+func NewNodeImporter(db dbm.DB, version int64, rootHash []byte) *NodeImporter {
+	pending := make(map[string]bool)
+	if len(rootHash) != 0 {
+		pending[string(rootHash)] = true
+	}
+
+	return &NodeImporter{
+		db:      db,
+		batch:   db.NewBatch(),
+		version: version,
+		root:    rootHash,
+		pending: pending,
+	}
+}
+
+// Add imports the next node of the tree.
+func (ni *NodeImporter) Add(bz []byte) error {
+	node, err := decodeNode(bz)
+	if err != nil {
+		return err
+	}
+
+	hash := node.hash()
+	if !ni.pending[string(hash)] {
+		return fmt.Errorf("unexpected node %X", hash)
+	}
+	delete(ni.pending, string(hash))
+
+	if !node.isLeaf() {
+		ni.pending[string(node.leftHash)] = true
+		ni.pending[string(node.rightHash)] = true
+	}
+
+	ni.batch.Set(nodeKeyFormat.Key(hash), bz)
+	ni.size++
+	if ni.size >= importBatchSize {
+		ni.batch.Write()
+		ni.batch = ni.db.NewBatch()
+		ni.size = 0
+	}
+
+	return nil
+}
+
+// Commit verifies that all the nodes of the tree were imported and saves its
+// root, after which the version can be loaded.
+func (ni *NodeImporter) Commit() error {
+	if len(ni.pending) != 0 {
+		return fmt.Errorf("%d nodes missing from the tree at version %d", len(ni.pending), ni.version)
+	}
+
+	root := ni.root
+	if root == nil {
+		root = []byte{}
+	}
+	ni.batch.Set(rootKeyFormat.Key(ni.version), root)
+	ni.batch.Write()
+	return nil
+}
+
+// snapshotNode holds the fields of a persisted node needed to walk the tree
+// and compute the node hash.
+type snapshotNode struct {
+	height    int8
+	size      int64
+	version   int64
+	key       []byte
+	value     []byte
+	leftHash  []byte
+	rightHash []byte
+}
+
+func (node snapshotNode) isLeaf() bool {
+	return node.height == 0
+}
+
+// hash returns the hash of the node as computed by the IAVL tree.
+func (node snapshotNode) hash() []byte {
+	var buf bytes.Buffer
+
+	// writes to a bytes.Buffer never fail
+	_ = amino.EncodeInt8(&buf, node.height)
+	_ = amino.EncodeVarint(&buf, node.size)
+	_ = amino.EncodeVarint(&buf, node.version)
+	if node.isLeaf() {
+		_ = amino.EncodeByteSlice(&buf, node.key)
+		_ = amino.EncodeByteSlice(&buf, tmhash.Sum(node.value))
+	} else {
+		_ = amino.EncodeByteSlice(&buf, node.leftHash)
+		_ = amino.EncodeByteSlice(&buf, node.rightHash)
+	}
+
+	return tmhash.Sum(buf.Bytes())
+}
+
+// decodeNode decodes a node persisted by the IAVL tree.
+func decodeNode(bz []byte) (node snapshotNode, err error) {
+	var n int
+
+	if node.height, n, err = amino.DecodeInt8(bz); err != nil {
+		return node, fmt.Errorf("decoding node height: %v", err)
+	}
+	bz = bz[n:]
+
+	if node.size, n, err = amino.DecodeVarint(bz); err != nil {
+		return node, fmt.Errorf("decoding node size: %v", err)
+	}
+	bz = bz[n:]
+
+	if node.version, n, err = amino.DecodeVarint(bz); err != nil {
+		return node, fmt.Errorf("decoding node version: %v", err)
+	}
+	bz = bz[n:]
+
+	if node.key, n, err = amino.DecodeByteSlice(bz); err != nil {
+		return node, fmt.Errorf("decoding node key: %v", err)
+	}
+	bz = bz[n:]
+
+	if node.isLeaf() {
+		if node.value, _, err = amino.DecodeByteSlice(bz); err != nil {
+			return node, fmt.Errorf("decoding node value: %v", err)
+		}
+		return node, nil
+	}
+
+	if node.leftHash, n, err = amino.DecodeByteSlice(bz); err != nil {
+		return node, fmt.Errorf("decoding node left hash: %v", err)
+	}
+	bz = bz[n:]
+
+	if node.rightHash, _, err = amino.DecodeByteSlice(bz); err != nil {
+		return node, fmt.Errorf("decoding node right hash: %v", err)
+	}
+	if len(node.leftHash) == 0 || len(node.rightHash) == 0 {
+		return node, fmt.Errorf("inner node with an empty child hash")
+	}
+
+	return node, nil
+}
@@ -0,0 +1,124 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func newSnapshotTree(t *testing.T, db dbm.DB) *iavl.MutableTree {
+	tree := iavl.NewMutableTree(db, cacheSize)
+	for v := 0; v < 3; v++ {
+		for i := 0; i < 50; i++ {
+			tree.Set([]byte(fmt.Sprintf("key%03d", i*(v+1))), []byte(fmt.Sprintf("value%d", v)))
+		}
+		tree.Remove([]byte("key002"))
+		_, _, err := tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	return tree
+}
+
+func exportNodes(t *testing.T, db dbm.DB, version int64) ([]byte, [][]byte) {
+	var nodes [][]byte
+	root, err := ExportNodes(db, version, func(node []byte) error {
+		nodes = append(nodes, node)
+		return nil
+	})
+	require.NoError(t, err)
+	return root, nodes
+}
+
+func TestExportImportNodes(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := newSnapshotTree(t, db)
+
+	for _, version := range []int64{2, 3} {
+		root, nodes := exportNodes(t, db, version)
+
+		restoredDB := dbm.NewMemDB()
+		importer := NewNodeImporter(restoredDB, version, root)
+		for _, node := range nodes {
+			require.NoError(t, importer.Add(node))
+		}
+		require.NoError(t, importer.Commit())
+
+		restored := iavl.NewMutableTree(restoredDB, cacheSize)
+		latest, err := restored.LoadVersion(version)
+		require.NoError(t, err)
+		require.Equal(t, version, latest)
+		require.Equal(t, root, restored.Hash())
+
+		expected, err := tree.GetImmutable(version)
+		require.NoError(t, err)
+		require.Equal(t, expected.Size(), restored.Size())
+		expected.Iterate(func(key, value []byte) bool {
+			_, restoredValue := restored.Get(key)
+			require.Equal(t, value, restoredValue)
+			return false
+		})
+
+		// the restored tree can be written to
+		restored.Set([]byte("new"), []byte("value"))
+		_, newVersion, err := restored.SaveVersion()
+		require.NoError(t, err)
+		require.Equal(t, version+1, newVersion)
+	}
+
+	_, err := ExportNodes(db, 4, func([]byte) error { return nil })
+	require.Error(t, err)
+}
+
+func TestExportImportEmptyTree(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	_, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+
+	root, nodes := exportNodes(t, db, version)
+	require.Empty(t, root)
+	require.Empty(t, nodes)
+
+	restoredDB := dbm.NewMemDB()
+	require.NoError(t, NewNodeImporter(restoredDB, version, root).Commit())
+
+	restored := iavl.NewMutableTree(restoredDB, cacheSize)
+	_, err = restored.LoadVersion(version)
+	require.NoError(t, err)
+	require.Empty(t, restored.Hash())
+}
+
+func TestImportNodesVerification(t *testing.T) {
+	db := dbm.NewMemDB()
+	newSnapshotTree(t, db)
+	root, nodes := exportNodes(t, db, 3)
+
+	// missing nodes
+	importer := NewNodeImporter(dbm.NewMemDB(), 3, root)
+	for _, node := range nodes[:len(nodes)-1] {
+		require.NoError(t, importer.Add(node))
+	}
+	require.Error(t, importer.Commit())
+
+	// tampered node
+	importer = NewNodeImporter(dbm.NewMemDB(), 3, root)
+	require.NoError(t, importer.Add(nodes[0]))
+	leaf := nodes[len(nodes)-1]
+	tampered := append(append([]byte{}, leaf[:len(leaf)-1]...), leaf[len(leaf)-1]+1)
+	for _, node := range nodes[1 : len(nodes)-1] {
+		require.NoError(t, importer.Add(node))
+	}
+	require.Error(t, importer.Add(tampered))
+
+	// nodes of another tree
+	importer = NewNodeImporter(dbm.NewMemDB(), 3, []byte("invalid root hash"))
+	require.Error(t, importer.Add(nodes[0]))
+
+	// garbage
+	importer = NewNodeImporter(dbm.NewMemDB(), 3, root)
+	require.Error(t, importer.Add([]byte{0x01}))
+}
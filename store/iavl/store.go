@@ -30,9 +30,28 @@ func LoadStore(db dbm.DB, id types.CommitID, pruning types.PruningOptions) (type
 	}
 	iavl := UnsafeNewStore(tree, int64(0), int64(0))
 	iavl.SetPruning(pruning)
+
+	// the versions before the earliest one have already been pruned
+	if earliest := earliestVersion(db); earliest > 0 {
+		iavl.earliest = earliest
+		iavl.lastPruned = earliest - 1
+	}
 	return iavl, nil
 }
 
+// earliestVersion returns the earliest version of the tree persisted in db, or
+// 0 if there is none.
+func earliestVersion(db dbm.DB) int64 {
+	it := dbm.IteratePrefix(db, rootKeyFormat.Key())
+	defer it.Close()
+	if !it.Valid() {
+		return 0
+	}
+	var version int64
+	rootKeyFormat.Scan(it.Key(), &version)
+	return version
+}
+
 //----------------------------------------
 
 var _ types.KVStore = (*Store)(nil)
@@ -44,26 +63,39 @@ type Store struct {
 	// The underlying tree.
 	tree *iavl.MutableTree
 
-	// How many old versions we hold onto.
-	// A value of 0 means keep no recent states.
-	numRecent int64
-
-	// This is the distance between state-sync waypoint states to be stored.
+	// How many old versions we hold onto, which ones are kept as state-sync
+	// waypoints and how often the other ones are deleted.
 	// See https://github.com/tendermint/tendermint/issues/828
-	// A value of 1 means store every state.
-	// A value of 0 means store no waypoints. (node cannot assist in state-sync)
-	// By default this value should be set the same across all nodes,
+	// By default the waypoints should be the same across all nodes,
 	// so that nodes can know the waypoints their peers store.
-	storeEvery int64
+	pruning types.PruningOptions
+
+	// All the versions up to this one have been considered for pruning.
+	lastPruned int64
+
+	// Lower bound of the earliest available version, which is updated by the
+	// pruning and read by the queries.
+	earliest int64
+
+	// Versions read in the background, e.g. exported by a snapshot, which are
+	// not pruned until they are unpinned. The pinned versions skipped by a
+	// pruning run are pruned by the first run after they are unpinned.
+	pinned  map[int64]int
+	skipped map[int64]bool
+
+	// mtx guards earliest and the pinned versions
+	mtx sync.Mutex
 }
 
 // CONTRACT: tree should be fully loaded.
 // nolint: unparam
 func UnsafeNewStore(tree *iavl.MutableTree, numRecent int64, storeEvery int64) *Store {
 	st := &Store{
-		tree:       tree,
-		numRecent:  numRecent,
-		storeEvery: storeEvery,
+		tree:     tree,
+		pruning:  types.NewPruningOptions(numRecent, storeEvery),
+		earliest: 1,
+		pinned:   make(map[int64]int),
+		skipped:  make(map[int64]bool),
 	}
 	return st
 }
@@ -77,15 +109,10 @@ func (st *Store) Commit() types.CommitID {
 		panic(err)
 	}
 
-	// Release an old version of history, if not a sync waypoint.
-	previous := version - 1
-	if st.numRecent < previous {
-		toRelease := previous - st.numRecent
-		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			err := st.tree.DeleteVersion(toRelease)
-			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
-				panic(err)
-			}
+	// Release the old versions of history, if not sync waypoints.
+	if version%st.pruning.Interval() == 0 {
+		if err := st.Prune(); err != nil {
+			panic(err)
 		}
 	}
 
@@ -95,6 +122,99 @@ func (st *Store) Commit() types.CommitID {
 	}
 }
 
+// Prune deletes the versions released by the pruning options since the last
+// time the store was pruned. All the versions from the earliest existing one
+// to the recent ones are considered the first time a loaded store is pruned.
+func (st *Store) Prune() error {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	// the latest version and the keepRecent versions before it are kept
+	release := st.tree.Version() - 1 - st.pruning.KeepRecent()
+
+	for version := range st.skipped {
+		if st.pinned[version] > 0 {
+			continue
+		}
+		delete(st.skipped, version)
+		if err := st.pruneVersion(version); err != nil {
+			return err
+		}
+	}
+
+	for version := st.lastPruned + 1; version <= release; version++ {
+		if st.pinned[version] > 0 {
+			st.skipped[version] = true
+			continue
+		}
+		if err := st.pruneVersion(version); err != nil {
+			return err
+		}
+	}
+
+	if release > st.lastPruned {
+		st.lastPruned = release
+	}
+	st.earliest = st.earliestVersion()
+	return nil
+}
+
+// pruneVersion deletes the given version unless it is kept by the pruning
+// options or has already been deleted.
+func (st *Store) pruneVersion(version int64) error {
+	if st.pruning.KeepHeight(version) || !st.tree.VersionExists(version) {
+		return nil
+	}
+	err := st.tree.DeleteVersion(version)
+	if err != nil {
+		if cmnErr, ok := err.(cmn.Error); !ok || cmnErr.Data() != iavl.ErrVersionDoesNotExist {
+			return err
+		}
+	}
+	return nil
+}
+
+// PinVersion keeps the given version from being pruned until it is unpinned
+// as many times as it was pinned, so that it can be read in the background.
+func (st *Store) PinVersion(version int64) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+	st.pinned[version]++
+}
+
+// UnpinVersion releases a version pinned with PinVersion.
+func (st *Store) UnpinVersion(version int64) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+	if st.pinned[version] <= 1 {
+		delete(st.pinned, version)
+		return
+	}
+	st.pinned[version]--
+}
+
+// EarliestVersion returns the earliest version of the store that has not been
+// pruned.
+func (st *Store) EarliestVersion() int64 {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+	return st.earliestVersion()
+}
+
+// earliestVersion returns the earliest existing version from the lower bound,
+// without updating it, as the queries must not write to the store.
+// CONTRACT: st.mtx is locked.
+func (st *Store) earliestVersion() int64 {
+	latest := st.tree.Version()
+	earliest := st.earliest
+	for ; earliest < latest; earliest++ {
+		if st.tree.VersionExists(earliest) {
+			break
+		}
+	}
+	return earliest
+}
+
 // Implements Committer.
 func (st *Store) LastCommitID() types.CommitID {
 	return types.CommitID{
@@ -105,8 +225,7 @@ func (st *Store) LastCommitID() types.CommitID {
 
 // Implements Committer.
 func (st *Store) SetPruning(opt types.PruningOptions) {
-	st.numRecent = opt.KeepRecent()
-	st.storeEvery = opt.KeepEvery()
+	st.pruning = opt
 }
 
 // VersionExists returns whether or not a given version is stored.
@@ -200,6 +319,9 @@ func (st *Store) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 
 		res.Key = key
 		if !st.VersionExists(res.Height) {
+			if res.Height > 0 && res.Height < st.tree.Version() {
+				return errors.ErrPrunedHeight(res.Height, st.EarliestVersion()).QueryResult()
+			}
 			res.Log = cmn.ErrorWrap(iavl.ErrVersionDoesNotExist, "").Error()
 			break
 		}
@@ -229,6 +351,77 @@ func (st *Store) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 			_, res.Value = tree.GetVersioned(key, res.Height)
 		}
 
+	case "/keys": // get a list of keys, data holds their amino encoding
+		var keys [][]byte
+		if err := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &keys); err != nil || len(keys) == 0 {
+			return errors.ErrUnknownRequest("Query data must be a non-empty list of keys").QueryResult()
+		}
+
+		res.Key = req.Data
+		if !st.VersionExists(res.Height) {
+			return st.missingVersionResult(res.Height)
+		}
+
+		var KVs []types.KVPair
+		var proofs []*iavl.RangeProof
+		for _, key := range keys {
+			var value []byte
+			if req.Prove {
+				var proof *iavl.RangeProof
+				var err error
+				value, proof, err = tree.GetVersionedWithProof(key, res.Height)
+				if err != nil {
+					return errors.ErrInternal(err.Error()).QueryResult()
+				}
+				// proofs are nil if the tree is empty
+				if proof != nil {
+					proofs = append(proofs, proof)
+				}
+			} else {
+				_, value = tree.GetVersioned(key, res.Height)
+			}
+			if value != nil {
+				KVs = append(KVs, types.KVPair{Key: key, Value: value})
+			}
+		}
+
+		res.Value = cdc.MustMarshalBinaryLengthPrefixed(KVs)
+		if req.Prove {
+			res.Proof = &merkle.Proof{Ops: []merkle.ProofOp{NewKeysOp(keys, proofs).ProofOp()}}
+		}
+
+	case "/range": // get all the keys with a prefix, data holds the prefix
+		prefix := req.Data
+		res.Key = prefix
+		if !st.VersionExists(res.Height) {
+			return st.missingVersionResult(res.Height)
+		}
+
+		immutable, err := tree.GetImmutable(res.Height)
+		if err != nil {
+			return errors.ErrInternal(err.Error()).QueryResult()
+		}
+
+		var KVs []types.KVPair
+		end := types.PrefixEndBytes(prefix)
+		immutable.IterateRange(prefix, end, true, func(key, value []byte) bool {
+			KVs = append(KVs, types.KVPair{Key: key, Value: value})
+			return false
+		})
+
+		res.Value = cdc.MustMarshalBinaryLengthPrefixed(KVs)
+		if req.Prove {
+			// iavl stops a range proof right after a key whose increment reaches
+			// the end key, which doesn't prove that the range is complete, so
+			// the proof is limited to the leaf before the range, the pairs of
+			// the range and the leaf after it instead
+			_, _, proof, err := immutable.GetRangeWithProof(prefix, nil, len(KVs)+2)
+			if err != nil {
+				return errors.ErrInternal(err.Error()).QueryResult()
+			}
+			res.Proof = &merkle.Proof{Ops: []merkle.ProofOp{NewRangeOp(prefix, end, proof).ProofOp()}}
+		}
+
 	case "/subspace":
 		var KVs []types.KVPair
 
@@ -251,6 +444,16 @@ func (st *Store) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	return
 }
 
+// missingVersionResult returns the result of a query at a height whose version
+// doesn't exist.
+func (st *Store) missingVersionResult(height int64) abci.ResponseQuery {
+	if height > 0 && height < st.tree.Version() {
+		return errors.ErrPrunedHeight(height, st.EarliestVersion()).QueryResult()
+	}
+	msg := fmt.Sprintf("version %d does not exist", height)
+	return errors.ErrUnknownRequest(msg).QueryResult()
+}
+
 //----------------------------------------
 
 // Implements types.Iterator.
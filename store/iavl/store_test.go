@@ -302,6 +302,46 @@ func TestIAVLReversePrefixIterator(t *testing.T) {
 	require.Equal(t, len(expected), i)
 }
 
+func TestIAVLGetImmutable(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree, cID := newAlohaTree(t, db)
+	iavlStore := UnsafeNewStore(tree, numRecent, storeEvery)
+
+	// the working tree is modified and committed after the snapshot is taken
+	immutable, err := iavlStore.GetImmutable(cID.Version)
+	require.NoError(t, err)
+	iavlStore.Set([]byte("hello"), []byte("adios"))
+	iavlStore.Set([]byte("bonjour"), []byte("au revoir"))
+	newCID := iavlStore.Commit()
+
+	require.Equal(t, cID.Version, immutable.Version())
+	require.Equal(t, []byte(treeData["hello"]), immutable.Get([]byte("hello")))
+	require.False(t, immutable.Has([]byte("bonjour")))
+
+	iter := immutable.Iterator(nil, nil)
+	var keys []string
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	iter.Close()
+	require.Equal(t, []string{"aloha", "hello"}, keys)
+
+	require.Panics(t, func() { immutable.Set([]byte("hello"), []byte("adios")) })
+	require.Panics(t, func() { immutable.Delete([]byte("hello")) })
+
+	// the writes to a cache of the snapshot are discarded
+	cache := immutable.CacheWrap().(types.KVStore)
+	cache.Set([]byte("hello"), []byte("adios"))
+	require.Equal(t, []byte("adios"), cache.Get([]byte("hello")))
+
+	immutable, err = iavlStore.GetImmutable(newCID.Version)
+	require.NoError(t, err)
+	require.Equal(t, []byte("adios"), immutable.Get([]byte("hello")))
+
+	_, err = iavlStore.GetImmutable(newCID.Version + 1)
+	require.Error(t, err)
+}
+
 func nextVersion(iavl *Store) {
 	key := []byte(fmt.Sprintf("Key for tree: %d", iavl.LastCommitID().Version))
 	value := []byte(fmt.Sprintf("Value for tree: %d", iavl.LastCommitID().Version))
@@ -414,6 +454,101 @@ func TestIAVLPruneEverything(t *testing.T) {
 	}
 }
 
+func TestIAVLPruningIntervalAndKeepHeights(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := UnsafeNewStore(tree, int64(2), int64(0))
+	iavlStore.SetPruning(types.NewPruningOptions(2, 0).WithInterval(4).WithKeepHeights(2))
+
+	// versions are only pruned every 4 heights
+	for i := 0; i < 7; i++ {
+		nextVersion(iavlStore)
+	}
+	for v := int64(1); v <= 7; v++ {
+		require.Equal(t, v > 1, iavlStore.VersionExists(v), "version %d", v)
+	}
+
+	nextVersion(iavlStore)
+	for v := int64(1); v <= 8; v++ {
+		require.Equal(t, v == 2 || v >= 6, iavlStore.VersionExists(v), "version %d", v)
+	}
+	require.Equal(t, int64(2), iavlStore.EarliestVersion())
+
+	// a reloaded store prunes all the versions released by new options
+	tree = iavl.NewMutableTree(db, cacheSize)
+	_, err := tree.LoadVersion(8)
+	require.NoError(t, err)
+	iavlStore = UnsafeNewStore(tree, int64(0), int64(0))
+	require.NoError(t, iavlStore.Prune())
+	for v := int64(1); v < 8; v++ {
+		require.False(t, iavlStore.VersionExists(v), "version %d", v)
+	}
+	require.Equal(t, int64(8), iavlStore.EarliestVersion())
+}
+
+func TestIAVLLoadStorePruned(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := UnsafeNewStore(tree, int64(2), int64(0))
+	for i := 0; i < 10; i++ {
+		nextVersion(iavlStore)
+	}
+
+	// pruning resumes from the earliest version left
+	store, err := LoadStore(db, iavlStore.LastCommitID(), types.PruneNothing)
+	require.NoError(t, err)
+	loaded := store.(*Store)
+	require.Equal(t, int64(8), loaded.EarliestVersion())
+	require.Equal(t, int64(7), loaded.lastPruned)
+}
+
+func TestIAVLPinVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := UnsafeNewStore(tree, int64(0), int64(0))
+
+	// pinned versions are skipped until they are unpinned as many times
+	nextVersion(iavlStore)
+	iavlStore.PinVersion(1)
+	iavlStore.PinVersion(1)
+	for i := 0; i < 3; i++ {
+		nextVersion(iavlStore)
+	}
+	require.True(t, iavlStore.VersionExists(1))
+	require.False(t, iavlStore.VersionExists(2))
+
+	iavlStore.UnpinVersion(1)
+	nextVersion(iavlStore)
+	require.True(t, iavlStore.VersionExists(1))
+
+	iavlStore.UnpinVersion(1)
+	nextVersion(iavlStore)
+	require.False(t, iavlStore.VersionExists(1))
+	require.Equal(t, int64(6), iavlStore.EarliestVersion())
+}
+
+func TestIAVLQueryPrunedHeight(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := UnsafeNewStore(tree, int64(1), int64(0))
+	for i := 0; i < 5; i++ {
+		nextVersion(iavlStore)
+	}
+
+	query := abci.RequestQuery{Path: "/key", Data: []byte("key"), Height: 2}
+	res := iavlStore.Query(query)
+	require.Equal(t, uint32(errors.CodePrunedHeight), res.Code)
+	require.Contains(t, res.Log, "the earliest available height is 4")
+
+	query.Height = 4
+	require.True(t, iavlStore.Query(query).IsOK())
+
+	// heights that have not been committed yet are not pruned
+	query.Height = 6
+	res = iavlStore.Query(query)
+	require.NotEqual(t, uint32(errors.CodePrunedHeight), res.Code)
+}
+
 func TestIAVLStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewMutableTree(db, cacheSize)
@@ -0,0 +1,135 @@
+package listenkv
+
+import (
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/tracekv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var _ types.CacheKVStore = (*Store)(nil)
+
+// Store implements the CacheKVStore interface with listening enabled. The
+// sets and deletes made on the cache are recorded in order in a Buffer, which
+// reports them once the cache multistore holding it is written.
+type Store struct {
+	parent   types.CacheKVStore
+	storeKey types.StoreKey
+	buffer   *Buffer
+}
+
+// NewStore returns a reference to a new listenkv Store recording the writes
+// made to the parent cache of the store with the given key.
+func NewStore(parent types.CacheKVStore, storeKey types.StoreKey, buffer *Buffer) *Store {
+	return &Store{parent: parent, storeKey: storeKey, buffer: buffer}
+}
+
+// Get implements the KVStore interface. It delegates the Get call to the
+// parent KVStore.
+func (s *Store) Get(key []byte) []byte {
+	return s.parent.Get(key)
+}
+
+// Has implements the KVStore interface. It delegates the Has call to the
+// parent KVStore.
+func (s *Store) Has(key []byte) bool {
+	return s.parent.Has(key)
+}
+
+// Set implements the KVStore interface. It records a set and delegates the
+// Set call to the parent KVStore.
+func (s *Store) Set(key []byte, value []byte) {
+	s.parent.Set(key, value)
+	s.buffer.record(s.storeKey, key, value, false)
+}
+
+// Delete implements the KVStore interface. It records a delete and delegates
+// the Delete call to the parent KVStore.
+func (s *Store) Delete(key []byte) {
+	s.parent.Delete(key)
+	s.buffer.record(s.storeKey, key, nil, true)
+}
+
+// Iterator implements the KVStore interface. It delegates the Iterator call
+// to the parent KVStore.
+func (s *Store) Iterator(start, end []byte) types.Iterator {
+	return s.parent.Iterator(start, end)
+}
+
+// ReverseIterator implements the KVStore interface. It delegates the
+// ReverseIterator call to the parent KVStore.
+func (s *Store) ReverseIterator(start, end []byte) types.Iterator {
+	return s.parent.ReverseIterator(start, end)
+}
+
+// Write implements the CacheKVStore interface. It delegates the Write call to
+// the parent cache.
+func (s *Store) Write() {
+	s.parent.Write()
+}
+
+// GetStoreType implements the KVStore interface. It returns the underlying
+// KVStore type.
+func (s *Store) GetStoreType() types.StoreType {
+	return s.parent.GetStoreType()
+}
+
+// CacheWrap implements the KVStore interface. The writes of the returned
+// cache are recorded when it is written.
+func (s *Store) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+// CacheWrapWithTrace implements the KVStore interface.
+func (s *Store) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
+
+//----------------------------------------
+// Buffer
+
+// Buffer records the writes to the listened stores of a cache multistore.
+// The writes are tagged with the transaction index of the given pointer at
+// the time they are made.
+type Buffer struct {
+	listened map[types.StoreKey]bool
+	txIndex  *int64
+	onWrite  func(pairs []types.StoreKVPair)
+	pairs    []types.StoreKVPair
+}
+
+// NewBuffer returns a new Buffer for the given listened stores, calling
+// onWrite with the recorded writes when it is written.
+func NewBuffer(
+	listened map[types.StoreKey]bool, txIndex *int64, onWrite func(pairs []types.StoreKVPair),
+) *Buffer {
+	return &Buffer{listened: listened, txIndex: txIndex, onWrite: onWrite}
+}
+
+// ListeningEnabled returns true if the writes to the store with the given key
+// are recorded.
+func (b *Buffer) ListeningEnabled(key types.StoreKey) bool {
+	return b.listened[key]
+}
+
+func (b *Buffer) record(storeKey types.StoreKey, key, value []byte, delete bool) {
+	pair := types.StoreKVPair{
+		TxIndex:  *b.txIndex,
+		StoreKey: storeKey.Name(),
+		Delete:   delete,
+		Key:      append([]byte{}, key...),
+	}
+	if !delete {
+		pair.Value = append([]byte{}, value...)
+	}
+	b.pairs = append(b.pairs, pair)
+}
+
+// Write reports the recorded writes and resets the buffer.
+func (b *Buffer) Write() {
+	if len(b.pairs) != 0 {
+		b.onWrite(b.pairs)
+	}
+	b.pairs = nil
+}
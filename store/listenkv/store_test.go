@@ -0,0 +1,64 @@
+package listenkv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var testStoreKey = types.NewKVStoreKey("test")
+
+func newListenStore(txIndex *int64, written *[]types.StoreKVPair) (*Store, *Buffer) {
+	buffer := NewBuffer(
+		map[types.StoreKey]bool{testStoreKey: true}, txIndex,
+		func(pairs []types.StoreKVPair) { *written = append(*written, pairs...) },
+	)
+	parent := cachekv.NewStore(dbadapter.Store{DB: dbm.NewMemDB()})
+	return NewStore(parent, testStoreKey, buffer), buffer
+}
+
+func TestListenKVStore(t *testing.T) {
+	txIndex := int64(-1)
+	var written []types.StoreKVPair
+	store, buffer := newListenStore(&txIndex, &written)
+	require.True(t, buffer.ListeningEnabled(testStoreKey))
+	require.False(t, buffer.ListeningEnabled(types.NewKVStoreKey("other")))
+
+	key, value := []byte("key"), []byte("value")
+	store.Set(key, value)
+	require.Equal(t, value, store.Get(key))
+
+	// the writes of a cache are recorded once it is written
+	txIndex = 0
+	cache := store.CacheWrap().(types.CacheKVStore)
+	cache.Delete(key)
+	cache.Set([]byte("other"), value)
+	require.True(t, store.Has(key))
+	cache.Write()
+	require.False(t, store.Has(key))
+
+	// the writes of a discarded cache are not recorded
+	txIndex = 1
+	store.CacheWrap().(types.CacheKVStore).Set(key, value)
+
+	// buffered values are not affected by later changes of the caller
+	value[0] = 'V'
+
+	require.Empty(t, written)
+	buffer.Write()
+	expected := []types.StoreKVPair{
+		{TxIndex: -1, StoreKey: "test", Key: []byte("key"), Value: []byte("value")},
+		{TxIndex: 0, StoreKey: "test", Delete: true, Key: []byte("key")},
+		{TxIndex: 0, StoreKey: "test", Key: []byte("other"), Value: []byte("value")},
+	}
+	require.Equal(t, expected, written)
+
+	// the buffer is reset once written
+	buffer.Write()
+	require.Len(t, written, 3)
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
 	"github.com/cosmos/cosmos-sdk/store/types"
 	stypes "github.com/cosmos/cosmos-sdk/store/types"
 )
@@ -30,6 +31,14 @@ type (
 	Gas              = stypes.Gas
 	GasMeter         = types.GasMeter
 	GasConfig        = stypes.GasConfig
+	SnapshotOptions  = rootmulti.SnapshotOptions
+	SnapshotManifest = rootmulti.SnapshotManifest
+	StoreUpgrades    = types.StoreUpgrades
+	StoreRename      = types.StoreRename
+	StoreKVPair      = types.StoreKVPair
+	WriteListener    = types.WriteListener
+
+	MultiStorePersistentCache = types.MultiStorePersistentCache
 )
 
 // nolint - reexport
@@ -37,4 +46,7 @@ var (
 	PruneNothing    = types.PruneNothing
 	PruneEverything = types.PruneEverything
 	PruneSyncable   = types.PruneSyncable
+	PruneDefault    = types.PruneDefault
+
+	NewPruningOptions = types.NewPruningOptions
 )
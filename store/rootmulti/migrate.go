@@ -0,0 +1,163 @@
+package rootmulti
+
+import (
+	"bytes"
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// number of copied entries buffered before they are written to disk
+const copyBatchSize = 10000
+
+// CopyDB copies the state of a multistore persisted in db, the stores in
+// storeDBs being kept in their own db by name, to the empty newDB and
+// newStoreDBs, which may keep different stores apart. This allows changing the
+// backend of the dbs as well as moving stores in or out of the main db.
+func CopyDB(db dbm.DB, storeDBs map[string]dbm.DB, newDB dbm.DB, newStoreDBs map[string]dbm.DB) error {
+	for name, storeDB := range newStoreDBs {
+		if !isEmpty(storeDB) {
+			return fmt.Errorf("the new db of store %s is not empty", name)
+		}
+	}
+	if !isEmpty(newDB) {
+		return fmt.Errorf("the new db is not empty")
+	}
+
+	c := newDBCopier(newDB)
+	defer c.close()
+
+	it := db.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		if name, rest, ok := splitStoreKey(key); ok {
+			c.setStoreKey(name, rest, it.Value(), newStoreDBs)
+			continue
+		}
+		c.set(newDB, key, it.Value())
+	}
+	it.Close()
+
+	for name, storeDB := range storeDBs {
+		it := storeDB.Iterator(nil, nil)
+		for ; it.Valid(); it.Next() {
+			key := it.Key()
+			if !bytes.HasPrefix(key, []byte(storeDBPrefix)) {
+				return fmt.Errorf("unexpected key %X in the db of store %s", key, name)
+			}
+			c.setStoreKey(name, key[len(storeDBPrefix):], it.Value(), newStoreDBs)
+		}
+		it.Close()
+	}
+
+	c.write()
+	return nil
+}
+
+// VerifyLatestVersion checks that the IAVL stores committed at the latest
+// version have the hashes of the commit info of the version, and returns its
+// commit ID. The stores do not need to be mounted, except for those which have
+// their own db.
+func (rs *Store) VerifyLatestVersion() (types.CommitID, error) {
+	latest := getLatestVersion(rs.db)
+	if latest == 0 {
+		return types.CommitID{}, nil
+	}
+
+	cInfo, err := getCommitInfo(rs.db, latest)
+	if err != nil {
+		return types.CommitID{}, err
+	}
+	for _, info := range cInfo.StoreInfos {
+		db, err := rs.iavlStoreDB(info.Name)
+		if err != nil {
+			return types.CommitID{}, err
+		}
+		store, err := iavl.LoadStore(db, info.Core.CommitID, rs.pruningOpts)
+		if err != nil {
+			return types.CommitID{}, fmt.Errorf("failed to load store %s: %v", info.Name, err)
+		}
+		if commitID := store.LastCommitID(); !bytes.Equal(commitID.Hash, info.Core.CommitID.Hash) {
+			return types.CommitID{}, fmt.Errorf("store %s has hash %X instead of %X", info.Name, commitID.Hash, info.Core.CommitID.Hash)
+		}
+	}
+
+	return cInfo.CommitID(), nil
+}
+
+// splitStoreKey returns the name of the store the key of a multistore db
+// belongs to and the key in the store.
+func splitStoreKey(key []byte) (name string, rest []byte, ok bool) {
+	if !bytes.HasPrefix(key, []byte(storeKeyPrefix)) {
+		return "", nil, false
+	}
+	key = key[len(storeKeyPrefix):]
+	i := bytes.IndexByte(key, '/')
+	if i < 0 {
+		return "", nil, false
+	}
+	return string(key[:i]), key[i+1:], true
+}
+
+func isEmpty(db dbm.DB) bool {
+	it := db.Iterator(nil, nil)
+	defer it.Close()
+	return !it.Valid()
+}
+
+// dbCopier writes the entries copied to several dbs in batches.
+type dbCopier struct {
+	db      dbm.DB
+	batches map[dbm.DB]dbm.Batch
+	size    int
+}
+
+func newDBCopier(db dbm.DB) *dbCopier {
+	return &dbCopier{
+		db:      db,
+		batches: make(map[dbm.DB]dbm.Batch),
+	}
+}
+
+// setStoreKey copies a key of the store with the given name, to its own db if
+// it has one.
+func (c *dbCopier) setStoreKey(name string, key, value []byte, storeDBs map[string]dbm.DB) {
+	if db, ok := storeDBs[name]; ok {
+		c.set(db, append([]byte(storeDBPrefix), key...), value)
+		return
+	}
+	c.set(c.db, append(storePrefix(name), key...), value)
+}
+
+func (c *dbCopier) set(db dbm.DB, key, value []byte) {
+	batch, ok := c.batches[db]
+	if !ok {
+		batch = db.NewBatch()
+		c.batches[db] = batch
+	}
+
+	batch.Set(key, value)
+	c.size++
+	if c.size >= copyBatchSize {
+		c.write()
+	}
+}
+
+// write writes the pending batches.
+func (c *dbCopier) write() {
+	for db, batch := range c.batches {
+		batch.WriteSync()
+		batch.Close()
+		delete(c.batches, db)
+	}
+	c.size = 0
+}
+
+func (c *dbCopier) close() {
+	for _, batch := range c.batches {
+		batch.Close()
+	}
+}
@@ -0,0 +1,66 @@
+package rootmulti
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// newMultiStoreWithDBs mounts the three test stores, those in storeDBs on
+// their own db.
+func newMultiStoreWithDBs(db dbm.DB, storeDBs map[string]dbm.DB) *Store {
+	store := NewStore(db)
+	store.pruningOpts = types.PruneSyncable
+	for _, name := range []string{"store1", "store2", "store3"} {
+		store.MountStoreWithDB(types.NewKVStoreKey(name), types.StoreTypeIAVL, storeDBs[name])
+	}
+	return store
+}
+
+func TestCopyDB(t *testing.T) {
+	db := dbm.NewMemDB()
+	storeDBs := map[string]dbm.DB{"store2": dbm.NewMemDB()}
+	store := newMultiStoreWithDBs(db, storeDBs)
+	require.NoError(t, store.LoadLatestVersion())
+	var commitID types.CommitID
+	for i := 1; i <= 3; i++ {
+		store.GetKVStore(store.keysByName["store1"]).Set([]byte("key"), []byte{byte(i)})
+		store.GetKVStore(store.keysByName["store2"]).Set([]byte{byte(i)}, []byte("value"))
+		commitID = store.Commit()
+	}
+
+	verified, err := newMultiStoreWithDBs(db, storeDBs).VerifyLatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, commitID, verified)
+
+	// move store1 to its own db and store2 back to the main db
+	newDB := dbm.NewMemDB()
+	newStoreDBs := map[string]dbm.DB{"store1": dbm.NewMemDB()}
+	require.NoError(t, CopyDB(db, storeDBs, newDB, newStoreDBs))
+	require.Error(t, CopyDB(db, storeDBs, newDB, newStoreDBs))
+
+	verified, err = newMultiStoreWithDBs(newDB, newStoreDBs).VerifyLatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, commitID, verified)
+
+	// store1 is not found in the main db
+	_, err = newMultiStoreWithDBs(newDB, nil).VerifyLatestVersion()
+	require.Error(t, err)
+
+	store = newMultiStoreWithDBs(newDB, newStoreDBs)
+	require.NoError(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+	require.Equal(t, []byte{3}, store.GetKVStore(store.keysByName["store1"]).Get([]byte("key")))
+	require.Equal(t, []byte("value"), store.GetKVStore(store.keysByName["store2"]).Get([]byte{2}))
+
+	// the copy can be committed to like the original
+	store.GetKVStore(store.keysByName["store3"]).Set([]byte("key"), []byte("value"))
+	store.Commit()
+	verified, err = newMultiStoreWithDBs(newDB, newStoreDBs).VerifyLatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, commitID.Version+1, verified.Version)
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"fmt"
 
-	"github.com/tendermint/iavl"
+	tmiavl "github.com/tendermint/iavl"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/store/types"
 )
 
 // MultiStoreProof defines a collection of store proofs in a multi-store
@@ -30,10 +33,15 @@ func (proof *MultiStoreProof) ComputeRootHash() []byte {
 // RequireProof returns whether proof is required for the subpath.
 func RequireProof(subpath string) bool {
 	// XXX: create a better convention.
-	// Currently, only when query subpath is "/key", will proof be included in
-	// response. If there are some changes about proof building in iavlstore.go,
-	// we must change code here to keep consistency with iavlStore#Query.
-	return subpath == "/key"
+	// Currently, only when query subpath is "/key", "/keys" or "/range", will
+	// proof be included in response. If there are some changes about proof
+	// building in iavlstore.go, we must change code here to keep consistency
+	// with iavlStore#Query.
+	switch subpath {
+	case "/key", "/keys", "/range":
+		return true
+	}
+	return false
 }
 
 //-----------------------------------------------------------------------------
@@ -129,8 +137,69 @@ func (op MultiStoreProofOp) Run(args [][]byte) ([][]byte, error) {
 func DefaultProofRuntime() (prt *merkle.ProofRuntime) {
 	prt = merkle.NewProofRuntime()
 	prt.RegisterOpDecoder(merkle.ProofOpSimpleValue, merkle.SimpleValueOpDecoder)
-	prt.RegisterOpDecoder(iavl.ProofOpIAVLValue, iavl.IAVLValueOpDecoder)
-	prt.RegisterOpDecoder(iavl.ProofOpIAVLAbsence, iavl.IAVLAbsenceOpDecoder)
+	prt.RegisterOpDecoder(tmiavl.ProofOpIAVLValue, tmiavl.IAVLValueOpDecoder)
+	prt.RegisterOpDecoder(tmiavl.ProofOpIAVLAbsence, tmiavl.IAVLAbsenceOpDecoder)
+	prt.RegisterOpDecoder(iavl.ProofOpIAVLKeys, iavl.KeysOpDecoder)
+	prt.RegisterOpDecoder(iavl.ProofOpIAVLRange, iavl.RangeOpDecoder)
 	prt.RegisterOpDecoder(ProofOpMultiStore, MultiStoreProofOpDecoder)
 	return
 }
+
+//-----------------------------------------------------------------------------
+
+// VerifyKeysProof verifies the proof returned by a "/keys" query to the store
+// storeName against a trusted app hash. kvs are the key/value pairs of the
+// queried keys that exist, in the order of the keys.
+func VerifyKeysProof(proof *merkle.Proof, appHash []byte, storeName string, keys [][]byte, kvs []types.KVPair) error {
+	op, err := decodeStoreProof(proof, iavl.ProofOpIAVLKeys)
+	if err != nil {
+		return err
+	}
+
+	provenKeys := op.(iavl.KeysOp).Keys
+	if len(provenKeys) != len(keys) {
+		return cmn.NewError("proof of %d keys for %d queried keys", len(provenKeys), len(keys))
+	}
+	for i, key := range keys {
+		if !bytes.Equal(provenKeys[i], key) {
+			return cmn.NewError("proof of key %X for queried key %X", provenKeys[i], key)
+		}
+	}
+
+	return verifyStoreProof(proof, appHash, storeName, kvs)
+}
+
+// VerifyRangeProof verifies the proof returned by a "/range" query to the
+// store storeName against a trusted app hash. kvs are all the key/value pairs
+// with the given prefix.
+func VerifyRangeProof(proof *merkle.Proof, appHash []byte, storeName string, prefix []byte, kvs []types.KVPair) error {
+	op, err := decodeStoreProof(proof, iavl.ProofOpIAVLRange)
+	if err != nil {
+		return err
+	}
+
+	rangeOp := op.(iavl.RangeOp)
+	if !bytes.Equal(rangeOp.Start, prefix) || !bytes.Equal(rangeOp.End, types.PrefixEndBytes(prefix)) {
+		return cmn.NewError("proof of range %X-%X for queried prefix %X", rangeOp.Start, rangeOp.End, prefix)
+	}
+
+	return verifyStoreProof(proof, appHash, storeName, kvs)
+}
+
+// decodeStoreProof decodes the substore operation of a proof made of a
+// substore operation of the given type followed by a multistore operation.
+func decodeStoreProof(proof *merkle.Proof, opType string) (merkle.ProofOperator, error) {
+	if proof == nil || len(proof.Ops) != 2 {
+		return nil, cmn.NewError("expected a substore and a multistore proof operation")
+	}
+	if proof.Ops[0].Type != opType || proof.Ops[1].Type != ProofOpMultiStore {
+		return nil, cmn.NewError("unexpected proof operations %v, %v", proof.Ops[0].Type, proof.Ops[1].Type)
+	}
+	return DefaultProofRuntime().Decode(proof.Ops[0])
+}
+
+func verifyStoreProof(proof *merkle.Proof, appHash []byte, storeName string, kvs []types.KVPair) error {
+	keyPath := merkle.KeyPath{}.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
+	value := cdc.MustMarshalBinaryLengthPrefixed(kvs)
+	return DefaultProofRuntime().VerifyValue(proof, appHash, keyPath.String(), value)
+}
@@ -174,3 +174,128 @@ func TestVerifyMultiStoreQueryProofAbsence(t *testing.T) {
 	err = prt.VerifyValue(res.Proof, cid.Hash, "/iavlStoreKey/MYABSENTKEY", []byte(""))
 	require.NotNil(t, err)
 }
+
+func newProofTestStore(t *testing.T, kvs ...types.KVPair) (*Store, types.CommitID) {
+	store := NewStore(dbm.NewMemDB())
+	iavlStoreKey := types.NewKVStoreKey("iavlStoreKey")
+	store.MountStoreWithDB(iavlStoreKey, types.StoreTypeIAVL, nil)
+	require.NoError(t, store.LoadVersion(0))
+
+	iavlStore := store.GetCommitStore(iavlStoreKey).(*iavl.Store)
+	for _, kv := range kvs {
+		iavlStore.Set(kv.Key, kv.Value)
+	}
+	return store, store.Commit()
+}
+
+func queryKVPairs(t *testing.T, store *Store, path string, data []byte) ([]types.KVPair, abci.ResponseQuery) {
+	res := store.Query(abci.RequestQuery{Path: path, Data: data, Prove: true})
+	require.True(t, res.IsOK(), res.Log)
+	require.NotNil(t, res.Proof)
+
+	var kvs []types.KVPair
+	require.NoError(t, cdc.UnmarshalBinaryLengthPrefixed(res.Value, &kvs))
+	return kvs, res
+}
+
+func TestVerifyKeysProof(t *testing.T) {
+	store, cid := newProofTestStore(t,
+		types.KVPair{Key: []byte("a"), Value: []byte("1")},
+		types.KVPair{Key: []byte("c"), Value: []byte("3")},
+		types.KVPair{Key: []byte("d"), Value: []byte("4")},
+		types.KVPair{Key: []byte("f"), Value: []byte("6")},
+	)
+
+	keys := [][]byte{[]byte("f"), []byte("b"), []byte("a"), []byte("z")}
+	kvs, res := queryKVPairs(t, store, "/iavlStoreKey/keys", cdc.MustMarshalBinaryLengthPrefixed(keys))
+	require.Equal(t, []types.KVPair{
+		{Key: []byte("f"), Value: []byte("6")},
+		{Key: []byte("a"), Value: []byte("1")},
+	}, kvs)
+	require.Len(t, res.Proof.Ops, 2)
+	require.NoError(t, VerifyKeysProof(res.Proof, cid.Hash, "iavlStoreKey", keys, kvs))
+
+	// other keys, values, stores or app hashes are not proven
+	require.Error(t, VerifyKeysProof(res.Proof, cid.Hash, "iavlStoreKey", keys[1:], kvs))
+	require.Error(t, VerifyKeysProof(res.Proof, cid.Hash, "iavlStoreKey", keys, kvs[1:]))
+	require.Error(t, VerifyKeysProof(res.Proof, cid.Hash, "iavlStoreKey", keys, []types.KVPair{
+		{Key: []byte("f"), Value: []byte("6")},
+		{Key: []byte("b"), Value: []byte("2")},
+		{Key: []byte("a"), Value: []byte("1")},
+	}))
+	require.Error(t, VerifyKeysProof(res.Proof, cid.Hash, "iavlStoreKey", keys, []types.KVPair{
+		{Key: []byte("f"), Value: []byte("6")},
+		{Key: []byte("a"), Value: []byte("2")},
+	}))
+	require.Error(t, VerifyKeysProof(res.Proof, cid.Hash, "otherStoreKey", keys, kvs))
+	require.Error(t, VerifyKeysProof(res.Proof, []byte("app hash"), "iavlStoreKey", keys, kvs))
+
+	// a single key proof doesn't prove a list of keys
+	res = store.Query(abci.RequestQuery{Path: "/iavlStoreKey/key", Data: []byte("a"), Prove: true})
+	require.Error(t, VerifyKeysProof(res.Proof, cid.Hash, "iavlStoreKey", [][]byte{[]byte("a")}, kvs[1:]))
+
+	// all the keys are absent from an empty store
+	store, cid = newProofTestStore(t)
+	kvs, res = queryKVPairs(t, store, "/iavlStoreKey/keys", cdc.MustMarshalBinaryLengthPrefixed(keys))
+	require.Empty(t, kvs)
+	require.NoError(t, VerifyKeysProof(res.Proof, cid.Hash, "iavlStoreKey", keys, kvs))
+
+	res = store.Query(abci.RequestQuery{Path: "/iavlStoreKey/keys", Data: []byte("garbage"), Prove: true})
+	require.False(t, res.IsOK())
+}
+
+func TestVerifyRangeProof(t *testing.T) {
+	store, cid := newProofTestStore(t,
+		types.KVPair{Key: []byte("a"), Value: []byte("1")},
+		types.KVPair{Key: []byte("ba"), Value: []byte("2")},
+		types.KVPair{Key: []byte("bb"), Value: []byte("3")},
+		types.KVPair{Key: []byte("bc"), Value: []byte("4")},
+		types.KVPair{Key: []byte("c"), Value: []byte("5")},
+		types.KVPair{Key: []byte{0xff, 0x01}, Value: []byte("6")},
+	)
+
+	testCases := []struct {
+		prefix []byte
+		keys   []string
+	}{
+		{[]byte("b"), []string{"ba", "bb", "bc"}},
+		{[]byte("a"), []string{"a"}},
+		{[]byte("bb"), []string{"bb"}},
+		{[]byte("c"), []string{"c"}},
+		{[]byte("0"), nil},
+		{[]byte("d"), nil},
+		{[]byte{0xff}, []string{"\xff\x01"}},
+	}
+	for _, tc := range testCases {
+		kvs, res := queryKVPairs(t, store, "/iavlStoreKey/range", tc.prefix)
+		var keys []string
+		for _, kv := range kvs {
+			keys = append(keys, string(kv.Key))
+		}
+		require.Equal(t, tc.keys, keys, "prefix %X", tc.prefix)
+		require.NoError(t, VerifyRangeProof(res.Proof, cid.Hash, "iavlStoreKey", tc.prefix, kvs), "prefix %X", tc.prefix)
+	}
+
+	kvs, res := queryKVPairs(t, store, "/iavlStoreKey/range", []byte("b"))
+	require.NoError(t, VerifyRangeProof(res.Proof, cid.Hash, "iavlStoreKey", []byte("b"), kvs))
+
+	// missing, additional or modified pairs are not proven
+	require.Error(t, VerifyRangeProof(res.Proof, cid.Hash, "iavlStoreKey", []byte("b"), kvs[1:]))
+	require.Error(t, VerifyRangeProof(res.Proof, cid.Hash, "iavlStoreKey", []byte("b"), kvs[:2]))
+	require.Error(t, VerifyRangeProof(res.Proof, cid.Hash, "iavlStoreKey", []byte("b"),
+		append(kvs, types.KVPair{Key: []byte("bd"), Value: []byte("7")})))
+	require.Error(t, VerifyRangeProof(res.Proof, cid.Hash, "iavlStoreKey", []byte("b"), []types.KVPair{
+		kvs[0], kvs[1], {Key: kvs[2].Key, Value: []byte("7")},
+	}))
+	require.Error(t, VerifyRangeProof(res.Proof, cid.Hash, "iavlStoreKey", []byte("ba"), kvs[:1]))
+	require.Error(t, VerifyRangeProof(res.Proof, []byte("app hash"), "iavlStoreKey", []byte("b"), kvs))
+
+	// the proof of a range doesn't prove a larger one
+	kvs, res = queryKVPairs(t, store, "/iavlStoreKey/range", []byte("bb"))
+	require.Error(t, VerifyRangeProof(res.Proof, cid.Hash, "iavlStoreKey", []byte("b"), kvs))
+
+	store, cid = newProofTestStore(t)
+	kvs, res = queryKVPairs(t, store, "/iavlStoreKey/range", []byte("b"))
+	require.Empty(t, kvs)
+	require.NoError(t, VerifyRangeProof(res.Proof, cid.Hash, "iavlStoreKey", []byte("b"), kvs))
+}
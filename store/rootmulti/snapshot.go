@@ -0,0 +1,477 @@
+package rootmulti
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+const (
+	// SnapshotFormat is the version of the snapshot format produced by the store
+	SnapshotFormat uint32 = 1
+
+	// DefaultSnapshotChunkSize is the default size of snapshot chunks in bytes
+	DefaultSnapshotChunkSize = 10 << 20
+
+	snapshotManifestFile = "manifest.json"
+)
+
+// SnapshotOptions configures the periodic state sync snapshots of the store.
+type SnapshotOptions struct {
+	// Dir is the directory snapshots are written to
+	Dir string
+
+	// Interval is the height interval between snapshots, 0 disables them
+	Interval int64
+
+	// KeepRecent is the number of recent snapshots to keep, 0 keeps all of them
+	KeepRecent int
+
+	// ChunkSize is the maximum size of snapshot chunks, DefaultSnapshotChunkSize if 0
+	ChunkSize int
+
+	// Logger reports the outcome of periodic snapshots
+	Logger log.Logger
+}
+
+// SnapshotStore is the commit ID of a store at the height of a snapshot.
+type SnapshotStore struct {
+	Name     string         `json:"name"`
+	CommitID types.CommitID `json:"commit_id"`
+}
+
+// SnapshotManifest describes a snapshot of all the stores at a given height.
+// Chunks are verified against their hash in the manifest, and the manifest is
+// tied to the app hash through the commit IDs of the stores.
+type SnapshotManifest struct {
+	Height      int64           `json:"height"`
+	Format      uint32          `json:"format"`
+	AppHash     []byte          `json:"app_hash"`
+	Stores      []SnapshotStore `json:"stores"`
+	ChunkHashes [][]byte        `json:"chunk_hashes"`
+}
+
+// Hash returns the hash of the manifest.
+func (m SnapshotManifest) Hash() []byte {
+	return tmhash.Sum(cdc.MustMarshalBinaryBare(m))
+}
+
+// ValidateBasic checks that the manifest is well formed and that the app hash
+// matches the commit IDs of the stores.
+func (m SnapshotManifest) ValidateBasic() error {
+	if m.Format != SnapshotFormat {
+		return fmt.Errorf("unsupported snapshot format %d", m.Format)
+	}
+	if m.Height <= 0 {
+		return fmt.Errorf("invalid snapshot height %d", m.Height)
+	}
+	if !bytes.Equal(m.commitInfo().Hash(), m.AppHash) {
+		return fmt.Errorf("snapshot app hash %X does not match its stores", m.AppHash)
+	}
+	return nil
+}
+
+func (m SnapshotManifest) commitInfo() commitInfo {
+	infos := make([]storeInfo, len(m.Stores))
+	for i, store := range m.Stores {
+		infos[i] = storeInfo{
+			Name: store.Name,
+			Core: storeCore{CommitID: store.CommitID},
+		}
+	}
+	return commitInfo{
+		Version:    m.Height,
+		StoreInfos: infos,
+	}
+}
+
+// snapshotItem is an entry of a snapshot chunk. An item with a store name
+// starts the nodes of that store, the following items each hold a node.
+type snapshotItem struct {
+	Store string
+	Node  []byte
+}
+
+// SetSnapshotOptions enables periodic snapshots of the store.
+func (rs *Store) SetSnapshotOptions(opts SnapshotOptions) {
+	if opts.Logger == nil {
+		opts.Logger = log.NewNopLogger()
+	}
+	rs.snapshotOpts = opts
+}
+
+// snapshotInBackground takes a snapshot of the given height unless another
+// one is still in progress. The snapshot is taken concurrently with the next
+// blocks as a committed version is immutable, and the version is pinned in the
+// IAVL stores so that it is not pruned before it has been exported.
+func (rs *Store) snapshotInBackground(height int64) {
+	logger := rs.snapshotOpts.Logger.With("module", "snapshots")
+	if !atomic.CompareAndSwapInt32(&rs.snapshotting, 0, 1) {
+		logger.Info("skipping snapshot, another one is in progress", "height", height)
+		return
+	}
+	unpin := rs.PinVersion(height)
+
+	go func() {
+		defer atomic.StoreInt32(&rs.snapshotting, 0)
+		defer unpin()
+
+		manifest, err := rs.Snapshot(rs.snapshotOpts.Dir, height)
+		if err != nil {
+			logger.Error("failed to take snapshot", "height", height, "err", err)
+			return
+		}
+		logger.Info("took snapshot", "height", height, "hash", fmt.Sprintf("%X", manifest.Hash()))
+
+		if err := pruneSnapshots(rs.snapshotOpts.Dir, rs.snapshotOpts.KeepRecent); err != nil {
+			logger.Error("failed to prune snapshots", "err", err)
+		}
+	}()
+}
+
+// PinVersion pins the given version in the mounted IAVL stores, and returns a
+// function unpinning it.
+func (rs *Store) PinVersion(version int64) (unpin func()) {
+	var pinned []*iavl.Store
+	for key, store := range rs.stores {
+		if rs.interBlockCache != nil {
+			if unwrapped := rs.interBlockCache.Unwrap(key); unwrapped != nil {
+				store = unwrapped
+			}
+		}
+		if store, ok := store.(*iavl.Store); ok {
+			store.PinVersion(version)
+			pinned = append(pinned, store)
+		}
+	}
+
+	return func() {
+		for _, store := range pinned {
+			store.UnpinVersion(version)
+		}
+	}
+}
+
+// Snapshot writes a snapshot of all the stores at the given height to a
+// subdirectory of dir named after the height. Only IAVL stores are supported.
+func (rs *Store) Snapshot(dir string, height int64) (manifest SnapshotManifest, err error) {
+	cInfo, err := getCommitInfo(rs.db, height)
+	if err != nil {
+		return manifest, err
+	}
+
+	snapshotDir := filepath.Join(dir, strconv.FormatInt(height, 10))
+	if _, err := os.Stat(snapshotDir); err == nil {
+		return manifest, fmt.Errorf("snapshot at height %d already exists", height)
+	}
+
+	// write to a temporary directory so that only complete snapshots are listed
+	tmpDir := snapshotDir + ".tmp"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return manifest, err
+	}
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return manifest, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	infos := cInfo.StoreInfos
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+
+	chunkSize := rs.snapshotOpts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultSnapshotChunkSize
+	}
+	w := &chunkWriter{dir: tmpDir, chunkSize: chunkSize}
+
+	manifest = SnapshotManifest{
+		Height:  height,
+		Format:  SnapshotFormat,
+		AppHash: cInfo.Hash(),
+	}
+	for _, info := range infos {
+		db, err := rs.iavlStoreDB(info.Name)
+		if err != nil {
+			return manifest, err
+		}
+
+		if err := w.add(snapshotItem{Store: info.Name}); err != nil {
+			return manifest, err
+		}
+		root, err := iavl.ExportNodes(db, info.Core.CommitID.Version, func(node []byte) error {
+			return w.add(snapshotItem{Node: node})
+		})
+		if err != nil {
+			return manifest, fmt.Errorf("failed to export store %s: %v", info.Name, err)
+		}
+		if !bytes.Equal(root, info.Core.CommitID.Hash) {
+			return manifest, fmt.Errorf("store %s root hash %X does not match its commit ID", info.Name, root)
+		}
+
+		manifest.Stores = append(manifest.Stores, SnapshotStore{
+			Name:     info.Name,
+			CommitID: info.Core.CommitID,
+		})
+	}
+
+	if err := w.flush(); err != nil {
+		return manifest, err
+	}
+	manifest.ChunkHashes = w.hashes
+
+	bz, err := cdc.MarshalJSONIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, snapshotManifestFile), bz, 0644); err != nil {
+		return manifest, err
+	}
+
+	return manifest, os.Rename(tmpDir, snapshotDir)
+}
+
+// Restore loads the snapshot at the given height from dir into an empty
+// store. Every chunk and node is verified before it is written, and the
+// restored stores must match the app hash of the snapshot manifest.
+func (rs *Store) Restore(dir string, height int64) (SnapshotManifest, error) {
+	manifest, err := LoadSnapshotManifest(dir, height)
+	if err != nil {
+		return manifest, err
+	}
+
+	if getLatestVersion(rs.db) != 0 {
+		return manifest, fmt.Errorf("cannot restore a snapshot over an existing state")
+	}
+
+	r := snapshotRestorer{rs: rs, stores: manifest.Stores}
+	snapshotDir := filepath.Join(dir, strconv.FormatInt(height, 10))
+	for i, hash := range manifest.ChunkHashes {
+		chunk, err := ioutil.ReadFile(filepath.Join(snapshotDir, strconv.Itoa(i)))
+		if err != nil {
+			return manifest, err
+		}
+		if !bytes.Equal(tmhash.Sum(chunk), hash) {
+			return manifest, fmt.Errorf("snapshot chunk %d does not match its hash", i)
+		}
+		if err := r.addChunk(chunk); err != nil {
+			return manifest, fmt.Errorf("failed to restore chunk %d: %v", i, err)
+		}
+	}
+	if err := r.finish(); err != nil {
+		return manifest, err
+	}
+
+	batch := rs.db.NewBatch()
+	setCommitInfo(batch, height, manifest.commitInfo())
+	setLatestVersion(batch, height)
+	batch.Write()
+
+	return manifest, rs.LoadVersion(height)
+}
+
+// LoadSnapshotManifest returns the validated manifest of the snapshot at the
+// given height in dir.
+func LoadSnapshotManifest(dir string, height int64) (manifest SnapshotManifest, err error) {
+	path := filepath.Join(dir, strconv.FormatInt(height, 10), snapshotManifestFile)
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return manifest, err
+	}
+	if err := cdc.UnmarshalJSON(bz, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to decode snapshot manifest %s: %v", path, err)
+	}
+	if manifest.Height != height {
+		return manifest, fmt.Errorf("snapshot manifest %s is for height %d", path, manifest.Height)
+	}
+	return manifest, manifest.ValidateBasic()
+}
+
+// ListSnapshots returns the manifests of the snapshots in dir sorted by
+// increasing height.
+func ListSnapshots(dir string) ([]SnapshotManifest, error) {
+	heights, err := snapshotHeights(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make([]SnapshotManifest, len(heights))
+	for i, height := range heights {
+		if manifests[i], err = LoadSnapshotManifest(dir, height); err != nil {
+			return nil, err
+		}
+	}
+	return manifests, nil
+}
+
+// snapshotHeights returns the heights of the snapshots in dir in increasing order.
+func snapshotHeights(dir string) ([]int64, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var heights []int64
+	for _, entry := range entries {
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil || !entry.IsDir() {
+			continue // temporary directories and unrelated files
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights, nil
+}
+
+// pruneSnapshots deletes all but the keepRecent most recent snapshots in dir.
+func pruneSnapshots(dir string, keepRecent int) error {
+	if keepRecent <= 0 {
+		return nil
+	}
+
+	heights, err := snapshotHeights(dir)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(heights)-keepRecent; i++ {
+		if err := os.RemoveAll(filepath.Join(dir, strconv.FormatInt(heights[i], 10))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iavlStoreDB returns the db of the IAVL store with the given name, which
+// does not need to be mounted.
+func (rs *Store) iavlStoreDB(name string) (dbm.DB, error) {
+	key, ok := rs.keysByName[name]
+	if !ok {
+		return dbm.NewPrefixDB(rs.db, storePrefix(name)), nil
+	}
+
+	params := rs.storesParams[key]
+	if params.typ != types.StoreTypeIAVL {
+		return nil, fmt.Errorf("snapshots of store %s of type %v are not supported", name, params.typ)
+	}
+	return rs.storeDB(params), nil
+}
+
+// chunkWriter splits snapshot items into numbered chunk files.
+type chunkWriter struct {
+	dir       string
+	chunkSize int
+	buf       bytes.Buffer
+	hashes    [][]byte
+}
+
+func (w *chunkWriter) add(item snapshotItem) error {
+	bz, err := cdc.MarshalBinaryLengthPrefixed(item)
+	if err != nil {
+		return err
+	}
+	w.buf.Write(bz)
+
+	if w.buf.Len() >= w.chunkSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *chunkWriter) flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	path := filepath.Join(w.dir, strconv.Itoa(len(w.hashes)))
+	if err := ioutil.WriteFile(path, w.buf.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	w.hashes = append(w.hashes, tmhash.Sum(w.buf.Bytes()))
+	w.buf.Reset()
+	return nil
+}
+
+// snapshotRestorer imports the items of snapshot chunks into the stores of
+// the manifest, in order.
+type snapshotRestorer struct {
+	rs       *Store
+	stores   []SnapshotStore
+	next     int
+	importer *iavl.NodeImporter
+}
+
+func (r *snapshotRestorer) addChunk(chunk []byte) error {
+	for len(chunk) > 0 {
+		size, n := binary.Uvarint(chunk)
+		if n <= 0 || uint64(len(chunk)-n) < size {
+			return fmt.Errorf("invalid snapshot item length")
+		}
+
+		var item snapshotItem
+		if err := cdc.UnmarshalBinaryBare(chunk[n:n+int(size)], &item); err != nil {
+			return err
+		}
+		chunk = chunk[n+int(size):]
+
+		if err := r.addItem(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *snapshotRestorer) addItem(item snapshotItem) error {
+	if item.Store == "" {
+		if r.importer == nil {
+			return fmt.Errorf("snapshot node does not belong to any store")
+		}
+		return r.importer.Add(item.Node)
+	}
+
+	if r.importer != nil {
+		if err := r.importer.Commit(); err != nil {
+			return err
+		}
+	}
+
+	if r.next >= len(r.stores) || r.stores[r.next].Name != item.Store {
+		return fmt.Errorf("unexpected store %s in snapshot", item.Store)
+	}
+	store := r.stores[r.next]
+	r.next++
+
+	db, err := r.rs.iavlStoreDB(store.Name)
+	if err != nil {
+		return err
+	}
+	r.importer = iavl.NewNodeImporter(db, store.CommitID.Version, store.CommitID.Hash)
+	return nil
+}
+
+func (r *snapshotRestorer) finish() error {
+	if r.importer != nil {
+		if err := r.importer.Commit(); err != nil {
+			return err
+		}
+	}
+	if r.next != len(r.stores) {
+		return fmt.Errorf("snapshot is missing store %s", r.stores[r.next].Name)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package rootmulti
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func newSnapshotStore(t *testing.T, versions int) *Store {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.NoError(t, store.LoadLatestVersion())
+
+	for v := 0; v < versions; v++ {
+		for _, name := range []string{"store1", "store2"} {
+			kv := store.getStoreByName(name).(types.KVStore)
+			for i := 0; i < 100; i++ {
+				kv.Set([]byte(fmt.Sprintf("key%03d", i*(v+1))), []byte(fmt.Sprintf("%s-%d", name, v)))
+			}
+		}
+		store.Commit()
+	}
+	return store
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newSnapshotStore(t, 3)
+	store.SetSnapshotOptions(SnapshotOptions{ChunkSize: 1024})
+
+	manifest, err := store.Snapshot(dir, 3)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), manifest.Height)
+	require.Equal(t, store.LastCommitID().Hash, manifest.AppHash)
+	require.Len(t, manifest.Stores, 3)
+	require.True(t, len(manifest.ChunkHashes) > 1)
+
+	_, err = store.Snapshot(dir, 3)
+	require.Error(t, err)
+
+	manifests, err := ListSnapshots(dir)
+	require.NoError(t, err)
+	require.Equal(t, []SnapshotManifest{manifest}, manifests)
+
+	restored := newMultiStoreWithMounts(dbm.NewMemDB())
+	_, err = restored.Restore(dir, 3)
+	require.NoError(t, err)
+	require.Equal(t, store.LastCommitID(), restored.LastCommitID())
+
+	for _, name := range []string{"store1", "store2", "store3"} {
+		expected := store.getStoreByName(name).(types.KVStore).Iterator(nil, nil)
+		got := restored.getStoreByName(name).(types.KVStore).Iterator(nil, nil)
+		for ; expected.Valid(); expected.Next() {
+			require.True(t, got.Valid())
+			require.Equal(t, expected.Key(), got.Key())
+			require.Equal(t, expected.Value(), got.Value())
+			got.Next()
+		}
+		require.False(t, got.Valid())
+		expected.Close()
+		got.Close()
+	}
+
+	// the restored store keeps committing the same state
+	for _, s := range []*Store{store, restored} {
+		s.getStoreByName("store3").(types.KVStore).Set([]byte("key"), []byte("value"))
+		s.Commit()
+	}
+	require.Equal(t, store.LastCommitID(), restored.LastCommitID())
+
+	// a snapshot can only be restored to an empty store
+	_, err = restored.Restore(dir, 3)
+	require.Error(t, err)
+}
+
+func TestRestoreTamperedSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newSnapshotStore(t, 2)
+	_, err = store.Snapshot(dir, 2)
+	require.NoError(t, err)
+
+	chunk := filepath.Join(dir, "2", "0")
+	bz, err := ioutil.ReadFile(chunk)
+	require.NoError(t, err)
+	bz[len(bz)-1]++
+	require.NoError(t, ioutil.WriteFile(chunk, bz, 0644))
+
+	_, err = newMultiStoreWithMounts(dbm.NewMemDB()).Restore(dir, 2)
+	require.Error(t, err)
+
+	_, err = newMultiStoreWithMounts(dbm.NewMemDB()).Restore(dir, 1)
+	require.Error(t, err)
+}
+
+func TestPeriodicSnapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.NoError(t, store.LoadLatestVersion())
+	store.SetSnapshotOptions(SnapshotOptions{Dir: dir, Interval: 2, KeepRecent: 2})
+
+	waitSnapshot := func(height int64) {
+		for i := 0; i < 100; i++ {
+			_, err := LoadSnapshotManifest(dir, height)
+			if err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		// wait for pruning to complete
+		for i := 0; i < 100 && atomic.LoadInt32(&store.snapshotting) != 0; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	for v := int64(1); v <= 6; v++ {
+		store.getStoreByName("store1").(types.KVStore).Set([]byte("key"), []byte{byte(v)})
+		store.Commit()
+		if v%2 == 0 {
+			waitSnapshot(v)
+		}
+	}
+
+	manifests, err := ListSnapshots(dir)
+	require.NoError(t, err)
+	require.Len(t, manifests, 2)
+	require.Equal(t, int64(4), manifests[0].Height)
+	require.Equal(t, int64(6), manifests[1].Height)
+}
+
+func TestPeriodicSnapshotPinsVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	store.SetPruning(types.PruneEverything)
+	require.NoError(t, store.LoadLatestVersion())
+	store.SetSnapshotOptions(SnapshotOptions{Dir: dir, Interval: 2})
+
+	// the snapshot is held back while blocks are committed, which would prune
+	// its version otherwise
+	atomic.StoreInt32(&store.snapshotting, 1)
+	unpin := store.PinVersion(2)
+	for v := int64(1); v <= 4; v++ {
+		store.getStoreByName("store1").(types.KVStore).Set([]byte("key"), []byte{byte(v)})
+		store.Commit()
+	}
+	_, err = store.Snapshot(dir, 2)
+	require.NoError(t, err)
+	unpin()
+	atomic.StoreInt32(&store.snapshotting, 0)
+
+	store.Commit()
+	_, err = store.Snapshot(dir, 4)
+	require.Error(t, err)
+}
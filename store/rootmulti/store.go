@@ -1,8 +1,10 @@
 package rootmulti
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -14,6 +16,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/store/dbadapter"
 	"github.com/cosmos/cosmos-sdk/store/errors"
 	"github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/store/listenkv"
 	"github.com/cosmos/cosmos-sdk/store/tracekv"
 	"github.com/cosmos/cosmos-sdk/store/transient"
 	"github.com/cosmos/cosmos-sdk/store/types"
@@ -22,6 +25,11 @@ import (
 const (
 	latestVersionKey = "s/latest"
 	commitInfoKeyFmt = "s/%d" // s/<version>
+	storeKeyPrefix   = "s/k:" // s/k:<name>/, the data of a store in the db of the multistore
+	storeDBPrefix    = "s/_/" // the data of a store in its own db
+
+	// the rollback being applied
+	rollbackKey = "s/rollback"
 )
 
 // Store is composed of many CommitStores. Name contrasts with
@@ -37,6 +45,16 @@ type Store struct {
 
 	traceWriter  io.Writer
 	traceContext types.TraceContext
+
+	snapshotOpts SnapshotOptions
+	snapshotting int32 // set while a periodic snapshot is in progress
+
+	interBlockCache types.MultiStorePersistentCache
+
+	listeners     map[types.StoreKey][]types.WriteListener
+	listenedKeys  []types.StoreKey // in registration order
+	listenTxIndex int64
+	pendingWrites []types.StoreKVPair // written to the stores with the next commit
 }
 
 var _ types.CommitMultiStore = (*Store)(nil)
@@ -49,6 +67,8 @@ func NewStore(db dbm.DB) *Store {
 		storesParams: make(map[types.StoreKey]storeParams),
 		stores:       make(map[types.StoreKey]types.CommitStore),
 		keysByName:   make(map[string]types.StoreKey),
+
+		listenTxIndex: -1,
 	}
 }
 
@@ -60,6 +80,12 @@ func (rs *Store) SetPruning(pruningOpts types.PruningOptions) {
 	}
 }
 
+// SetInterBlockCache sets the cache wrapping the IAVL stores loaded from then
+// on, which persists across blocks.
+func (rs *Store) SetInterBlockCache(cache types.MultiStorePersistentCache) {
+	rs.interBlockCache = cache
+}
+
 // Implements Store.
 func (rs *Store) GetStoreType() types.StoreType {
 	return types.StoreTypeMulti
@@ -96,12 +122,375 @@ func (rs *Store) GetCommitKVStore(key types.StoreKey) types.CommitKVStore {
 
 // Implements CommitMultiStore.
 func (rs *Store) LoadLatestVersion() error {
-	ver := getLatestVersion(rs.db)
-	return rs.LoadVersion(ver)
+	return rs.LoadLatestVersionAndUpgrade(nil)
 }
 
 // Implements CommitMultiStore.
 func (rs *Store) LoadVersion(ver int64) error {
+	return rs.LoadVersionAndUpgrade(ver, nil)
+}
+
+// Implements CommitMultiStore.
+func (rs *Store) AddListeners(key types.StoreKey, listeners ...types.WriteListener) {
+	if rs.listeners == nil {
+		rs.listeners = make(map[types.StoreKey][]types.WriteListener)
+	}
+	if _, ok := rs.listeners[key]; !ok {
+		rs.listenedKeys = append(rs.listenedKeys, key)
+	}
+	rs.listeners[key] = append(rs.listeners[key], listeners...)
+}
+
+// Implements CommitMultiStore.
+func (rs *Store) ListeningEnabled(key types.StoreKey) bool {
+	return len(rs.listeners[key]) != 0
+}
+
+// Implements CommitMultiStore.
+func (rs *Store) SetListeningTxIndex(txIndex int64) {
+	rs.listenTxIndex = txIndex
+}
+
+// addPendingWrites records the writes of a cache of the multistore, which
+// are reported to the listeners with the next commit.
+func (rs *Store) addPendingWrites(pairs []types.StoreKVPair) {
+	rs.pendingWrites = append(rs.pendingWrites, pairs...)
+}
+
+// reportWrites reports the pending writes committed at the given version to
+// the listeners of their store.
+func (rs *Store) reportWrites(version int64) {
+	if len(rs.listeners) == 0 {
+		return
+	}
+
+	for _, pair := range rs.pendingWrites {
+		pair.Height = version
+		for _, listener := range rs.listeners[rs.keysByName[pair.StoreKey]] {
+			if err := listener.OnWrite(pair); err != nil {
+				panic(fmt.Sprintf("failed to report write to %s at height %d: %v", pair.StoreKey, version, err))
+			}
+		}
+	}
+	rs.pendingWrites = nil
+
+	for _, listener := range rs.uniqueListeners() {
+		if err := listener.OnCommit(version); err != nil {
+			panic(fmt.Sprintf("failed to report commit at height %d: %v", version, err))
+		}
+	}
+}
+
+// uniqueListeners returns the registered listeners in registration order,
+// without duplicates.
+func (rs *Store) uniqueListeners() []types.WriteListener {
+	var unique []types.WriteListener
+	for _, key := range rs.listenedKeys {
+		for _, listener := range rs.listeners[key] {
+			found := false
+			for _, l := range unique {
+				if l == listener {
+					found = true
+					break
+				}
+			}
+			if !found {
+				unique = append(unique, listener)
+			}
+		}
+	}
+	return unique
+}
+
+// PruneStores deletes the versions of the IAVL stores committed at the latest
+// version that are not kept by the given pruning options, all at once. The
+// stores do not need to be mounted, so that the db of a stopped node can be
+// pruned.
+func (rs *Store) PruneStores(opts types.PruningOptions) error {
+	latest := getLatestVersion(rs.db)
+	if latest == 0 {
+		return nil
+	}
+
+	cInfo, err := getCommitInfo(rs.db, latest)
+	if err != nil {
+		return err
+	}
+	for _, info := range cInfo.StoreInfos {
+		db, err := rs.iavlStoreDB(info.Name)
+		if err != nil {
+			return err
+		}
+
+		store, err := iavl.LoadStore(db, info.Core.CommitID, opts)
+		if err != nil {
+			return fmt.Errorf("failed to load store %s: %v", info.Name, err)
+		}
+		if err := store.(*iavl.Store).Prune(); err != nil {
+			return fmt.Errorf("failed to prune store %s: %v", info.Name, err)
+		}
+	}
+	return nil
+}
+
+// Rollback reverts the committed state to the given version, deleting the
+// later versions of every IAVL store along with their commit info, and returns
+// the commit ID of the version, which becomes the latest one. The stores do
+// not need to be mounted, so that the db of a stopped node can be rolled back,
+// but the stores kept in their own db must be.
+//
+// The stores renamed by an upgrade after the version get their former name
+// back and the ones added after it are deleted. The version must exist in all
+// its stores, which cannot have been deleted since, and every store is checked
+// before any of them is rolled back. The rollback is then persisted before
+// any store is modified, so that a rollback interrupted by a crash is
+// finished by the next load or rollback.
+func (rs *Store) Rollback(version int64) (types.CommitID, error) {
+	if err := rs.finishPendingRollback(); err != nil {
+		return types.CommitID{}, err
+	}
+
+	plan, err := rs.planRollback(version)
+	if err != nil {
+		return types.CommitID{}, err
+	}
+	rs.db.SetSync([]byte(rollbackKey), cdc.MustMarshalBinaryLengthPrefixed(plan))
+
+	if err := rs.finishRollback(plan); err != nil {
+		return types.CommitID{}, err
+	}
+	cInfo, err := getCommitInfo(rs.db, version)
+	if err != nil {
+		return types.CommitID{}, err
+	}
+	return cInfo.CommitID(), nil
+}
+
+// rollbackPlan lists the changes of a rollback, which are all safe to apply
+// again, so that a rollback can be finished after a crash.
+type rollbackPlan struct {
+	Version int64               `json:"version"`
+	Latest  int64               `json:"latest"`
+	Stores  []string            `json:"stores"`  // the stores of the version
+	Renamed []types.StoreRename `json:"renamed"` // from their name at the latest version
+	Added   []string            `json:"added"`   // after the version
+}
+
+// planRollback checks the state can be rolled back to the given version and
+// returns the changes of the rollback, without modifying anything.
+func (rs *Store) planRollback(version int64) (plan rollbackPlan, err error) {
+	latest := getLatestVersion(rs.db)
+	if version <= 0 || version >= latest {
+		return plan, fmt.Errorf("cannot roll back to version %d, the latest version is %d", version, latest)
+	}
+
+	cInfo, err := getCommitInfo(rs.db, version)
+	if err != nil {
+		return plan, fmt.Errorf("failed to get commit info at version %d: %v", version, err)
+	}
+	latestInfo, err := getCommitInfo(rs.db, latest)
+	if err != nil {
+		return plan, fmt.Errorf("failed to get commit info at version %d: %v", latest, err)
+	}
+
+	// the stores committed at the latest version but not at the version were
+	// either renamed or added after it
+	later := make(map[string]bool)
+	for _, info := range latestInfo.StoreInfos {
+		later[info.Name] = true
+	}
+	for _, info := range cInfo.StoreInfos {
+		delete(later, info.Name)
+	}
+
+	plan = rollbackPlan{Version: version, Latest: latest}
+	for _, info := range cInfo.StoreInfos {
+		from := info.Name
+		db, err := rs.iavlStoreDB(from)
+		if err != nil {
+			return plan, err
+		}
+		if _, ok := iavl.RootHash(db, version); !ok {
+			if from, db, err = rs.renamedStore(info, version, later); err != nil {
+				return plan, err
+			}
+			delete(later, from)
+			plan.Renamed = append(plan.Renamed, types.StoreRename{OldKey: from, NewKey: info.Name})
+		}
+
+		// loading the store checks that the version has not been pruned
+		if _, err := iavl.LoadStore(db, info.Core.CommitID, rs.pruningOpts); err != nil {
+			return plan, fmt.Errorf("failed to load store %s at version %d: %v", from, version, err)
+		}
+		plan.Stores = append(plan.Stores, info.Name)
+	}
+
+	for name := range later {
+		if _, err := rs.iavlStoreDB(name); err != nil {
+			return plan, err
+		}
+		plan.Added = append(plan.Added, name)
+	}
+	sort.Strings(plan.Added)
+	return plan, nil
+}
+
+// finishRollback applies the changes of a persisted rollback, and then deletes
+// the later commit info and the rollback itself in a single batch.
+func (rs *Store) finishRollback(plan rollbackPlan) error {
+	// the data is moved before the versions are deleted, as the versions of a
+	// renamed store are deleted under its former name
+	for _, rename := range plan.Renamed {
+		from, err := rs.iavlStoreDB(rename.OldKey)
+		if err != nil {
+			return err
+		}
+		to, err := rs.iavlStoreDB(rename.NewKey)
+		if err != nil {
+			return err
+		}
+		moveStoreDB(from, to)
+	}
+	for _, name := range plan.Stores {
+		db, err := rs.iavlStoreDB(name)
+		if err != nil {
+			return err
+		}
+		if err := iavl.DeleteVersionsAfter(db, plan.Version); err != nil {
+			return fmt.Errorf("failed to roll back store %s: %v", name, err)
+		}
+	}
+	for _, name := range plan.Added {
+		db, err := rs.iavlStoreDB(name)
+		if err != nil {
+			return err
+		}
+		deleteAll(db)
+	}
+
+	batch := rs.db.NewBatch()
+	defer batch.Close()
+	for ver := plan.Version + 1; ver <= plan.Latest; ver++ {
+		batch.Delete([]byte(fmt.Sprintf(commitInfoKeyFmt, ver)))
+	}
+	setLatestVersion(batch, plan.Version)
+	batch.Delete([]byte(rollbackKey))
+	batch.WriteSync()
+	return nil
+}
+
+// getPendingRollback returns the rollback interrupted before it was finished,
+// if any.
+func getPendingRollback(db dbm.DB) (plan rollbackPlan, ok bool) {
+	bz := db.Get([]byte(rollbackKey))
+	if bz == nil {
+		return plan, false
+	}
+	cdc.MustUnmarshalBinaryLengthPrefixed(bz, &plan)
+	return plan, true
+}
+
+// finishPendingRollback finishes the rollback interrupted by a crash, if any.
+func (rs *Store) finishPendingRollback() error {
+	plan, ok := getPendingRollback(rs.db)
+	if !ok {
+		return nil
+	}
+	if err := rs.finishRollback(plan); err != nil {
+		return fmt.Errorf("failed to finish the rollback to version %d: %v", plan.Version, err)
+	}
+	return nil
+}
+
+// renamedStore returns the name and db of the store committed after the given
+// version the store of the version was renamed to, as the one among the
+// candidates whose tree at the version matches the commit ID of the store.
+func (rs *Store) renamedStore(info storeInfo, version int64, candidates map[string]bool) (string, dbm.DB, error) {
+	// the candidates are sorted for the outcome not to depend on the map order
+	names := make([]string, 0, len(candidates))
+	for name := range candidates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		db, err := rs.iavlStoreDB(name)
+		if err != nil {
+			return "", nil, err
+		}
+		hash, ok := iavl.RootHash(db, version)
+		if ok && bytes.Equal(hash, info.Core.CommitID.Hash) {
+			return name, db, nil
+		}
+	}
+	return "", nil, fmt.Errorf("store %s committed at version %d no longer exists, it was deleted or pruned", info.Name, version)
+}
+
+// moveStoreDB moves all the data of the store db from to the store db to, which
+// may be prefixes of different dbs. The data is copied before it is deleted,
+// so that an interrupted move can be run again.
+func moveStoreDB(from, to dbm.DB) {
+	batch := to.NewBatch()
+	defer batch.Close()
+
+	it := from.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		batch.Set(it.Key(), it.Value())
+	}
+	it.Close()
+	batch.WriteSync()
+
+	deleteAll(from)
+}
+
+// deleteAll deletes all the keys of db.
+func deleteAll(db dbm.DB) {
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	it := db.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		batch.Delete(it.Key())
+	}
+	it.Close()
+	batch.WriteSync()
+}
+
+// Implements CommitMultiStore.
+// The version of a rollback interrupted by a crash is the latest one, as the
+// rollback is finished on load.
+func (rs *Store) LatestVersion() int64 {
+	if plan, ok := getPendingRollback(rs.db); ok {
+		return plan.Version
+	}
+	return getLatestVersion(rs.db)
+}
+
+// Implements CommitMultiStore.
+func (rs *Store) LoadLatestVersionAndUpgrade(upgrades *types.StoreUpgrades) error {
+	if err := rs.finishPendingRollback(); err != nil {
+		return err
+	}
+	ver := getLatestVersion(rs.db)
+	return rs.LoadVersionAndUpgrade(ver, upgrades)
+}
+
+// Implements CommitMultiStore.
+//
+// The mounted stores must match the stores committed at the given version,
+// after the upgrades are applied. The data of renamed and deleted stores is
+// moved in a single batch, while the commit info of the version is kept as is
+// so that its app hash doesn't change: the upgraded set of stores is committed
+// with the next version.
+func (rs *Store) LoadVersionAndUpgrade(ver int64, upgrades *types.StoreUpgrades) error {
+	if err := rs.finishPendingRollback(); err != nil {
+		return err
+	}
+
+	// the cached values of another version are stale
+	if rs.interBlockCache != nil {
+		rs.interBlockCache.Reset()
+	}
 
 	// Special logic for version 0
 	if ver == 0 {
@@ -126,21 +515,70 @@ func (rs *Store) LoadVersion(ver int64) error {
 	}
 
 	// Convert StoreInfos slice to map
-	infos := make(map[types.StoreKey]storeInfo)
+	infos := make(map[string]storeInfo)
 	for _, storeInfo := range cInfo.StoreInfos {
-		infos[rs.nameToKey(storeInfo.Name)] = storeInfo
+		infos[storeInfo.Name] = storeInfo
 	}
 
-	// Load each Store
-	var newStores = make(map[types.StoreKey]types.CommitStore)
+	// Apply the upgrades and find the commit ID of each mounted store
+	batch := rs.db.NewBatch()
+	ids := make(map[types.StoreKey]types.CommitID)
+	var added []storeParams
 	for key, storeParams := range rs.storesParams {
-		var id types.CommitID
-		info, ok := infos[key]
-		if ok {
-			id = info.Core.CommitID
+		name := key.Name()
+		if storeParams.typ == types.StoreTypeTransient {
+			continue
 		}
 
-		store, err := rs.loadCommitStoreFromParams(key, id, storeParams)
+		if upgrades.IsDeleted(name) {
+			return fmt.Errorf("deleted store %s is mounted", name)
+		}
+		if info, ok := infos[name]; ok {
+			ids[key] = info.Core.CommitID
+			delete(infos, name)
+			continue
+		}
+
+		oldName := upgrades.RenamedFrom(name)
+		if info, ok := infos[oldName]; ok && oldName != "" {
+			if storeParams.db != nil {
+				return fmt.Errorf("cannot rename store %s with its own db", oldName)
+			}
+			moveStoreData(batch, rs.db, oldName, name)
+			ids[key] = info.Core.CommitID
+			delete(infos, oldName)
+			continue
+		}
+
+		if !upgrades.IsAdded(name) {
+			return fmt.Errorf("store %s was not committed at version %d, it must be added by a store upgrade", name, ver)
+		}
+		// an added store starts empty at the loaded version, so that its
+		// versions match the ones of the other stores
+		ids[key] = types.CommitID{Version: ver}
+		added = append(added, storeParams)
+	}
+
+	for name := range infos {
+		if !upgrades.IsDeleted(name) {
+			return fmt.Errorf("store %s committed at version %d is not mounted, it must be deleted by a store upgrade", name, ver)
+		}
+		moveStoreData(batch, rs.db, name, "")
+	}
+	batch.Write()
+
+	for _, storeParams := range added {
+		if storeParams.typ == types.StoreTypeIAVL {
+			if err := iavl.NewNodeImporter(rs.storeDB(storeParams), ver, nil).Commit(); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Load each Store
+	var newStores = make(map[types.StoreKey]types.CommitStore)
+	for key, storeParams := range rs.storesParams {
+		store, err := rs.loadCommitStoreFromParams(key, ids[key], storeParams)
 		if err != nil {
 			return fmt.Errorf("failed to load Store: %v", err)
 		}
@@ -153,6 +591,23 @@ func (rs *Store) LoadVersion(ver int64) error {
 	return nil
 }
 
+// moveStoreData moves the data of the store named from to the store named to
+// in the given batch, or deletes it if to is empty. It is a no-op once the
+// batch is written, so that upgrades can be applied again.
+func moveStoreData(batch dbm.Batch, db dbm.DB, from, to string) {
+	prefix := storePrefix(from)
+	it := dbm.IteratePrefix(db, prefix)
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		if to != "" {
+			key := append(storePrefix(to), it.Key()[len(prefix):]...)
+			batch.Set(key, it.Value())
+		}
+		batch.Delete(it.Key())
+	}
+}
+
 // SetTracer sets the tracer for the MultiStore that the underlying
 // stores will utilize to trace operations. A MultiStore is returned.
 func (rs *Store) SetTracer(w io.Writer) types.MultiStore {
@@ -208,6 +663,11 @@ func (rs *Store) Commit() types.CommitID {
 		Hash:    commitInfo.Hash(),
 	}
 	rs.lastCommitID = commitID
+	rs.reportWrites(version)
+
+	if rs.snapshotOpts.Interval > 0 && version%rs.snapshotOpts.Interval == 0 {
+		rs.snapshotInBackground(version)
+	}
 	return commitID
 }
 
@@ -230,7 +690,56 @@ func (rs *Store) CacheMultiStore() types.CacheMultiStore {
 	for k, v := range rs.stores {
 		stores[k] = v
 	}
-	return cachemulti.NewStore(rs.db, stores, rs.keysByName, rs.traceWriter, rs.traceContext)
+
+	// the writes of every cache are recorded, only the ones of the deliver
+	// state are eventually written
+	var listenBuffer *listenkv.Buffer
+	if len(rs.listeners) != 0 {
+		listened := make(map[types.StoreKey]bool, len(rs.listeners))
+		for key := range rs.listeners {
+			listened[key] = true
+		}
+		listenBuffer = listenkv.NewBuffer(listened, &rs.listenTxIndex, rs.addPendingWrites)
+	}
+	return cachemulti.NewStore(rs.db, stores, rs.keysByName, rs.traceWriter, rs.traceContext, listenBuffer)
+}
+
+// CacheMultiStoreWithVersion implements the CommitMultiStore interface. The
+// IAVL stores are read-only snapshots of the given committed version and the
+// transient stores are empty, so that the returned cache can be read while
+// blocks are executed and committed. Its writes are never persisted nor
+// listened to.
+func (rs *Store) CacheMultiStoreWithVersion(version int64) (types.CacheMultiStore, error) {
+	// the commit info is read from the db rather than the last commit ID,
+	// which is written by the commits
+	if _, err := getCommitInfo(rs.db, version); err != nil {
+		return nil, fmt.Errorf("version %d is not committed: %v", version, err)
+	}
+
+	stores := make(map[types.StoreKey]types.CacheWrapper)
+	for key, store := range rs.stores {
+		// snapshots are taken of the store itself rather than its inter-block
+		// cache, which holds the latest version
+		if rs.interBlockCache != nil {
+			if unwrapped := rs.interBlockCache.Unwrap(key); unwrapped != nil {
+				store = unwrapped
+			}
+		}
+
+		switch store := store.(type) {
+		case *iavl.Store:
+			immutable, err := store.GetImmutable(version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load version %d of store %s: %v", version, key.Name(), err)
+			}
+			stores[key] = immutable
+		case *transient.Store:
+			stores[key] = transient.NewStore()
+		default:
+			stores[key] = store
+		}
+	}
+	return cachemulti.NewStore(rs.db, stores, rs.keysByName, nil, nil, nil), nil
 }
 
 // Implements MultiStore.
@@ -291,6 +800,12 @@ func (rs *Store) Query(req abci.RequestQuery) abci.ResponseQuery {
 		msg := fmt.Sprintf("no such store: %s", storeName)
 		return errors.ErrUnknownRequest(msg).QueryResult()
 	}
+	// queries are served by the store itself rather than its inter-block cache
+	if rs.interBlockCache != nil {
+		if unwrapped := rs.interBlockCache.Unwrap(rs.keysByName[storeName]); unwrapped != nil {
+			store = unwrapped
+		}
+	}
 	queryable, ok := store.(types.Queryable)
 	if !ok {
 		msg := fmt.Sprintf("store %s doesn't support queries", storeName)
@@ -301,7 +816,7 @@ func (rs *Store) Query(req abci.RequestQuery) abci.ResponseQuery {
 	req.Path = subpath
 	res := queryable.Query(req)
 
-	if !req.Prove || !RequireProof(subpath) {
+	if !req.Prove || !RequireProof(subpath) || !res.IsOK() {
 		return res
 	}
 
@@ -346,13 +861,16 @@ func parsePath(path string) (storeName string, subpath string, err errors.Error)
 
 //----------------------------------------
 
-func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID, params storeParams) (store types.CommitStore, err error) {
-	var db dbm.DB
+// storeDB returns the db holding the data of a mounted store.
+func (rs *Store) storeDB(params storeParams) dbm.DB {
 	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
+		return dbm.NewPrefixDB(params.db, []byte(storeDBPrefix))
 	}
+	return dbm.NewPrefixDB(rs.db, storePrefix(params.key.Name()))
+}
+
+func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID, params storeParams) (store types.CommitStore, err error) {
+	db := rs.storeDB(params)
 	switch params.typ {
 	case types.StoreTypeMulti:
 		panic("recursive MultiStores not yet supported")
@@ -360,6 +878,9 @@ func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID
 		// return NewCommitMultiStore(db, id)
 	case types.StoreTypeIAVL:
 		store, err = iavl.LoadStore(db, id, rs.pruningOpts)
+		if err == nil && rs.interBlockCache != nil {
+			store = rs.interBlockCache.GetStoreCache(key, store.(types.CommitKVStore))
+		}
 		return
 	case types.StoreTypeDB:
 		store = commitDBStoreAdapter{dbadapter.Store{db}}
@@ -377,13 +898,10 @@ func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID
 	}
 }
 
-func (rs *Store) nameToKey(name string) types.StoreKey {
-	for key := range rs.storesParams {
-		if key.Name() == name {
-			return key
-		}
-	}
-	panic("Unknown name " + name)
+// storePrefix returns the prefix of the data of the store with the given name
+// in the db of the multistore.
+func storePrefix(name string) []byte {
+	return []byte(storeKeyPrefix + name + "/")
 }
 
 //----------------------------------------
@@ -8,7 +8,9 @@ import (
 	"github.com/tendermint/tendermint/crypto/merkle"
 	dbm "github.com/tendermint/tendermint/libs/db"
 
+	"github.com/cosmos/cosmos-sdk/store/cache"
 	"github.com/cosmos/cosmos-sdk/store/errors"
+	"github.com/cosmos/cosmos-sdk/store/iavl"
 	"github.com/cosmos/cosmos-sdk/store/types"
 )
 
@@ -100,6 +102,381 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestMultistoreLoadWithUpgrade(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+
+	for _, name := range []string{"store1", "store2", "store3"} {
+		store.getStoreByName(name).(types.KVStore).Set([]byte("key"), []byte(name))
+	}
+	store.Commit()
+	store.Commit()
+	commitID := store.LastCommitID()
+
+	// store2 is renamed to renamed2, store3 is deleted and added4 is added
+	upgrades := &types.StoreUpgrades{
+		Added:   []string{"added4"},
+		Renamed: []types.StoreRename{{OldKey: "store2", NewKey: "renamed2"}},
+		Deleted: []string{"store3"},
+	}
+	newUpgradedStore := func() *Store {
+		store := NewStore(db)
+		store.pruningOpts = types.PruneSyncable
+		for _, name := range []string{"store1", "renamed2", "added4"} {
+			store.MountStoreWithDB(types.NewKVStoreKey(name), types.StoreTypeIAVL, nil)
+		}
+		return store
+	}
+
+	// the set of stores must match the committed one
+	require.Error(t, newUpgradedStore().LoadLatestVersion())
+	require.Error(t, newMultiStoreWithMounts(db).LoadLatestVersionAndUpgrade(upgrades))
+
+	// the upgrades can be applied again until the next commit
+	for i := 0; i < 2; i++ {
+		store = newUpgradedStore()
+		require.NoError(t, store.LoadLatestVersionAndUpgrade(upgrades))
+		require.Equal(t, commitID, store.LastCommitID())
+	}
+
+	require.Equal(t, []byte("store1"), store.getStoreByName("store1").(types.KVStore).Get([]byte("key")))
+	require.Equal(t, []byte("store2"), store.getStoreByName("renamed2").(types.KVStore).Get([]byte("key")))
+	require.Nil(t, store.getStoreByName("added4").(types.KVStore).Get([]byte("key")))
+	require.Nil(t, store.getStoreByName("store3"))
+	require.False(t, dbm.IteratePrefix(db, storePrefix("store2")).Valid())
+	require.False(t, dbm.IteratePrefix(db, storePrefix("store3")).Valid())
+
+	// the versions of the added store follow the ones of the multistore
+	store.getStoreByName("added4").(types.KVStore).Set([]byte("key"), []byte("added4"))
+	commitID = store.Commit()
+	require.Equal(t, int64(3), commitID.Version)
+	for _, name := range []string{"store1", "renamed2", "added4"} {
+		require.Equal(t, int64(3), store.getStoreByName(name).(types.CommitStore).LastCommitID().Version)
+	}
+	cInfo, err := getCommitInfo(db, 3)
+	require.NoError(t, err)
+	require.Len(t, cInfo.StoreInfos, 3)
+
+	// the upgraded stores can be loaded with or without the upgrades
+	for _, u := range []*types.StoreUpgrades{nil, upgrades} {
+		store = newUpgradedStore()
+		require.NoError(t, store.LoadLatestVersionAndUpgrade(u))
+		require.Equal(t, commitID, store.LastCommitID())
+		require.Equal(t, []byte("added4"), store.getStoreByName("added4").(types.KVStore).Get([]byte("key")))
+	}
+
+	// a deleted store cannot be mounted
+	store = newMultiStoreWithMounts(db)
+	require.Error(t, store.LoadVersionAndUpgrade(2, &types.StoreUpgrades{Deleted: []string{"store1"}}))
+}
+
+type recordingListener struct {
+	pairs   []types.StoreKVPair
+	commits []int64
+}
+
+func (l *recordingListener) OnWrite(pair types.StoreKVPair) error {
+	l.pairs = append(l.pairs, pair)
+	return nil
+}
+
+func (l *recordingListener) OnCommit(height int64) error {
+	l.commits = append(l.commits, height)
+	return nil
+}
+
+func TestMultistoreListeners(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.NoError(t, store.LoadLatestVersion())
+
+	key1, key2, key3 := store.keysByName["store1"], store.keysByName["store2"], store.keysByName["store3"]
+	listener1, listener2 := &recordingListener{}, &recordingListener{}
+	store.AddListeners(key1, listener1, listener2)
+	store.AddListeners(key2, listener1)
+	require.True(t, store.ListeningEnabled(key1))
+	require.False(t, store.ListeningEnabled(key3))
+
+	// the writes of a cache that is not written are not reported
+	store.CacheMultiStore().GetKVStore(key1).Set([]byte("check"), []byte("value"))
+
+	deliver := store.CacheMultiStore()
+	deliver.GetKVStore(key1).Set([]byte("begin"), []byte("value"))
+	store.SetListeningTxIndex(0)
+	tx := deliver.CacheMultiStore()
+	tx.GetKVStore(key2).Set([]byte("tx"), []byte("value"))
+	tx.GetKVStore(key3).Set([]byte("tx"), []byte("value"))
+	tx.Write()
+	store.SetListeningTxIndex(-1)
+	deliver.GetKVStore(key1).Delete([]byte("begin"))
+	deliver.Write()
+
+	commitID := store.Commit()
+	require.Equal(t, []types.StoreKVPair{
+		{Height: 1, TxIndex: -1, StoreKey: "store1", Key: []byte("begin"), Value: []byte("value")},
+		{Height: 1, TxIndex: 0, StoreKey: "store2", Key: []byte("tx"), Value: []byte("value")},
+		{Height: 1, TxIndex: -1, StoreKey: "store1", Delete: true, Key: []byte("begin")},
+	}, listener1.pairs)
+	require.Equal(t, []types.StoreKVPair{
+		{Height: 1, TxIndex: -1, StoreKey: "store1", Key: []byte("begin"), Value: []byte("value")},
+		{Height: 1, TxIndex: -1, StoreKey: "store1", Delete: true, Key: []byte("begin")},
+	}, listener2.pairs)
+	require.Equal(t, []int64{1}, listener1.commits)
+	require.Equal(t, []int64{1}, listener2.commits)
+
+	// every commit is reported, and listening doesn't change the state
+	store.Commit()
+	require.Len(t, listener1.pairs, 3)
+	require.Equal(t, []int64{1, 2}, listener1.commits)
+
+	other := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.NoError(t, other.LoadLatestVersion())
+	other.GetKVStore(other.keysByName["store2"]).Set([]byte("tx"), []byte("value"))
+	other.GetKVStore(other.keysByName["store3"]).Set([]byte("tx"), []byte("value"))
+	require.Equal(t, commitID, other.Commit())
+}
+
+func TestMultistorePruneStores(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	for i := 0; i < 5; i++ {
+		store.GetKVStore(store.keysByName["store1"]).Set([]byte("key"), []byte{byte(i)})
+		store.Commit()
+	}
+
+	require.NoError(t, NewStore(db).PruneStores(types.NewPruningOptions(1, 0)))
+
+	store = newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	query := abci.RequestQuery{Path: "/store1/key", Data: []byte("key")}
+	for height := int64(1); height <= 5; height++ {
+		query.Height = height
+		res := store.Query(query)
+		if height <= 3 {
+			require.Equal(t, uint32(errors.CodePrunedHeight), res.Code, "height %d", height)
+		} else {
+			require.True(t, res.IsOK(), "height %d", height)
+			require.Equal(t, []byte{byte(height - 1)}, res.Value)
+		}
+	}
+}
+
+func TestMultistoreRollback(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	commit := func(i int) types.CommitID {
+		store.GetKVStore(store.keysByName["store1"]).Set([]byte("key"), []byte{byte(i)})
+		store.GetKVStore(store.keysByName["store2"]).Set([]byte{byte(i)}, []byte("value"))
+		return store.Commit()
+	}
+	var commitIDs []types.CommitID
+	for i := 1; i <= 5; i++ {
+		commitIDs = append(commitIDs, commit(i))
+	}
+
+	for _, version := range []int64{0, 5, 6} {
+		_, err := NewStore(db).Rollback(version)
+		require.Error(t, err)
+	}
+	commitID, err := NewStore(db).Rollback(3)
+	require.NoError(t, err)
+	require.Equal(t, commitIDs[2], commitID)
+
+	store = newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	require.Equal(t, commitIDs[2], store.LastCommitID())
+	_, err = getCommitInfo(db, 4)
+	require.Error(t, err)
+	require.Nil(t, store.GetKVStore(store.keysByName["store2"]).Get([]byte{4}))
+
+	// the same blocks can be committed again
+	require.Equal(t, commitIDs[3], commit(4))
+	require.Equal(t, commitIDs[4], commit(5))
+}
+
+// commitUpgradedStores commits two versions of store1, store2 and store3, and
+// two more after store2 is renamed to renamed2 and added4 is added, returning
+// the commit ID of the second version.
+func commitUpgradedStores(t *testing.T, db dbm.DB) types.CommitID {
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	for _, name := range []string{"store1", "store2", "store3"} {
+		store.getStoreByName(name).(types.KVStore).Set([]byte("key"), []byte(name))
+	}
+	store.Commit()
+	commitID := store.Commit()
+
+	// store2 is renamed to renamed2 and added4 is added
+	upgrades := &types.StoreUpgrades{
+		Added:   []string{"added4"},
+		Renamed: []types.StoreRename{{OldKey: "store2", NewKey: "renamed2"}},
+	}
+	store = NewStore(db)
+	store.pruningOpts = types.PruneSyncable
+	for _, name := range []string{"store1", "renamed2", "store3", "added4"} {
+		store.MountStoreWithDB(types.NewKVStoreKey(name), types.StoreTypeIAVL, nil)
+	}
+	require.NoError(t, store.LoadLatestVersionAndUpgrade(upgrades))
+	store.getStoreByName("renamed2").(types.KVStore).Set([]byte("key"), []byte("renamed2"))
+	store.getStoreByName("added4").(types.KVStore).Set([]byte("key"), []byte("added4"))
+	store.Commit()
+	store.Commit()
+	return commitID
+}
+
+func TestMultistoreRollbackUpgrades(t *testing.T) {
+	db := dbm.NewMemDB()
+	commitID := commitUpgradedStores(t, db)
+
+	rolledBack, err := NewStore(db).Rollback(2)
+	require.NoError(t, err)
+	require.Equal(t, commitID, rolledBack)
+
+	// the stores of the version are restored under their former name
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+	require.Equal(t, []byte("store2"), store.getStoreByName("store2").(types.KVStore).Get([]byte("key")))
+	require.False(t, dbm.IteratePrefix(db, storePrefix("renamed2")).Valid())
+	require.False(t, dbm.IteratePrefix(db, storePrefix("added4")).Valid())
+}
+
+func TestMultistoreRollbackInterrupted(t *testing.T) {
+	db := dbm.NewMemDB()
+	commitID := commitUpgradedStores(t, db)
+
+	// the rollback is interrupted once the renamed store is moved back and the
+	// first store is rolled back
+	rs := NewStore(db)
+	plan, err := rs.planRollback(2)
+	require.NoError(t, err)
+	require.Equal(t, []types.StoreRename{{OldKey: "renamed2", NewKey: "store2"}}, plan.Renamed)
+	require.Equal(t, []string{"added4"}, plan.Added)
+	db.SetSync([]byte(rollbackKey), cdc.MustMarshalBinaryLengthPrefixed(plan))
+	moveStoreDB(dbm.NewPrefixDB(db, storePrefix("renamed2")), dbm.NewPrefixDB(db, storePrefix("store2")))
+	require.NoError(t, iavl.DeleteVersionsAfter(dbm.NewPrefixDB(db, storePrefix(plan.Stores[0])), 2))
+	require.Equal(t, int64(2), rs.LatestVersion())
+
+	// the rollback is finished on load
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+	require.Equal(t, []byte("store2"), store.getStoreByName("store2").(types.KVStore).Get([]byte("key")))
+	require.Nil(t, db.Get([]byte(rollbackKey)))
+	require.Equal(t, int64(2), getLatestVersion(db))
+	require.False(t, dbm.IteratePrefix(db, storePrefix("added4")).Valid())
+	_, err = getCommitInfo(db, 3)
+	require.Error(t, err)
+}
+
+func TestMultistoreRollbackStoreDB(t *testing.T) {
+	db := dbm.NewMemDB()
+	commitID := commitUpgradedStores(t, db)
+
+	// renamed2 was moved to its own db after the upgrade
+	storeDB := dbm.NewMemDB()
+	moveStoreDB(dbm.NewPrefixDB(db, storePrefix("renamed2")), dbm.NewPrefixDB(storeDB, []byte(storeDBPrefix)))
+
+	rs := NewStore(db)
+	rs.MountStoreWithDB(types.NewKVStoreKey("renamed2"), types.StoreTypeIAVL, storeDB)
+	rolledBack, err := rs.Rollback(2)
+	require.NoError(t, err)
+	require.Equal(t, commitID, rolledBack)
+
+	// store2 is restored in the db of the multistore
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+	require.Equal(t, []byte("store2"), store.getStoreByName("store2").(types.KVStore).Get([]byte("key")))
+	require.False(t, storeDB.Iterator(nil, nil).Valid())
+}
+
+func TestMultistoreRollbackDeletedStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	store.Commit()
+	store.Commit()
+
+	store = NewStore(db)
+	store.pruningOpts = types.PruneSyncable
+	for _, name := range []string{"store1", "store2"} {
+		store.MountStoreWithDB(types.NewKVStoreKey(name), types.StoreTypeIAVL, nil)
+	}
+	require.NoError(t, store.LoadLatestVersionAndUpgrade(&types.StoreUpgrades{Deleted: []string{"store3"}}))
+	store.Commit()
+
+	// nothing is rolled back if any store cannot be
+	_, err := NewStore(db).Rollback(1)
+	require.Error(t, err)
+	require.Equal(t, int64(3), getLatestVersion(db))
+	for _, name := range []string{"store1", "store2"} {
+		hash, ok := iavl.RootHash(dbm.NewPrefixDB(db, storePrefix(name)), 3)
+		require.True(t, ok)
+		require.Nil(t, hash)
+	}
+}
+
+func TestMultistoreInterBlockCache(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	store.SetInterBlockCache(cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize))
+	require.NoError(t, store.LoadLatestVersion())
+	key1 := store.keysByName["store1"]
+	_, ok := store.GetCommitKVStore(key1).(*cache.CommitKVStoreCache)
+	require.True(t, ok)
+
+	// the writes of a block go through the cache
+	for i := 1; i <= 3; i++ {
+		cacheMulti := store.CacheMultiStore()
+		cacheMulti.GetKVStore(key1).Set([]byte("key"), []byte{byte(i)})
+		cacheMulti.Write()
+		store.Commit()
+	}
+	require.Equal(t, []byte{3}, store.CacheMultiStore().GetKVStore(key1).Get([]byte("key")))
+
+	// queries are served by the IAVL store
+	qres := store.Query(abci.RequestQuery{Path: "/store1/key", Data: []byte("key"), Height: 2})
+	require.EqualValues(t, errors.CodeOK, qres.Code)
+	require.Equal(t, []byte{2}, qres.Value)
+
+	// the cache is dropped when loading another version
+	require.NoError(t, store.LoadVersion(2))
+	require.Equal(t, []byte{2}, store.CacheMultiStore().GetKVStore(key1).Get([]byte("key")))
+}
+
+func TestMultistoreCacheMultiStoreWithVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	store.SetInterBlockCache(cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize))
+	require.NoError(t, store.LoadLatestVersion())
+	key1 := store.keysByName["store1"]
+
+	_, err := store.CacheMultiStoreWithVersion(1)
+	require.Error(t, err)
+
+	for i := 1; i <= 3; i++ {
+		cacheMulti := store.CacheMultiStore()
+		cacheMulti.GetKVStore(key1).Set([]byte("key"), []byte{byte(i)})
+		cacheMulti.Write()
+		store.Commit()
+	}
+
+	cacheMulti, err := store.CacheMultiStoreWithVersion(2)
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, cacheMulti.GetKVStore(key1).Get([]byte("key")))
+
+	// the writes to the snapshot are never persisted
+	cacheMulti.GetKVStore(key1).Set([]byte("key"), []byte{4})
+	require.Panics(t, cacheMulti.Write)
+	require.Equal(t, []byte{3}, store.CacheMultiStore().GetKVStore(key1).Get([]byte("key")))
+
+	_, err = store.CacheMultiStoreWithVersion(4)
+	require.Error(t, err)
+}
+
 func TestParsePath(t *testing.T) {
 	_, _, err := parsePath("foo")
 	require.Error(t, err)
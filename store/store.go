@@ -11,16 +11,31 @@ func NewCommitMultiStore(db dbm.DB) types.CommitMultiStore {
 	return rootmulti.NewStore(db)
 }
 
+// Pruning strategies
+const (
+	PruningStrategyDefault    = "default"
+	PruningStrategyEverything = "everything"
+	PruningStrategyNothing    = "nothing"
+	PruningStrategyCustom     = "custom"
+
+	// PruningStrategySyncable is kept for compatibility, it prunes the same
+	// states as the default strategy but at every height
+	PruningStrategySyncable = "syncable"
+)
+
+// NewPruningOptionsFromString returns the options of a named pruning strategy,
+// the default one if the strategy is unknown. The options of the custom
+// strategy are given by NewPruningOptions.
 func NewPruningOptionsFromString(strategy string) (opt PruningOptions) {
 	switch strategy {
-	case "nothing":
+	case PruningStrategyNothing:
 		opt = PruneNothing
-	case "everything":
+	case PruningStrategyEverything:
 		opt = PruneEverything
-	case "syncable":
+	case PruningStrategySyncable:
 		opt = PruneSyncable
 	default:
-		opt = PruneSyncable
+		opt = PruneDefault
 	}
 	return
 }
@@ -0,0 +1,96 @@
+package streaming
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var _ types.WriteListener = (*FileListener)(nil)
+
+// FileListener is a WriteListener writing the state changes of each block to
+// a file named after the block height in a directory. Each file holds the
+// length-prefixed binary encoding of the StoreKVPairs of the block, in order,
+// and is only visible once complete, so that an indexer can mirror the state
+// of a node by reading the files in height order.
+type FileListener struct {
+	dir string
+	buf bytes.Buffer
+}
+
+// NewFileListener returns a new FileListener writing to the given directory,
+// which is created if needed.
+func NewFileListener(dir string) (*FileListener, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileListener{dir: dir}, nil
+}
+
+// BlockFile returns the path of the file holding the state changes of the
+// block at the given height.
+func BlockFile(dir string, height int64) string {
+	return filepath.Join(dir, fmt.Sprintf("block-%d", height))
+}
+
+// OnWrite implements the WriteListener interface.
+func (fl *FileListener) OnWrite(pair types.StoreKVPair) error {
+	bz, err := cdc.MarshalBinaryLengthPrefixed(pair)
+	if err != nil {
+		return err
+	}
+	_, err = fl.buf.Write(bz)
+	return err
+}
+
+// OnCommit implements the WriteListener interface. It writes the state changes
+// of the block to its file.
+func (fl *FileListener) OnCommit(height int64) error {
+	defer fl.buf.Reset()
+
+	path := BlockFile(fl.dir, height)
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, fl.buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// ReadBlockFile returns the state changes written to the given block file.
+func ReadBlockFile(path string) ([]types.StoreKVPair, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pairs []types.StoreKVPair
+	r := bufio.NewReader(f)
+	for {
+		size, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			return pairs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		bz := make([]byte, size)
+		if _, err := io.ReadFull(r, bz); err != nil {
+			return nil, err
+		}
+
+		var pair types.StoreKVPair
+		if err := cdc.UnmarshalBinaryBare(bz, &pair); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+}
@@ -0,0 +1,44 @@
+package streaming
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func TestFileListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	listener, err := NewFileListener(dir)
+	require.NoError(t, err)
+
+	pairs := []types.StoreKVPair{
+		{Height: 1, TxIndex: -1, StoreKey: "store1", Key: []byte("key1"), Value: []byte("value1")},
+		{Height: 1, TxIndex: 0, StoreKey: "store2", Delete: true, Key: []byte("key2")},
+		{Height: 1, TxIndex: 1, StoreKey: "store1", Key: []byte("key1"), Value: []byte("value2")},
+	}
+	for _, pair := range pairs {
+		require.NoError(t, listener.OnWrite(pair))
+	}
+	require.NoError(t, listener.OnCommit(1))
+
+	// blocks without state changes have an empty file
+	require.NoError(t, listener.OnCommit(2))
+
+	read, err := ReadBlockFile(BlockFile(dir, 1))
+	require.NoError(t, err)
+	require.Equal(t, pairs, read)
+
+	read, err = ReadBlockFile(BlockFile(dir, 2))
+	require.NoError(t, err)
+	require.Empty(t, read)
+
+	_, err = ReadBlockFile(BlockFile(dir, 3))
+	require.Error(t, err)
+}
@@ -0,0 +1,7 @@
+package streaming
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+var cdc = codec.New()
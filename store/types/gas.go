@@ -143,15 +143,62 @@ func (g *infiniteGasMeter) IsOutOfGas() bool {
 	return false
 }
 
+// softGasMeter is a gas meter whose limit is not enforced by the meter: gas
+// consumed past the limit doesn't panic, leaving it to the caller to stop its
+// work once the meter is out of gas.
+type softGasMeter struct {
+	limit    Gas
+	consumed Gas
+}
+
+// NewSoftGasMeter returns a reference to a new softGasMeter.
+func NewSoftGasMeter(limit Gas) GasMeter {
+	return &softGasMeter{
+		limit:    limit,
+		consumed: 0,
+	}
+}
+
+func (g *softGasMeter) GasConsumed() Gas {
+	return g.consumed
+}
+
+func (g *softGasMeter) Limit() Gas {
+	return g.limit
+}
+
+func (g *softGasMeter) GasConsumedToLimit() Gas {
+	if g.IsPastLimit() {
+		return g.limit
+	}
+	return g.consumed
+}
+
+func (g *softGasMeter) ConsumeGas(amount Gas, descriptor string) {
+	var overflow bool
+	g.consumed, overflow = addUint64Overflow(g.consumed, amount)
+	if overflow {
+		panic(ErrorGasOverflow{descriptor})
+	}
+}
+
+func (g *softGasMeter) IsPastLimit() bool {
+	return g.consumed > g.limit
+}
+
+func (g *softGasMeter) IsOutOfGas() bool {
+	return g.consumed >= g.limit
+}
+
 // GasConfig defines gas cost for each operation on KVStores
 type GasConfig struct {
-	HasCost          Gas
-	DeleteCost       Gas
-	ReadCostFlat     Gas
-	ReadCostPerByte  Gas
-	WriteCostFlat    Gas
-	WriteCostPerByte Gas
-	IterNextCostFlat Gas
+	HasCost          Gas `json:"has_cost"`
+	DeleteCost       Gas `json:"delete_cost"`
+	ReadCostFlat     Gas `json:"read_cost_flat"`
+	ReadCostPerByte  Gas `json:"read_cost_per_byte"`
+	WriteCostFlat    Gas `json:"write_cost_flat"`
+	WriteCostPerByte Gas `json:"write_cost_per_byte"`
+	IterNextCostFlat Gas `json:"iter_next_cost_flat"`
 }
 
 // KVGasConfig returns a default gas config for KVStores.
@@ -172,3 +219,38 @@ func TransientGasConfig() GasConfig {
 	// TODO: define gasconfig for transient stores
 	return KVGasConfig()
 }
+
+// GasSchedule defines the gas configs charged for the accesses to the
+// KVStores and TransientStores of a block, along with the configs of the
+// stores which are charged differently, by store name.
+type GasSchedule struct {
+	KV        GasConfig
+	Transient GasConfig
+	Stores    map[string]GasConfig
+}
+
+// DefaultGasSchedule returns a gas schedule charging the default gas configs
+// for all the stores.
+func DefaultGasSchedule() GasSchedule {
+	return GasSchedule{
+		KV:        cachedKVGasConfig,
+		Transient: cachedTransientGasConfig,
+	}
+}
+
+// KVStoreGasConfig returns the gas config of the KVStore with the given name.
+func (gs GasSchedule) KVStoreGasConfig(name string) GasConfig {
+	if config, ok := gs.Stores[name]; ok {
+		return config
+	}
+	return gs.KV
+}
+
+// TransientStoreGasConfig returns the gas config of the TransientStore with
+// the given name.
+func (gs GasSchedule) TransientStoreGasConfig(name string) GasConfig {
+	if config, ok := gs.Stores[name]; ok {
+		return config
+	}
+	return gs.Transient
+}
@@ -45,6 +45,24 @@ func TestGasMeter(t *testing.T) {
 	}
 }
 
+func TestSoftGasMeter(t *testing.T) {
+	meter := NewSoftGasMeter(100)
+	meter.ConsumeGas(60, "")
+	require.False(t, meter.IsOutOfGas())
+
+	meter.ConsumeGas(40, "")
+	require.True(t, meter.IsOutOfGas())
+	require.False(t, meter.IsPastLimit())
+
+	// the limit is not enforced
+	require.NotPanics(t, func() { meter.ConsumeGas(50, "") })
+	require.True(t, meter.IsPastLimit())
+	require.Equal(t, Gas(150), meter.GasConsumed())
+	require.Equal(t, Gas(100), meter.GasConsumedToLimit())
+
+	require.Panics(t, func() { meter.ConsumeGas(math.MaxUint64, "") })
+}
+
 func TestAddUint64Overflow(t *testing.T) {
 	testCases := []struct {
 		a, b     uint64
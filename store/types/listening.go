@@ -0,0 +1,32 @@
+package types
+
+// StoreKVPair is a set or delete of a key in a store. Writes made outside of
+// transactions, in BeginBlock, EndBlock or InitChain, have a TxIndex of -1.
+type StoreKVPair struct {
+	Height   int64  `json:"height"`
+	TxIndex  int64  `json:"tx_index"`
+	StoreKey string `json:"store_key"`
+	Delete   bool   `json:"delete"`
+	Key      []byte `json:"key"`
+	Value    []byte `json:"value"`
+}
+
+// WriteListener is notified of the writes committed to the stores it is
+// registered for. The writes of a block are reported when it is committed, in
+// the order they were made, followed by a call to OnCommit.
+//
+// An error returned by a listener halts the node, as the listener would
+// otherwise miss state changes.
+type WriteListener interface {
+	OnWrite(pair StoreKVPair) error
+	OnCommit(height int64) error
+}
+
+// StoreDiff is the change of the value of a key in a store. Before and After
+// are nil if the key is not set before or after the change, respectively.
+type StoreDiff struct {
+	StoreKey string `json:"store_key"`
+	Key      []byte `json:"key"`
+	Before   []byte `json:"before"`
+	After    []byte `json:"after"`
+}
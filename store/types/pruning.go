@@ -1,16 +1,26 @@
 package types
 
+import (
+	"fmt"
+)
+
 // PruningStrategy specifies how old states will be deleted over time where
 // keepRecent can be used with keepEvery to create a pruning "strategy".
+// Heights listed in keepHeights are never deleted, and pruning runs every
+// interval heights, deleting in a batch the states released since the
+// previous run.
 type PruningOptions struct {
-	keepRecent int64
-	keepEvery  int64
+	keepRecent  int64
+	keepEvery   int64
+	interval    int64
+	keepHeights map[int64]bool
 }
 
 func NewPruningOptions(keepRecent, keepEvery int64) PruningOptions {
 	return PruningOptions{
 		keepRecent: keepRecent,
 		keepEvery:  keepEvery,
+		interval:   1,
 	}
 }
 
@@ -24,6 +34,63 @@ func (po PruningOptions) KeepEvery() int64 {
 	return po.keepEvery
 }
 
+// Interval returns the number of heights between two pruning runs, which
+// defaults to 1.
+func (po PruningOptions) Interval() int64 {
+	if po.interval == 0 {
+		return 1
+	}
+	return po.interval
+}
+
+// WithInterval returns the options with pruning running every interval
+// heights.
+func (po PruningOptions) WithInterval(interval int64) PruningOptions {
+	po.interval = interval
+	return po
+}
+
+// WithKeepHeights returns the options with the given heights never deleted.
+func (po PruningOptions) WithKeepHeights(heights ...int64) PruningOptions {
+	keepHeights := make(map[int64]bool, len(po.keepHeights)+len(heights))
+	for height := range po.keepHeights {
+		keepHeights[height] = true
+	}
+	for _, height := range heights {
+		keepHeights[height] = true
+	}
+	po.keepHeights = keepHeights
+	return po
+}
+
+// KeepHeight returns true if the state at the given height is kept whatever
+// the latest height, as a multiple of keepEvery or a listed height.
+func (po PruningOptions) KeepHeight(height int64) bool {
+	if po.keepEvery != 0 && height%po.keepEvery == 0 {
+		return true
+	}
+	return po.keepHeights[height]
+}
+
+// Validate returns an error if the options are invalid.
+func (po PruningOptions) Validate() error {
+	if po.keepRecent < 0 {
+		return fmt.Errorf("negative number of recent states to keep: %d", po.keepRecent)
+	}
+	if po.keepEvery < 0 {
+		return fmt.Errorf("negative interval of states to keep: %d", po.keepEvery)
+	}
+	if po.interval < 0 {
+		return fmt.Errorf("negative pruning interval: %d", po.interval)
+	}
+	for height := range po.keepHeights {
+		if height <= 0 {
+			return fmt.Errorf("invalid height to keep: %d", height)
+		}
+	}
+	return nil
+}
+
 // default pruning strategies
 var (
 	// PruneEverything means all saved states will be deleted, storing only the current state
@@ -32,4 +99,6 @@ var (
 	PruneNothing = NewPruningOptions(0, 1)
 	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100 + every 10000th)
 	PruneSyncable = NewPruningOptions(100, 10000)
+	// PruneDefault keeps the same states as PruneSyncable, pruning the others every 10 heights
+	PruneDefault = NewPruningOptions(100, 10000).WithInterval(10)
 )
@@ -95,6 +95,94 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// Register listeners notified of the writes committed to the store
+	// with the given key through a cache of the multistore.
+	AddListeners(key StoreKey, listeners ...WriteListener)
+
+	// Returns true if listeners are registered for the store with the
+	// given key.
+	ListeningEnabled(key StoreKey) bool
+
+	// Set the index in the block of the transaction whose writes are
+	// reported next, or -1 for writes outside of transactions.
+	SetListeningTxIndex(txIndex int64)
+
+	// Returns the latest persisted version, which can be called before
+	// any version is loaded.
+	LatestVersion() int64
+
+	// Load the latest persisted version, applying the given store
+	// upgrades first.
+	LoadLatestVersionAndUpgrade(upgrades *StoreUpgrades) error
+
+	// Load a specific persisted version, applying the given store upgrades
+	// first. The upgrades are idempotent so that loading the same version
+	// again with the same upgrades is safe.
+	LoadVersionAndUpgrade(ver int64, upgrades *StoreUpgrades) error
+
+	// Returns a cache of a committed version, whose IAVL stores are
+	// read-only snapshots that can be read concurrently with the commits.
+	CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error)
+
+	// Keeps a committed version from being pruned until the returned
+	// function is called, so that it can be read in the background.
+	PinVersion(version int64) (unpin func())
+}
+
+// StoreUpgrades lists the stores added, renamed and deleted since the version
+// being loaded, so that the set of stores of a chain can change at an upgrade
+// height. Stores are referred to by the name of their key.
+type StoreUpgrades struct {
+	Added   []string      `json:"added"`
+	Renamed []StoreRename `json:"renamed"`
+	Deleted []string      `json:"deleted"`
+}
+
+// StoreRename is a store renamed from OldKey to NewKey.
+type StoreRename struct {
+	OldKey string `json:"old_key"`
+	NewKey string `json:"new_key"`
+}
+
+// IsAdded returns true if the store with the given name is added.
+func (s *StoreUpgrades) IsAdded(key string) bool {
+	if s == nil {
+		return false
+	}
+	for _, added := range s.Added {
+		if key == added {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeleted returns true if the store with the given name is deleted.
+func (s *StoreUpgrades) IsDeleted(key string) bool {
+	if s == nil {
+		return false
+	}
+	for _, deleted := range s.Deleted {
+		if key == deleted {
+			return true
+		}
+	}
+	return false
+}
+
+// RenamedFrom returns the old name of the store with the given name, or an
+// empty string if it is not renamed.
+func (s *StoreUpgrades) RenamedFrom(key string) string {
+	if s == nil {
+		return ""
+	}
+	for _, rename := range s.Renamed {
+		if rename.NewKey == key {
+			return rename.OldKey
+		}
+	}
+	return ""
 }
 
 //---------subsp-------------------------------
@@ -151,6 +239,21 @@ type CommitKVStore interface {
 	KVStore
 }
 
+// MultiStorePersistentCache provides inter-block (persistent) caching of the
+// CommitKVStores of a CommitMultiStore, by StoreKey.
+type MultiStorePersistentCache interface {
+	// Wrap the given store in a cache that persists across blocks, replacing
+	// any cache previously returned for the key.
+	GetStoreCache(key StoreKey, store CommitKVStore) CommitKVStore
+
+	// Return the store wrapped by the cache of the given key, or nil if
+	// there is none.
+	Unwrap(key StoreKey) CommitKVStore
+
+	// Drop all the caches.
+	Reset()
+}
+
 //----------------------------------------
 // CacheWrap
 
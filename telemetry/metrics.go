@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"sync"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics contains the metrics exposed by the node. The labels of the metrics
+// only take values from a set fixed by the application, e.g. the message
+// routes and types, the error codes or the store names, so that their
+// cardinality stays bounded.
+type Metrics struct {
+	// Number of txs processed, by mode (check, recheck, deliver) and result
+	// (ok, error).
+	Txs metrics.Counter
+	// Number of txs rejected by the ante handler, by mode, codespace and code.
+	AnteRejections metrics.Counter
+	// Number of messages delivered successfully, by message route and type.
+	Msgs metrics.Counter
+	// Gas used by the messages delivered successfully, by message route.
+	MsgGasUsed metrics.Counter
+	// Duration of the commits, in seconds.
+	CommitDuration metrics.Histogram
+	// Gas used by the module blockers of the last block, by module and blocker.
+	BlockerGasUsed metrics.Gauge
+	// Number of reads and writes of the stores, by store name.
+	StoreReads  metrics.Counter
+	StoreWrites metrics.Counter
+	// Size of the queues of the modules at the end of the last block, by
+	// module and queue.
+	QueueSize metrics.Gauge
+
+	// the store counters labelled with each store name
+	storeCounters sync.Map
+}
+
+type storeCounters struct {
+	reads, writes metrics.Counter
+}
+
+// StoreCounters returns the StoreReads and StoreWrites counters labelled with
+// the store name. They are labelled once per store and cached, as the stores
+// are wrapped on every access to them.
+func (m *Metrics) StoreCounters(name string) (reads, writes metrics.Counter) {
+	if counters, ok := m.storeCounters.Load(name); ok {
+		return counters.(storeCounters).reads, counters.(storeCounters).writes
+	}
+	counters := storeCounters{
+		reads:  m.StoreReads.With("store", name),
+		writes: m.StoreWrites.With("store", name),
+	}
+	m.storeCounters.Store(name, counters)
+	return counters.reads, counters.writes
+}
+
+// PrometheusMetrics returns Metrics registered on the default Prometheus
+// registry under the given namespace.
+func PrometheusMetrics(namespace string) *Metrics {
+	return &Metrics{
+		Txs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "baseapp",
+			Name:      "txs",
+			Help:      "Number of txs processed.",
+		}, []string{"mode", "result"}),
+		AnteRejections: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "baseapp",
+			Name:      "ante_rejections",
+			Help:      "Number of txs rejected by the ante handler.",
+		}, []string{"mode", "codespace", "code"}),
+		Msgs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "baseapp",
+			Name:      "msgs",
+			Help:      "Number of messages delivered.",
+		}, []string{"route", "type"}),
+		MsgGasUsed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "baseapp",
+			Name:      "msg_gas_used",
+			Help:      "Gas used by the messages delivered.",
+		}, []string{"route"}),
+		CommitDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "baseapp",
+			Name:      "commit_duration_seconds",
+			Help:      "Duration of the commits in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{}),
+		BlockerGasUsed: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "module",
+			Name:      "blocker_gas_used",
+			Help:      "Gas used by the module blockers of the last block.",
+		}, []string{"module", "blocker"}),
+		StoreReads: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "reads",
+			Help:      "Number of reads of the stores.",
+		}, []string{"store"}),
+		StoreWrites: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "writes",
+			Help:      "Number of writes of the stores.",
+		}, []string{"store"}),
+		QueueSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "module",
+			Name:      "queue_size",
+			Help:      "Size of the queues of the modules at the end of the last block.",
+		}, []string{"module", "queue"}),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Txs:            discard.NewCounter(),
+		AnteRejections: discard.NewCounter(),
+		Msgs:           discard.NewCounter(),
+		MsgGasUsed:     discard.NewCounter(),
+		CommitDuration: discard.NewHistogram(),
+		BlockerGasUsed: discard.NewGauge(),
+		StoreReads:     discard.NewCounter(),
+		StoreWrites:    discard.NewCounter(),
+		QueueSize:      discard.NewGauge(),
+	}
+}
@@ -0,0 +1,96 @@
+// Package telemetry exposes the metrics of a node on a Prometheus endpoint.
+//
+// The metrics are recorded through the package level Metrics returned by
+// Default, which are no-op until Enable is called, so that baseapp, the stores
+// and the keepers can record them unconditionally.
+package telemetry
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultNamespace is the default namespace of the metrics.
+const DefaultNamespace = "cosmos"
+
+// Config defines the telemetry configuration of a node.
+type Config struct {
+	// Enabled enables the recording of the metrics and the Prometheus endpoint.
+	Enabled bool `mapstructure:"enabled"`
+
+	// PrometheusListenAddr is the address the Prometheus endpoint listens on.
+	PrometheusListenAddr string `mapstructure:"prometheus-listen-addr"`
+
+	// Namespace is the namespace the metrics are registered under.
+	Namespace string `mapstructure:"namespace"`
+}
+
+// DefaultConfig returns the default telemetry configuration.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:              false,
+		PrometheusListenAddr: ":26661",
+		Namespace:            DefaultNamespace,
+	}
+}
+
+var (
+	mtx      sync.RWMutex
+	enabled  bool
+	defaults = NopMetrics()
+)
+
+// Default returns the metrics recorded by the node.
+func Default() *Metrics {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return defaults
+}
+
+// Enabled returns true if the metrics are recorded, which allows callers to
+// skip computing metrics that are expensive to collect.
+func Enabled() bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return enabled
+}
+
+// Enable registers the Prometheus metrics under the given namespace and starts
+// recording them. It must be called once, before the application is created.
+func Enable(namespace string) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if enabled {
+		panic("telemetry already enabled")
+	}
+	defaults = PrometheusMetrics(namespace)
+	enabled = true
+}
+
+// StartServer enables the metrics as configured and starts serving them on the
+// Prometheus endpoint. It returns a nil server if telemetry is disabled.
+func StartServer(cfg Config) (*http.Server, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.PrometheusListenAddr == "" {
+		return nil, errors.New("telemetry enabled without a prometheus listen address")
+	}
+
+	// listen first, so that an address in use is reported to the caller
+	ln, err := net.Listen("tcp", cfg.PrometheusListenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	Enable(cfg.Namespace)
+
+	srv := &http.Server{Addr: ln.Addr().String(), Handler: promhttp.Handler()}
+	go srv.Serve(ln) // nolint: errcheck
+	return srv, nil
+}
@@ -0,0 +1,49 @@
+package telemetry
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartServer(t *testing.T) {
+	// disabled telemetry records nothing
+	srv, err := StartServer(DefaultConfig())
+	require.NoError(t, err)
+	require.Nil(t, srv)
+	require.False(t, Enabled())
+	Default().Txs.With("mode", "deliver", "result", "ok").Add(1)
+
+	_, err = StartServer(Config{Enabled: true, Namespace: "test"})
+	require.Error(t, err)
+	require.False(t, Enabled())
+
+	srv, err = StartServer(Config{Enabled: true, PrometheusListenAddr: "127.0.0.1:0", Namespace: "test"})
+	require.NoError(t, err)
+	defer srv.Close()
+	require.True(t, Enabled())
+	require.Panics(t, func() { Enable("test") })
+
+	Default().Txs.With("mode", "deliver", "result", "ok").Add(2)
+	reads, writes := Default().StoreCounters("acc")
+	reads.Add(3)
+	writes.Add(1)
+	// the store counters are labelled once
+	readsAgain, writesAgain := Default().StoreCounters("acc")
+	require.True(t, reads == readsAgain)
+	require.True(t, writes == writesAgain)
+	Default().QueueSize.With("module", "gov", "queue", "active_proposal").Set(4)
+
+	res, err := http.Get("http://" + srv.Addr + "/metrics")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	require.Contains(t, string(body), `test_baseapp_txs{mode="deliver",result="ok"} 2`)
+	require.Contains(t, string(body), `test_store_reads{store="acc"} 3`)
+	require.Contains(t, string(body), `test_store_writes{store="acc"} 1`)
+	require.Contains(t, string(body), `test_module_queue_size{module="gov",queue="active_proposal"} 4`)
+}
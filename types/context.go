@@ -46,12 +46,15 @@ func NewContext(ms MultiStore, header abci.Header, isCheckTx bool, logger log.Lo
 	c = c.WithBlockHeight(header.Height)
 	c = c.WithChainID(header.ChainID)
 	c = c.WithIsCheckTx(isCheckTx)
+	c = c.WithIsReCheckTx(false)
 	c = c.WithTxBytes(nil)
 	c = c.WithLogger(logger)
 	c = c.WithVoteInfos(nil)
 	c = c.WithGasMeter(stypes.NewInfiniteGasMeter())
 	c = c.WithMinGasPrices(DecCoins{})
 	c = c.WithConsensusParams(nil)
+	c = c.WithGasSchedule(DefaultGasSchedule())
+	c = c.WithEventManager(NewEventManager())
 	return c
 }
 
@@ -77,12 +80,12 @@ func (c Context) Value(key interface{}) interface{} {
 
 // KVStore fetches a KVStore from the MultiStore.
 func (c Context) KVStore(key StoreKey) KVStore {
-	return gaskv.NewStore(c.MultiStore().GetKVStore(key), c.GasMeter(), stypes.KVGasConfig())
+	return gaskv.NewNamedStore(c.MultiStore().GetKVStore(key), c.GasMeter(), c.GasSchedule().KVStoreGasConfig(key.Name()), key.Name())
 }
 
 // TransientStore fetches a TransientStore from the MultiStore.
 func (c Context) TransientStore(key StoreKey) KVStore {
-	return gaskv.NewStore(c.MultiStore().GetKVStore(key), c.GasMeter(), stypes.TransientGasConfig())
+	return gaskv.NewNamedStore(c.MultiStore().GetKVStore(key), c.GasMeter(), c.GasSchedule().TransientStoreGasConfig(key.Name()), key.Name())
 }
 
 //----------------------------------------
@@ -139,6 +142,7 @@ const (
 	contextKeyBlockHeight
 	contextKeyChainID
 	contextKeyIsCheckTx
+	contextKeyIsReCheckTx
 	contextKeyTxBytes
 	contextKeyLogger
 	contextKeyVoteInfos
@@ -146,6 +150,8 @@ const (
 	contextKeyBlockGasMeter
 	contextKeyMinGasPrices
 	contextKeyConsensusParams
+	contextKeyGasSchedule
+	contextKeyEventManager
 )
 
 func (c Context) MultiStore() MultiStore {
@@ -172,12 +178,22 @@ func (c Context) BlockGasMeter() GasMeter { return c.Value(contextKeyBlockGasMet
 
 func (c Context) IsCheckTx() bool { return c.Value(contextKeyIsCheckTx).(bool) }
 
+// IsReCheckTx returns true if the tx is rechecked after a block was committed,
+// in which case it has already passed CheckTx once.
+func (c Context) IsReCheckTx() bool { return c.Value(contextKeyIsReCheckTx).(bool) }
+
 func (c Context) MinGasPrices() DecCoins { return c.Value(contextKeyMinGasPrices).(DecCoins) }
 
 func (c Context) ConsensusParams() *abci.ConsensusParams {
 	return c.Value(contextKeyConsensusParams).(*abci.ConsensusParams)
 }
 
+func (c Context) GasSchedule() GasSchedule { return c.Value(contextKeyGasSchedule).(GasSchedule) }
+
+func (c Context) EventManager() *EventManager {
+	return c.Value(contextKeyEventManager).(*EventManager)
+}
+
 func (c Context) WithMultiStore(ms MultiStore) Context {
 	return c.withValue(contextKeyMultiStore, ms)
 }
@@ -225,6 +241,15 @@ func (c Context) WithIsCheckTx(isCheckTx bool) Context {
 	return c.withValue(contextKeyIsCheckTx, isCheckTx)
 }
 
+// WithIsReCheckTx sets the recheck mode of the context. A recheck is always a
+// CheckTx as well, thus setting it also sets the check mode.
+func (c Context) WithIsReCheckTx(isReCheckTx bool) Context {
+	if isReCheckTx {
+		c = c.WithIsCheckTx(true)
+	}
+	return c.withValue(contextKeyIsReCheckTx, isReCheckTx)
+}
+
 func (c Context) WithMinGasPrices(gasPrices DecCoins) Context {
 	return c.withValue(contextKeyMinGasPrices, gasPrices)
 }
@@ -233,6 +258,14 @@ func (c Context) WithConsensusParams(params *abci.ConsensusParams) Context {
 	return c.withValue(contextKeyConsensusParams, params)
 }
 
+func (c Context) WithGasSchedule(schedule GasSchedule) Context {
+	return c.withValue(contextKeyGasSchedule, schedule)
+}
+
+func (c Context) WithEventManager(em *EventManager) Context {
+	return c.withValue(contextKeyEventManager, em)
+}
+
 // Cache the multistore and return a new cached context. The cached context is
 // written to the context when writeCache is called.
 func (c Context) CacheContext() (cc Context, writeCache func()) {
@@ -183,4 +183,10 @@ func TestContextWithCustom(t *testing.T) {
 	require.Equal(t, voteinfos, ctx.VoteInfos())
 	require.Equal(t, meter, ctx.GasMeter())
 	require.Equal(t, minGasPrices, ctx.MinGasPrices())
+	require.False(t, ctx.IsReCheckTx())
+
+	// a recheck is a check as well
+	ctx = types.NewContext(nil, header, false, logger).WithIsReCheckTx(true)
+	require.True(t, ctx.IsReCheckTx())
+	require.True(t, ctx.IsCheckTx())
 }
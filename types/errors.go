@@ -44,6 +44,9 @@ const (
 	CodeTooManySignatures CodeType = 15
 	CodeGasOverflow       CodeType = 16
 	CodeNoSignatures      CodeType = 17
+	CodeTxTimeoutHeight   CodeType = 18
+	CodeDuplicateTx       CodeType = 19
+	CodePrunedHeight      CodeType = 20
 
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
@@ -91,6 +94,12 @@ func CodeToDefaultMsg(code CodeType) string {
 		return "maximum numer of signatures exceeded"
 	case CodeNoSignatures:
 		return "no signatures supplied"
+	case CodeTxTimeoutHeight:
+		return "tx timeout height"
+	case CodeDuplicateTx:
+		return "duplicate tx"
+	case CodePrunedHeight:
+		return "height has been pruned"
 	default:
 		return unknownCodeMsg(code)
 	}
@@ -152,6 +161,15 @@ func ErrNoSignatures(msg string) Error {
 func ErrGasOverflow(msg string) Error {
 	return newErrorWithRootCodespace(CodeGasOverflow, msg)
 }
+func ErrTxTimeoutHeight(msg string) Error {
+	return newErrorWithRootCodespace(CodeTxTimeoutHeight, msg)
+}
+func ErrDuplicateTx(msg string) Error {
+	return newErrorWithRootCodespace(CodeDuplicateTx, msg)
+}
+func ErrPrunedHeight(msg string) Error {
+	return newErrorWithRootCodespace(CodePrunedHeight, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
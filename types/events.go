@@ -0,0 +1,154 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Event Manager
+// ----------------------------------------------------------------------------
+
+// EventManager collects the events emitted while running a message or a
+// block hook. It is shared by all the copies of the Context it is set on.
+type EventManager struct {
+	events Events
+}
+
+// NewEventManager creates a new EventManager object
+func NewEventManager() *EventManager {
+	return &EventManager{EmptyEvents()}
+}
+
+// Events returns the events emitted so far.
+func (em *EventManager) Events() Events { return em.events }
+
+// EmitEvent stores a single Event object.
+func (em *EventManager) EmitEvent(event Event) {
+	em.events = em.events.AppendEvent(event)
+}
+
+// EmitEvents stores a series of Event objects.
+func (em *EventManager) EmitEvents(events Events) {
+	em.events = em.events.AppendEvents(events)
+}
+
+// ----------------------------------------------------------------------------
+// Events
+// ----------------------------------------------------------------------------
+
+type (
+	// Attribute is a key/value pair describing an event.
+	Attribute struct {
+		Key   string `json:"key"`
+		Value string `json:"value,omitempty"`
+	}
+
+	// Event is a typed list of attributes, such as a transfer with its
+	// recipient and amount.
+	Event struct {
+		Type       string      `json:"type"`
+		Attributes []Attribute `json:"attributes,omitempty"`
+	}
+
+	// Events defines a slice of Event objects
+	Events []Event
+)
+
+// NewAttribute returns a new key/value Attribute object.
+func NewAttribute(k, v string) Attribute {
+	return Attribute{k, v}
+}
+
+func (a Attribute) String() string {
+	return fmt.Sprintf("%s: %s", a.Key, a.Value)
+}
+
+// NewEvent creates a new Event object with a given type and a slice of
+// attributes.
+func NewEvent(ty string, attrs ...Attribute) Event {
+	return Event{Type: ty, Attributes: attrs}
+}
+
+// AppendAttributes adds one or more attributes to an Event.
+func (e Event) AppendAttributes(attrs ...Attribute) Event {
+	e.Attributes = append(e.Attributes, attrs...)
+	return e
+}
+
+// ToTags flattens the event into tags keyed by "<type>.<attribute>", which
+// is how events are indexed and searched for by Tendermint.
+func (e Event) ToTags() Tags {
+	tags := make(Tags, 0, len(e.Attributes))
+	for _, attr := range e.Attributes {
+		tags = tags.AppendTag(e.Type+"."+attr.Key, attr.Value)
+	}
+	return tags
+}
+
+// EmptyEvents returns an empty slice of events.
+func EmptyEvents() Events {
+	return make(Events, 0)
+}
+
+// AppendEvent adds an Event to a slice of events.
+func (e Events) AppendEvent(event Event) Events {
+	return append(e, event)
+}
+
+// AppendEvents adds a slice of Event objects to an exist slice of Event objects.
+func (e Events) AppendEvents(events Events) Events {
+	return append(e, events...)
+}
+
+// ToTags flattens the events into tags keyed by "<type>.<attribute>".
+func (e Events) ToTags() Tags {
+	tags := EmptyTags()
+	for _, event := range e {
+		tags = tags.AppendTags(event.ToTags())
+	}
+	return tags
+}
+
+func (e Events) String() string {
+	var sb strings.Builder
+	for _, event := range e {
+		sb.WriteString(fmt.Sprintf("    - %s\n", event.Type))
+		for _, attr := range event.Attributes {
+			sb.WriteString(fmt.Sprintf("      - %s\n", attr.String()))
+		}
+	}
+	return sb.String()
+}
+
+// ----------------------------------------------------------------------------
+// Common event types and attributes
+// ----------------------------------------------------------------------------
+
+// The message event is emitted for every message of a transaction, with the
+// action of the message. Handlers emit another message event with the module
+// and the sender of the message.
+const (
+	EventTypeMessage = "message"
+
+	AttributeKeyAction = "action"
+	AttributeKeyModule = "module"
+	AttributeKeySender = "sender"
+	AttributeKeyAmount = "amount"
+)
+
+// The blocker gas event is returned by BeginBlock and EndBlock with the gas
+// consumed by the blocker of every module, keyed by module name. The blocker
+// panic event is returned for every module whose blocker panicked.
+const (
+	EventTypeBlockerGas   = "blocker_gas"
+	EventTypeBlockerPanic = "blocker_panic"
+)
+
+// The tx event is returned by CheckTx with the mempool priority and the sender
+// of a transaction.
+const (
+	EventTypeTx = "tx"
+
+	AttributeKeyPriority = "priority"
+)
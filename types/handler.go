@@ -6,3 +6,63 @@ type Handler func(ctx Context, msg Msg) Result
 // AnteHandler authenticates transactions, before their internal messages are handled.
 // If newCtx.IsZero(), ctx is used instead.
 type AnteHandler func(ctx Context, tx Tx, simulate bool) (newCtx Context, result Result, abort bool)
+
+// AnteDecorator performs one step of the authentication of transactions. It
+// either aborts, returning ctx, or calls next with a possibly updated context,
+// and may update the result returned by next, e.g. to set its GasWanted.
+type AnteDecorator interface {
+	AnteHandle(ctx Context, tx Tx, simulate bool, next AnteHandler) (newCtx Context, result Result, abort bool)
+}
+
+// ChainAnteDecorators returns an AnteHandler running the given decorators in
+// order, each of them wrapping the ones after it.
+func ChainAnteDecorators(chain ...AnteDecorator) AnteHandler {
+	if len(chain) == 0 {
+		return func(ctx Context, _ Tx, _ bool) (Context, Result, bool) {
+			return ctx, Result{}, false
+		}
+	}
+
+	next := ChainAnteDecorators(chain[1:]...)
+	return func(ctx Context, tx Tx, simulate bool) (Context, Result, bool) {
+		return chain[0].AnteHandle(ctx, tx, simulate, next)
+	}
+}
+
+// PostHandler runs after the messages of a transaction were handled
+// successfully, with the state changes and the result of the messages. A
+// failing result reverts the state changes of the messages, like a failing
+// message does. Post-handlers are not run during CheckTx.
+type PostHandler func(ctx Context, tx Tx, simulate bool, result Result) Result
+
+// PostDecorator performs one step of the post-processing of transactions. It
+// either fails or calls next, and may update the result returned by next.
+type PostDecorator interface {
+	PostHandle(ctx Context, tx Tx, simulate bool, result Result, next PostHandler) Result
+}
+
+// ChainPostDecorators returns a PostHandler running the given decorators in
+// order, each of them wrapping the ones after it.
+func ChainPostDecorators(chain ...PostDecorator) PostHandler {
+	if len(chain) == 0 {
+		return func(_ Context, _ Tx, _ bool, result Result) Result {
+			return result
+		}
+	}
+
+	next := ChainPostDecorators(chain[1:]...)
+	return func(ctx Context, tx Tx, simulate bool, result Result) Result {
+		return chain[0].PostHandle(ctx, tx, simulate, result, next)
+	}
+}
+
+// MsgAuthorizer checks that grantee was authorized by granter to execute msg on
+// granter's behalf, updating or consuming the authorization as needed.
+type MsgAuthorizer func(ctx Context, granter, grantee AccAddress, msg Msg) Error
+
+// GasScheduler returns the gas schedule of the block being processed in ctx.
+type GasScheduler func(ctx Context) GasSchedule
+
+// BlockGasCapper returns the block gas caps of the modules, by module name, for
+// the block being processed in ctx.
+type BlockGasCapper func(ctx Context) map[string]Gas
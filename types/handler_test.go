@@ -0,0 +1,108 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// testDecorator records its name when run, and aborts or fails if told to.
+type testDecorator struct {
+	name  string
+	calls *[]string
+	abort bool
+}
+
+func (d testDecorator) AnteHandle(
+	ctx types.Context, tx types.Tx, simulate bool, next types.AnteHandler,
+) (types.Context, types.Result, bool) {
+	*d.calls = append(*d.calls, d.name)
+	if d.abort {
+		return ctx, types.ErrUnauthorized(d.name).Result(), true
+	}
+
+	// each decorator passes an updated context to the next ones
+	newCtx, res, abort := next(ctx.WithChainID(ctx.ChainID()+d.name), tx, simulate)
+	res.Log += d.name
+	return newCtx, res, abort
+}
+
+func (d testDecorator) PostHandle(
+	ctx types.Context, tx types.Tx, simulate bool, result types.Result, next types.PostHandler,
+) types.Result {
+	*d.calls = append(*d.calls, d.name)
+	if d.abort {
+		return types.ErrUnauthorized(d.name).Result()
+	}
+
+	result.Log += d.name
+	return next(ctx, tx, simulate, result)
+}
+
+func TestChainAnteDecorators(t *testing.T) {
+	ctx := types.NewContext(nil, abci.Header{}, false, log.NewNopLogger())
+
+	// an empty chain accepts every tx
+	newCtx, res, abort := types.ChainAnteDecorators()(ctx, nil, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+	require.False(t, newCtx.IsZero())
+
+	var calls []string
+	handler := types.ChainAnteDecorators(
+		testDecorator{name: "a", calls: &calls},
+		testDecorator{name: "b", calls: &calls},
+		testDecorator{name: "c", calls: &calls},
+	)
+	newCtx, res, abort = handler(ctx, nil, false)
+	require.False(t, abort)
+	require.Equal(t, []string{"a", "b", "c"}, calls)
+	require.Equal(t, "abc", newCtx.ChainID())
+	require.Equal(t, "cba", res.Log)
+
+	// an aborting decorator stops the chain
+	calls = nil
+	handler = types.ChainAnteDecorators(
+		testDecorator{name: "a", calls: &calls},
+		testDecorator{name: "b", calls: &calls, abort: true},
+		testDecorator{name: "c", calls: &calls},
+	)
+	newCtx, res, abort = handler(ctx, nil, false)
+	require.True(t, abort)
+	require.Equal(t, types.CodeUnauthorized, res.Code)
+	require.Equal(t, []string{"a", "b"}, calls)
+	require.Equal(t, "a", newCtx.ChainID())
+}
+
+func TestChainPostDecorators(t *testing.T) {
+	ctx := types.NewContext(nil, abci.Header{}, false, log.NewNopLogger())
+
+	// an empty chain returns the result of the messages
+	res := types.ChainPostDecorators()(ctx, nil, false, types.Result{Log: "msgs"})
+	require.Equal(t, "msgs", res.Log)
+
+	var calls []string
+	handler := types.ChainPostDecorators(
+		testDecorator{name: "a", calls: &calls},
+		testDecorator{name: "b", calls: &calls},
+	)
+	res = handler(ctx, nil, false, types.Result{Log: "msgs"})
+	require.True(t, res.IsOK())
+	require.Equal(t, []string{"a", "b"}, calls)
+	require.Equal(t, "msgsab", res.Log)
+
+	// a failing decorator stops the chain
+	calls = nil
+	handler = types.ChainPostDecorators(
+		testDecorator{name: "a", calls: &calls, abort: true},
+		testDecorator{name: "b", calls: &calls},
+	)
+	res = handler(ctx, nil, false, types.Result{})
+	require.Equal(t, types.CodeUnauthorized, res.Code)
+	require.Equal(t, []string{"a"}, calls)
+}
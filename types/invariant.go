@@ -8,3 +8,8 @@ type Invariant func(ctx Context) error
 
 // Invariants defines a group of invariants
 type Invariants []Invariant
+
+// InvariantRouter registers the invariants of the modules of an application
+type InvariantRouter interface {
+	RegisterRoute(moduleName, route string, invar Invariant)
+}
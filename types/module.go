@@ -0,0 +1,443 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+/*
+The module interfaces let an application be assembled from its modules instead
+of wiring the keepers, routes, blockers and genesis of every module by hand.
+
+AppModuleBasic holds the elements of a module which don't depend on the other
+modules, so that the daemon and CLI can build the codec and the default
+genesis without instantiating the keepers. AppModule adds the elements that
+need the keepers, and the ModuleManager runs them in an explicit order:
+
+	mm := sdk.NewModuleManager(
+		auth.NewAppModule(accountKeeper, feeCollectionKeeper),
+		bank.NewAppModule(bankKeeper, accountKeeper),
+		...
+	)
+	mm.SetOrderBeginBlockers(mint.ModuleName, distr.ModuleName, ...)
+	mm.RegisterRoutes(app.Router(), app.MsgRouter(), app.QueryRouter())
+
+A module implementing AppModuleMsgHandlers registers a handler per message type
+on the MsgRouter instead of a handler for its route on the Router. The messages
+it declares without a handler, the handlers of messages it doesn't declare and
+the messages handled by two modules make RegisterRoutes panic, so that they are
+caught when the application is built rather than when the messages are
+delivered.
+
+The blockers of the modules are run one at a time, each with its own gas meter
+and cache-wrapped stores. A blocker that panics has its state changes and
+events discarded instead of halting the chain, and the gas consumed by every
+blocker is returned in the tags of the block. A module may be given a block gas
+cap, which its blockers read from the gas meter of the context to defer their
+remaining work to the next block once it is out of gas.
+*/
+
+// AppModuleBasic is the standard form for the basic elements of a module.
+type AppModuleBasic interface {
+	// Name returns the name of the module, which is also the key of its
+	// genesis state in the genesis of the application.
+	Name() string
+	RegisterCodec(*codec.Codec)
+
+	// DefaultGenesis returns the default genesis state of the module.
+	DefaultGenesis(cdc *codec.Codec) json.RawMessage
+	// ValidateGenesis performs the stateless validation of a genesis state
+	// of the module.
+	ValidateGenesis(cdc *codec.Codec, bz json.RawMessage) error
+}
+
+// ModuleBasicManager is a collection of the basic elements of the modules of
+// an application.
+type ModuleBasicManager []AppModuleBasic
+
+// NewModuleBasicManager creates a new ModuleBasicManager object
+func NewModuleBasicManager(modules ...AppModuleBasic) ModuleBasicManager {
+	return modules
+}
+
+// RegisterCodecs registers the types of all the modules on the codec.
+func (mbm ModuleBasicManager) RegisterCodecs(cdc *codec.Codec) {
+	for _, module := range mbm {
+		module.RegisterCodec(cdc)
+	}
+}
+
+// DefaultGenesis returns the default genesis states of all the modules, by
+// module name.
+func (mbm ModuleBasicManager) DefaultGenesis(cdc *codec.Codec) map[string]json.RawMessage {
+	genesis := make(map[string]json.RawMessage)
+	for _, module := range mbm {
+		genesis[module.Name()] = module.DefaultGenesis(cdc)
+	}
+	return genesis
+}
+
+// ValidateGenesis validates the genesis states of all the modules. The modules
+// missing from genesis are skipped, as they are given their default genesis
+// state.
+func (mbm ModuleBasicManager) ValidateGenesis(cdc *codec.Codec, genesis map[string]json.RawMessage) error {
+	for _, module := range mbm {
+		bz, ok := genesis[module.Name()]
+		if !ok {
+			continue
+		}
+		if err := module.ValidateGenesis(cdc, bz); err != nil {
+			return fmt.Errorf("invalid %s genesis state: %s", module.Name(), err)
+		}
+	}
+	return nil
+}
+
+// AppModuleGenesis is the standard form for the genesis functions of a module.
+type AppModuleGenesis interface {
+	AppModuleBasic
+
+	InitGenesis(ctx Context, cdc *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate
+	ExportGenesis(ctx Context, cdc *codec.Codec) json.RawMessage
+}
+
+// AppModule is the standard form for the elements of a module which depend on
+// its keeper. Route and QuerierRoute return an empty string for a module which
+// doesn't handle messages or queries.
+type AppModule interface {
+	AppModuleGenesis
+
+	RegisterInvariants(InvariantRouter)
+
+	Route() string
+	NewHandler() Handler
+	QuerierRoute() string
+	NewQuerierHandler() Querier
+
+	// BeginBlock and EndBlock emit their events on the EventManager of the
+	// context.
+	BeginBlock(Context, abci.RequestBeginBlock)
+	EndBlock(Context, abci.RequestEndBlock) []abci.ValidatorUpdate
+}
+
+// AppModuleMsgHandlers is implemented by the modules which route their
+// messages by concrete type.
+type AppModuleMsgHandlers interface {
+	// Msgs returns a value of each message type of the module.
+	Msgs() []Msg
+	// RegisterMsgHandlers registers the handler of each message type of the
+	// module.
+	RegisterMsgHandlers(MsgRouter)
+}
+
+// ModuleManager runs the modules of an application in the order set for each
+// of their functions, which defaults to the order the modules are given in.
+type ModuleManager struct {
+	Modules            map[string]AppModule
+	OrderInitGenesis   []string
+	OrderExportGenesis []string
+	OrderBeginBlockers []string
+	OrderEndBlockers   []string
+	BlockGasCaps       map[string]Gas
+
+	blockGasCapper BlockGasCapper // reads the block gas caps at the start of each block
+	blockCaps      map[string]Gas // block gas caps read for the current block
+}
+
+// NewModuleManager creates a new ModuleManager object
+func NewModuleManager(modules ...AppModule) *ModuleManager {
+	moduleMap := make(map[string]AppModule)
+	var names []string
+	for _, module := range modules {
+		if _, ok := moduleMap[module.Name()]; ok {
+			panic(fmt.Sprintf("duplicate module %s", module.Name()))
+		}
+		moduleMap[module.Name()] = module
+		names = append(names, module.Name())
+	}
+
+	return &ModuleManager{
+		Modules:            moduleMap,
+		OrderInitGenesis:   names,
+		OrderExportGenesis: names,
+		OrderBeginBlockers: names,
+		OrderEndBlockers:   names,
+		BlockGasCaps:       make(map[string]Gas),
+	}
+}
+
+// SetBlockGasCap sets the gas a module may consume in each of its BeginBlock
+// and EndBlock. The cap must be the same on every node, as the modules which
+// check it process less work in a block once it is reached.
+func (mm *ModuleManager) SetBlockGasCap(name string, gasCap Gas) {
+	if _, ok := mm.Modules[name]; !ok {
+		panic(fmt.Sprintf("unknown module %s", name))
+	}
+	mm.BlockGasCaps[name] = gasCap
+}
+
+// SetBlockGasCapper sets the function reading the block gas caps of the
+// modules at the start of each block, e.g. from params which governance can
+// change, so that every node applies the same caps. The caps it returns
+// override the ones set with SetBlockGasCap.
+func (mm *ModuleManager) SetBlockGasCapper(capper BlockGasCapper) {
+	mm.blockGasCapper = capper
+}
+
+// SetOrderInitGenesis sets the order in which the genesis states of the
+// modules are initialized.
+func (mm *ModuleManager) SetOrderInitGenesis(names ...string) {
+	mm.assertOrder("InitGenesis", names)
+	mm.OrderInitGenesis = names
+}
+
+// SetOrderExportGenesis sets the order in which the genesis states of the
+// modules are exported.
+func (mm *ModuleManager) SetOrderExportGenesis(names ...string) {
+	mm.assertOrder("ExportGenesis", names)
+	mm.OrderExportGenesis = names
+}
+
+// SetOrderBeginBlockers sets the order in which BeginBlock is run on the
+// modules.
+func (mm *ModuleManager) SetOrderBeginBlockers(names ...string) {
+	mm.assertOrder("BeginBlock", names)
+	mm.OrderBeginBlockers = names
+}
+
+// SetOrderEndBlockers sets the order in which EndBlock is run on the modules.
+func (mm *ModuleManager) SetOrderEndBlockers(names ...string) {
+	mm.assertOrder("EndBlock", names)
+	mm.OrderEndBlockers = names
+}
+
+// assertOrder panics unless the order names every module once, so that no
+// module is forgotten.
+func (mm *ModuleManager) assertOrder(function string, names []string) {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if _, ok := mm.Modules[name]; !ok {
+			panic(fmt.Sprintf("unknown module %s in the %s order", name, function))
+		}
+		if seen[name] {
+			panic(fmt.Sprintf("duplicate module %s in the %s order", name, function))
+		}
+		seen[name] = true
+	}
+	if len(seen) != len(mm.Modules) {
+		for name := range mm.Modules {
+			if !seen[name] {
+				panic(fmt.Sprintf("module %s missing from the %s order", name, function))
+			}
+		}
+	}
+}
+
+// RegisterInvariants registers the invariants of all the modules.
+func (mm *ModuleManager) RegisterInvariants(invarRouter InvariantRouter) {
+	for _, name := range mm.OrderInitGenesis {
+		mm.Modules[name].RegisterInvariants(invarRouter)
+	}
+}
+
+// RegisterRoutes registers the message and query routes of all the modules.
+// The messages of the modules implementing AppModuleMsgHandlers are registered
+// on the msgRouter.
+func (mm *ModuleManager) RegisterRoutes(router Router, msgRouter MsgRouter, queryRouter QueryRouter) {
+	for _, name := range mm.OrderInitGenesis {
+		module := mm.Modules[name]
+		if msgModule, ok := module.(AppModuleMsgHandlers); ok {
+			registerMsgHandlers(name, msgModule, msgRouter)
+		} else if module.Route() != "" {
+			router.AddRoute(module.Route(), module.NewHandler())
+		}
+		if module.QuerierRoute() != "" {
+			queryRouter.AddRoute(module.QuerierRoute(), module.NewQuerierHandler())
+		}
+	}
+}
+
+// registerMsgHandlers registers the message handlers of a module, and panics
+// unless they handle exactly the messages the module declares.
+func registerMsgHandlers(name string, module AppModuleMsgHandlers, msgRouter MsgRouter) {
+	declared := make(map[reflect.Type]bool)
+	for _, msg := range module.Msgs() {
+		declared[reflect.TypeOf(msg)] = true
+	}
+
+	rtr := &moduleMsgRouter{
+		MsgRouter:  msgRouter,
+		name:       name,
+		declared:   declared,
+		registered: make(map[reflect.Type]bool),
+	}
+	module.RegisterMsgHandlers(rtr)
+
+	for _, msg := range module.Msgs() {
+		if !rtr.registered[reflect.TypeOf(msg)] {
+			panic(fmt.Sprintf("message %T of module %s has no handler", msg, name))
+		}
+	}
+}
+
+// moduleMsgRouter records the message handlers registered by a module.
+type moduleMsgRouter struct {
+	MsgRouter
+	name       string
+	declared   map[reflect.Type]bool
+	registered map[reflect.Type]bool
+}
+
+func (rtr *moduleMsgRouter) AddMsgHandler(msg Msg, h Handler) MsgRouter {
+	if !rtr.declared[reflect.TypeOf(msg)] {
+		panic(fmt.Sprintf("module %s registered a handler for message %T, which it doesn't declare", rtr.name, msg))
+	}
+	rtr.MsgRouter.AddMsgHandler(msg, h)
+	rtr.registered[reflect.TypeOf(msg)] = true
+	return rtr
+}
+
+// InitGenesis initializes the genesis states of all the modules, using the
+// default genesis state of the modules missing from genesis. It returns the
+// initial validator set, which only one module may set.
+func (mm *ModuleManager) InitGenesis(ctx Context, cdc *codec.Codec, genesis map[string]json.RawMessage) []abci.ValidatorUpdate {
+	var validatorUpdates []abci.ValidatorUpdate
+	for _, name := range mm.OrderInitGenesis {
+		module := mm.Modules[name]
+		bz, ok := genesis[name]
+		if !ok {
+			bz = module.DefaultGenesis(cdc)
+		}
+
+		updates := module.InitGenesis(ctx, cdc, bz)
+		if len(updates) > 0 {
+			if len(validatorUpdates) > 0 {
+				panic(fmt.Sprintf("validator set updated by more than one module, including %s", name))
+			}
+			validatorUpdates = updates
+		}
+	}
+	return validatorUpdates
+}
+
+// ExportGenesis exports the genesis states of all the modules, by module name.
+func (mm *ModuleManager) ExportGenesis(ctx Context, cdc *codec.Codec) map[string]json.RawMessage {
+	genesis := make(map[string]json.RawMessage)
+	for _, name := range mm.OrderExportGenesis {
+		genesis[name] = mm.Modules[name].ExportGenesis(ctx, cdc)
+	}
+	return genesis
+}
+
+// BeginBlock runs BeginBlock on all the modules and returns their events and
+// the gas they consumed, flattened into tags.
+func (mm *ModuleManager) BeginBlock(ctx Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	ctx = ctx.WithEventManager(NewEventManager())
+	mm.blockCaps = nil
+	if mm.blockGasCapper != nil {
+		mm.blockCaps = mm.blockGasCapper(ctx.WithGasMeter(NewInfiniteGasMeter()))
+	}
+
+	gasEvent := NewEvent(EventTypeBlockerGas)
+	for _, name := range mm.OrderBeginBlockers {
+		module := mm.Modules[name]
+		gasUsed := mm.runBlocker(ctx, name, "BeginBlock", func(ctx Context) {
+			module.BeginBlock(ctx, req)
+		})
+		gasEvent = gasEvent.AppendAttributes(NewAttribute(name, strconv.FormatUint(gasUsed, 10)))
+	}
+	ctx.EventManager().EmitEvent(gasEvent)
+
+	return abci.ResponseBeginBlock{
+		Tags: ctx.EventManager().Events().ToTags().ToKVPairs(),
+	}
+}
+
+// EndBlock runs EndBlock on all the modules and returns their events and the
+// gas they consumed, flattened into tags, and the validator set updates, which
+// only one module may return.
+func (mm *ModuleManager) EndBlock(ctx Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+	ctx = ctx.WithEventManager(NewEventManager())
+	gasEvent := NewEvent(EventTypeBlockerGas)
+	var validatorUpdates []abci.ValidatorUpdate
+	for _, name := range mm.OrderEndBlockers {
+		module := mm.Modules[name]
+		var updates []abci.ValidatorUpdate
+		gasUsed := mm.runBlocker(ctx, name, "EndBlock", func(ctx Context) {
+			updates = module.EndBlock(ctx, req)
+		})
+		gasEvent = gasEvent.AppendAttributes(NewAttribute(name, strconv.FormatUint(gasUsed, 10)))
+
+		if len(updates) > 0 {
+			if len(validatorUpdates) > 0 {
+				panic(fmt.Sprintf("validator set updated by more than one module, including %s", name))
+			}
+			validatorUpdates = updates
+		}
+	}
+	ctx.EventManager().EmitEvent(gasEvent)
+
+	return abci.ResponseEndBlock{
+		ValidatorUpdates: validatorUpdates,
+		Tags:             ctx.EventManager().Events().ToTags().ToKVPairs(),
+	}
+}
+
+// runBlocker runs a blocker of a module with its own gas meter, limited by the
+// block gas cap of the module if any, and returns the gas it consumed. The
+// state changes and events of the blocker are only kept if it doesn't panic.
+func (mm *ModuleManager) runBlocker(ctx Context, name, blocker string, run func(Context)) (gasUsed Gas) {
+	var gasMeter GasMeter
+	if gasCap, ok := mm.blockGasCap(name); ok {
+		gasMeter = NewSoftGasMeter(gasCap)
+	} else {
+		gasMeter = NewInfiniteGasMeter()
+	}
+
+	blockerCtx, writeCache := ctx.WithGasMeter(gasMeter).WithEventManager(NewEventManager()).CacheContext()
+
+	defer func() {
+		gasUsed = gasMeter.GasConsumed()
+		telemetry.Default().BlockerGasUsed.With("module", name, "blocker", blocker).Set(float64(gasUsed))
+
+		if r := recover(); r != nil {
+			ctx.Logger().Error(
+				fmt.Sprintf("%s of module %s panicked, its state changes are discarded: %v", blocker, name, r),
+				"height", ctx.BlockHeight(), "stack", string(debug.Stack()),
+			)
+			ctx.EventManager().EmitEvent(NewEvent(EventTypeBlockerPanic, NewAttribute(AttributeKeyModule, name)))
+			return
+		}
+
+		if gasMeter.IsPastLimit() {
+			ctx.Logger().Info(
+				fmt.Sprintf("%s of module %s consumed %d gas, past its cap of %d", blocker, name, gasUsed, gasMeter.Limit()),
+				"height", ctx.BlockHeight(),
+			)
+		}
+
+		writeCache()
+		ctx.EventManager().EmitEvents(blockerCtx.EventManager().Events())
+	}()
+
+	run(blockerCtx)
+	return
+}
+
+// blockGasCap returns the block gas cap of a module for the current block, if
+// any.
+func (mm *ModuleManager) blockGasCap(name string) (Gas, bool) {
+	if gasCap, ok := mm.blockCaps[name]; ok {
+		return gasCap, true
+	}
+	gasCap, ok := mm.BlockGasCaps[name]
+	return gasCap, ok
+}
@@ -9,3 +9,31 @@ type ModuleClients interface {
 	GetQueryCmd() *cobra.Command
 	GetTxCmd() *cobra.Command
 }
+
+// ModuleClientManager is a collection of the clients of the modules of an
+// application, which adds their commands to the tx and query commands of the
+// CLI.
+type ModuleClientManager []ModuleClients
+
+// NewModuleClientManager creates a new ModuleClientManager object
+func NewModuleClientManager(clients ...ModuleClients) ModuleClientManager {
+	return clients
+}
+
+// AddTxCommands adds the tx commands of all the modules to the command.
+func (mcm ModuleClientManager) AddTxCommands(txCmd *cobra.Command) {
+	for _, client := range mcm {
+		if cmd := client.GetTxCmd(); cmd != nil {
+			txCmd.AddCommand(cmd)
+		}
+	}
+}
+
+// AddQueryCommands adds the query commands of all the modules to the command.
+func (mcm ModuleClientManager) AddQueryCommands(queryCmd *cobra.Command) {
+	for _, client := range mcm {
+		if cmd := client.GetQueryCmd(); cmd != nil {
+			queryCmd.AddCommand(cmd)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/cachemulti"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+)
+
+// mockModule records the calls made on it in a shared log.
+type mockModule struct {
+	name       string
+	route      string
+	log        *[]string
+	genesis    map[string]string
+	valUpdates []abci.ValidatorUpdate
+
+	// the blockers write to the store of key if any, then panic if panics
+	key    StoreKey
+	panics bool
+}
+
+func (m mockModule) Name() string                       { return m.name }
+func (m mockModule) RegisterCodec(*codec.Codec)         {}
+func (m mockModule) RegisterInvariants(InvariantRouter) {}
+
+func (m mockModule) DefaultGenesis(*codec.Codec) json.RawMessage {
+	return json.RawMessage(`"default"`)
+}
+
+func (m mockModule) ValidateGenesis(_ *codec.Codec, bz json.RawMessage) error {
+	if string(bz) == `"invalid"` {
+		return errors.New("invalid")
+	}
+	return nil
+}
+
+func (m mockModule) Route() string              { return m.route }
+func (m mockModule) NewHandler() Handler        { return nil }
+func (m mockModule) QuerierRoute() string       { return m.route }
+func (m mockModule) NewQuerierHandler() Querier { return nil }
+
+func (m mockModule) InitGenesis(_ Context, _ *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate {
+	*m.log = append(*m.log, "init "+m.name)
+	m.genesis[m.name] = string(bz)
+	return m.valUpdates
+}
+
+func (m mockModule) ExportGenesis(Context, *codec.Codec) json.RawMessage {
+	return json.RawMessage(`"` + m.name + `"`)
+}
+
+func (m mockModule) BeginBlock(ctx Context, _ abci.RequestBeginBlock) {
+	*m.log = append(*m.log, "begin "+m.name)
+	ctx.EventManager().EmitEvent(NewEvent("begin", NewAttribute(AttributeKeyModule, m.name)))
+	m.runBlocker(ctx, "begin")
+}
+
+func (m mockModule) EndBlock(ctx Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	*m.log = append(*m.log, "end "+m.name)
+	ctx.EventManager().EmitEvent(NewEvent("end", NewAttribute(AttributeKeyModule, m.name)))
+	m.runBlocker(ctx, "end")
+	return m.valUpdates
+}
+
+func (m mockModule) runBlocker(ctx Context, blocker string) {
+	if m.key != nil {
+		ctx.KVStore(m.key).Set([]byte(blocker+" "+m.name), []byte{1})
+	}
+	if m.panics {
+		panic(blocker + " " + m.name)
+	}
+}
+
+type mockRouter struct{ routes []string }
+
+func (r *mockRouter) AddRoute(route string, _ Handler) Router {
+	r.routes = append(r.routes, route)
+	return r
+}
+func (r *mockRouter) Route(string) Handler { return nil }
+
+type mockQueryRouter struct{ routes []string }
+
+func (r *mockQueryRouter) AddRoute(route string, _ Querier) QueryRouter {
+	r.routes = append(r.routes, route)
+	return r
+}
+func (r *mockQueryRouter) Route(string) Querier { return nil }
+
+func newMockModules(log *[]string, genesis map[string]string) (a, b, c mockModule) {
+	a = mockModule{name: "a", route: "a", log: log, genesis: genesis}
+	b = mockModule{name: "b", log: log, genesis: genesis}
+	c = mockModule{name: "c", route: "c", log: log, genesis: genesis}
+	return a, b, c
+}
+
+// newMockContext returns a context with a store mounted for key.
+func newMockContext(key StoreKey) Context {
+	stores := map[StoreKey]CacheWrapper{key: dbadapter.Store{DB: dbm.NewMemDB()}}
+	ms := cachemulti.NewStore(dbm.NewMemDB(), stores, nil, nil, nil, nil)
+	return NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+}
+
+func TestModuleBasicManager(t *testing.T) {
+	a, b, _ := newMockModules(nil, nil)
+	mbm := NewModuleBasicManager(a, b)
+
+	genesis := mbm.DefaultGenesis(nil)
+	require.Equal(t, map[string]json.RawMessage{
+		"a": json.RawMessage(`"default"`),
+		"b": json.RawMessage(`"default"`),
+	}, genesis)
+	require.NoError(t, mbm.ValidateGenesis(nil, genesis))
+
+	// the modules missing from genesis are not validated
+	require.NoError(t, mbm.ValidateGenesis(nil, map[string]json.RawMessage{"a": genesis["a"]}))
+	require.Error(t, mbm.ValidateGenesis(nil, map[string]json.RawMessage{"b": json.RawMessage(`"invalid"`)}))
+}
+
+func TestModuleManagerOrder(t *testing.T) {
+	var calls []string
+	a, b, c := newMockModules(&calls, map[string]string{})
+	require.Panics(t, func() { NewModuleManager(a, a) })
+
+	mm := NewModuleManager(a, b, c)
+	require.Equal(t, []string{"a", "b", "c"}, mm.OrderBeginBlockers)
+
+	// an order must name every module once
+	require.Panics(t, func() { mm.SetOrderBeginBlockers("a", "b") })
+	require.Panics(t, func() { mm.SetOrderBeginBlockers("a", "b", "b") })
+	require.Panics(t, func() { mm.SetOrderBeginBlockers("a", "b", "c", "d") })
+
+	mm.SetOrderBeginBlockers("c", "a", "b")
+	mm.SetOrderEndBlockers("b", "c", "a")
+	ctx := newMockContext(NewKVStoreKey("mock"))
+
+	// the events of the modules are returned as tags, followed by the gas
+	// consumed by every module
+	res := mm.BeginBlock(ctx, abci.RequestBeginBlock{})
+	require.Equal(t, []string{"begin c", "begin a", "begin b"}, calls)
+	require.Len(t, res.Tags, 6)
+	require.Equal(t, MakeTag("begin.module", "c"), res.Tags[0])
+	require.Equal(t, MakeTag("blocker_gas.c", "0"), res.Tags[3])
+
+	calls = nil
+	resEnd := mm.EndBlock(ctx, abci.RequestEndBlock{})
+	require.Equal(t, []string{"end b", "end c", "end a"}, calls)
+	require.Equal(t, MakeTag("end.module", "b"), resEnd.Tags[0])
+}
+
+func TestModuleManagerBlockers(t *testing.T) {
+	var calls []string
+	key := NewKVStoreKey("mock")
+	a, b, c := newMockModules(&calls, map[string]string{})
+	a.key, b.key, c.key = key, key, key
+	a.panics = true
+	c.valUpdates = []abci.ValidatorUpdate{{Power: 1}}
+	c.panics = true
+	mm := NewModuleManager(a, b, c)
+	ctx := newMockContext(key)
+
+	// the state changes and events of a panicking blocker are discarded
+	res := mm.BeginBlock(ctx, abci.RequestBeginBlock{})
+	require.Equal(t, []string{"begin a", "begin b", "begin c"}, calls)
+	require.False(t, ctx.KVStore(key).Has([]byte("begin a")))
+	require.True(t, ctx.KVStore(key).Has([]byte("begin b")))
+
+	tags := TagsToStringTags(res.Tags)
+	require.Contains(t, tags, StringTag{Key: "blocker_panic.module", Value: "a"})
+	require.Contains(t, tags, StringTag{Key: "begin.module", Value: "b"})
+	require.NotContains(t, tags, StringTag{Key: "begin.module", Value: "a"})
+
+	// the gas consumed by the panicking blockers is reported as well
+	for _, tag := range tags {
+		if tag.Key == "blocker_gas.a" || tag.Key == "blocker_gas.b" {
+			require.NotEqual(t, "0", tag.Value)
+		}
+	}
+
+	// the validator updates of a panicking blocker are discarded
+	resEnd := mm.EndBlock(ctx, abci.RequestEndBlock{})
+	require.Empty(t, resEnd.ValidatorUpdates)
+
+	// a block gas cap is set on the gas meter of the blockers of the module
+	var limit Gas
+	mm = NewModuleManager(capModule{mockModule: b, limit: &limit})
+	require.Panics(t, func() { mm.SetBlockGasCap("a", 100) })
+	mm.SetBlockGasCap("b", 100)
+	mm.BeginBlock(ctx, abci.RequestBeginBlock{})
+	require.Equal(t, Gas(100), limit)
+
+	// the caps read at the start of each block override the ones set
+	caps := map[string]Gas{"b": 50}
+	mm.SetBlockGasCapper(func(Context) map[string]Gas { return caps })
+	mm.BeginBlock(ctx, abci.RequestBeginBlock{})
+	require.Equal(t, Gas(50), limit)
+	caps = nil
+	mm.BeginBlock(ctx, abci.RequestBeginBlock{})
+	require.Equal(t, Gas(100), limit)
+}
+
+// capModule records the gas limit of its BeginBlock.
+type capModule struct {
+	mockModule
+	limit *Gas
+}
+
+func (m capModule) BeginBlock(ctx Context, req abci.RequestBeginBlock) {
+	*m.limit = ctx.GasMeter().Limit()
+	m.mockModule.BeginBlock(ctx, req)
+}
+
+func TestModuleManagerRoutes(t *testing.T) {
+	a, b, c := newMockModules(nil, nil)
+	mm := NewModuleManager(a, b, c)
+
+	// the modules without routes are skipped
+	router, queryRouter := &mockRouter{}, &mockQueryRouter{}
+	mm.RegisterRoutes(router, NewMsgRouter(), queryRouter)
+	require.Equal(t, []string{"a", "c"}, router.routes)
+	require.Equal(t, []string{"a", "c"}, queryRouter.routes)
+}
+
+func TestModuleManagerGenesis(t *testing.T) {
+	var calls []string
+	genesis := map[string]string{}
+	a, b, c := newMockModules(&calls, genesis)
+	updates := []abci.ValidatorUpdate{{Power: 1}}
+	b.valUpdates = updates
+	mm := NewModuleManager(a, b, c)
+	mm.SetOrderInitGenesis("b", "a", "c")
+
+	// the modules missing from genesis get their default genesis
+	res := mm.InitGenesis(Context{}, nil, map[string]json.RawMessage{"a": json.RawMessage(`"a"`)})
+	require.Equal(t, updates, res)
+	require.Equal(t, []string{"init b", "init a", "init c"}, calls)
+	require.Equal(t, map[string]string{"a": `"a"`, "b": `"default"`, "c": `"default"`}, genesis)
+
+	require.Equal(t, map[string]json.RawMessage{
+		"a": json.RawMessage(`"a"`),
+		"b": json.RawMessage(`"b"`),
+		"c": json.RawMessage(`"c"`),
+	}, mm.ExportGenesis(Context{}, nil))
+
+	// only one module may update the validator set
+	c.valUpdates = updates
+	mm = NewModuleManager(a, b, c)
+	require.Panics(t, func() { mm.InitGenesis(Context{}, nil, nil) })
+	require.Panics(t, func() { mm.EndBlock(NewContext(nil, abci.Header{}, false, log.NewNopLogger()), abci.RequestEndBlock{}) })
+}
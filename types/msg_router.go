@@ -0,0 +1,87 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// MsgInfo describes a message accepted by a MsgRouter, with the JSON schema of
+// its Amino JSON encoding.
+type MsgInfo struct {
+	Route  string          `json:"route"`
+	Type   string          `json:"type"`
+	Schema json.RawMessage `json:"schema"`
+}
+
+type msgRouter struct {
+	handlers map[reflect.Type]Handler
+	msgs     map[reflect.Type]Msg
+}
+
+var _ MsgRouter = (*msgRouter)(nil)
+
+// NewMsgRouter returns a new MsgRouter.
+func NewMsgRouter() MsgRouter {
+	return &msgRouter{
+		handlers: make(map[reflect.Type]Handler),
+		msgs:     make(map[reflect.Type]Msg),
+	}
+}
+
+// AddMsgHandler adds the handler of the concrete type of msg, which may only
+// have one.
+func (rtr *msgRouter) AddMsgHandler(msg Msg, h Handler) MsgRouter {
+	if msg == nil || h == nil {
+		panic("message handlers need a message and a handler")
+	}
+	rt := reflect.TypeOf(msg)
+	if rtr.handlers[rt] != nil {
+		panic(fmt.Sprintf("message %s has already been registered", rt))
+	}
+
+	rtr.handlers[rt] = h
+	rtr.msgs[rt] = msg
+	return rtr
+}
+
+// MsgHandler returns the handler of the concrete type of msg, if any.
+func (rtr *msgRouter) MsgHandler(msg Msg) Handler {
+	return rtr.handlers[reflect.TypeOf(msg)]
+}
+
+// Msgs implements the MsgRouter interface.
+func (rtr *msgRouter) Msgs() []MsgInfo {
+	infos := make([]MsgInfo, 0, len(rtr.msgs))
+	for _, msg := range rtr.msgs {
+		infos = append(infos, MsgInfo{
+			Route:  msg.Route(),
+			Type:   msg.Type(),
+			Schema: codec.JSONSchema(msg),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Route != infos[j].Route {
+			return infos[i].Route < infos[j].Route
+		}
+		return infos[i].Type < infos[j].Type
+	})
+	return infos
+}
+
+// NewMsgRouterHandler returns a handler passing the messages to their handler
+// on rtr, which rejects the messages without one.
+func NewMsgRouterHandler(rtr MsgRouter) Handler {
+	return func(ctx Context, msg Msg) Result {
+		handler := rtr.MsgHandler(msg)
+		if handler == nil {
+			errMsg := fmt.Sprintf("unrecognized %s message type: %T", msg.Route(), msg)
+			return ErrUnknownRequest(errMsg).Result()
+		}
+		return handler(ctx, msg)
+	}
+}
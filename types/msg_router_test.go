@@ -0,0 +1,99 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type routerMsg struct {
+	Amount Coins `json:"amount"`
+}
+
+func (routerMsg) Route() string            { return "router" }
+func (routerMsg) Type() string             { return "router" }
+func (routerMsg) GetSignBytes() []byte     { return nil }
+func (routerMsg) GetSigners() []AccAddress { return nil }
+func (routerMsg) ValidateBasic() Error     { return nil }
+
+func okHandler(data string) Handler {
+	return func(Context, Msg) Result { return Result{Data: []byte(data)} }
+}
+
+// msgModule declares msgs and registers a handler for the handled messages.
+type msgModule struct {
+	mockModule
+	msgs, handled []Msg
+}
+
+func (m msgModule) Msgs() []Msg { return m.msgs }
+
+func (m msgModule) RegisterMsgHandlers(rtr MsgRouter) {
+	for _, msg := range m.handled {
+		rtr.AddMsgHandler(msg, okHandler(m.name))
+	}
+}
+
+func TestMsgRouter(t *testing.T) {
+	rtr := NewMsgRouter()
+	rtr.AddMsgHandler(routerMsg{}, okHandler("router")).
+		AddMsgHandler(&TestMsg{}, okHandler("test"))
+
+	// the messages are routed by concrete type
+	require.Equal(t, []byte("router"), rtr.MsgHandler(routerMsg{})(Context{}, nil).Data)
+	require.Equal(t, []byte("test"), rtr.MsgHandler(NewTestMsg())(Context{}, nil).Data)
+	require.Nil(t, rtr.MsgHandler(&routerMsg{}))
+
+	// a message type has only one handler
+	require.Panics(t, func() { rtr.AddMsgHandler(routerMsg{}, okHandler("other")) })
+
+	// the messages are listed by route and type, with their schema
+	msgs := rtr.Msgs()
+	require.Len(t, msgs, 2)
+	require.Equal(t, "TestMsg", msgs[0].Route)
+	require.Equal(t, "router", msgs[1].Route)
+	require.Equal(t, "router", msgs[1].Type)
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {"amount": {"type": "array", "items": {
+			"type": "object",
+			"properties": {"denom": {"type": "string"}, "amount": {"type": "string"}},
+			"required": ["denom", "amount"]
+		}}},
+		"required": ["amount"]
+	}`, string(msgs[1].Schema))
+
+	// the router handler rejects the messages without a handler
+	handler := NewMsgRouterHandler(rtr)
+	require.Equal(t, []byte("router"), handler(Context{}, routerMsg{}).Data)
+	require.Equal(t, CodeUnknownRequest, handler(Context{}, &routerMsg{}).Code)
+}
+
+func TestModuleManagerMsgHandlers(t *testing.T) {
+	a, b, c := newMockModules(nil, nil)
+	msgA := msgModule{mockModule: a, msgs: []Msg{routerMsg{}}, handled: []Msg{routerMsg{}}}
+	mm := NewModuleManager(msgA, b, c)
+
+	// the messages of the modules routing them by type are not routed by route
+	router, msgRouter, queryRouter := &mockRouter{}, NewMsgRouter(), &mockQueryRouter{}
+	mm.RegisterRoutes(router, msgRouter, queryRouter)
+	require.Equal(t, []string{"c"}, router.routes)
+	require.Equal(t, []string{"a", "c"}, queryRouter.routes)
+	require.Equal(t, []byte("a"), msgRouter.MsgHandler(routerMsg{})(Context{}, nil).Data)
+
+	registerRoutes := func(modules ...AppModule) func() {
+		return func() {
+			NewModuleManager(modules...).RegisterRoutes(&mockRouter{}, NewMsgRouter(), &mockQueryRouter{})
+		}
+	}
+
+	// a declared message without a handler
+	require.Panics(t, registerRoutes(msgModule{mockModule: a, msgs: []Msg{routerMsg{}}}))
+
+	// a handler of an undeclared message
+	require.Panics(t, registerRoutes(msgModule{mockModule: a, handled: []Msg{routerMsg{}}}))
+
+	// a message handled by two modules
+	msgC := msgModule{mockModule: c, msgs: []Msg{routerMsg{}}, handled: []Msg{routerMsg{}}}
+	require.Panics(t, registerRoutes(msgA, msgC))
+}
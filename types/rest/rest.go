@@ -40,6 +40,7 @@ type BaseReq struct {
 	Gas           string       `json:"gas"`
 	GasAdjustment string       `json:"gas_adjustment"`
 	Simulate      bool         `json:"simulate"`
+	ShowDiff      bool         `json:"show_diff"`
 }
 
 // NewBaseReq creates a new basic request instance and sanitizes its values
@@ -64,10 +65,12 @@ func NewBaseReq(
 
 // Sanitize performs basic sanitization on a BaseReq object.
 func (br BaseReq) Sanitize() BaseReq {
-	return NewBaseReq(
+	sanitized := NewBaseReq(
 		br.From, br.Memo, br.ChainID, br.Gas, br.GasAdjustment,
 		br.AccountNumber, br.Sequence, br.Fees, br.GasPrices, br.Simulate,
 	)
+	sanitized.ShowDiff = br.ShowDiff
+	return sanitized
 }
 
 // ValidateBasic performs basic validation of a BaseReq. If custom validation
@@ -152,6 +155,27 @@ func WriteSimulationResponse(w http.ResponseWriter, cdc *codec.Codec, gas uint64
 	_, _ = w.Write(resp)
 }
 
+// SimulationDiffResponse defines a response definition for a tx simulation
+// along with its result and state changes.
+type SimulationDiffResponse struct {
+	GasEstimate uint64                 `json:"gas_estimate"`
+	Simulation  sdk.SimulationResponse `json:"simulation"`
+}
+
+// WriteSimulationDiffResponse prepares and writes an HTTP response for a tx
+// simulation along with its result and state changes.
+func WriteSimulationDiffResponse(w http.ResponseWriter, cdc *codec.Codec, gas uint64, res sdk.SimulationResponse) {
+	resp, err := cdc.MarshalJSON(SimulationDiffResponse{GasEstimate: gas, Simulation: res})
+	if err != nil {
+		WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
 // ParseInt64OrReturnBadRequest converts s to a int64 value.
 func ParseInt64OrReturnBadRequest(w http.ResponseWriter, s string) (n int64, ok bool) {
 	var err error
@@ -57,6 +57,18 @@ func TestBaseReqValidateBasic(t *testing.T) {
 	}
 }
 
+func TestBaseReqSanitize(t *testing.T) {
+	req := NewBaseReq(" cosmos1 ", " memo ", " chain ", "", "", 0, 0, nil, nil, true)
+	req.ShowDiff = true
+
+	sanitized := req.Sanitize()
+	require.Equal(t, "cosmos1", sanitized.From)
+	require.Equal(t, "memo", sanitized.Memo)
+	require.Equal(t, "chain", sanitized.ChainID)
+	require.True(t, sanitized.Simulate)
+	require.True(t, sanitized.ShowDiff)
+}
+
 func TestParseHTTPArgs(t *testing.T) {
 	req0 := mustNewRequest(t, "", "/", nil)
 	req1 := mustNewRequest(t, "", "/?limit=5", nil)
@@ -30,8 +30,18 @@ type Result struct {
 	// GasUsed is the amount of gas actually consumed. NOTE: unimplemented
 	GasUsed uint64
 
-	// Tags are used for transaction indexing and pubsub.
-	Tags Tags
+	// Events contains a slice of Event objects that were emitted during some
+	// execution. They are flattened into tags for transaction indexing and
+	// pubsub.
+	Events Events
+
+	// Priority is the priority of the tx in the mempool, set by the AnteHandler
+	// during CheckTx. Higher priority txs should be included in blocks first.
+	Priority int64
+
+	// Sender is the address of the account the tx originates from, set by the
+	// AnteHandler during CheckTx.
+	Sender string
 }
 
 // TODO: In the future, more codes may be OK.
@@ -47,6 +57,10 @@ type ABCIMessageLog struct {
 	MsgIndex int    `json:"msg_index"`
 	Success  bool   `json:"success"`
 	Log      string `json:"log"`
+
+	// Events contains the events emitted by the message, which tell the
+	// events of the different messages of a transaction apart.
+	Events Events `json:"events,omitempty"`
 }
 
 // String implements the fmt.Stringer interface for the ABCIMessageLogs type.
@@ -244,6 +258,76 @@ func (r TxResponse) Empty() bool {
 	return r.TxHash == "" && r.Logs == nil
 }
 
+// SimulationResponse defines a structure containing the result of a simulated
+// tx along with the changes it made to the state, sorted by store name and key.
+// The changes are computed on a cache which is discarded.
+type SimulationResponse struct {
+	Code      uint32          `json:"code,omitempty"`
+	Codespace string          `json:"codespace,omitempty"`
+	Data      []byte          `json:"data,omitempty"`
+	RawLog    string          `json:"raw_log,omitempty"`
+	Logs      ABCIMessageLogs `json:"logs,omitempty"`
+	GasWanted uint64          `json:"gas_wanted,omitempty"`
+	GasUsed   uint64          `json:"gas_used,omitempty"`
+	Events    Events          `json:"events,omitempty"`
+	Diff      []StoreDiff     `json:"diff,omitempty"`
+}
+
+// NewSimulationResponse returns a SimulationResponse given the result of a
+// simulated tx and the changes it made to the state.
+func NewSimulationResponse(res Result, diff []StoreDiff) SimulationResponse {
+	parsedLogs, _ := ParseABCILogs(res.Log)
+
+	return SimulationResponse{
+		Code:      uint32(res.Code),
+		Codespace: string(res.Codespace),
+		Data:      res.Data,
+		RawLog:    res.Log,
+		Logs:      parsedLogs,
+		GasWanted: res.GasWanted,
+		GasUsed:   res.GasUsed,
+		Events:    res.Events,
+		Diff:      diff,
+	}
+}
+
+func (r SimulationResponse) String() string {
+	var sb strings.Builder
+	sb.WriteString("Simulation:\n")
+
+	if r.Code > 0 {
+		sb.WriteString(fmt.Sprintf("  Code: %d\n", r.Code))
+	}
+
+	if r.Codespace != "" {
+		sb.WriteString(fmt.Sprintf("  Codespace: %s\n", r.Codespace))
+	}
+
+	if r.Data != nil {
+		sb.WriteString(fmt.Sprintf("  Data: %s\n", string(r.Data)))
+	}
+
+	if r.RawLog != "" {
+		sb.WriteString(fmt.Sprintf("  Raw Log: %s\n", r.RawLog))
+	}
+
+	sb.WriteString(fmt.Sprintf("  GasWanted: %d\n", r.GasWanted))
+	sb.WriteString(fmt.Sprintf("  GasUsed: %d\n", r.GasUsed))
+
+	if len(r.Events) > 0 {
+		sb.WriteString(fmt.Sprintf("  Events:\n%s", r.Events.String()))
+	}
+
+	if len(r.Diff) > 0 {
+		sb.WriteString("  Diff:\n")
+		for _, diff := range r.Diff {
+			sb.WriteString(fmt.Sprintf("    - %s/%X: %X -> %X\n", diff.StoreKey, diff.Key, diff.Before, diff.After))
+		}
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
 // ParseABCILogs attempts to parse a stringified ABCI tx log into a slice of
 // ABCIMessageLog types. It returns an error upon JSON decoding failure.
 func ParseABCILogs(logs string) (res ABCIMessageLogs, err error) {
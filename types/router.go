@@ -0,0 +1,23 @@
+package types
+
+// Router provides handlers for each transaction type.
+type Router interface {
+	AddRoute(r string, h Handler) (rtr Router)
+	Route(path string) (h Handler)
+}
+
+// QueryRouter provides queryables for each query path.
+type QueryRouter interface {
+	AddRoute(r string, h Querier) (rtr QueryRouter)
+	Route(path string) (h Querier)
+}
+
+// MsgRouter provides handlers for each concrete message type.
+type MsgRouter interface {
+	AddMsgHandler(msg Msg, h Handler) (rtr MsgRouter)
+	MsgHandler(msg Msg) (h Handler)
+
+	// Msgs describes the messages which have a handler, sorted by route and
+	// type.
+	Msgs() []MsgInfo
+}
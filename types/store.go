@@ -20,6 +20,11 @@ type (
 	MultiStore       = types.MultiStore
 	CacheMultiStore  = types.CacheMultiStore
 	CommitMultiStore = types.CommitMultiStore
+	StoreUpgrades    = types.StoreUpgrades
+	StoreRename      = types.StoreRename
+	StoreKVPair      = types.StoreKVPair
+	StoreDiff        = types.StoreDiff
+	WriteListener    = types.WriteListener
 	KVStore          = types.KVStore
 	Iterator         = types.Iterator
 )
@@ -110,7 +115,8 @@ type TraceContext = types.TraceContext
 type (
 	Gas       = types.Gas
 	GasMeter  = types.GasMeter
-	GasConfig = types.GasConfig
+	GasConfig   = types.GasConfig
+	GasSchedule = types.GasSchedule
 )
 
 // nolint - reexport
@@ -128,3 +134,13 @@ type (
 func NewInfiniteGasMeter() GasMeter {
 	return types.NewInfiniteGasMeter()
 }
+
+// nolint - reexport
+func NewSoftGasMeter(limit Gas) GasMeter {
+	return types.NewSoftGasMeter(limit)
+}
+
+// nolint - reexport
+func DefaultGasSchedule() GasSchedule {
+	return types.DefaultGasSchedule()
+}
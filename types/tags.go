@@ -66,18 +66,6 @@ func MakeTag(k string, v string) Tag {
 	return Tag{Key: []byte(k), Value: []byte(v)}
 }
 
-//__________________________________________________
-
-// common tags
-var (
-	TagAction       = "action"
-	TagCategory     = "category"
-	TagSender       = "sender"
-	TagSrcValidator = "source-validator"
-	TagDstValidator = "destination-validator"
-	TagDelegator    = "delegator"
-)
-
 // A KVPair where the Key and Value are both strings, rather than []byte
 type StringTag struct {
 	Key   string `json:"key"`
@@ -28,6 +28,17 @@ type Msg interface {
 	GetSigners() []AccAddress
 }
 
+// ExecMsg is a Msg wrapping other messages that its signer executes on behalf
+// of the signers of the wrapped messages. The BaseApp dispatches the wrapped
+// messages to their handlers once a MsgAuthorizer has checked the signer was
+// authorized to do so.
+type ExecMsg interface {
+	Msg
+
+	// GetExecMsgs returns the wrapped messages to execute.
+	GetExecMsgs() []Msg
+}
+
 //__________________________________________________________
 
 // Transactions objects must fulfill the Tx
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/multisig"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -29,124 +31,586 @@ func init() {
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
 // numbers, checks signatures & account numbers, and deducts fees from the first
-// signer.
+// signer. Transactions that specify a fee granter are rejected. During CheckTx,
+// the returned result holds the mempool priority and the sender of the
+// transaction, and the signatures verified already are not verified again on
+// recheck.
 func NewAnteHandler(ak AccountKeeper, fck FeeCollectionKeeper) sdk.AnteHandler {
-	return func(
-		ctx sdk.Context, tx sdk.Tx, simulate bool,
-	) (newCtx sdk.Context, res sdk.Result, abort bool) {
+	return NewAnteHandlerWithFeeGrants(ak, fck, nil)
+}
 
-		// all transactions must be of type auth.StdTx
-		stdTx, ok := tx.(StdTx)
-		if !ok {
-			// Set a gas meter with limit 0 as to prevent an infinite gas meter attack
-			// during runTx.
-			newCtx = SetGasMeter(simulate, ctx, 0)
-			return newCtx, sdk.ErrInternal("tx must be StdTx").Result(), true
-		}
+// NewAnteHandlerWithFeeGrants returns an AnteHandler that behaves like the one
+// returned by NewAnteHandler, except that if the transaction fee specifies a
+// granter, the fees are deducted from the granter's account after the fee
+// allowance granted to the first signer has been charged through fgk.
+func NewAnteHandlerWithFeeGrants(ak AccountKeeper, fck FeeCollectionKeeper, fgk FeeGrantKeeper) sdk.AnteHandler {
+	return sdk.ChainAnteDecorators(NewAnteDecorators(ak, fck, fgk)...)
+}
+
+// NewAnteDecorators returns the decorators of the AnteHandler returned by
+// NewAnteHandlerWithFeeGrants, in order. Applications adding their own checks
+// can chain them along with these decorators with sdk.ChainAnteDecorators.
+func NewAnteDecorators(ak AccountKeeper, fck FeeCollectionKeeper, fgk FeeGrantKeeper) []sdk.AnteDecorator {
+	return []sdk.AnteDecorator{
+		NewSetUpContextDecorator(), // must be first, it sets the gas meter of the transaction
+		NewMempoolFeeDecorator(),
+		NewValidateBasicDecorator(),
+		NewConsumeTxSizeGasDecorator(ak),
+		NewValidateMemoDecorator(ak),
+		NewValidateFeeDecorator(ak),
+		NewValidateTimeoutDecorator(),
+		NewUnorderedTxDecorator(ak),
+		NewTxPriorityDecorator(ak),
+		NewSignerAccountsDecorator(ak), // must precede the decorators reading and writing the signer accounts
+		NewDeductFeeDecorator(ak, fck, fgk),
+		NewSetPubKeyDecorator(ak),
+		NewSigVerificationDecorator(ak),
+		NewIncrementSequenceDecorator(ak),
+	}
+}
+
+// SetUpContextDecorator rejects the transactions which are not a StdTx and
+// sets the gas meter of the transaction. It recovers from running out of gas
+// in the next decorators, which is why it must be the first decorator, and
+// sets the GasWanted of the result.
+type SetUpContextDecorator struct{}
 
-		params := ak.GetParams(ctx)
+// NewSetUpContextDecorator returns a new SetUpContextDecorator.
+func NewSetUpContextDecorator() SetUpContextDecorator {
+	return SetUpContextDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (SetUpContextDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	// all transactions must be of type auth.StdTx
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		// Set a gas meter with limit 0 as to prevent an infinite gas meter attack
+		// during runTx.
+		newCtx = SetGasMeter(simulate, ctx, 0)
+		return newCtx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
 
-		// Ensure that the provided fees meet a minimum threshold for the validator,
-		// if this is a CheckTx. This is only for local mempool purposes, and thus
-		// is only ran on check tx.
-		if ctx.IsCheckTx() && !simulate {
-			res := EnsureSufficientMempoolFees(ctx, stdTx.Fee)
-			if !res.IsOK() {
-				return newCtx, res, true
+	newCtx = SetGasMeter(simulate, ctx, stdTx.Fee.Gas)
+
+	// AnteHandlers must have their own defer/recover in order for the BaseApp
+	// to know how much gas was used! This is because the GasMeter is created in
+	// the AnteHandler, but if it panics the context won't be set properly in
+	// runTx's recover call.
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				log := fmt.Sprintf(
+					"out of gas in location: %v; gasWanted: %d, gasUsed: %d",
+					rType.Descriptor, stdTx.Fee.Gas, newCtx.GasMeter().GasConsumed(),
+				)
+				res = sdk.ErrOutOfGas(log).Result()
+
+				res.GasWanted = stdTx.Fee.Gas
+				res.GasUsed = newCtx.GasMeter().GasConsumed()
+				abort = true
+			default:
+				panic(r)
 			}
 		}
+	}()
 
-		newCtx = SetGasMeter(simulate, ctx, stdTx.Fee.Gas)
-
-		// AnteHandlers must have their own defer/recover in order for the BaseApp
-		// to know how much gas was used! This is because the GasMeter is created in
-		// the AnteHandler, but if it panics the context won't be set properly in
-		// runTx's recover call.
-		defer func() {
-			if r := recover(); r != nil {
-				switch rType := r.(type) {
-				case sdk.ErrorOutOfGas:
-					log := fmt.Sprintf(
-						"out of gas in location: %v; gasWanted: %d, gasUsed: %d",
-						rType.Descriptor, stdTx.Fee.Gas, newCtx.GasMeter().GasConsumed(),
-					)
-					res = sdk.ErrOutOfGas(log).Result()
-
-					res.GasWanted = stdTx.Fee.Gas
-					res.GasUsed = newCtx.GasMeter().GasConsumed()
-					abort = true
-				default:
-					panic(r)
-				}
-			}
-		}()
+	newCtx, res, abort = next(newCtx, tx, simulate)
+	if !abort {
+		res.GasWanted = stdTx.Fee.Gas
+	}
+
+	return newCtx, res, abort
+}
+
+// MempoolFeeDecorator ensures that the fees of a transaction meet the minimum
+// gas prices of the node. This is only for local mempool purposes, and thus is
+// only ran on CheckTx.
+type MempoolFeeDecorator struct{}
 
-		if err := tx.ValidateBasic(); err != nil {
-			return newCtx, err.Result(), true
+// NewMempoolFeeDecorator returns a new MempoolFeeDecorator.
+func NewMempoolFeeDecorator() MempoolFeeDecorator {
+	return MempoolFeeDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (MempoolFeeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+
+	if ctx.IsCheckTx() && !simulate {
+		if res := EnsureSufficientMempoolFees(ctx, tx.(StdTx).Fee); !res.IsOK() {
+			return ctx, res, true
 		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// ValidateBasicDecorator runs the stateless checks of a transaction.
+type ValidateBasicDecorator struct{}
+
+// NewValidateBasicDecorator returns a new ValidateBasicDecorator.
+func NewValidateBasicDecorator() ValidateBasicDecorator {
+	return ValidateBasicDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (ValidateBasicDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+
+	if err := tx.ValidateBasic(); err != nil {
+		return ctx, err.Result(), true
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// ConsumeTxSizeGasDecorator consumes gas proportionally to the size of the
+// transaction.
+type ConsumeTxSizeGasDecorator struct {
+	ak AccountKeeper
+}
+
+// NewConsumeTxSizeGasDecorator returns a new ConsumeTxSizeGasDecorator.
+func NewConsumeTxSizeGasDecorator(ak AccountKeeper) ConsumeTxSizeGasDecorator {
+	return ConsumeTxSizeGasDecorator{ak: ak}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d ConsumeTxSizeGasDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+
+	params := getParams(ctx, d.ak)
+	ctx.GasMeter().ConsumeGas(params.TxSizeCostPerByte*sdk.Gas(len(ctx.TxBytes())), "txSize")
+
+	return next(ctx, tx, simulate)
+}
+
+// ValidateMemoDecorator validates the size of the memo of a transaction.
+type ValidateMemoDecorator struct {
+	ak AccountKeeper
+}
+
+// NewValidateMemoDecorator returns a new ValidateMemoDecorator.
+func NewValidateMemoDecorator(ak AccountKeeper) ValidateMemoDecorator {
+	return ValidateMemoDecorator{ak: ak}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d ValidateMemoDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
 
-		newCtx.GasMeter().ConsumeGas(params.TxSizeCostPerByte*sdk.Gas(len(newCtx.TxBytes())), "txSize")
+	if res := ValidateMemo(tx.(StdTx), getParams(ctx, d.ak)); !res.IsOK() {
+		return ctx, res, true
+	}
+
+	return next(ctx, tx, simulate)
+}
 
-		if res := ValidateMemo(stdTx, params); !res.IsOK() {
-			return newCtx, res, true
+// ValidateFeeDecorator enforces the on-chain fee policy. The policy is
+// consensus state and thus applies to both CheckTx and DeliverTx.
+type ValidateFeeDecorator struct {
+	ak AccountKeeper
+}
+
+// NewValidateFeeDecorator returns a new ValidateFeeDecorator.
+func NewValidateFeeDecorator(ak AccountKeeper) ValidateFeeDecorator {
+	return ValidateFeeDecorator{ak: ak}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d ValidateFeeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+
+	if !simulate && ctx.BlockHeight() != 0 {
+		if res := EnsureSufficientFees(tx.(StdTx).Fee, getParams(ctx, d.ak)); !res.IsOK() {
+			return ctx, res, true
 		}
+	}
+
+	return next(ctx, tx, simulate)
+}
 
-		// stdSigs contains the sequence number, account number, and signatures.
-		// When simulating, this would just be a 0-length slice.
-		signerAddrs := stdTx.GetSigners()
-		signerAccs := make([]Account, len(signerAddrs))
-		isGenesis := ctx.BlockHeight() == 0
+// ValidateTimeoutDecorator rejects the transactions which timed out.
+type ValidateTimeoutDecorator struct{}
 
-		// fetch first signer, who's going to pay the fees
-		signerAccs[0], res = GetSignerAcc(newCtx, ak, signerAddrs[0])
+// NewValidateTimeoutDecorator returns a new ValidateTimeoutDecorator.
+func NewValidateTimeoutDecorator() ValidateTimeoutDecorator {
+	return ValidateTimeoutDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (ValidateTimeoutDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+
+	if res := ValidateTimeout(ctx, tx.(StdTx)); !res.IsOK() {
+		return ctx, res, true
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// UnorderedTxDecorator rejects the unordered transactions processed already
+// and records the others.
+type UnorderedTxDecorator struct {
+	ak AccountKeeper
+}
+
+// NewUnorderedTxDecorator returns a new UnorderedTxDecorator.
+func NewUnorderedTxDecorator(ak AccountKeeper) UnorderedTxDecorator {
+	return UnorderedTxDecorator{ak: ak}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d UnorderedTxDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+
+	if stdTx := tx.(StdTx); stdTx.Unordered {
+		if res := CheckUnorderedTx(ctx, d.ak, stdTx); !res.IsOK() {
+			return ctx, res, true
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// TxPriorityDecorator sets the mempool priority and the sender of the
+// transaction in the result of the next decorators during CheckTx.
+type TxPriorityDecorator struct {
+	ak AccountKeeper
+}
+
+// NewTxPriorityDecorator returns a new TxPriorityDecorator.
+func NewTxPriorityDecorator(ak AccountKeeper) TxPriorityDecorator {
+	return TxPriorityDecorator{ak: ak}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d TxPriorityDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	newCtx, res, abort = next(ctx, tx, simulate)
+	if abort || !ctx.IsCheckTx() {
+		return newCtx, res, abort
+	}
+
+	stdTx := tx.(StdTx)
+	res.Priority = GetTxPriority(ctx, stdTx.Fee, getParams(ctx, d.ak))
+	res.Sender = stdTx.GetSigners()[0].String()
+
+	return newCtx, res, abort
+}
+
+// SignerAccountsDecorator shares the accounts of the signers between the next
+// decorators: GetSignerAcc reads each account once, and the accounts set with
+// SetSignerAcc are written once, after the next decorators succeeded.
+type SignerAccountsDecorator struct {
+	ak AccountKeeper
+}
+
+// NewSignerAccountsDecorator returns a new SignerAccountsDecorator.
+func NewSignerAccountsDecorator(ak AccountKeeper) SignerAccountsDecorator {
+	return SignerAccountsDecorator{ak: ak}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d SignerAccountsDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	accs := &signerAccs{accs: make(map[string]Account)}
+	newCtx, res, abort = next(ctx.WithValue(contextKeySignerAccs, accs), tx, simulate)
+	if abort {
+		return newCtx, res, abort
+	}
+
+	accs.write(ctx, d.ak)
+	return newCtx, res, abort
+}
+
+type contextKey int // local to the auth module
+
+const contextKeySignerAccs contextKey = iota
+
+// signerAccs holds the signer accounts shared by the decorators following a
+// SignerAccountsDecorator, and the addresses of the ones to write, in the
+// order they were first set.
+type signerAccs struct {
+	accs    map[string]Account
+	updated []sdk.AccAddress
+	written bool
+}
+
+func getSignerAccs(ctx sdk.Context) *signerAccs {
+	accs, _ := ctx.Value(contextKeySignerAccs).(*signerAccs)
+	if accs == nil || accs.written {
+		return nil
+	}
+	return accs
+}
+
+func (sa *signerAccs) set(acc Account) {
+	addr := acc.GetAddress()
+	if !sa.isUpdated(addr) {
+		sa.updated = append(sa.updated, addr)
+	}
+	sa.accs[string(addr)] = acc
+}
+
+func (sa *signerAccs) isUpdated(addr sdk.AccAddress) bool {
+	for _, updated := range sa.updated {
+		if updated.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// write writes the updated accounts. The accounts are no longer shared
+// afterwards, so that the state read by the next handlers is up to date.
+func (sa *signerAccs) write(ctx sdk.Context, ak AccountKeeper) {
+	for _, addr := range sa.updated {
+		ak.SetAccount(ctx, sa.accs[string(addr)])
+	}
+	sa.written = true
+}
+
+// DeductFeeDecorator deducts the fees of a transaction from the first signer,
+// or from the fee granter if any, and adds them to the collected fees.
+type DeductFeeDecorator struct {
+	ak  AccountKeeper
+	fck FeeCollectionKeeper
+	fgk FeeGrantKeeper
+}
+
+// NewDeductFeeDecorator returns a new DeductFeeDecorator. Transactions that
+// specify a fee granter are rejected if fgk is nil.
+func NewDeductFeeDecorator(ak AccountKeeper, fck FeeCollectionKeeper, fgk FeeGrantKeeper) DeductFeeDecorator {
+	return DeductFeeDecorator{ak: ak, fck: fck, fgk: fgk}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d DeductFeeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+
+	stdTx := tx.(StdTx)
+	feePayer := stdTx.GetSigners()[0]
+
+	// the fee payer must exist, even if a granter pays the fees
+	feePayerAcc, res := GetSignerAcc(ctx, d.ak, feePayer)
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+
+	if !stdTx.Fee.Granter.Empty() {
+		if res := DeductGrantedFees(ctx, d.ak, d.fgk, feePayer, stdTx.Fee); !res.IsOK() {
+			return ctx, res, true
+		}
+
+		d.fck.AddCollectedFees(ctx, stdTx.Fee.Amount)
+	} else if !stdTx.Fee.Amount.IsZero() {
+		feePayerAcc, res = DeductFees(ctx.BlockHeader().Time, feePayerAcc, stdTx.Fee)
 		if !res.IsOK() {
-			return newCtx, res, true
+			return ctx, res, true
 		}
 
-		if !stdTx.Fee.Amount.IsZero() {
-			signerAccs[0], res = DeductFees(ctx.BlockHeader().Time, signerAccs[0], stdTx.Fee)
-			if !res.IsOK() {
-				return newCtx, res, true
-			}
+		SetSignerAcc(ctx, d.ak, feePayerAcc)
+		d.fck.AddCollectedFees(ctx, stdTx.Fee.Amount)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// SetPubKeyDecorator sets the public keys of the signers which don't have one
+// yet from the signatures, checking that they match their addresses.
+type SetPubKeyDecorator struct {
+	ak AccountKeeper
+}
+
+// NewSetPubKeyDecorator returns a new SetPubKeyDecorator.
+func NewSetPubKeyDecorator(ak AccountKeeper) SetPubKeyDecorator {
+	return SetPubKeyDecorator{ak: ak}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d SetPubKeyDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
 
-			fck.AddCollectedFees(newCtx, stdTx.Fee.Amount)
+	stdTx := tx.(StdTx)
+	signerAddrs := stdTx.GetSigners()
+
+	// stdSigs contains the sequence number, account number, and signatures.
+	// When simulating, this would just be a 0-length slice.
+	for i, sig := range stdTx.GetSignatures() {
+		acc, res := GetSignerAcc(ctx, d.ak, signerAddrs[i])
+		if !res.IsOK() {
+			return ctx, res, true
 		}
 
-		// stdSigs contains the sequence number, account number, and signatures.
-		// When simulating, this would just be a 0-length slice.
-		stdSigs := stdTx.GetSignatures()
-
-		for i := 0; i < len(stdSigs); i++ {
-			// skip the fee payer, account is cached and fees were deducted already
-			if i != 0 {
-				signerAccs[i], res = GetSignerAcc(newCtx, ak, signerAddrs[i])
-				if !res.IsOK() {
-					return newCtx, res, true
-				}
-			}
+		pubKey, res := ProcessPubKey(acc, sig, simulate)
+		if !res.IsOK() {
+			return ctx, res, true
+		}
 
-			// check signature, return account with incremented nonce
-			signBytes := GetSignBytes(newCtx.ChainID(), stdTx, signerAccs[i], isGenesis)
-			signerAccs[i], res = processSig(newCtx, signerAccs[i], stdSigs[i], signBytes, simulate, params)
-			if !res.IsOK() {
-				return newCtx, res, true
-			}
+		if acc.GetPubKey() != nil {
+			continue
+		}
+
+		// when simulating, the simulation key is set so that the gas consumed
+		// matches a transaction setting the public key
+		if err := acc.SetPubKey(pubKey); err != nil {
+			return ctx, sdk.ErrInternal("setting PubKey on signer's account").Result(), true
+		}
+
+		SetSignerAcc(ctx, d.ak, acc)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// SigVerificationDecorator verifies the signatures of a transaction against
+// the account numbers and sequences of the signers, consuming gas depending on
+// the public key types. It must run after the SetPubKeyDecorator. The
+// signatures verified during CheckTx are cached and trusted when rechecking.
+type SigVerificationDecorator struct {
+	ak       AccountKeeper
+	sigCache *sigCache
+}
+
+// NewSigVerificationDecorator returns a new SigVerificationDecorator.
+func NewSigVerificationDecorator(ak AccountKeeper) SigVerificationDecorator {
+	return SigVerificationDecorator{ak: ak, sigCache: newSigCache()}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d SigVerificationDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+
+	stdTx := tx.(StdTx)
+	params := getParams(ctx, d.ak)
+	signerAddrs := stdTx.GetSigners()
+	isGenesis := ctx.BlockHeight() == 0
+
+	for i, sig := range stdTx.GetSignatures() {
+		acc, res := GetSignerAcc(ctx, d.ak, signerAddrs[i])
+		if !res.IsOK() {
+			return ctx, res, true
+		}
+
+		pubKey := acc.GetPubKey()
+		if simulate {
+			// Simulated txs should not contain a signature and are not required to
+			// contain a pubkey, so we must account for tx size of including a
+			// StdSignature (Amino encoding) and simulate gas consumption
+			// (assuming a SECP256k1 simulation key).
+			consumeSimSigGas(ctx.GasMeter(), pubKey, sig, params)
+		}
+
+		if res := consumeSigVerificationGas(ctx.GasMeter(), sig.Signature, pubKey, params); !res.IsOK() {
+			return ctx, res, true
+		}
+
+		if simulate {
+			continue
+		}
+
+		signBytes := GetSignBytes(ctx.ChainID(), stdTx, acc, isGenesis)
+		if res := verifySig(ctx, pubKey, sig, signBytes, d.sigCache); !res.IsOK() {
+			return ctx, res, true
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// IncrementSequenceDecorator increments the sequences of the signers of a
+// transaction, unless the transaction is unordered.
+type IncrementSequenceDecorator struct {
+	ak AccountKeeper
+}
+
+// NewIncrementSequenceDecorator returns a new IncrementSequenceDecorator.
+func NewIncrementSequenceDecorator(ak AccountKeeper) IncrementSequenceDecorator {
+	return IncrementSequenceDecorator{ak: ak}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d IncrementSequenceDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+
+	stdTx := tx.(StdTx)
+	if stdTx.Unordered {
+		return next(ctx, tx, simulate)
+	}
+
+	for _, addr := range stdTx.GetSigners() {
+		acc, res := GetSignerAcc(ctx, d.ak, addr)
+		if !res.IsOK() {
+			return ctx, res, true
+		}
 
-			ak.SetAccount(newCtx, signerAccs[i])
+		if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+			panic(err)
 		}
 
-		// TODO: tx tags (?)
-		return newCtx, sdk.Result{GasWanted: stdTx.Fee.Gas}, false // continue...
+		SetSignerAcc(ctx, d.ak, acc)
 	}
+
+	return next(ctx, tx, simulate)
 }
 
 // GetSignerAcc returns an account for a given address that is expected to sign
-// a transaction.
+// a transaction. After a SignerAccountsDecorator, the account is only read
+// once, the next calls returning the account last set with SetSignerAcc.
 func GetSignerAcc(ctx sdk.Context, ak AccountKeeper, addr sdk.AccAddress) (Account, sdk.Result) {
-	if acc := ak.GetAccount(ctx, addr); acc != nil {
-		return acc, sdk.Result{}
+	accs := getSignerAccs(ctx)
+	if accs != nil {
+		if acc, ok := accs.accs[string(addr)]; ok {
+			return acc, sdk.Result{}
+		}
+	}
+
+	acc := ak.GetAccount(ctx, addr)
+	if acc == nil {
+		return nil, sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr)).Result()
 	}
-	return nil, sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr)).Result()
+	if accs != nil {
+		accs.accs[string(addr)] = acc
+	}
+	return acc, sdk.Result{}
+}
+
+// SetSignerAcc sets an account returned by GetSignerAcc. After a
+// SignerAccountsDecorator, the account is written once the decorators
+// succeeded, however many times it is set.
+func SetSignerAcc(ctx sdk.Context, ak AccountKeeper, acc Account) {
+	if accs := getSignerAccs(ctx); accs != nil {
+		accs.set(acc)
+		return
+	}
+	ak.SetAccount(ctx, acc)
+}
+
+// getParams returns the auth params. Reading them is not charged to the
+// transaction, as every transaction reads them, several times.
+func getParams(ctx sdk.Context, ak AccountKeeper) Params {
+	return ak.GetParams(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()))
 }
 
 // ValidateMemo validates the memo size.
@@ -164,43 +628,42 @@ func ValidateMemo(stdTx StdTx, params Params) sdk.Result {
 	return sdk.Result{}
 }
 
-// verify the signature and increment the sequence. If the account doesn't have
-// a pubkey, set it.
-func processSig(
-	ctx sdk.Context, acc Account, sig StdSignature, signBytes []byte, simulate bool, params Params,
-) (updatedAcc Account, res sdk.Result) {
+// CheckUnorderedTx rejects an unordered transaction that has already been
+// processed and records it otherwise. Transactions are identified by the hash
+// of their sign bytes, so that resubmitting a transaction with differently
+// encoded signatures is detected as well.
+func CheckUnorderedTx(ctx sdk.Context, ak AccountKeeper, stdTx StdTx) sdk.Result {
+	signBytes := StdSignBytes(
+		ctx.ChainID(), 0, 0, stdTx.TimeoutHeight, stdTx.Unordered, stdTx.Fee, stdTx.Msgs, stdTx.Memo,
+	)
+	txHash := tmhash.Sum(signBytes)
 
-	pubKey, res := ProcessPubKey(acc, sig, simulate)
-	if !res.IsOK() {
-		return nil, res
+	if ak.ContainsUnorderedTx(ctx, stdTx.TimeoutHeight, txHash) {
+		return sdk.ErrDuplicateTx(fmt.Sprintf("unordered tx %X has already been processed", txHash)).Result()
 	}
 
-	err := acc.SetPubKey(pubKey)
-	if err != nil {
-		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
-	}
+	ak.AddUnorderedTx(ctx, stdTx.TimeoutHeight, txHash)
+	return sdk.Result{}
+}
 
-	if simulate {
-		// Simulated txs should not contain a signature and are not required to
-		// contain a pubkey, so we must account for tx size of including a
-		// StdSignature (Amino encoding) and simulate gas consumption
-		// (assuming a SECP256k1 simulation key).
-		consumeSimSigGas(ctx.GasMeter(), pubKey, sig, params)
-	}
+// verifySig verifies a signature of the given sign bytes. The signatures
+// verified during CheckTx are cached and trusted when rechecking.
+func verifySig(
+	ctx sdk.Context, pubKey crypto.PubKey, sig StdSignature, signBytes []byte, sigCache *sigCache,
+) sdk.Result {
 
-	if res := consumeSigVerificationGas(ctx.GasMeter(), sig.Signature, pubKey, params); !res.IsOK() {
-		return nil, res
-	}
+	switch {
+	case ctx.IsReCheckTx() && sigCache.has(pubKey, signBytes, sig.Signature):
+		// verified when the transaction was checked first
 
-	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
-		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
-	}
+	case !pubKey.VerifyBytes(signBytes, sig.Signature):
+		return sdk.ErrUnauthorized("signature verification failed").Result()
 
-	if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
-		panic(err)
+	case ctx.IsCheckTx():
+		sigCache.add(pubKey, signBytes, sig.Signature)
 	}
 
-	return acc, res
+	return sdk.Result{}
 }
 
 func consumeSimSigGas(gasmeter sdk.GasMeter, pubkey crypto.PubKey, sig StdSignature, params Params) {
@@ -337,6 +800,40 @@ func DeductFees(blockTime time.Time, acc Account, fee StdFee) (Account, sdk.Resu
 	return acc, sdk.Result{}
 }
 
+// DeductGrantedFees charges the fee allowance the fee granter has issued to the
+// grantee and deducts the fees from the granter's account.
+func DeductGrantedFees(
+	ctx sdk.Context, ak AccountKeeper, fgk FeeGrantKeeper, grantee sdk.AccAddress, fee StdFee,
+) sdk.Result {
+
+	if fgk == nil {
+		return sdk.ErrUnauthorized("fee grants are not supported").Result()
+	}
+	if fee.Granter.Equals(grantee) {
+		return sdk.ErrUnauthorized("fee granter cannot be the fee payer").Result()
+	}
+	if fee.Amount.IsZero() {
+		return sdk.Result{}
+	}
+
+	if err := fgk.UseGrantedFees(ctx, fee.Granter, grantee, fee.Amount); err != nil {
+		return err.Result()
+	}
+
+	granterAcc, res := GetSignerAcc(ctx, ak, fee.Granter)
+	if !res.IsOK() {
+		return res
+	}
+
+	granterAcc, res = DeductFees(ctx.BlockHeader().Time, granterAcc, fee)
+	if !res.IsOK() {
+		return res
+	}
+
+	SetSignerAcc(ctx, ak, granterAcc)
+	return sdk.Result{}
+}
+
 // EnsureSufficientMempoolFees verifies that the given transaction has supplied
 // enough fees to cover a proposer's minimum fees. A result object is returned
 // indicating success or failure.
@@ -368,6 +865,74 @@ func EnsureSufficientMempoolFees(ctx sdk.Context, stdFee StdFee) sdk.Result {
 	return sdk.Result{}
 }
 
+// EnsureSufficientFees verifies that the fee is paid in denominations accepted
+// by the chain and that its value, converted to the base fee denomination,
+// meets the chain-wide minimum gas price, i.e. value >= ceil(minGasPrice * gasLimit).
+// Fees in any denomination are accepted if no fee denomination is configured.
+func EnsureSufficientFees(stdFee StdFee, params Params) sdk.Result {
+	if len(params.FeeDenoms) == 0 {
+		return sdk.Result{}
+	}
+
+	value := sdk.ZeroDec()
+	for _, coin := range stdFee.Amount {
+		rate, ok := params.FeeDenomRate(coin.Denom)
+		if !ok {
+			return sdk.ErrInsufficientFee(
+				fmt.Sprintf("fee denomination %s is not accepted", coin.Denom),
+			).Result()
+		}
+		value = value.Add(rate.MulInt(coin.Amount))
+	}
+
+	required := params.MinGasPrice.Mul(sdk.NewDec(int64(stdFee.Gas))).Ceil()
+	if value.LT(required) {
+		return sdk.ErrInsufficientFee(
+			fmt.Sprintf(
+				"insufficient fees; got: %q worth %s required: %s", stdFee.Amount, value, required,
+			),
+		).Result()
+	}
+
+	return sdk.Result{}
+}
+
+// GetTxPriority returns the mempool priority of a transaction paying the given
+// fee, i.e. its fee per unit of gas. If the chain accepts fees in configured
+// denominations only, the fee is valued in the base fee denomination, otherwise
+// only the fee paid in the denomination of the node's first minimum gas price
+// counts. The priority is zero if there is no such denomination.
+func GetTxPriority(ctx sdk.Context, stdFee StdFee, params Params) int64 {
+	if stdFee.Gas == 0 {
+		return 0
+	}
+
+	value := sdk.ZeroDec()
+	minGasPrices := ctx.MinGasPrices()
+
+	switch {
+	case len(params.FeeDenoms) != 0:
+		for _, coin := range stdFee.Amount {
+			if rate, ok := params.FeeDenomRate(coin.Denom); ok {
+				value = value.Add(rate.MulInt(coin.Amount))
+			}
+		}
+
+	case len(minGasPrices) != 0:
+		value = sdk.NewDecFromInt(stdFee.Amount.AmountOf(minGasPrices[0].Denom))
+
+	default:
+		return 0
+	}
+
+	priority := value.QuoInt64(int64(stdFee.Gas)).TruncateInt()
+	if !priority.IsInt64() {
+		return math.MaxInt64
+	}
+
+	return priority.Int64()
+}
+
 // SetGasMeter returns a new context with a gas meter set from a given context.
 func SetGasMeter(simulate bool, ctx sdk.Context, gasLimit uint64) sdk.Context {
 	// In various cases such as simulation and during the genesis block, we do not
@@ -380,14 +945,19 @@ func SetGasMeter(simulate bool, ctx sdk.Context, gasLimit uint64) sdk.Context {
 }
 
 // GetSignBytes returns a slice of bytes to sign over for a given transaction
-// and an account.
+// and an account. Unordered transactions are signed with a zero sequence.
 func GetSignBytes(chainID string, stdTx StdTx, acc Account, genesis bool) []byte {
 	var accNum uint64
 	if !genesis {
 		accNum = acc.GetAccountNumber()
 	}
 
+	var sequence uint64
+	if !stdTx.Unordered {
+		sequence = acc.GetSequence()
+	}
+
 	return StdSignBytes(
-		chainID, accNum, acc.GetSequence(), stdTx.Fee, stdTx.Msgs, stdTx.Memo,
+		chainID, accNum, sequence, stdTx.TimeoutHeight, stdTx.Unordered, stdTx.Fee, stdTx.Msgs, stdTx.Memo,
 	)
 }
@@ -275,6 +275,101 @@ func TestAnteHandlerSequences(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test logic around timeout heights.
+func TestAnteHandlerTimeoutHeight(t *testing.T) {
+	// setup
+	input := setupTestInput()
+	anteHandler := NewAnteHandler(input.ak, input.fck)
+	ctx := input.ctx.WithBlockHeight(10)
+
+	// keys and addresses
+	priv1, _, addr1 := keyPubAddr()
+
+	// set the accounts
+	acc1 := input.ak.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	input.ak.SetAccount(ctx, acc1)
+
+	// msg and signatures
+	var tx sdk.Tx
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	fee := newStdFee()
+	privs, accnums := []crypto.PrivKey{priv1}, []uint64{0}
+
+	// test tx past its timeout height fails
+	tx = newTestTxWithTimeout(ctx, msgs, privs, accnums, []uint64{0}, fee, 9, false)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeTxTimeoutHeight)
+
+	// test tx at its timeout height passes
+	tx = newTestTxWithTimeout(ctx, msgs, privs, accnums, []uint64{0}, fee, 10, false)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+
+	// test timeout height is signed
+	tx = newTestTxWithTimeout(ctx, msgs, privs, accnums, []uint64{1}, fee, 10, false)
+	tx = tx.(StdTx).WithTimeout(20, false)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+}
+
+// Test logic around unordered transactions.
+func TestAnteHandlerUnordered(t *testing.T) {
+	// setup
+	input := setupTestInput()
+	anteHandler := NewAnteHandler(input.ak, input.fck)
+	ctx := input.ctx.WithBlockHeight(10)
+
+	// keys and addresses
+	priv1, _, addr1 := keyPubAddr()
+
+	// set the accounts
+	acc1 := input.ak.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	input.ak.SetAccount(ctx, acc1)
+
+	// msg and signatures
+	var tx sdk.Tx
+	fee := newStdFee()
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+
+	// test unordered txs without a timeout height fail
+	tx = newTestTxWithTimeout(ctx, []sdk.Msg{newTestMsg(addr1)}, privs, accnums, seqs, fee, 0, true)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeTxTimeoutHeight)
+
+	// test unordered txs timing out too far ahead fail
+	tx = newTestTxWithTimeout(ctx, []sdk.Msg{newTestMsg(addr1)}, privs, accnums, seqs, fee, 11+MaxUnorderedTxTTL, true)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeTxTimeoutHeight)
+
+	// test distinct unordered txs pass without bumping the sequence
+	tx = newTestTxWithTimeout(ctx, []sdk.Msg{newTestMsg(addr1)}, privs, accnums, seqs, fee, 20, true)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+
+	tx2 := newTestTxWithTimeout(ctx, []sdk.Msg{newTestMsg(addr1), newTestMsg(addr1)}, privs, accnums, seqs, fee, 20, true)
+	checkValidTx(t, anteHandler, ctx, tx2, false)
+	require.Equal(t, uint64(0), input.ak.GetAccount(ctx, addr1).GetSequence())
+
+	// test sending it again fails (replay protection)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeDuplicateTx)
+
+	// test resending it with another encoding of the signature fails as well
+	stdTx := tx.(StdTx)
+	stdTx.Signatures = []StdSignature{{Signature: stdTx.Signatures[0].Signature}}
+	checkInvalidTx(t, anteHandler, ctx, stdTx, false, sdk.CodeDuplicateTx)
+
+	// test ordered txs still use the sequence
+	tx = newTestTx(ctx, []sdk.Msg{newTestMsg(addr1)}, privs, accnums, seqs, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.Equal(t, uint64(1), input.ak.GetAccount(ctx, addr1).GetSequence())
+
+	// test the hashes are pruned once the txs timed out
+	EndBlocker(ctx.WithBlockHeight(19), input.ak)
+	tx = newTestTxWithTimeout(ctx, []sdk.Msg{newTestMsg(addr1)}, privs, accnums, seqs, fee, 20, true)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeDuplicateTx)
+
+	EndBlocker(ctx.WithBlockHeight(20), input.ak)
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(input.ak.key), UnorderedTxKeyPrefix)
+	require.False(t, iter.Valid())
+	iter.Close()
+}
+
 // Test logic around fee deduction.
 func TestAnteHandlerFees(t *testing.T) {
 	// setup
@@ -458,7 +553,7 @@ func TestAnteHandlerBadSignBytes(t *testing.T) {
 	for _, cs := range cases {
 		tx := newTestTxWithSignBytes(
 			msgs, privs, accnums, seqs, fee,
-			StdSignBytes(cs.chainID, cs.accnum, cs.seq, cs.fee, cs.msgs, ""),
+			StdSignBytes(cs.chainID, cs.accnum, cs.seq, 0, false, cs.fee, cs.msgs, ""),
 			"",
 		)
 		checkInvalidTx(t, anteHandler, ctx, tx, false, cs.code)
@@ -756,3 +851,314 @@ func TestEnsureSufficientMempoolFees(t *testing.T) {
 		)
 	}
 }
+
+func TestEnsureSufficientFees(t *testing.T) {
+	params := DefaultParams()
+
+	// any denomination is accepted without fee denominations
+	fee := NewStdFee(200000, sdk.NewCoins(sdk.NewInt64Coin("photino", 1)))
+	require.True(t, EnsureSufficientFees(fee, params).IsOK())
+
+	params.MinGasPrice = sdk.NewDecWithPrec(1, 4) // 0.0001stake
+	params.FeeDenoms = []FeeDenom{
+		NewFeeDenom("stake", sdk.OneDec()),
+		NewFeeDenom("photino", sdk.NewDecWithPrec(5, 1)), // 1photino = 0.5stake
+	}
+
+	testCases := []struct {
+		input      StdFee
+		expectedOK bool
+	}{
+		{NewStdFee(200000, sdk.NewCoins(sdk.NewInt64Coin("stake", 19))), false},
+		{NewStdFee(200000, sdk.NewCoins(sdk.NewInt64Coin("stake", 20))), true},
+		{NewStdFee(200000, sdk.NewCoins(sdk.NewInt64Coin("photino", 39))), false},
+		{NewStdFee(200000, sdk.NewCoins(sdk.NewInt64Coin("photino", 40))), true},
+		{NewStdFee(200000, sdk.NewCoins(sdk.NewInt64Coin("photino", 20), sdk.NewInt64Coin("stake", 10))), true},
+		{NewStdFee(200000, sdk.NewCoins(sdk.NewInt64Coin("atom", 100), sdk.NewInt64Coin("stake", 20))), false},
+		{NewStdFee(200001, sdk.NewCoins(sdk.NewInt64Coin("stake", 20))), false},
+		{NewStdFee(0, sdk.NewCoins()), true},
+	}
+
+	for i, tc := range testCases {
+		res := EnsureSufficientFees(tc.input, params)
+		require.Equal(
+			t, tc.expectedOK, res.IsOK(),
+			"unexpected result; tc #%d, input: %v, log: %v", i, tc.input, res.Log,
+		)
+	}
+}
+
+func TestGetTxPriority(t *testing.T) {
+	ctx := setupTestInput().ctx
+	params := DefaultParams()
+	fee := NewStdFee(1000, sdk.NewCoins(sdk.NewInt64Coin("photino", 3000), sdk.NewInt64Coin("stake", 5500)))
+
+	// no denomination to value the fee in
+	require.Equal(t, int64(0), GetTxPriority(ctx, fee, params))
+
+	// the fee is valued in the denomination of the first min gas price
+	minGasPrices := sdk.DecCoins{sdk.NewDecCoinFromDec("stake", sdk.NewDecWithPrec(1, 2))}
+	require.Equal(t, int64(5), GetTxPriority(ctx.WithMinGasPrices(minGasPrices), fee, params))
+	require.Equal(t, int64(0), GetTxPriority(ctx.WithMinGasPrices(minGasPrices), NewStdFee(0, fee.Amount), params))
+
+	// the accepted fee denominations are converted to the base fee denomination
+	params.FeeDenoms = []FeeDenom{
+		NewFeeDenom("stake", sdk.OneDec()),
+		NewFeeDenom("photino", sdk.NewDecWithPrec(5, 1)), // 1photino = 0.5stake
+	}
+	require.Equal(t, int64(7), GetTxPriority(ctx.WithMinGasPrices(minGasPrices), fee, params))
+}
+
+func TestAnteHandlerReCheck(t *testing.T) {
+	// setup
+	input := setupTestInput()
+	anteHandler := NewAnteHandler(input.ak, input.fck)
+	ctx := input.ctx.WithBlockHeight(1)
+	minGasPrices := sdk.DecCoins{sdk.NewDecCoinFromDec("atom", sdk.NewDecWithPrec(1, 2))}
+
+	// keys and addresses
+	priv1, _, addr1 := keyPubAddr()
+
+	// set the accounts
+	acc1 := input.ak.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(sdk.NewCoins(sdk.NewInt64Coin("atom", 1000000)))
+	input.ak.SetAccount(ctx, acc1)
+
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	fee := NewStdFee(50000, sdk.NewCoins(sdk.NewInt64Coin("atom", 150000)))
+	tx := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}, fee)
+
+	// the priority and the sender are returned during CheckTx only
+	checkCtx, _ := ctx.WithIsCheckTx(true).WithMinGasPrices(minGasPrices).CacheContext()
+	newCtx, res, abort := anteHandler(checkCtx, tx, false)
+	require.False(t, abort, res.Log)
+	require.Equal(t, int64(3), res.Priority)
+	require.Equal(t, addr1.String(), res.Sender)
+	checkGas := newCtx.GasMeter().GasConsumed()
+
+	// the recheck consumes the same gas
+	reCheckCtx, _ := ctx.WithIsReCheckTx(true).WithMinGasPrices(minGasPrices).CacheContext()
+	newCtx, res, abort = anteHandler(reCheckCtx, tx, false)
+	require.False(t, abort, res.Log)
+	require.Equal(t, checkGas, newCtx.GasMeter().GasConsumed())
+
+	_, res, abort = anteHandler(ctx, tx, false)
+	require.False(t, abort, res.Log)
+	require.Zero(t, res.Priority)
+	require.Empty(t, res.Sender)
+
+	// a rechecked tx with a stale sequence fails
+	checkInvalidTx(t, anteHandler, ctx.WithIsReCheckTx(true).WithMinGasPrices(minGasPrices), tx, false, sdk.CodeUnauthorized)
+}
+
+func TestAnteHandlerFeeDenoms(t *testing.T) {
+	// setup
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockHeight(1)
+	anteHandler := NewAnteHandler(input.ak, input.fck)
+
+	params := input.ak.GetParams(ctx)
+	params.MinGasPrice = sdk.NewDecWithPrec(1, 3) // 0.001atom
+	params.FeeDenoms = []FeeDenom{NewFeeDenom("atom", sdk.OneDec())}
+	input.ak.SetParams(ctx, params)
+
+	// keys and addresses
+	priv1, _, addr1 := keyPubAddr()
+
+	// set the accounts
+	acc1 := input.ak.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(sdk.NewCoins(sdk.NewInt64Coin("atom", 1000), sdk.NewInt64Coin("photino", 1000)))
+	input.ak.SetAccount(ctx, acc1)
+
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+
+	// the fee policy applies when delivering a tx, regardless of the node's min gas prices
+	fee := NewStdFee(50000, sdk.NewCoins(sdk.NewInt64Coin("photino", 150)))
+	tx := newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeInsufficientFee)
+	checkInvalidTx(t, anteHandler, ctx.WithIsCheckTx(true), tx, false, sdk.CodeInsufficientFee)
+
+	fee = NewStdFee(50000, sdk.NewCoins(sdk.NewInt64Coin("atom", 49)))
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeInsufficientFee)
+
+	// simulation does not require fees
+	simCtx, _ := ctx.CacheContext()
+	checkValidTx(t, anteHandler, simCtx, tx, true)
+
+	fee = NewStdFee(50000, sdk.NewCoins(sdk.NewInt64Coin("atom", 50)))
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.True(t, input.fck.GetCollectedFees(ctx).IsEqual(sdk.NewCoins(sdk.NewInt64Coin("atom", 50))))
+}
+
+// mockFeeGrantKeeper grants every grantee an allowance of limit from granter.
+type mockFeeGrantKeeper struct {
+	granter sdk.AccAddress
+	limit   sdk.Coins
+}
+
+func (fgk *mockFeeGrantKeeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins) sdk.Error {
+	left, hasNeg := fgk.limit.SafeSub(fee)
+	if !granter.Equals(fgk.granter) || hasNeg {
+		return sdk.ErrUnauthorized("fee allowance exceeded")
+	}
+
+	fgk.limit = left
+	return nil
+}
+
+// Test that fees are deducted from the fee granter when one is set.
+func TestAnteHandlerFeeGranter(t *testing.T) {
+	// setup
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockHeight(1)
+
+	// keys and addresses
+	priv1, _, addr1 := keyPubAddr()
+	_, _, addr2 := keyPubAddr()
+	_, _, addr3 := keyPubAddr()
+
+	acc1 := input.ak.NewAccountWithAddress(ctx, addr1)
+	input.ak.SetAccount(ctx, acc1)
+	acc2 := input.ak.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(newCoins())
+	input.ak.SetAccount(ctx, acc2)
+
+	fee := newStdFee()
+	fgk := &mockFeeGrantKeeper{granter: addr2, limit: fee.Amount}
+	anteHandler := NewAnteHandlerWithFeeGrants(input.ak, input.fck, fgk)
+
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+
+	// fee grants are rejected if not supported
+	tx := newTestTx(ctx, msgs, privs, accnums, seqs, fee.WithGranter(addr2))
+	checkInvalidTx(t, NewAnteHandler(input.ak, input.fck), ctx, tx, false, sdk.CodeUnauthorized)
+
+	// fees cannot be charged to a granter that issued no allowance
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee.WithGranter(addr3))
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+
+	// the granter pays the fees of the signer
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee.WithGranter(addr2))
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.True(t, input.ak.GetAccount(ctx, addr1).GetCoins().IsZero())
+	require.Equal(t, newCoins().Sub(fee.Amount), input.ak.GetAccount(ctx, addr2).GetCoins())
+	require.Equal(t, fee.Amount, input.fck.GetCollectedFees(ctx))
+
+	// the allowance is used up
+	seqs = []uint64{1}
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee.WithGranter(addr2))
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+}
+
+// whitelistDecorator only accepts the transactions signed by whitelisted
+// accounts.
+type whitelistDecorator struct {
+	whitelist map[string]bool
+}
+
+func (d whitelistDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	for _, signer := range tx.(StdTx).GetSigners() {
+		if !d.whitelist[signer.String()] {
+			return ctx, sdk.ErrUnauthorized(fmt.Sprintf("%s is not whitelisted", signer)).Result(), true
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+func TestAnteDecoratorsCustomChain(t *testing.T) {
+	// setup
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockHeight(1)
+
+	// keys and addresses
+	priv1, _, addr1 := keyPubAddr()
+	priv2, _, addr2 := keyPubAddr()
+
+	for _, addr := range []sdk.AccAddress{addr1, addr2} {
+		acc := input.ak.NewAccountWithAddress(ctx, addr)
+		acc.SetCoins(newCoins())
+		input.ak.SetAccount(ctx, acc)
+	}
+
+	// the whitelist check runs before the fees are deducted
+	decorators := NewAnteDecorators(input.ak, input.fck, nil)
+	decorators = append(decorators[:1], append(
+		[]sdk.AnteDecorator{whitelistDecorator{whitelist: map[string]bool{addr1.String(): true}}},
+		decorators[1:]...,
+	)...)
+	anteHandler := sdk.ChainAnteDecorators(decorators...)
+
+	fee := newStdFee()
+	tx := newTestTx(ctx, []sdk.Msg{newTestMsg(addr1)}, []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.Equal(t, uint64(1), input.ak.GetAccount(ctx, addr1).GetSequence())
+
+	tx = newTestTx(ctx, []sdk.Msg{newTestMsg(addr2)}, []crypto.PrivKey{priv2}, []uint64{1}, []uint64{0}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+	require.Equal(t, newCoins(), input.ak.GetAccount(ctx, addr2).GetCoins())
+	require.Equal(t, uint64(0), input.ak.GetAccount(ctx, addr2).GetSequence())
+}
+
+// anteDecoratorFunc turns a function into a sdk.AnteDecorator.
+type anteDecoratorFunc func(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool)
+
+func (fn anteDecoratorFunc) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	return fn(ctx, tx, simulate, next)
+}
+
+func TestAnteHandlerSignerAccounts(t *testing.T) {
+	// setup
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockHeight(1)
+
+	// keys and addresses
+	priv1, _, addr1 := keyPubAddr()
+	priv2, _, addr2 := keyPubAddr()
+
+	for _, addr := range []sdk.AccAddress{addr1, addr2} {
+		acc := input.ak.NewAccountWithAddress(ctx, addr)
+		acc.SetCoins(newCoins())
+		input.ak.SetAccount(ctx, acc)
+	}
+
+	// the last decorator sees the updated accounts, which are not written yet
+	fee := newStdFee()
+	checkAccounts := anteDecoratorFunc(func(
+		ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+	) (sdk.Context, sdk.Result, bool) {
+		acc, res := GetSignerAcc(ctx, input.ak, addr2)
+		require.True(t, res.IsOK())
+		require.Equal(t, uint64(1), acc.GetSequence())
+		require.Equal(t, newCoins().Sub(fee.Amount), acc.GetCoins())
+		require.Equal(t, uint64(0), input.ak.GetAccount(ctx, addr2).GetSequence())
+		require.Equal(t, newCoins(), input.ak.GetAccount(ctx, addr2).GetCoins())
+
+		return next(ctx, tx, simulate)
+	})
+	fgk := &mockFeeGrantKeeper{granter: addr2, limit: fee.Amount}
+	anteHandler := sdk.ChainAnteDecorators(append(NewAnteDecorators(input.ak, input.fck, fgk), checkAccounts)...)
+
+	// the second signer grants the fees of the first one
+	msgs := []sdk.Msg{newTestMsg(addr1, addr2)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1, priv2}, []uint64{0, 1}, []uint64{0, 0}
+	tx := newTestTx(ctx, msgs, privs, accnums, seqs, fee.WithGranter(addr2))
+	checkValidTx(t, anteHandler, ctx, tx, false)
+
+	acc1 := input.ak.GetAccount(ctx, addr1)
+	require.Equal(t, newCoins(), acc1.GetCoins())
+	require.Equal(t, uint64(1), acc1.GetSequence())
+	require.NotNil(t, acc1.GetPubKey())
+	acc2 := input.ak.GetAccount(ctx, addr2)
+	require.Equal(t, newCoins().Sub(fee.Amount), acc2.GetCoins())
+	require.Equal(t, uint64(1), acc2.GetSequence())
+	require.NotNil(t, acc2.GetPubKey())
+}
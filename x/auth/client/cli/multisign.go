@@ -98,10 +98,16 @@ func makeMultiSignCmd(cdc *amino.Codec) func(cmd *cobra.Command, args []string)
 			}
 
 			// Validate each signature
-			sigBytes := auth.StdSignBytes(
-				txBldr.ChainID(), txBldr.AccountNumber(), txBldr.Sequence(),
-				stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo(),
-			)
+			sigBytes := authtxb.StdSignMsg{
+				ChainID:       txBldr.ChainID(),
+				AccountNumber: txBldr.AccountNumber(),
+				Sequence:      txBldr.Sequence(),
+				Fee:           stdTx.Fee,
+				Msgs:          stdTx.GetMsgs(),
+				Memo:          stdTx.GetMemo(),
+				TimeoutHeight: stdTx.TimeoutHeight,
+				Unordered:     stdTx.Unordered,
+			}.Bytes()
 			if ok := stdSig.PubKey.VerifyBytes(sigBytes, stdSig.Signature); !ok {
 				return fmt.Errorf("couldn't verify signature")
 			}
@@ -111,7 +117,8 @@ func makeMultiSignCmd(cdc *amino.Codec) func(cmd *cobra.Command, args []string)
 		}
 
 		newStdSig := auth.StdSignature{Signature: cdc.MustMarshalBinaryBare(multisigSig), PubKey: multisigPub}
-		newTx := auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, []auth.StdSignature{newStdSig}, stdTx.GetMemo())
+		newTx := auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, []auth.StdSignature{newStdSig}, stdTx.GetMemo()).
+			WithTimeout(stdTx.TimeoutHeight, stdTx.Unordered)
 
 		sigOnly := viper.GetBool(flagSigOnly)
 		var json []byte
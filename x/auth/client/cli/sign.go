@@ -226,10 +226,7 @@ func printAndValidateSigs(
 				return false
 			}
 
-			sigBytes := auth.StdSignBytes(
-				chainID, acc.GetAccountNumber(), acc.GetSequence(),
-				stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo(),
-			)
+			sigBytes := auth.GetSignBytes(chainID, stdTx, acc, false)
 
 			if ok := sig.VerifyBytes(sigBytes, sig.Signature); !ok {
 				sigSanity = "ERROR: signature invalid"
@@ -15,9 +15,19 @@ type StdSignMsg struct {
 	Fee           auth.StdFee `json:"fee"`
 	Msgs          []sdk.Msg   `json:"msgs"`
 	Memo          string      `json:"memo"`
+	TimeoutHeight uint64      `json:"timeout_height,omitempty"`
+	Unordered     bool        `json:"unordered,omitempty"`
 }
 
-// get message bytes
+// get message bytes, unordered transactions are signed with a zero sequence
 func (msg StdSignMsg) Bytes() []byte {
-	return auth.StdSignBytes(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Fee, msg.Msgs, msg.Memo)
+	sequence := msg.Sequence
+	if msg.Unordered {
+		sequence = 0
+	}
+
+	return auth.StdSignBytes(
+		msg.ChainID, msg.AccountNumber, sequence, msg.TimeoutHeight, msg.Unordered,
+		msg.Fee, msg.Msgs, msg.Memo,
+	)
 }
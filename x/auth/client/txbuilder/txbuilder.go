@@ -29,6 +29,9 @@ type TxBuilder struct {
 	memo               string
 	fees               sdk.Coins
 	gasPrices          sdk.DecCoins
+	feeGranter         sdk.AccAddress
+	timeoutHeight      uint64
+	unordered          bool
 }
 
 // NewTxBuilder returns a new initialized TxBuilder.
@@ -72,6 +75,9 @@ func NewTxBuilderFromCLI() TxBuilder {
 
 	txbldr = txbldr.WithFees(viper.GetString(client.FlagFees))
 	txbldr = txbldr.WithGasPrices(viper.GetString(client.FlagGasPrices))
+	txbldr = txbldr.WithFeeGranter(viper.GetString(client.FlagFeeGranter))
+	txbldr = txbldr.WithTimeoutHeight(uint64(viper.GetInt64(client.FlagTimeoutHeight)))
+	txbldr = txbldr.WithUnordered(viper.GetBool(client.FlagUnordered))
 
 	return txbldr
 }
@@ -110,6 +116,17 @@ func (bldr TxBuilder) Fees() sdk.Coins { return bldr.fees }
 // GasPrices returns the gas prices set for the transaction, if any.
 func (bldr TxBuilder) GasPrices() sdk.DecCoins { return bldr.gasPrices }
 
+// FeeGranter returns the address of the account paying the fees, if any.
+func (bldr TxBuilder) FeeGranter() sdk.AccAddress { return bldr.feeGranter }
+
+// TimeoutHeight returns the height after which the transaction is rejected,
+// if any.
+func (bldr TxBuilder) TimeoutHeight() uint64 { return bldr.timeoutHeight }
+
+// Unordered returns whether the transaction is not bound to the signer's
+// sequence.
+func (bldr TxBuilder) Unordered() bool { return bldr.unordered }
+
 // WithTxEncoder returns a copy of the context with an updated codec.
 func (bldr TxBuilder) WithTxEncoder(txEncoder sdk.TxEncoder) TxBuilder {
 	bldr.txEncoder = txEncoder
@@ -150,6 +167,35 @@ func (bldr TxBuilder) WithGasPrices(gasPrices string) TxBuilder {
 	return bldr
 }
 
+// WithFeeGranter returns a copy of the context with an updated fee granter.
+func (bldr TxBuilder) WithFeeGranter(granter string) TxBuilder {
+	if granter == "" {
+		bldr.feeGranter = nil
+		return bldr
+	}
+
+	granterAddr, err := sdk.AccAddressFromBech32(granter)
+	if err != nil {
+		panic(err)
+	}
+
+	bldr.feeGranter = granterAddr
+	return bldr
+}
+
+// WithTimeoutHeight returns a copy of the context with an updated timeout
+// height.
+func (bldr TxBuilder) WithTimeoutHeight(height uint64) TxBuilder {
+	bldr.timeoutHeight = height
+	return bldr
+}
+
+// WithUnordered returns a copy of the context with an updated unordered mode.
+func (bldr TxBuilder) WithUnordered(unordered bool) TxBuilder {
+	bldr.unordered = unordered
+	return bldr
+}
+
 // WithKeybase returns a copy of the context with updated keybase.
 func (bldr TxBuilder) WithKeybase(keybase crkeys.Keybase) TxBuilder {
 	bldr.keybase = keybase
@@ -205,7 +251,9 @@ func (bldr TxBuilder) BuildSignMsg(msgs []sdk.Msg) (StdSignMsg, error) {
 		Sequence:      bldr.sequence,
 		Memo:          bldr.memo,
 		Msgs:          msgs,
-		Fee:           auth.NewStdFee(bldr.gas, fees),
+		Fee:           auth.NewStdFee(bldr.gas, fees).WithGranter(bldr.feeGranter),
+		TimeoutHeight: bldr.timeoutHeight,
+		Unordered:     bldr.unordered,
 	}, nil
 }
 
@@ -217,7 +265,8 @@ func (bldr TxBuilder) Sign(name, passphrase string, msg StdSignMsg) ([]byte, err
 		return nil, err
 	}
 
-	return bldr.txEncoder(auth.NewStdTx(msg.Msgs, msg.Fee, []auth.StdSignature{sig}, msg.Memo))
+	stdTx := auth.NewStdTx(msg.Msgs, msg.Fee, []auth.StdSignature{sig}, msg.Memo)
+	return bldr.txEncoder(stdTx.WithTimeout(msg.TimeoutHeight, msg.Unordered))
 }
 
 // BuildAndSign builds a single message to be signed, and signs a transaction
@@ -241,7 +290,8 @@ func (bldr TxBuilder) BuildTxForSim(msgs []sdk.Msg) ([]byte, error) {
 
 	// the ante handler will populate with a sentinel pubkey
 	sigs := []auth.StdSignature{{}}
-	return bldr.txEncoder(auth.NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, signMsg.Memo))
+	stdTx := auth.NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, signMsg.Memo)
+	return bldr.txEncoder(stdTx.WithTimeout(signMsg.TimeoutHeight, signMsg.Unordered))
 }
 
 // SignStdTx appends a signature to a StdTx and returns a copy of it. If append
@@ -258,6 +308,8 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx auth.StdTx, appen
 		Fee:           stdTx.Fee,
 		Msgs:          stdTx.GetMsgs(),
 		Memo:          stdTx.GetMemo(),
+		TimeoutHeight: stdTx.TimeoutHeight,
+		Unordered:     stdTx.Unordered,
 	})
 	if err != nil {
 		return
@@ -269,7 +321,8 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx auth.StdTx, appen
 	} else {
 		sigs = append(sigs, stdSignature)
 	}
-	signedStdTx = auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, sigs, stdTx.GetMemo())
+	signedStdTx = auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, sigs, stdTx.GetMemo()).
+		WithTimeout(stdTx.TimeoutHeight, stdTx.Unordered)
 	return
 }
 
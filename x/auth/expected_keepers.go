@@ -0,0 +1,13 @@
+package auth
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeGrantKeeper defines the expected fee grant keeper used by the AnteHandler
+// to pay the fees of a grantee from a granter's account.
+type FeeGrantKeeper interface {
+	// UseGrantedFees charges the fee against the allowance granter has issued
+	// to grantee, returning an error if the allowance does not cover it.
+	UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins) sdk.Error
+}
@@ -27,6 +27,9 @@ func DefaultGenesisState() GenesisState {
 
 // InitGenesis - Init store state from genesis data
 func InitGenesis(ctx sdk.Context, ak AccountKeeper, fck FeeCollectionKeeper, data GenesisState) {
+	if data.Params.MinGasPrice.IsNil() {
+		data.Params.MinGasPrice = sdk.ZeroDec()
+	}
 	ak.SetParams(ctx, data.Params)
 	fck.setCollectedFees(ctx, data.CollectedFees)
 }
@@ -57,5 +60,16 @@ func ValidateGenesis(data GenesisState) error {
 	if data.Params.TxSizeCostPerByte == 0 {
 		return fmt.Errorf("invalid tx size cost per byte: %d", data.Params.TxSizeCostPerByte)
 	}
-	return nil
+	// the genesis files predating the min gas price don't set it
+	minGasPrice := data.Params.MinGasPrice
+	if minGasPrice.IsNil() {
+		minGasPrice = sdk.ZeroDec()
+	}
+	if err := validateMinGasPrice(minGasPrice); err != nil {
+		return err
+	}
+	if minGasPrice.IsPositive() && len(data.Params.FeeDenoms) == 0 {
+		return fmt.Errorf("min gas price %s set without any accepted fee denomination", minGasPrice)
+	}
+	return validateFeeDenoms(data.Params.FeeDenoms)
 }
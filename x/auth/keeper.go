@@ -11,6 +11,9 @@ import (
 )
 
 const (
+	// ModuleName is the name of the auth module
+	ModuleName = "auth"
+
 	// StoreKey is string representation of the store key for auth
 	StoreKey = "acc"
 
@@ -206,9 +209,13 @@ func (ak AccountKeeper) SetParams(ctx sdk.Context, params Params) {
 	ak.paramSubspace.SetParamSet(ctx, &params)
 }
 
-// GetParams gets the auth module's parameters.
+// GetParams gets the auth module's parameters. The fee params missing from the
+// state predating them default to a zero min gas price and no fee denoms.
 func (ak AccountKeeper) GetParams(ctx sdk.Context) (params Params) {
-	ak.paramSubspace.GetParamSet(ctx, &params)
+	params.MinGasPrice = sdk.ZeroDec()
+	for _, pair := range params.ParamSetPairs() {
+		ak.paramSubspace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
 	return
 }
 
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ sdk.AppModule      = AppModule{}
+	_ sdk.AppModuleBasic = AppModuleBasic{}
+)
+
+// AppModuleBasic defines the basic elements of the auth module.
+type AppModuleBasic struct{}
+
+// Name returns the auth module's name.
+func (AppModuleBasic) Name() string { return ModuleName }
+
+// RegisterCodec registers the auth module's types on the codec.
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) { RegisterCodec(cdc) }
+
+// DefaultGenesis returns the default genesis state of the auth module.
+func (AppModuleBasic) DefaultGenesis(cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// ValidateGenesis validates a genesis state of the auth module.
+func (AppModuleBasic) ValidateGenesis(cdc *codec.Codec, bz json.RawMessage) error {
+	var data GenesisState
+	if err := cdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+	return ValidateGenesis(data)
+}
+
+// AppModule implements the sdk.AppModule interface for the auth module.
+type AppModule struct {
+	AppModuleBasic
+	accountKeeper       AccountKeeper
+	feeCollectionKeeper FeeCollectionKeeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(accountKeeper AccountKeeper, feeCollectionKeeper FeeCollectionKeeper) AppModule {
+	return AppModule{
+		accountKeeper:       accountKeeper,
+		feeCollectionKeeper: feeCollectionKeeper,
+	}
+}
+
+// RegisterInvariants registers the auth module's invariants.
+func (AppModule) RegisterInvariants(sdk.InvariantRouter) {}
+
+// Route returns the auth module's message route, as it has no messages.
+func (AppModule) Route() string { return "" }
+
+// NewHandler returns no handler, as the auth module has no messages.
+func (AppModule) NewHandler() sdk.Handler { return nil }
+
+// QuerierRoute returns the auth module's querier route.
+func (AppModule) QuerierRoute() string { return QuerierRoute }
+
+// NewQuerierHandler returns the auth module's querier.
+func (am AppModule) NewQuerierHandler() sdk.Querier {
+	return NewQuerier(am.accountKeeper)
+}
+
+// InitGenesis initializes the auth module's genesis state.
+func (am AppModule) InitGenesis(ctx sdk.Context, cdc *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	cdc.MustUnmarshalJSON(bz, &data)
+	InitGenesis(ctx, am.accountKeeper, am.feeCollectionKeeper, data)
+	return nil
+}
+
+// ExportGenesis exports the auth module's genesis state.
+func (am AppModule) ExportGenesis(ctx sdk.Context, cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(ExportGenesis(ctx, am.accountKeeper, am.feeCollectionKeeper))
+}
+
+// BeginBlock runs nothing at the start of a block.
+func (AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {}
+
+// EndBlock prunes the expired unordered transactions.
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	EndBlocker(ctx, am.accountKeeper)
+	return nil
+}
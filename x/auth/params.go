@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
@@ -27,6 +28,8 @@ var (
 	KeyTxSizeCostPerByte      = []byte("TxSizeCostPerByte")
 	KeySigVerifyCostED25519   = []byte("SigVerifyCostED25519")
 	KeySigVerifyCostSecp256k1 = []byte("SigVerifyCostSecp256k1")
+	KeyMinGasPrice            = []byte("MinGasPrice")
+	KeyFeeDenoms              = []byte("FeeDenoms")
 )
 
 var _ params.ParamSet = &Params{}
@@ -38,11 +41,47 @@ type Params struct {
 	TxSizeCostPerByte      uint64 `json:"tx_size_cost_per_byte"`
 	SigVerifyCostED25519   uint64 `json:"sig_verify_cost_ed25519"`
 	SigVerifyCostSecp256k1 uint64 `json:"sig_verify_cost_secp256k1"`
+
+	// MinGasPrice is the chain-wide minimum gas price, in units of the base fee
+	// denomination, enforced when FeeDenoms is not empty.
+	MinGasPrice sdk.Dec `json:"min_gas_price"`
+	// FeeDenoms are the denominations accepted for fee payment. Fees in any
+	// denomination are accepted if it is empty.
+	FeeDenoms []FeeDenom `json:"fee_denoms"`
+}
+
+// FeeDenom defines a denomination accepted for fee payment along with the
+// amount of the base fee denomination a single unit of it is worth.
+type FeeDenom struct {
+	Denom string  `json:"denom"`
+	Rate  sdk.Dec `json:"rate"`
+}
+
+// NewFeeDenom returns a new FeeDenom.
+func NewFeeDenom(denom string, rate sdk.Dec) FeeDenom {
+	return FeeDenom{
+		Denom: denom,
+		Rate:  rate,
+	}
 }
 
-// ParamKeyTable for auth module
+// String implements the stringer interface.
+func (fd FeeDenom) String() string {
+	return fmt.Sprintf("%s: %s", fd.Denom, fd.Rate)
+}
+
+// ParamKeyTable for auth module, with the fee params validated when they are
+// updated, e.g. by governance
 func ParamKeyTable() params.KeyTable {
-	return params.NewKeyTable().RegisterParamSet(&Params{})
+	return params.NewKeyTable(
+		KeyMaxMemoCharacters, uint64(0),
+		KeyTxSigLimit, uint64(0),
+		KeyTxSizeCostPerByte, uint64(0),
+		KeySigVerifyCostED25519, uint64(0),
+		KeySigVerifyCostSecp256k1, uint64(0),
+	).
+		RegisterTypeWithValidator(KeyMinGasPrice, sdk.Dec{}, validateMinGasPrice).
+		RegisterTypeWithValidator(KeyFeeDenoms, []FeeDenom{}, validateFeeDenoms)
 }
 
 // ParamSetPairs implements the ParamSet interface and returns all the key/value pairs
@@ -55,6 +94,8 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		{KeyTxSizeCostPerByte, &p.TxSizeCostPerByte},
 		{KeySigVerifyCostED25519, &p.SigVerifyCostED25519},
 		{KeySigVerifyCostSecp256k1, &p.SigVerifyCostSecp256k1},
+		{KeyMinGasPrice, &p.MinGasPrice},
+		{KeyFeeDenoms, &p.FeeDenoms},
 	}
 }
 
@@ -73,7 +114,52 @@ func DefaultParams() Params {
 		TxSizeCostPerByte:      DefaultTxSizeCostPerByte,
 		SigVerifyCostED25519:   DefaultSigVerifyCostED25519,
 		SigVerifyCostSecp256k1: DefaultSigVerifyCostSecp256k1,
+		MinGasPrice:            sdk.ZeroDec(),
+	}
+}
+
+func validateMinGasPrice(i interface{}) error {
+	minGasPrice, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if minGasPrice.IsNil() || minGasPrice.IsNegative() {
+		return fmt.Errorf("invalid min gas price: %s", minGasPrice)
 	}
+	return nil
+}
+
+func validateFeeDenoms(i interface{}) error {
+	feeDenoms, ok := i.([]FeeDenom)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seenDenoms := make(map[string]bool)
+	for _, fd := range feeDenoms {
+		if !(sdk.Coins{sdk.Coin{Denom: fd.Denom, Amount: sdk.OneInt()}}).IsValid() {
+			return fmt.Errorf("invalid fee denomination: %s", fd.Denom)
+		}
+		if seenDenoms[fd.Denom] {
+			return fmt.Errorf("duplicate fee denomination: %s", fd.Denom)
+		}
+		if fd.Rate.IsNil() || !fd.Rate.IsPositive() {
+			return fmt.Errorf("invalid conversion rate for fee denomination %s: %s", fd.Denom, fd.Rate)
+		}
+		seenDenoms[fd.Denom] = true
+	}
+	return nil
+}
+
+// FeeDenomRate returns the conversion rate of an accepted fee denomination to
+// the base fee denomination.
+func (p Params) FeeDenomRate(denom string) (sdk.Dec, bool) {
+	for _, fd := range p.FeeDenoms {
+		if fd.Denom == denom {
+			return fd.Rate, true
+		}
+	}
+	return sdk.Dec{}, false
 }
 
 // String implements the stringer interface.
@@ -85,5 +171,10 @@ func (p Params) String() string {
 	sb.WriteString(fmt.Sprintf("TxSizeCostPerByte: %d\n", p.TxSizeCostPerByte))
 	sb.WriteString(fmt.Sprintf("SigVerifyCostED25519: %d\n", p.SigVerifyCostED25519))
 	sb.WriteString(fmt.Sprintf("SigVerifyCostSecp256k1: %d\n", p.SigVerifyCostSecp256k1))
+	sb.WriteString(fmt.Sprintf("MinGasPrice: %s\n", p.MinGasPrice))
+	sb.WriteString("FeeDenoms:\n")
+	for _, fd := range p.FeeDenoms {
+		sb.WriteString(fmt.Sprintf("  %s\n", fd))
+	}
 	return sb.String()
 }
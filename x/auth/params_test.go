@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func TestParamsEqual(t *testing.T) {
@@ -14,3 +16,72 @@ func TestParamsEqual(t *testing.T) {
 	p1.TxSigLimit += 10
 	require.NotEqual(t, p1, p2)
 }
+
+func TestValidateGenesisFeeDenoms(t *testing.T) {
+	require.NoError(t, ValidateGenesis(DefaultGenesisState()))
+
+	testCases := []struct {
+		minGasPrice sdk.Dec
+		feeDenoms   []FeeDenom
+		expectPass  bool
+	}{
+		{sdk.NewDecWithPrec(1, 2), []FeeDenom{NewFeeDenom("stake", sdk.OneDec())}, true},
+		{sdk.ZeroDec(), []FeeDenom{NewFeeDenom("stake", sdk.OneDec()), NewFeeDenom("photino", sdk.NewDecWithPrec(5, 1))}, true},
+		{sdk.Dec{}, nil, true},
+		{sdk.NewDec(-1), []FeeDenom{NewFeeDenom("stake", sdk.OneDec())}, false},
+		{sdk.NewDecWithPrec(1, 2), nil, false},
+		{sdk.ZeroDec(), []FeeDenom{NewFeeDenom("Stake", sdk.OneDec())}, false},
+		{sdk.ZeroDec(), []FeeDenom{NewFeeDenom("stake", sdk.OneDec()), NewFeeDenom("stake", sdk.OneDec())}, false},
+		{sdk.ZeroDec(), []FeeDenom{NewFeeDenom("stake", sdk.ZeroDec())}, false},
+		{sdk.ZeroDec(), []FeeDenom{NewFeeDenom("stake", sdk.Dec{})}, false},
+	}
+
+	for i, tc := range testCases {
+		data := DefaultGenesisState()
+		data.Params.MinGasPrice = tc.minGasPrice
+		data.Params.FeeDenoms = tc.feeDenoms
+
+		if tc.expectPass {
+			require.NoError(t, ValidateGenesis(data), "test: %v", i)
+		} else {
+			require.Error(t, ValidateGenesis(data), "test: %v", i)
+		}
+	}
+}
+
+func TestUpdateFeeParams(t *testing.T) {
+	input := setupTestInput()
+	ctx, space := input.ctx, input.ak.paramSubspace
+
+	require.NoError(t, space.Update(ctx, KeyMinGasPrice, []byte(`"0.010000000000000000"`)))
+	require.NoError(t, space.Update(ctx, KeyFeeDenoms, []byte(`[{"denom":"stake","rate":"1.000000000000000000"}]`)))
+	params := input.ak.GetParams(ctx)
+	require.Equal(t, sdk.NewDecWithPrec(1, 2), params.MinGasPrice)
+	require.Equal(t, []FeeDenom{NewFeeDenom("stake", sdk.OneDec())}, params.FeeDenoms)
+
+	require.Error(t, space.Update(ctx, KeyMinGasPrice, []byte(`"-1.000000000000000000"`)))
+	require.Error(t, space.Update(ctx, KeyMinGasPrice, []byte(`null`)))
+	require.Error(t, space.Update(ctx, KeyFeeDenoms, []byte(`[{"denom":"stake","rate":"0.000000000000000000"}]`)))
+	require.Error(t, space.Update(ctx, KeyFeeDenoms, []byte(`[{"denom":"stake","rate":"-1.000000000000000000"}]`)))
+	require.Error(t, space.Update(ctx, KeyFeeDenoms, []byte(`[{"denom":"stake"}]`)))
+	require.Error(t, space.Update(ctx, KeyFeeDenoms, []byte(`[{"denom":"stake","rate":"1.000000000000000000"},{"denom":"stake","rate":"2.000000000000000000"}]`)))
+	require.Equal(t, params, input.ak.GetParams(ctx))
+}
+
+func TestGetParamsMissingFeeParams(t *testing.T) {
+	input := setupTestInput()
+	ctx := input.ctx
+
+	// state set before the fee params were added
+	ak := NewAccountKeeper(input.cdc, sdk.NewKVStoreKey("acc2"), input.pk.Subspace("auth2"), ProtoBaseAccount)
+	space := ak.paramSubspace
+	space.Set(ctx, KeyMaxMemoCharacters, DefaultMaxMemoCharacters)
+	space.Set(ctx, KeyTxSigLimit, DefaultTxSigLimit)
+	space.Set(ctx, KeyTxSizeCostPerByte, DefaultTxSizeCostPerByte)
+	space.Set(ctx, KeySigVerifyCostED25519, DefaultSigVerifyCostED25519)
+	space.Set(ctx, KeySigVerifyCostSecp256k1, DefaultSigVerifyCostSecp256k1)
+
+	params := ak.GetParams(ctx)
+	require.Equal(t, DefaultParams(), params)
+	require.True(t, EnsureSufficientFees(NewStdFee(100000, nil), params).IsOK())
+}
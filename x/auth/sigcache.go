@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// maxSigCacheSize is the maximum number of verified signatures kept by a
+// signature cache. It is a bit larger than the default Tendermint mempool size.
+const maxSigCacheSize = 10000
+
+// sigCache caches the signatures verified during CheckTx, so that they are not
+// verified again when the transactions left in the mempool are rechecked after
+// a block. A signature is identified by the hash of the public key, the sign
+// bytes and the signature itself, hence a stale sequence or account number
+// results in a cache miss.
+type sigCache struct {
+	mtx  sync.Mutex
+	sigs map[string]struct{}
+}
+
+func newSigCache() *sigCache {
+	return &sigCache{sigs: make(map[string]struct{})}
+}
+
+func sigCacheKey(pubKey crypto.PubKey, signBytes, sig []byte) string {
+	bz := make([]byte, 0, len(pubKey.Bytes())+len(signBytes)+len(sig))
+	bz = append(bz, pubKey.Bytes()...)
+	bz = append(bz, signBytes...)
+	bz = append(bz, sig...)
+	return string(tmhash.Sum(bz))
+}
+
+// has returns true if the signature was verified already.
+func (c *sigCache) has(pubKey crypto.PubKey, signBytes, sig []byte) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, ok := c.sigs[sigCacheKey(pubKey, signBytes, sig)]
+	return ok
+}
+
+// add records a verified signature. The cache is emptied once full, the
+// signatures of the next rechecked transactions being verified again.
+func (c *sigCache) add(pubKey crypto.PubKey, signBytes, sig []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if len(c.sigs) >= maxSigCacheSize {
+		c.sigs = make(map[string]struct{})
+	}
+	c.sigs[sigCacheKey(pubKey, signBytes, sig)] = struct{}{}
+}
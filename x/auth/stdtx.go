@@ -18,12 +18,20 @@ var (
 )
 
 // StdTx is a standard way to wrap a Msg with Fee and Signatures.
-// NOTE: the first signature is the fee payer (Signatures must not be nil).
+// NOTE: the first signature is the fee payer (Signatures must not be nil),
+// unless the fee specifies a granter that has issued a fee allowance to them.
+//
+// A non-zero TimeoutHeight is the last block height at which the transaction
+// can be included. An Unordered transaction is not bound to the signers'
+// sequences, so several of them can be submitted in parallel; it must set a
+// TimeoutHeight and is protected against replay by its hash until then.
 type StdTx struct {
-	Msgs       []sdk.Msg      `json:"msg"`
-	Fee        StdFee         `json:"fee"`
-	Signatures []StdSignature `json:"signatures"`
-	Memo       string         `json:"memo"`
+	Msgs          []sdk.Msg      `json:"msg"`
+	Fee           StdFee         `json:"fee"`
+	Signatures    []StdSignature `json:"signatures"`
+	Memo          string         `json:"memo"`
+	TimeoutHeight uint64         `json:"timeout_height,omitempty"`
+	Unordered     bool           `json:"unordered,omitempty"`
 }
 
 func NewStdTx(msgs []sdk.Msg, fee StdFee, sigs []StdSignature, memo string) StdTx {
@@ -49,6 +57,12 @@ func (tx StdTx) ValidateBasic() sdk.Error {
 	if tx.Fee.Amount.IsAnyNegative() {
 		return sdk.ErrInsufficientFee(fmt.Sprintf("invalid fee %s amount provided", tx.Fee.Amount))
 	}
+	if len(tx.Fee.Granter) != 0 && len(tx.Fee.Granter) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("invalid fee granter address %s", tx.Fee.Granter))
+	}
+	if tx.Unordered && tx.TimeoutHeight == 0 {
+		return sdk.ErrTxTimeoutHeight("unordered transactions must set a timeout height")
+	}
 	if len(stdSigs) == 0 {
 		return sdk.ErrNoSignatures("no signers")
 	}
@@ -103,9 +117,26 @@ func (tx StdTx) GetSigners() []sdk.AccAddress {
 	return signers
 }
 
+// FeePayer returns the address that pays the fees of the transaction. This is
+// the fee granter if one is set, otherwise it is the first signer.
+func (tx StdTx) FeePayer() sdk.AccAddress {
+	if !tx.Fee.Granter.Empty() {
+		return tx.Fee.Granter
+	}
+	return tx.GetSigners()[0]
+}
+
 // GetMemo returns the memo
 func (tx StdTx) GetMemo() string { return tx.Memo }
 
+// WithTimeout returns a copy of the transaction with the given timeout height
+// and unordered mode.
+func (tx StdTx) WithTimeout(timeoutHeight uint64, unordered bool) StdTx {
+	tx.TimeoutHeight = timeoutHeight
+	tx.Unordered = unordered
+	return tx
+}
+
 // GetSignatures returns the signature of signers who signed the Msg.
 // GetSignatures returns the signature of signers who signed the Msg.
 // CONTRACT: Length returned is same as length of
@@ -121,9 +152,13 @@ func (tx StdTx) GetSignatures() []StdSignature { return tx.Signatures }
 // StdFee includes the amount of coins paid in fees and the maximum
 // gas to be used by the transaction. The ratio yields an effective "gasprice",
 // which must be above some miminum to be accepted into the mempool.
+//
+// The optional Granter is an account that has granted the first signer a fee
+// allowance; if set, the fees are deducted from the granter instead.
 type StdFee struct {
-	Amount sdk.Coins `json:"amount"`
-	Gas    uint64    `json:"gas"`
+	Amount  sdk.Coins      `json:"amount"`
+	Gas     uint64         `json:"gas"`
+	Granter sdk.AccAddress `json:"granter,omitempty"`
 }
 
 // NewStdFee returns a new instance of StdFee
@@ -134,6 +169,12 @@ func NewStdFee(gas uint64, amount sdk.Coins) StdFee {
 	}
 }
 
+// WithGranter returns a copy of the fee with the given fee granter.
+func (fee StdFee) WithGranter(granter sdk.AccAddress) StdFee {
+	fee.Granter = granter
+	return fee
+}
+
 // Bytes for signing later
 func (fee StdFee) Bytes() []byte {
 	// normalize. XXX
@@ -166,6 +207,7 @@ func (fee StdFee) GasPrices() sdk.DecCoins {
 // as well as the ChainID (prevent cross chain replay)
 // and the Sequence numbers for each signature (prevent
 // inchain replay and enforce tx ordering per account).
+// Unordered transactions are signed with a zero Sequence.
 type StdSignDoc struct {
 	AccountNumber uint64            `json:"account_number"`
 	ChainID       string            `json:"chain_id"`
@@ -173,10 +215,16 @@ type StdSignDoc struct {
 	Memo          string            `json:"memo"`
 	Msgs          []json.RawMessage `json:"msgs"`
 	Sequence      uint64            `json:"sequence"`
+	TimeoutHeight uint64            `json:"timeout_height,omitempty"`
+	Unordered     bool              `json:"unordered,omitempty"`
 }
 
 // StdSignBytes returns the bytes to sign for a transaction.
-func StdSignBytes(chainID string, accnum uint64, sequence uint64, fee StdFee, msgs []sdk.Msg, memo string) []byte {
+func StdSignBytes(
+	chainID string, accnum, sequence, timeoutHeight uint64, unordered bool,
+	fee StdFee, msgs []sdk.Msg, memo string,
+) []byte {
+
 	var msgsBytes []json.RawMessage
 	for _, msg := range msgs {
 		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
@@ -188,6 +236,8 @@ func StdSignBytes(chainID string, accnum uint64, sequence uint64, fee StdFee, ms
 		Memo:          memo,
 		Msgs:          msgsBytes,
 		Sequence:      sequence,
+		TimeoutHeight: timeoutHeight,
+		Unordered:     unordered,
 	})
 	if err != nil {
 		panic(err)
@@ -34,12 +34,14 @@ func TestStdTx(t *testing.T) {
 
 func TestStdSignBytes(t *testing.T) {
 	type args struct {
-		chainID  string
-		accnum   uint64
-		sequence uint64
-		fee      StdFee
-		msgs     []sdk.Msg
-		memo     string
+		chainID       string
+		accnum        uint64
+		sequence      uint64
+		timeoutHeight uint64
+		unordered     bool
+		fee           StdFee
+		msgs          []sdk.Msg
+		memo          string
 	}
 	defaultFee := newStdFee()
 	tests := []struct {
@@ -47,12 +49,19 @@ func TestStdSignBytes(t *testing.T) {
 		want string
 	}{
 		{
-			args{"1234", 3, 6, defaultFee, []sdk.Msg{sdk.NewTestMsg(addr)}, "memo"},
+			args{"1234", 3, 6, 0, false, defaultFee, []sdk.Msg{sdk.NewTestMsg(addr)}, "memo"},
 			fmt.Sprintf("{\"account_number\":\"3\",\"chain_id\":\"1234\",\"fee\":{\"amount\":[{\"amount\":\"150\",\"denom\":\"atom\"}],\"gas\":\"50000\"},\"memo\":\"memo\",\"msgs\":[[\"%s\"]],\"sequence\":\"6\"}", addr),
 		},
+		{
+			args{"1234", 3, 0, 100, true, defaultFee, []sdk.Msg{sdk.NewTestMsg(addr)}, "memo"},
+			fmt.Sprintf("{\"account_number\":\"3\",\"chain_id\":\"1234\",\"fee\":{\"amount\":[{\"amount\":\"150\",\"denom\":\"atom\"}],\"gas\":\"50000\"},\"memo\":\"memo\",\"msgs\":[[\"%s\"]],\"sequence\":\"0\",\"timeout_height\":\"100\",\"unordered\":true}", addr),
+		},
 	}
 	for i, tc := range tests {
-		got := string(StdSignBytes(tc.args.chainID, tc.args.accnum, tc.args.sequence, tc.args.fee, tc.args.msgs, tc.args.memo))
+		got := string(StdSignBytes(
+			tc.args.chainID, tc.args.accnum, tc.args.sequence, tc.args.timeoutHeight, tc.args.unordered,
+			tc.args.fee, tc.args.msgs, tc.args.memo,
+		))
 		require.Equal(t, tc.want, got, "Got unexpected result on test case i: %d", i)
 	}
 }
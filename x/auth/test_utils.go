@@ -17,6 +17,7 @@ import (
 type testInput struct {
 	cdc *codec.Codec
 	ctx sdk.Context
+	pk  params.Keeper
 	ak  AccountKeeper
 	fck FeeCollectionKeeper
 }
@@ -46,7 +47,7 @@ func setupTestInput() testInput {
 
 	ak.SetParams(ctx, DefaultParams())
 
-	return testInput{cdc: cdc, ctx: ctx, ak: ak, fck: fck}
+	return testInput{cdc: cdc, ctx: ctx, pk: pk, ak: ak, fck: fck}
 }
 
 func newTestMsg(addrs ...sdk.AccAddress) *sdk.TestMsg {
@@ -76,7 +77,7 @@ func keyPubAddr() (crypto.PrivKey, crypto.PubKey, sdk.AccAddress) {
 func newTestTx(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []uint64, seqs []uint64, fee StdFee) sdk.Tx {
 	sigs := make([]StdSignature, len(privs))
 	for i, priv := range privs {
-		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], fee, msgs, "")
+		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], 0, false, fee, msgs, "")
 
 		sig, err := priv.Sign(signBytes)
 		if err != nil {
@@ -93,7 +94,7 @@ func newTestTx(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums
 func newTestTxWithMemo(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []uint64, seqs []uint64, fee StdFee, memo string) sdk.Tx {
 	sigs := make([]StdSignature, len(privs))
 	for i, priv := range privs {
-		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], fee, msgs, memo)
+		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], 0, false, fee, msgs, memo)
 
 		sig, err := priv.Sign(signBytes)
 		if err != nil {
@@ -107,6 +108,27 @@ func newTestTxWithMemo(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey,
 	return tx
 }
 
+func newTestTxWithTimeout(
+	ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []uint64, seqs []uint64, fee StdFee,
+	timeoutHeight uint64, unordered bool,
+) sdk.Tx {
+
+	sigs := make([]StdSignature, len(privs))
+	for i, priv := range privs {
+		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], seqs[i], timeoutHeight, unordered, fee, msgs, "")
+
+		sig, err := priv.Sign(signBytes)
+		if err != nil {
+			panic(err)
+		}
+
+		sigs[i] = StdSignature{PubKey: priv.PubKey(), Signature: sig}
+	}
+
+	tx := NewStdTx(msgs, fee, sigs, "").WithTimeout(timeoutHeight, unordered)
+	return tx
+}
+
 func newTestTxWithSignBytes(msgs []sdk.Msg, privs []crypto.PrivKey, accNums []uint64, seqs []uint64, fee StdFee, signBytes []byte, memo string) sdk.Tx {
 	sigs := make([]StdSignature, len(privs))
 	for i, priv := range privs {
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxUnorderedTxTTL is the maximum number of blocks past the current height an
+// unordered transaction can set its timeout height to. It bounds the number of
+// transaction hashes kept for replay protection.
+const MaxUnorderedTxTTL uint64 = 100
+
+var (
+	// UnorderedTxKeyPrefix prefix for the hashes of the unordered transactions
+	// that have not timed out yet
+	UnorderedTxKeyPrefix = []byte{0x02}
+)
+
+// UnorderedTxKey is the key under which the hash of an unordered transaction
+// is stored until its timeout height: 0x02<timeout_height><tx_hash>. Keying by
+// the timeout height first allows pruning the hashes that timed out.
+func UnorderedTxKey(timeoutHeight uint64, txHash []byte) []byte {
+	return append(unorderedTxHeightKey(timeoutHeight), txHash...)
+}
+
+func unorderedTxHeightKey(timeoutHeight uint64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, timeoutHeight)
+	return append(UnorderedTxKeyPrefix, bz...)
+}
+
+// ContainsUnorderedTx returns true if an unordered transaction with the given
+// hash and timeout height has already been processed.
+func (ak AccountKeeper) ContainsUnorderedTx(ctx sdk.Context, timeoutHeight uint64, txHash []byte) bool {
+	store := ctx.KVStore(ak.key)
+	return store.Has(UnorderedTxKey(timeoutHeight, txHash))
+}
+
+// AddUnorderedTx records the hash of an unordered transaction until its
+// timeout height.
+func (ak AccountKeeper) AddUnorderedTx(ctx sdk.Context, timeoutHeight uint64, txHash []byte) {
+	store := ctx.KVStore(ak.key)
+	store.Set(UnorderedTxKey(timeoutHeight, txHash), []byte{})
+}
+
+// RemoveExpiredUnorderedTxs prunes the hashes of the unordered transactions
+// that can no longer be included in a block after the current one.
+func (ak AccountKeeper) RemoveExpiredUnorderedTxs(ctx sdk.Context) {
+	store := ctx.KVStore(ak.key)
+	end := unorderedTxHeightKey(uint64(ctx.BlockHeight()) + 1)
+	iter := store.Iterator(UnorderedTxKeyPrefix, end)
+
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// ValidateTimeout checks that the transaction has not timed out and, for
+// unordered transactions, that its timeout height is within MaxUnorderedTxTTL
+// blocks of the current height.
+func ValidateTimeout(ctx sdk.Context, stdTx StdTx) sdk.Result {
+	height := uint64(ctx.BlockHeight())
+	if stdTx.TimeoutHeight != 0 && height > stdTx.TimeoutHeight {
+		return sdk.ErrTxTimeoutHeight(
+			fmt.Sprintf("block height %d is past the tx timeout height %d", height, stdTx.TimeoutHeight),
+		).Result()
+	}
+
+	if stdTx.Unordered && stdTx.TimeoutHeight > height+MaxUnorderedTxTTL {
+		return sdk.ErrTxTimeoutHeight(
+			fmt.Sprintf(
+				"unordered tx timeout height %d is more than %d blocks past the block height %d",
+				stdTx.TimeoutHeight, MaxUnorderedTxTTL, height,
+			),
+		).Result()
+	}
+
+	return sdk.Result{}
+}
+
+// EndBlocker prunes the unordered transaction hashes that timed out.
+func EndBlocker(ctx sdk.Context, ak AccountKeeper) {
+	ak.RemoveExpiredUnorderedTxs(ctx)
+}
@@ -0,0 +1,122 @@
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// Authorization defines the interface of a permission a granter gives a
+// grantee to execute messages of a given type on its behalf. Implementations
+// must be registered on the module codec.
+type Authorization interface {
+	// MsgType returns the type of the messages the authorization applies to,
+	// as returned by MsgType.
+	MsgType() string
+
+	// Accept checks whether msg may be executed under the authorization and,
+	// if so, updates the authorization to reflect its use. If remove is true
+	// the authorization is used up and should be deleted from state.
+	Accept(msg sdk.Msg) (remove bool, err sdk.Error)
+
+	// ValidateBasic performs stateless validation of the authorization.
+	ValidateBasic() sdk.Error
+}
+
+// MsgType returns the identifier of the type of msg used to match it against
+// authorizations, in the form <route>/<type>.
+func MsgType(msg sdk.Msg) string {
+	return fmt.Sprintf("%s/%s", msg.Route(), msg.Type())
+}
+
+var (
+	_ Authorization = (*GenericAuthorization)(nil)
+	_ Authorization = (*SendAuthorization)(nil)
+)
+
+// GenericAuthorization grants unrestricted permission to execute messages of
+// type Msg, as returned by MsgType.
+type GenericAuthorization struct {
+	Msg string `json:"msg"`
+}
+
+// NewGenericAuthorization returns a new GenericAuthorization.
+func NewGenericAuthorization(msgType string) *GenericAuthorization {
+	return &GenericAuthorization{
+		Msg: msgType,
+	}
+}
+
+// MsgType implements Authorization.
+func (a *GenericAuthorization) MsgType() string {
+	return a.Msg
+}
+
+// Accept implements Authorization.
+func (a *GenericAuthorization) Accept(msg sdk.Msg) (bool, sdk.Error) {
+	if MsgType(msg) != a.Msg {
+		return false, ErrMsgTypeMismatch(DefaultCodespace, MsgType(msg), a.Msg)
+	}
+	return false, nil
+}
+
+// ValidateBasic implements Authorization.
+func (a *GenericAuthorization) ValidateBasic() sdk.Error {
+	if a.Msg == "" {
+		return ErrInvalidAuthorization(DefaultCodespace, "missing message type")
+	}
+	return nil
+}
+
+func (a GenericAuthorization) String() string {
+	return fmt.Sprintf(`Generic Authorization:
+  Msg: %s`, a.Msg)
+}
+
+// SendAuthorization grants permission to send up to SpendLimit coins from the
+// granter's account with bank.MsgSend.
+type SendAuthorization struct {
+	SpendLimit sdk.Coins `json:"spend_limit"`
+}
+
+// NewSendAuthorization returns a new SendAuthorization.
+func NewSendAuthorization(spendLimit sdk.Coins) *SendAuthorization {
+	return &SendAuthorization{
+		SpendLimit: spendLimit,
+	}
+}
+
+// MsgType implements Authorization.
+func (a *SendAuthorization) MsgType() string {
+	return MsgType(bank.MsgSend{})
+}
+
+// Accept implements Authorization.
+func (a *SendAuthorization) Accept(msg sdk.Msg) (bool, sdk.Error) {
+	send, ok := msg.(bank.MsgSend)
+	if !ok {
+		return false, ErrMsgTypeMismatch(DefaultCodespace, MsgType(msg), a.MsgType())
+	}
+
+	left, hasNeg := a.SpendLimit.SafeSub(send.Amount)
+	if hasNeg {
+		return false, ErrSpendLimitExceeded(DefaultCodespace, send.Amount, a.SpendLimit)
+	}
+
+	a.SpendLimit = left
+	return left.IsZero(), nil
+}
+
+// ValidateBasic implements Authorization.
+func (a *SendAuthorization) ValidateBasic() sdk.Error {
+	if !a.SpendLimit.IsValid() || a.SpendLimit.IsZero() {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("invalid spend limit %s", a.SpendLimit))
+	}
+	return nil
+}
+
+func (a SendAuthorization) String() string {
+	return fmt.Sprintf(`Send Authorization:
+  Spend Limit: %s`, a.SpendLimit)
+}
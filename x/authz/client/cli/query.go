@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// GetCmdQueryAuthorization implements a command to return the authorization a
+// granter gave a grantee for a message type.
+func GetCmdQueryAuthorization(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "authorization [granter-addr] [grantee-addr] [msg-type]",
+		Short: "Query the authorization a granter gave a grantee for a message type",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(authz.NewQueryAuthorizationParams(granter, grantee, args[2]))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", authz.QuerierRoute, authz.QueryAuthorization)
+			res, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var grant authz.AuthorizationGrant
+			if err := cdc.UnmarshalJSON(res, &grant); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(grant)
+		},
+	}
+}
+
+// GetCmdQueryAuthorizations implements a command to return all the
+// authorizations a granter gave a grantee.
+func GetCmdQueryAuthorizations(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "authorizations [granter-addr] [grantee-addr]",
+		Short: "Query all the authorizations a granter gave a grantee",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(authz.NewQueryAuthorizationsParams(granter, grantee))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", authz.QuerierRoute, authz.QueryAuthorizations)
+			res, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var grants authz.AuthorizationGrants
+			if err := cdc.UnmarshalJSON(res, &grants); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(grants)
+		},
+	}
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+const (
+	flagSpendLimit = "spend-limit"
+	flagExpiration = "expiration"
+)
+
+// GetCmdGrantAuthorization implements the command to grant an authorization.
+func GetCmdGrantAuthorization(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee-addr] [msg-type]",
+		Short: "Grant an account permission to execute messages of a type on your behalf",
+		Long: strings.TrimSpace(`Grant an account permission to execute messages of a type on your behalf.
+Message types are given as <route>/<type>. For bank/send a spend limit is
+required; without an expiration the authorization never expires:
+
+$ gaiacli tx authz grant cosmos1... distr/withdraw_delegator_reward --from mykey
+$ gaiacli tx authz grant cosmos1... gov/vote --expiration=2020-01-01T00:00:00Z --from mykey
+$ gaiacli tx authz grant cosmos1... bank/send --spend-limit=1000uatom --from mykey
+`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			var authorization authz.Authorization = authz.NewGenericAuthorization(args[1])
+			if limit := viper.GetString(flagSpendLimit); limit != "" {
+				spendLimit, err := sdk.ParseCoins(limit)
+				if err != nil {
+					return err
+				}
+				authorization = authz.NewSendAuthorization(spendLimit)
+			}
+
+			var expiration time.Time
+			if exp := viper.GetString(flagExpiration); exp != "" {
+				expiration, err = time.Parse(time.RFC3339, exp)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := authz.NewMsgGrantAuthorization(cliCtx.GetFromAddress(), grantee, authorization, expiration)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, false)
+		},
+	}
+
+	cmd.Flags().String(flagSpendLimit, "", "The maximum amount of coins that can be sent (bank/send only)")
+	cmd.Flags().String(flagExpiration, "", "The time (RFC3339) at which the authorization expires")
+	return cmd
+}
+
+// GetCmdRevokeAuthorization implements the command to revoke an authorization.
+func GetCmdRevokeAuthorization(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke [grantee-addr] [msg-type]",
+		Short: "Revoke the authorization you gave an account for a message type",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgRevokeAuthorization(cliCtx.GetFromAddress(), grantee, args[1])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, false)
+		},
+	}
+}
+
+// GetCmdExec implements the command to execute the messages of a transaction
+// on behalf of their signers.
+func GetCmdExec(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "exec [tx-json-file]",
+		Short: "Execute the messages of a transaction on behalf of their signers",
+		Long: strings.TrimSpace(`Execute the messages of a transaction on behalf of their signers, using
+the authorizations they gave you. The transaction is typically created with
+--generate-only:
+
+$ gaiacli tx distr withdraw-rewards cosmosvaloper1... --from cosmos1... --generate-only > tx.json
+$ gaiacli tx authz exec tx.json --from mykey
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgExec(cliCtx.GetFromAddress(), stdTx.GetMsgs())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, false)
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"github.com/spf13/cobra"
+	amino "github.com/tendermint/go-amino"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/cosmos-sdk/x/authz/client/cli"
+)
+
+// ModuleClient exports all client functionality from this module
+type ModuleClient struct {
+	storeKey string
+	cdc      *amino.Codec
+}
+
+// NewModuleClient creates a new ModuleClient object
+func NewModuleClient(storeKey string, cdc *amino.Codec) ModuleClient {
+	return ModuleClient{
+		storeKey: storeKey,
+		cdc:      cdc,
+	}
+}
+
+// GetQueryCmd returns the cli query commands for this module
+func (mc ModuleClient) GetQueryCmd() *cobra.Command {
+	queryCmd := &cobra.Command{
+		Use:   authz.ModuleName,
+		Short: "Querying commands for the authz module",
+	}
+
+	queryCmd.AddCommand(sdkclient.GetCommands(
+		cli.GetCmdQueryAuthorization(mc.cdc),
+		cli.GetCmdQueryAuthorizations(mc.cdc),
+	)...)
+
+	return queryCmd
+}
+
+// GetTxCmd returns the transaction commands for this module
+func (mc ModuleClient) GetTxCmd() *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   authz.ModuleName,
+		Short: "Authorization transactions subcommands",
+	}
+
+	txCmd.AddCommand(sdkclient.PostCommands(
+		cli.GetCmdGrantAuthorization(mc.cdc),
+		cli.GetCmdRevokeAuthorization(mc.cdc),
+		cli.GetCmdExec(mc.cdc),
+	)...)
+
+	return txCmd
+}
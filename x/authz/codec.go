@@ -0,0 +1,28 @@
+package authz
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterInterface((*Authorization)(nil), nil)
+	cdc.RegisterConcrete(&GenericAuthorization{}, "cosmos-sdk/GenericAuthorization", nil)
+	cdc.RegisterConcrete(&SendAuthorization{}, "cosmos-sdk/SendAuthorization", nil)
+
+	cdc.RegisterConcrete(MsgGrantAuthorization{}, "cosmos-sdk/MsgGrantAuthorization", nil)
+	cdc.RegisterConcrete(MsgRevokeAuthorization{}, "cosmos-sdk/MsgRevokeAuthorization", nil)
+	cdc.RegisterConcrete(MsgExec{}, "cosmos-sdk/MsgExec", nil)
+}
+
+// generic sealed codec to be used throughout module
+var MsgCdc *codec.Codec
+
+func init() {
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	MsgCdc = cdc.Seal()
+}
@@ -0,0 +1,56 @@
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Authz errors reserve 100 ~ 199.
+const (
+	DefaultCodespace sdk.CodespaceType = ModuleName
+
+	CodeNoAuthorization      sdk.CodeType = 101
+	CodeAuthorizationExpired sdk.CodeType = 102
+	CodeInvalidAuthorization sdk.CodeType = 103
+	CodeMsgTypeMismatch      sdk.CodeType = 104
+	CodeSpendLimitExceeded   sdk.CodeType = 105
+	CodeSelfGrant            sdk.CodeType = 106
+	CodeInvalidExecMsg       sdk.CodeType = 107
+)
+
+// ErrNoAuthorization is returned if there is no authorization for the message
+func ErrNoAuthorization(codespace sdk.CodespaceType, msgType string) sdk.Error {
+	return sdk.NewError(codespace, CodeNoAuthorization, fmt.Sprintf("no authorization for message type %s", msgType))
+}
+
+// ErrAuthorizationExpired is returned if the authorization has expired
+func ErrAuthorizationExpired(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeAuthorizationExpired, "authorization has expired")
+}
+
+// ErrInvalidAuthorization is returned if an authorization is malformed
+func ErrInvalidAuthorization(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAuthorization, fmt.Sprintf("invalid authorization: %s", msg))
+}
+
+// ErrMsgTypeMismatch is returned if a message is checked against an
+// authorization for another message type
+func ErrMsgTypeMismatch(codespace sdk.CodespaceType, msgType, authorized string) sdk.Error {
+	return sdk.NewError(codespace, CodeMsgTypeMismatch, fmt.Sprintf("message type %s does not match authorized type %s", msgType, authorized))
+}
+
+// ErrSpendLimitExceeded is returned if a send exceeds the authorized amount
+func ErrSpendLimitExceeded(codespace sdk.CodespaceType, amount, limit sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeSpendLimitExceeded, fmt.Sprintf("amount %s exceeds the authorized spend limit %s", amount, limit))
+}
+
+// ErrSelfGrant is returned if the granter and grantee are the same account
+func ErrSelfGrant(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeSelfGrant, "cannot grant an authorization to oneself")
+}
+
+// ErrInvalidExecMsg is returned if a MsgExec wraps messages it cannot execute
+func ErrInvalidExecMsg(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidExecMsg, fmt.Sprintf("invalid exec message: %s", msg))
+}
@@ -0,0 +1,13 @@
+package authz
+
+// authz module event types
+const (
+	EventTypeGrantAuthorization  = "grant_authorization"
+	EventTypeRevokeAuthorization = "revoke_authorization"
+
+	AttributeKeyGranter = "granter"
+	AttributeKeyGrantee = "grantee"
+	AttributeKeyMsgType = "msg_type"
+
+	AttributeValueCategory = ModuleName
+)
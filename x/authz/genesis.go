@@ -0,0 +1,50 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState contains the authorizations in state
+type GenesisState struct {
+	Authorizations []AuthorizationGrant `json:"authorizations"`
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(authorizations []AuthorizationGrant) GenesisState {
+	return GenesisState{
+		Authorizations: authorizations,
+	}
+}
+
+// DefaultGenesisState returns a GenesisState without any authorizations
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// ValidateGenesis ensures all grants in the genesis state are valid
+func ValidateGenesis(data GenesisState) error {
+	for _, grant := range data.Authorizations {
+		if err := grant.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InitGenesis stores the authorizations of the genesis state
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.Authorizations {
+		k.Grant(ctx, grant)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var grants []AuthorizationGrant
+	k.IterateAllGrants(ctx, func(grant AuthorizationGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+
+	return NewGenesisState(grants)
+}
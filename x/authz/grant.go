@@ -0,0 +1,77 @@
+package authz
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AuthorizationGrant is stored in the KVStore to record an authorization the
+// Granter has given the Grantee. A zero Expiration means the authorization
+// never expires.
+type AuthorizationGrant struct {
+	Granter       sdk.AccAddress `json:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee"`
+	Authorization Authorization  `json:"authorization"`
+	Expiration    time.Time      `json:"expiration"`
+}
+
+// NewAuthorizationGrant returns a new AuthorizationGrant.
+func NewAuthorizationGrant(
+	granter, grantee sdk.AccAddress, authorization Authorization, expiration time.Time,
+) AuthorizationGrant {
+
+	return AuthorizationGrant{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+// ValidateBasic performs stateless validation of the grant.
+func (g AuthorizationGrant) ValidateBasic() sdk.Error {
+	if g.Granter.Empty() {
+		return sdk.ErrInvalidAddress("missing granter address")
+	}
+	if g.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("missing grantee address")
+	}
+	if g.Grantee.Equals(g.Granter) {
+		return ErrSelfGrant(DefaultCodespace)
+	}
+	if g.Authorization == nil {
+		return ErrInvalidAuthorization(DefaultCodespace, "missing authorization")
+	}
+
+	return g.Authorization.ValidateBasic()
+}
+
+// IsExpired returns true if the grant has expired at the given block time.
+func (g AuthorizationGrant) IsExpired(blockTime time.Time) bool {
+	return !g.Expiration.IsZero() && !blockTime.Before(g.Expiration)
+}
+
+func (g AuthorizationGrant) String() string {
+	return fmt.Sprintf(`Granter:    %s
+Grantee:    %s
+Expiration: %s
+%s`, g.Granter, g.Grantee, g.Expiration, g.Authorization)
+}
+
+// AuthorizationGrants is a collection of AuthorizationGrant
+type AuthorizationGrants []AuthorizationGrant
+
+func (gs AuthorizationGrants) String() string {
+	if len(gs) == 0 {
+		return "[]"
+	}
+
+	out := make([]string, len(gs))
+	for i, g := range gs {
+		out[i] = g.String()
+	}
+	return strings.Join(out, "\n")
+}
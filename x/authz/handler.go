@@ -0,0 +1,80 @@
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "authz" type messages. MsgExec is
+// dispatched by the BaseApp and never reaches the handler.
+func NewHandler(k Keeper) sdk.Handler {
+	router := sdk.NewMsgRouter()
+	RegisterMsgHandlers(router, k)
+	return sdk.NewMsgRouterHandler(router)
+}
+
+// RegisterMsgHandlers registers the handlers of the authz messages. MsgExec
+// has a handler so that it is listed with the messages of the router.
+func RegisterMsgHandlers(router sdk.MsgRouter, k Keeper) {
+	router.
+		AddMsgHandler(MsgGrantAuthorization{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgGrantAuthorization(ctx, k, msg.(MsgGrantAuthorization))
+		}).
+		AddMsgHandler(MsgRevokeAuthorization{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgRevokeAuthorization(ctx, k, msg.(MsgRevokeAuthorization))
+		}).
+		AddMsgHandler(MsgExec{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgExec(ctx, k, msg.(MsgExec))
+		})
+}
+
+func handleMsgGrantAuthorization(ctx sdk.Context, k Keeper, msg MsgGrantAuthorization) sdk.Result {
+	grant := NewAuthorizationGrant(msg.Granter, msg.Grantee, msg.Authorization, msg.Expiration)
+	k.Grant(ctx, grant)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeGrantAuthorization,
+			sdk.NewAttribute(AttributeKeyGranter, msg.Granter.String()),
+			sdk.NewAttribute(AttributeKeyGrantee, msg.Grantee.String()),
+			sdk.NewAttribute(AttributeKeyMsgType, msg.Authorization.MsgType()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRevokeAuthorization(ctx sdk.Context, k Keeper, msg MsgRevokeAuthorization) sdk.Result {
+	if _, found := k.GetGrant(ctx, msg.Granter, msg.Grantee, msg.MsgType); !found {
+		return ErrNoAuthorization(DefaultCodespace, msg.MsgType).Result()
+	}
+
+	k.Revoke(ctx, msg.Granter, msg.Grantee, msg.MsgType)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeRevokeAuthorization,
+			sdk.NewAttribute(AttributeKeyGranter, msg.Granter.String()),
+			sdk.NewAttribute(AttributeKeyGrantee, msg.Grantee.String()),
+			sdk.NewAttribute(AttributeKeyMsgType, msg.MsgType),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgExec(_ sdk.Context, _ Keeper, msg MsgExec) sdk.Result {
+	errMsg := fmt.Sprintf("%T is executed by the BaseApp", msg)
+	return sdk.ErrUnknownRequest(errMsg).Result()
+}
@@ -0,0 +1,140 @@
+package authz
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "authz"
+
+	// StoreKey is the store key string for the authz module
+	StoreKey = ModuleName
+
+	// RouterKey is the message route for the authz module
+	RouterKey = ModuleName
+
+	// QuerierRoute is the querier route for the authz module
+	QuerierRoute = ModuleName
+)
+
+var (
+	// AuthorizationKeyPrefix is the prefix of the keys storing authorizations
+	AuthorizationKeyPrefix = []byte{0x00}
+)
+
+// AuthorizationKey is the key under which the authorization granter gave
+// grantee for msgType is stored: 0x00<granter_bytes><grantee_bytes><msg_type>.
+func AuthorizationKey(granter, grantee sdk.AccAddress, msgType string) []byte {
+	return append(AuthorizationPrefixByPair(granter, grantee), []byte(msgType)...)
+}
+
+// AuthorizationPrefixByPair returns the prefix of all authorizations granter
+// gave grantee.
+func AuthorizationPrefixByPair(granter, grantee sdk.AccAddress) []byte {
+	prefix := append(AuthorizationKeyPrefix, granter.Bytes()...)
+	return append(prefix, grantee.Bytes()...)
+}
+
+// Keeper manages the authorizations granters give grantees.
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+}
+
+// NewKeeper creates a new authz Keeper.
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey) Keeper {
+	return Keeper{
+		storeKey: storeKey,
+		cdc:      cdc,
+	}
+}
+
+// Grant stores the grant, replacing any existing authorization from the same
+// granter to the same grantee for the same message type.
+func (k Keeper) Grant(ctx sdk.Context, grant AuthorizationGrant) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(grant)
+	store.Set(AuthorizationKey(grant.Granter, grant.Grantee, grant.Authorization.MsgType()), bz)
+}
+
+// Revoke removes any existing authorization from granter to grantee for
+// msgType.
+func (k Keeper) Revoke(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(AuthorizationKey(granter, grantee, msgType))
+}
+
+// GetGrant returns the grant from granter to grantee for msgType.
+func (k Keeper) GetGrant(
+	ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string,
+) (grant AuthorizationGrant, found bool) {
+
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(AuthorizationKey(granter, grantee, msgType))
+	if bz == nil {
+		return grant, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &grant)
+	return grant, true
+}
+
+// IterateGrants iterates over all the authorizations granter gave grantee. The
+// iteration stops if cb returns true.
+func (k Keeper) IterateGrants(
+	ctx sdk.Context, granter, grantee sdk.AccAddress, cb func(AuthorizationGrant) (stop bool),
+) {
+	k.iterateGrants(ctx, AuthorizationPrefixByPair(granter, grantee), cb)
+}
+
+// IterateAllGrants iterates over all the authorizations in state. The
+// iteration stops if cb returns true.
+func (k Keeper) IterateAllGrants(ctx sdk.Context, cb func(AuthorizationGrant) (stop bool)) {
+	k.iterateGrants(ctx, AuthorizationKeyPrefix, cb)
+}
+
+func (k Keeper) iterateGrants(ctx sdk.Context, prefix []byte, cb func(AuthorizationGrant) bool) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var grant AuthorizationGrant
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &grant)
+		if cb(grant) {
+			break
+		}
+	}
+}
+
+// Authorize checks that granter authorized grantee to execute msg on its
+// behalf. The authorization is updated, or removed once it is used up or has
+// expired. It implements sdk.MsgAuthorizer and is meant to be set on the
+// BaseApp.
+func (k Keeper) Authorize(ctx sdk.Context, granter, grantee sdk.AccAddress, msg sdk.Msg) sdk.Error {
+	msgType := MsgType(msg)
+
+	grant, found := k.GetGrant(ctx, granter, grantee, msgType)
+	if !found {
+		return ErrNoAuthorization(DefaultCodespace, msgType)
+	}
+
+	if grant.IsExpired(ctx.BlockHeader().Time) {
+		k.Revoke(ctx, granter, grantee, msgType)
+		return ErrAuthorizationExpired(DefaultCodespace)
+	}
+
+	remove, err := grant.Authorization.Accept(msg)
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		k.Revoke(ctx, granter, grantee, msgType)
+	} else {
+		k.Grant(ctx, grant)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package authz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+var (
+	addr1 = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr2 = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr3 = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+)
+
+func createTestInput() (sdk.Context, Keeper) {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey(StoreKey)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		panic(err)
+	}
+
+	cdc := codec.New()
+	RegisterCodec(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Now().UTC()}, false, log.NewNopLogger())
+	return ctx, NewKeeper(cdc, key)
+}
+
+func TestKeeperGrantRevoke(t *testing.T) {
+	ctx, k := createTestInput()
+	voteType := MsgType(gov.MsgVote{})
+
+	_, found := k.GetGrant(ctx, addr1, addr2, voteType)
+	require.False(t, found)
+
+	k.Grant(ctx, NewAuthorizationGrant(addr1, addr2, NewGenericAuthorization(voteType), time.Time{}))
+	k.Grant(ctx, NewAuthorizationGrant(addr1, addr2, NewSendAuthorization(sdk.NewCoins(sdk.NewInt64Coin("atom", 10))), time.Time{}))
+	k.Grant(ctx, NewAuthorizationGrant(addr1, addr3, NewGenericAuthorization(voteType), time.Time{}))
+
+	grant, found := k.GetGrant(ctx, addr1, addr2, voteType)
+	require.True(t, found)
+	require.Equal(t, NewGenericAuthorization(voteType), grant.Authorization)
+
+	var grants []AuthorizationGrant
+	k.IterateGrants(ctx, addr1, addr2, func(grant AuthorizationGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	require.Len(t, grants, 2)
+
+	k.Revoke(ctx, addr1, addr2, voteType)
+	_, found = k.GetGrant(ctx, addr1, addr2, voteType)
+	require.False(t, found)
+
+	_, found = k.GetGrant(ctx, addr1, addr3, voteType)
+	require.True(t, found)
+}
+
+func TestKeeperAuthorize(t *testing.T) {
+	ctx, k := createTestInput()
+	vote := gov.NewMsgVote(addr1, 1, gov.OptionYes)
+	send := bank.NewMsgSend(addr1, addr3, sdk.NewCoins(sdk.NewInt64Coin("atom", 6)))
+
+	require.Error(t, k.Authorize(ctx, addr1, addr2, vote))
+
+	// generic authorizations are not used up
+	k.Grant(ctx, NewAuthorizationGrant(addr1, addr2, NewGenericAuthorization(MsgType(vote)), time.Time{}))
+	require.NoError(t, k.Authorize(ctx, addr1, addr2, vote))
+	require.NoError(t, k.Authorize(ctx, addr1, addr2, vote))
+	require.Error(t, k.Authorize(ctx, addr1, addr3, vote))
+
+	// send authorizations are spent and removed once used up
+	limit := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	k.Grant(ctx, NewAuthorizationGrant(addr1, addr2, NewSendAuthorization(limit), time.Time{}))
+	require.NoError(t, k.Authorize(ctx, addr1, addr2, send))
+
+	grant, found := k.GetGrant(ctx, addr1, addr2, MsgType(send))
+	require.True(t, found)
+	require.Equal(t, NewSendAuthorization(sdk.NewCoins(sdk.NewInt64Coin("atom", 4))), grant.Authorization)
+
+	err := k.Authorize(ctx, addr1, addr2, send)
+	require.Equal(t, CodeSpendLimitExceeded, err.Code())
+
+	send.Amount = sdk.NewCoins(sdk.NewInt64Coin("atom", 4))
+	require.NoError(t, k.Authorize(ctx, addr1, addr2, send))
+	_, found = k.GetGrant(ctx, addr1, addr2, MsgType(send))
+	require.False(t, found)
+
+	// expired authorizations are removed
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+	k.Grant(ctx, NewAuthorizationGrant(addr1, addr2, NewGenericAuthorization(MsgType(vote)), expiration))
+	require.NoError(t, k.Authorize(ctx, addr1, addr2, vote))
+
+	ctx = ctx.WithBlockTime(expiration)
+	err = k.Authorize(ctx, addr1, addr2, vote)
+	require.Equal(t, CodeAuthorizationExpired, err.Code())
+	_, found = k.GetGrant(ctx, addr1, addr2, MsgType(vote))
+	require.False(t, found)
+}
@@ -0,0 +1,94 @@
+package authz
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ sdk.AppModule            = AppModule{}
+	_ sdk.AppModuleBasic       = AppModuleBasic{}
+	_ sdk.AppModuleMsgHandlers = AppModule{}
+)
+
+// AppModuleBasic defines the basic elements of the authz module.
+type AppModuleBasic struct{}
+
+// Name returns the authz module's name.
+func (AppModuleBasic) Name() string { return ModuleName }
+
+// RegisterCodec registers the authz module's types on the codec.
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) { RegisterCodec(cdc) }
+
+// Msgs returns the authz module's messages.
+func (AppModuleBasic) Msgs() []sdk.Msg {
+	return []sdk.Msg{MsgGrantAuthorization{}, MsgRevokeAuthorization{}, MsgExec{}}
+}
+
+// DefaultGenesis returns the default genesis state of the authz module.
+func (AppModuleBasic) DefaultGenesis(cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// ValidateGenesis validates a genesis state of the authz module.
+func (AppModuleBasic) ValidateGenesis(cdc *codec.Codec, bz json.RawMessage) error {
+	var data GenesisState
+	if err := cdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+	return ValidateGenesis(data)
+}
+
+// AppModule implements the sdk.AppModule interface for the authz module.
+type AppModule struct {
+	AppModuleBasic
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{
+		keeper: keeper,
+	}
+}
+
+// RegisterInvariants registers no invariants, as the authz module has none.
+func (AppModule) RegisterInvariants(sdk.InvariantRouter) {}
+
+// Route returns the authz module's message route.
+func (AppModule) Route() string { return RouterKey }
+
+// NewHandler returns the authz module's message handler.
+func (am AppModule) NewHandler() sdk.Handler { return NewHandler(am.keeper) }
+
+// RegisterMsgHandlers registers the authz module's message handlers.
+func (am AppModule) RegisterMsgHandlers(router sdk.MsgRouter) { RegisterMsgHandlers(router, am.keeper) }
+
+// QuerierRoute returns the authz module's querier route.
+func (AppModule) QuerierRoute() string { return QuerierRoute }
+
+// NewQuerierHandler returns the authz module's querier.
+func (am AppModule) NewQuerierHandler() sdk.Querier { return NewQuerier(am.keeper) }
+
+// InitGenesis initializes the authz module's genesis state.
+func (am AppModule) InitGenesis(ctx sdk.Context, cdc *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	cdc.MustUnmarshalJSON(bz, &data)
+	InitGenesis(ctx, am.keeper, data)
+	return nil
+}
+
+// ExportGenesis exports the authz module's genesis state.
+func (am AppModule) ExportGenesis(ctx sdk.Context, cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(ExportGenesis(ctx, am.keeper))
+}
+
+// BeginBlock runs nothing at the start of a block.
+func (AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {}
+
+// EndBlock runs nothing at the end of a block.
+func (AppModule) EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate { return nil }
@@ -0,0 +1,171 @@
+package authz
+
+import (
+	"encoding/json"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ensure Msg interface compliance at compile time
+var (
+	_ sdk.Msg     = MsgGrantAuthorization{}
+	_ sdk.Msg     = MsgRevokeAuthorization{}
+	_ sdk.ExecMsg = MsgExec{}
+)
+
+// MsgGrantAuthorization gives Grantee permission to execute messages covered
+// by Authorization on behalf of Granter until Expiration. If there was already
+// an authorization for the same message type, it is replaced.
+type MsgGrantAuthorization struct {
+	Granter       sdk.AccAddress `json:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee"`
+	Authorization Authorization  `json:"authorization"`
+	Expiration    time.Time      `json:"expiration"`
+}
+
+// NewMsgGrantAuthorization creates a new MsgGrantAuthorization.
+func NewMsgGrantAuthorization(
+	granter, grantee sdk.AccAddress, authorization Authorization, expiration time.Time,
+) MsgGrantAuthorization {
+
+	return MsgGrantAuthorization{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+//nolint
+func (msg MsgGrantAuthorization) Route() string { return RouterKey }
+func (msg MsgGrantAuthorization) Type() string  { return "grant_authorization" }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgGrantAuthorization) ValidateBasic() sdk.Error {
+	return NewAuthorizationGrant(msg.Granter, msg.Grantee, msg.Authorization, msg.Expiration).ValidateBasic()
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgGrantAuthorization) GetSignBytes() []byte {
+	return sdk.MustSortJSON(MsgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgGrantAuthorization) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgRevokeAuthorization removes any existing authorization from Granter to
+// Grantee for messages of type MsgType.
+type MsgRevokeAuthorization struct {
+	Granter sdk.AccAddress `json:"granter"`
+	Grantee sdk.AccAddress `json:"grantee"`
+	MsgType string         `json:"msg_type"`
+}
+
+// NewMsgRevokeAuthorization creates a new MsgRevokeAuthorization.
+func NewMsgRevokeAuthorization(granter, grantee sdk.AccAddress, msgType string) MsgRevokeAuthorization {
+	return MsgRevokeAuthorization{
+		Granter: granter,
+		Grantee: grantee,
+		MsgType: msgType,
+	}
+}
+
+//nolint
+func (msg MsgRevokeAuthorization) Route() string { return RouterKey }
+func (msg MsgRevokeAuthorization) Type() string  { return "revoke_authorization" }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgRevokeAuthorization) ValidateBasic() sdk.Error {
+	if msg.Granter.Empty() {
+		return sdk.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("missing grantee address")
+	}
+	if msg.MsgType == "" {
+		return ErrInvalidAuthorization(DefaultCodespace, "missing message type")
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgRevokeAuthorization) GetSignBytes() []byte {
+	return sdk.MustSortJSON(MsgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgRevokeAuthorization) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgExec executes Msgs on behalf of their signers. Grantee must hold an
+// authorization from every signer of the wrapped messages other than itself.
+// The wrapped messages are dispatched by the BaseApp, not by the authz
+// handler.
+type MsgExec struct {
+	Grantee sdk.AccAddress `json:"grantee"`
+	Msgs    []sdk.Msg      `json:"msgs"`
+}
+
+// NewMsgExec creates a new MsgExec.
+func NewMsgExec(grantee sdk.AccAddress, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+//nolint
+func (msg MsgExec) Route() string { return RouterKey }
+func (msg MsgExec) Type() string  { return "exec" }
+
+// GetExecMsgs implements sdk.ExecMsg
+func (msg MsgExec) GetExecMsgs() []sdk.Msg {
+	return msg.Msgs
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgExec) ValidateBasic() sdk.Error {
+	if msg.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("missing grantee address")
+	}
+	if len(msg.Msgs) == 0 {
+		return ErrInvalidExecMsg(DefaultCodespace, "no messages to execute")
+	}
+
+	for _, m := range msg.Msgs {
+		if _, ok := m.(sdk.ExecMsg); ok {
+			return ErrInvalidExecMsg(DefaultCodespace, "exec messages cannot be nested")
+		}
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg. The wrapped messages are signed with their
+// own sign bytes, as the module codec does not know their concrete types.
+func (msg MsgExec) GetSignBytes() []byte {
+	msgsBytes := make([]json.RawMessage, len(msg.Msgs))
+	for i, m := range msg.Msgs {
+		msgsBytes[i] = json.RawMessage(m.GetSignBytes())
+	}
+
+	bz, err := MsgCdc.MarshalJSON(struct {
+		Grantee sdk.AccAddress    `json:"grantee"`
+		Msgs    []json.RawMessage `json:"msgs"`
+	}{msg.Grantee, msgsBytes})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgExec) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Grantee}
+}
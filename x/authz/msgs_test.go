@@ -0,0 +1,66 @@
+package authz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+func TestMsgGrantAuthorizationValidateBasic(t *testing.T) {
+	vote := NewGenericAuthorization(MsgType(gov.MsgVote{}))
+	send := NewSendAuthorization(sdk.NewCoins(sdk.NewInt64Coin("atom", 10)))
+
+	cases := []struct {
+		msg   MsgGrantAuthorization
+		valid bool
+	}{
+		{NewMsgGrantAuthorization(addr1, addr2, vote, time.Time{}), true},
+		{NewMsgGrantAuthorization(addr1, addr2, send, time.Now()), true},
+		{NewMsgGrantAuthorization(nil, addr2, vote, time.Time{}), false},
+		{NewMsgGrantAuthorization(addr1, nil, vote, time.Time{}), false},
+		{NewMsgGrantAuthorization(addr1, addr1, vote, time.Time{}), false},
+		{NewMsgGrantAuthorization(addr1, addr2, nil, time.Time{}), false},
+		{NewMsgGrantAuthorization(addr1, addr2, NewGenericAuthorization(""), time.Time{}), false},
+		{NewMsgGrantAuthorization(addr1, addr2, NewSendAuthorization(nil), time.Time{}), false},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		require.Equal(t, tc.valid, err == nil, "unexpected result for case #%d: %v", i, err)
+	}
+}
+
+func TestMsgExecValidateBasic(t *testing.T) {
+	vote := gov.NewMsgVote(addr1, 1, gov.OptionYes)
+	send := bank.NewMsgSend(addr1, addr3, sdk.NewCoins(sdk.NewInt64Coin("atom", 6)))
+
+	cases := []struct {
+		msg   MsgExec
+		valid bool
+	}{
+		{NewMsgExec(addr2, []sdk.Msg{vote, send}), true},
+		{NewMsgExec(nil, []sdk.Msg{vote}), false},
+		{NewMsgExec(addr2, nil), false},
+		{NewMsgExec(addr2, []sdk.Msg{gov.NewMsgVote(nil, 1, gov.OptionYes)}), false},
+		{NewMsgExec(addr2, []sdk.Msg{NewMsgExec(addr1, []sdk.Msg{vote})}), false},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		require.Equal(t, tc.valid, err == nil, "unexpected result for case #%d: %v", i, err)
+	}
+}
+
+func TestMsgExecGetSignBytes(t *testing.T) {
+	vote := gov.NewMsgVote(addr1, 1, gov.OptionYes)
+	msg := NewMsgExec(addr2, []sdk.Msg{vote})
+
+	require.NotPanics(t, func() { msg.GetSignBytes() })
+	require.Contains(t, string(msg.GetSignBytes()), string(vote.GetSignBytes()))
+	require.Equal(t, []sdk.AccAddress{addr2}, msg.GetSigners())
+}
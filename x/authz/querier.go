@@ -0,0 +1,103 @@
+package authz
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the authz Querier
+const (
+	QueryAuthorization  = "authorization"
+	QueryAuthorizations = "authorizations"
+)
+
+// QueryAuthorizationParams defines the params for querying the authorization
+// a granter gave a grantee for a message type
+type QueryAuthorizationParams struct {
+	Granter sdk.AccAddress
+	Grantee sdk.AccAddress
+	MsgType string
+}
+
+// NewQueryAuthorizationParams creates a new instance of QueryAuthorizationParams
+func NewQueryAuthorizationParams(granter, grantee sdk.AccAddress, msgType string) QueryAuthorizationParams {
+	return QueryAuthorizationParams{
+		Granter: granter,
+		Grantee: grantee,
+		MsgType: msgType,
+	}
+}
+
+// QueryAuthorizationsParams defines the params for querying all the
+// authorizations a granter gave a grantee
+type QueryAuthorizationsParams struct {
+	Granter sdk.AccAddress
+	Grantee sdk.AccAddress
+}
+
+// NewQueryAuthorizationsParams creates a new instance of QueryAuthorizationsParams
+func NewQueryAuthorizationsParams(granter, grantee sdk.AccAddress) QueryAuthorizationsParams {
+	return QueryAuthorizationsParams{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+// NewQuerier returns an authz Querier handler.
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryAuthorization:
+			return queryAuthorization(ctx, req, k)
+
+		case QueryAuthorizations:
+			return queryAuthorizations(ctx, req, k)
+
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown authz query endpoint: %s", path[0]))
+		}
+	}
+}
+
+func queryAuthorization(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params QueryAuthorizationParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	grant, found := k.GetGrant(ctx, params.Granter, params.Grantee, params.MsgType)
+	if !found {
+		return nil, ErrNoAuthorization(DefaultCodespace, params.MsgType)
+	}
+
+	res, err := codec.MarshalJSONIndent(k.cdc, grant)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal JSON", err.Error()))
+	}
+
+	return res, nil
+}
+
+func queryAuthorizations(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params QueryAuthorizationsParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	grants := AuthorizationGrants{}
+	k.IterateGrants(ctx, params.Granter, params.Grantee, func(grant AuthorizationGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+
+	res, err := codec.MarshalJSONIndent(k.cdc, grants)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal JSON", err.Error()))
+	}
+
+	return res, nil
+}
@@ -40,6 +40,9 @@ var (
 	priv4 = secp256k1.GenPrivKey()
 	addr4 = sdk.AccAddress(priv4.PubKey().Address())
 
+	// moduleAccAddr is blocked from receiving funds through bank messages
+	moduleAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("moduleAcc")))
+
 	coins     = sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
 	halfCoins = sdk.Coins{sdk.NewInt64Coin("foocoin", 5)}
 	manyCoins = sdk.Coins{sdk.NewInt64Coin("foocoin", 1), sdk.NewInt64Coin("barcoin", 1)}
@@ -135,6 +138,47 @@ func TestSendNotEnoughBalance(t *testing.T) {
 	require.True(t, res2.GetSequence() == origSeq+1)
 }
 
+func TestSendToBlockedAddr(t *testing.T) {
+	mapp := getMockApp(t)
+	acc := &auth.BaseAccount{
+		Address: addr1,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 67)},
+	}
+
+	mock.SetGenesis(mapp, []auth.Account{acc})
+
+	testCases := []appTestCase{
+		{
+			msgs:       []sdk.Msg{NewMsgSend(addr1, moduleAccAddr, coins)},
+			accNums:    []uint64{0},
+			accSeqs:    []uint64{0},
+			expSimPass: false,
+			expPass:    false,
+			privKeys:   []crypto.PrivKey{priv1},
+		},
+		{
+			msgs: []sdk.Msg{MsgMultiSend{
+				Inputs:  []Input{NewInput(addr1, coins)},
+				Outputs: []Output{NewOutput(addr2, halfCoins), NewOutput(moduleAccAddr, halfCoins)},
+			}},
+			accNums:    []uint64{0},
+			accSeqs:    []uint64{1},
+			expSimPass: false,
+			expPass:    false,
+			privKeys:   []crypto.PrivKey{priv1},
+		},
+	}
+
+	for _, tc := range testCases {
+		header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+		mock.SignCheckDeliver(t, mapp.Cdc, mapp.BaseApp, header, tc.msgs, tc.accNums, tc.accSeqs, tc.expSimPass, tc.expPass, tc.privKeys...)
+
+		mock.CheckBalance(t, mapp, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 67)})
+		ctxCheck := mapp.BaseApp.NewContext(true, abci.Header{})
+		require.Nil(t, mapp.AccountKeeper.GetAccount(ctxCheck, moduleAccAddr))
+	}
+}
+
 func TestMsgMultiSendWithAccounts(t *testing.T) {
 	mapp := getMockApp(t)
 	acc := &auth.BaseAccount{
@@ -20,6 +20,7 @@ func getBenchmarkMockApp() (*mock.App, error) {
 		mapp.AccountKeeper,
 		mapp.ParamsKeeper.Subspace(DefaultParamspace),
 		DefaultCodespace,
+		map[string]bool{moduleAccAddr.String(): true},
 	)
 	mapp.Router().AddRoute("bank", NewHandler(bankKeeper))
 	mapp.SetInitChainer(getInitChainer(mapp, bankKeeper))
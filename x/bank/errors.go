@@ -1,6 +1,8 @@
 package bank
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -10,6 +12,7 @@ const (
 
 	CodeSendDisabled         sdk.CodeType = 101
 	CodeInvalidInputsOutputs sdk.CodeType = 102
+	CodeBlockedAddr          sdk.CodeType = 103
 )
 
 // ErrNoInputs is an error
@@ -31,3 +34,8 @@ func ErrInputOutputMismatch(codespace sdk.CodespaceType) sdk.Error {
 func ErrSendDisabled(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeSendDisabled, "send transactions are currently disabled")
 }
+
+// ErrBlockedAddr is an error
+func ErrBlockedAddr(codespace sdk.CodespaceType, addr sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeBlockedAddr, fmt.Sprintf("%s is not allowed to receive transactions", addr))
+}
@@ -2,22 +2,24 @@ package bank
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/bank/tags"
 )
 
 // NewHandler returns a handler for "bank" type messages.
 func NewHandler(k Keeper) sdk.Handler {
-	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
-		switch msg := msg.(type) {
-		case MsgSend:
-			return handleMsgSend(ctx, k, msg)
-		case MsgMultiSend:
-			return handleMsgMultiSend(ctx, k, msg)
-		default:
-			errMsg := "Unrecognized bank Msg type: %s" + msg.Type()
-			return sdk.ErrUnknownRequest(errMsg).Result()
-		}
-	}
+	router := sdk.NewMsgRouter()
+	RegisterMsgHandlers(router, k)
+	return sdk.NewMsgRouterHandler(router)
+}
+
+// RegisterMsgHandlers registers the handlers of the bank messages.
+func RegisterMsgHandlers(router sdk.MsgRouter, k Keeper) {
+	router.
+		AddMsgHandler(MsgSend{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgSend(ctx, k, msg.(MsgSend))
+		}).
+		AddMsgHandler(MsgMultiSend{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgMultiSend(ctx, k, msg.(MsgMultiSend))
+		})
 }
 
 // Handle MsgSend.
@@ -25,20 +27,25 @@ func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
 	if !k.GetSendEnabled(ctx) {
 		return ErrSendDisabled(k.Codespace()).Result()
 	}
+
+	if k.BlockedAddr(msg.ToAddress) {
+		return ErrBlockedAddr(k.Codespace(), msg.ToAddress).Result()
+	}
+
 	err := k.SendCoins(ctx, msg.FromAddress, msg.ToAddress, msg.Amount)
 	if err != nil {
 		return err.Result()
 	}
 
-	resTags := sdk.NewTags(
-		tags.Category, tags.TxCategory,
-		tags.Sender, msg.FromAddress.String(),
-		tags.Recipient, msg.ToAddress.String(),
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.FromAddress.String()),
+		),
 	)
 
-	return sdk.Result{
-		Tags: resTags,
-	}
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 // Handle MsgMultiSend.
@@ -47,13 +54,24 @@ func handleMsgMultiSend(ctx sdk.Context, k Keeper, msg MsgMultiSend) sdk.Result
 	if !k.GetSendEnabled(ctx) {
 		return ErrSendDisabled(k.Codespace()).Result()
 	}
-	resTags, err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
+
+	for _, out := range msg.Outputs {
+		if k.BlockedAddr(out.Address) {
+			return ErrBlockedAddr(k.Codespace(), out.Address).Result()
+		}
+	}
+
+	err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
 	if err != nil {
 		return err.Result()
 	}
 
-	resTags = resTags.AppendTag(tags.Category, tags.TxCategory)
-	return sdk.Result{
-		Tags: resTags,
-	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
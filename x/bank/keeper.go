@@ -6,7 +6,6 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
-	"github.com/cosmos/cosmos-sdk/x/bank/tags"
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
@@ -20,10 +19,10 @@ type Keeper interface {
 	SetCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error
 	SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Error)
 	AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Error)
-	InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error)
+	InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) sdk.Error
 
-	DelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error)
-	UndelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error)
+	DelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error
+	UndelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error
 }
 
 // BaseKeeper manages transfers between accounts. It implements the Keeper interface.
@@ -34,14 +33,15 @@ type BaseKeeper struct {
 	paramSpace params.Subspace
 }
 
-// NewBaseKeeper returns a new BaseKeeper
+// NewBaseKeeper returns a new BaseKeeper. Messages sending coins to any of the
+// blockedAddrs (keyed by their bech32 string) are rejected by the bank handler.
 func NewBaseKeeper(ak auth.AccountKeeper,
 	paramSpace params.Subspace,
-	codespace sdk.CodespaceType) BaseKeeper {
+	codespace sdk.CodespaceType, blockedAddrs map[string]bool) BaseKeeper {
 
 	ps := paramSpace.WithKeyTable(ParamKeyTable())
 	return BaseKeeper{
-		BaseSendKeeper: NewBaseSendKeeper(ak, ps, codespace),
+		BaseSendKeeper: NewBaseSendKeeper(ak, ps, codespace, blockedAddrs),
 		ak:             ak,
 		paramSpace:     ps,
 	}
@@ -83,7 +83,7 @@ func (keeper BaseKeeper) AddCoins(
 // InputOutputCoins handles a list of inputs and outputs
 func (keeper BaseKeeper) InputOutputCoins(
 	ctx sdk.Context, inputs []Input, outputs []Output,
-) (sdk.Tags, sdk.Error) {
+) sdk.Error {
 
 	return inputOutputCoins(ctx, keeper.ak, inputs, outputs)
 }
@@ -93,10 +93,10 @@ func (keeper BaseKeeper) InputOutputCoins(
 // vesting and vested coins.
 func (keeper BaseKeeper) DelegateCoins(
 	ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins,
-) (sdk.Tags, sdk.Error) {
+) sdk.Error {
 
 	if !amt.IsValid() {
-		return nil, sdk.ErrInvalidCoins(amt.String())
+		return sdk.ErrInvalidCoins(amt.String())
 	}
 	return delegateCoins(ctx, keeper.ak, addr, amt)
 }
@@ -107,10 +107,10 @@ func (keeper BaseKeeper) DelegateCoins(
 // If any of the undelegation amounts are negative, an error is returned.
 func (keeper BaseKeeper) UndelegateCoins(
 	ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins,
-) (sdk.Tags, sdk.Error) {
+) sdk.Error {
 
 	if !amt.IsValid() {
-		return nil, sdk.ErrInvalidCoins(amt.String())
+		return sdk.ErrInvalidCoins(amt.String())
 	}
 	return undelegateCoins(ctx, keeper.ak, addr, amt)
 }
@@ -124,6 +124,8 @@ type SendKeeper interface {
 
 	GetSendEnabled(ctx sdk.Context) bool
 	SetSendEnabled(ctx sdk.Context, enabled bool)
+
+	BlockedAddr(addr sdk.AccAddress) bool
 }
 
 var _ SendKeeper = (*BaseSendKeeper)(nil)
@@ -135,16 +137,20 @@ type BaseSendKeeper struct {
 
 	ak         auth.AccountKeeper
 	paramSpace params.Subspace
+
+	// list of addresses that are restricted from receiving transactions
+	blockedAddrs map[string]bool
 }
 
 // NewBaseSendKeeper returns a new BaseSendKeeper.
 func NewBaseSendKeeper(ak auth.AccountKeeper,
-	paramSpace params.Subspace, codespace sdk.CodespaceType) BaseSendKeeper {
+	paramSpace params.Subspace, codespace sdk.CodespaceType, blockedAddrs map[string]bool) BaseSendKeeper {
 
 	return BaseSendKeeper{
 		BaseViewKeeper: NewBaseViewKeeper(ak, codespace),
 		ak:             ak,
 		paramSpace:     paramSpace,
+		blockedAddrs:   blockedAddrs,
 	}
 }
 
@@ -172,6 +178,12 @@ func (keeper BaseSendKeeper) SetSendEnabled(ctx sdk.Context, enabled bool) {
 	keeper.paramSpace.Set(ctx, ParamStoreKeySendEnabled, &enabled)
 }
 
+// BlockedAddr checks if a given address is blocked (i.e restricted from
+// receiving funds through MsgSend or MsgMultiSend)
+func (keeper BaseSendKeeper) BlockedAddr(addr sdk.AccAddress) bool {
+	return keeper.blockedAddrs[addr.String()]
+}
+
 var _ ViewKeeper = (*BaseViewKeeper)(nil)
 
 // ViewKeeper defines a module interface that facilitates read only access to
@@ -318,101 +330,110 @@ func sendCoins(ctx sdk.Context, am auth.AccountKeeper, fromAddr sdk.AccAddress,
 		return err
 	}
 
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			EventTypeTransfer,
+			sdk.NewAttribute(AttributeKeySender, fromAddr.String()),
+			sdk.NewAttribute(AttributeKeyRecipient, toAddr.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, amt.String()),
+		),
+	)
+
 	return nil
 }
 
 // InputOutputCoins handles a list of inputs and outputs
 // NOTE: Make sure to revert state changes from tx on error
-func inputOutputCoins(ctx sdk.Context, am auth.AccountKeeper, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error) {
+func inputOutputCoins(ctx sdk.Context, am auth.AccountKeeper, inputs []Input, outputs []Output) sdk.Error {
 	// Safety check ensuring that when sending coins the keeper must maintain the
 	// Check supply invariant and validity of Coins.
 	if err := ValidateInputsOutputs(inputs, outputs); err != nil {
-		return nil, err
+		return err
 	}
 
-	allTags := sdk.EmptyTags()
-
 	for _, in := range inputs {
 		_, err := subtractCoins(ctx, am, in.Address, in.Coins)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		allTags = allTags.AppendTag(
-			tags.Sender, in.Address.String(),
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				sdk.EventTypeMessage,
+				sdk.NewAttribute(AttributeKeySender, in.Address.String()),
+			),
 		)
 	}
 
 	for _, out := range outputs {
 		_, err := addCoins(ctx, am, out.Address, out.Coins)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		allTags = allTags.AppendTag(
-			tags.Recipient, out.Address.String(),
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				EventTypeTransfer,
+				sdk.NewAttribute(AttributeKeyRecipient, out.Address.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, out.Coins.String()),
+			),
 		)
 	}
 
-	return allTags, nil
+	return nil
 }
 
 func delegateCoins(
 	ctx sdk.Context, ak auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins,
-) (sdk.Tags, sdk.Error) {
+) sdk.Error {
 
 	if !amt.IsValid() {
-		return nil, sdk.ErrInvalidCoins(amt.String())
+		return sdk.ErrInvalidCoins(amt.String())
 	}
 
 	acc := getAccount(ctx, ak, addr)
 	if acc == nil {
-		return nil, sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
+		return sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
 	}
 
 	oldCoins := acc.GetCoins()
 
 	_, hasNeg := oldCoins.SafeSub(amt)
 	if hasNeg {
-		return nil, sdk.ErrInsufficientCoins(
+		return sdk.ErrInsufficientCoins(
 			fmt.Sprintf("insufficient account funds; %s < %s", oldCoins, amt),
 		)
 	}
 
 	if err := trackDelegation(acc, ctx.BlockHeader().Time, amt); err != nil {
-		return nil, sdk.ErrInternal(fmt.Sprintf("failed to track delegation: %v", err))
+		return sdk.ErrInternal(fmt.Sprintf("failed to track delegation: %v", err))
 	}
 
 	setAccount(ctx, ak, acc)
 
-	return sdk.NewTags(
-		sdk.TagAction, tags.ActionDelegateCoins,
-		sdk.TagDelegator, addr.String(),
-	), nil
+	return nil
 }
 
 func undelegateCoins(
 	ctx sdk.Context, ak auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins,
-) (sdk.Tags, sdk.Error) {
+) sdk.Error {
 
 	if !amt.IsValid() {
-		return nil, sdk.ErrInvalidCoins(amt.String())
+		return sdk.ErrInvalidCoins(amt.String())
 	}
 
 	acc := getAccount(ctx, ak, addr)
 	if acc == nil {
-		return nil, sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
+		return sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
 	}
 
 	if err := trackUndelegation(acc, amt); err != nil {
-		return nil, sdk.ErrInternal(fmt.Sprintf("failed to track undelegation: %v", err))
+		return sdk.ErrInternal(fmt.Sprintf("failed to track undelegation: %v", err))
 	}
 
 	setAccount(ctx, ak, acc)
 
-	return sdk.NewTags(
-		sdk.TagAction, tags.ActionUndelegateCoins,
-		sdk.TagDelegator, addr.String(),
-	), nil
+	return nil
 }
 
 // CONTRACT: assumes that amt is valid.
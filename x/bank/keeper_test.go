@@ -56,7 +56,7 @@ func setupTestInput() testInput {
 func TestKeeper(t *testing.T) {
 	input := setupTestInput()
 	ctx := input.ctx
-	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace)
+	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace, nil)
 	bankKeeper.SetSendEnabled(ctx, true)
 
 	addr := sdk.AccAddress([]byte("addr1"))
@@ -137,8 +137,8 @@ func TestSendKeeper(t *testing.T) {
 	input := setupTestInput()
 	ctx := input.ctx
 	paramSpace := input.pk.Subspace(DefaultParamspace)
-	bankKeeper := NewBaseKeeper(input.ak, paramSpace, DefaultCodespace)
-	sendKeeper := NewBaseSendKeeper(input.ak, paramSpace, DefaultCodespace)
+	bankKeeper := NewBaseKeeper(input.ak, paramSpace, DefaultCodespace, nil)
+	sendKeeper := NewBaseSendKeeper(input.ak, paramSpace, DefaultCodespace, nil)
 	bankKeeper.SetSendEnabled(ctx, true)
 
 	addr := sdk.AccAddress([]byte("addr1"))
@@ -161,9 +161,18 @@ func TestSendKeeper(t *testing.T) {
 	bankKeeper.SetCoins(ctx, addr, sdk.NewCoins(sdk.NewInt64Coin("foocoin", 15)))
 
 	// Test SendCoins
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
 	sendKeeper.SendCoins(ctx, addr, addr2, sdk.NewCoins(sdk.NewInt64Coin("foocoin", 5)))
 	require.True(t, sendKeeper.GetCoins(ctx, addr).IsEqual(sdk.NewCoins(sdk.NewInt64Coin("foocoin", 10))))
 	require.True(t, sendKeeper.GetCoins(ctx, addr2).IsEqual(sdk.NewCoins(sdk.NewInt64Coin("foocoin", 5))))
+	require.Equal(t, sdk.Events{
+		sdk.NewEvent(
+			EventTypeTransfer,
+			sdk.NewAttribute(AttributeKeySender, addr.String()),
+			sdk.NewAttribute(AttributeKeyRecipient, addr2.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, "5foocoin"),
+		),
+	}, ctx.EventManager().Events())
 
 	err := sendKeeper.SendCoins(ctx, addr, addr2, sdk.NewCoins(sdk.NewInt64Coin("foocoin", 50)))
 	require.Implements(t, (*sdk.Error)(nil), err)
@@ -186,7 +195,7 @@ func TestViewKeeper(t *testing.T) {
 	input := setupTestInput()
 	ctx := input.ctx
 	paramSpace := input.pk.Subspace(DefaultParamspace)
-	bankKeeper := NewBaseKeeper(input.ak, paramSpace, DefaultCodespace)
+	bankKeeper := NewBaseKeeper(input.ak, paramSpace, DefaultCodespace, nil)
 	bankKeeper.SetSendEnabled(ctx, true)
 	viewKeeper := NewBaseViewKeeper(input.ak, DefaultCodespace)
 
@@ -215,7 +224,7 @@ func TestVestingAccountSend(t *testing.T) {
 
 	origCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
 	sendCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 50))
-	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace)
+	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace, nil)
 	bankKeeper.SetSendEnabled(ctx, true)
 
 	addr1 := sdk.AccAddress([]byte("addr1"))
@@ -249,7 +258,7 @@ func TestVestingAccountReceive(t *testing.T) {
 
 	origCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
 	sendCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 50))
-	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace)
+	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace, nil)
 	bankKeeper.SetSendEnabled(ctx, true)
 
 	addr1 := sdk.AccAddress([]byte("addr1"))
@@ -283,7 +292,7 @@ func TestDelegateCoins(t *testing.T) {
 
 	origCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
 	delCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 50))
-	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace)
+	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace, nil)
 	bankKeeper.SetSendEnabled(ctx, true)
 
 	addr1 := sdk.AccAddress([]byte("addr1"))
@@ -300,13 +309,13 @@ func TestDelegateCoins(t *testing.T) {
 	ctx = ctx.WithBlockTime(now.Add(12 * time.Hour))
 
 	// require the ability for a non-vesting account to delegate
-	_, err := bankKeeper.DelegateCoins(ctx, addr2, delCoins)
+	err := bankKeeper.DelegateCoins(ctx, addr2, delCoins)
 	acc = input.ak.GetAccount(ctx, addr2)
 	require.NoError(t, err)
 	require.Equal(t, delCoins, acc.GetCoins())
 
 	// require the ability for a vesting account to delegate
-	_, err = bankKeeper.DelegateCoins(ctx, addr1, delCoins)
+	err = bankKeeper.DelegateCoins(ctx, addr1, delCoins)
 	vacc = input.ak.GetAccount(ctx, addr1).(*auth.ContinuousVestingAccount)
 	require.NoError(t, err)
 	require.Equal(t, delCoins, vacc.GetCoins())
@@ -320,7 +329,7 @@ func TestUndelegateCoins(t *testing.T) {
 
 	origCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
 	delCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 50))
-	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace)
+	bankKeeper := NewBaseKeeper(input.ak, input.pk.Subspace(DefaultParamspace), DefaultCodespace, nil)
 	bankKeeper.SetSendEnabled(ctx, true)
 
 	addr1 := sdk.AccAddress([]byte("addr1"))
@@ -337,22 +346,22 @@ func TestUndelegateCoins(t *testing.T) {
 	ctx = ctx.WithBlockTime(now.Add(12 * time.Hour))
 
 	// require the ability for a non-vesting account to delegate
-	_, err := bankKeeper.DelegateCoins(ctx, addr2, delCoins)
+	err := bankKeeper.DelegateCoins(ctx, addr2, delCoins)
 	require.NoError(t, err)
 
 	// require the ability for a non-vesting account to undelegate
-	_, err = bankKeeper.UndelegateCoins(ctx, addr2, delCoins)
+	err = bankKeeper.UndelegateCoins(ctx, addr2, delCoins)
 	require.NoError(t, err)
 
 	acc = input.ak.GetAccount(ctx, addr2)
 	require.Equal(t, origCoins, acc.GetCoins())
 
 	// require the ability for a vesting account to delegate
-	_, err = bankKeeper.DelegateCoins(ctx, addr1, delCoins)
+	err = bankKeeper.DelegateCoins(ctx, addr1, delCoins)
 	require.NoError(t, err)
 
 	// require the ability for a vesting account to undelegate
-	_, err = bankKeeper.UndelegateCoins(ctx, addr1, delCoins)
+	err = bankKeeper.UndelegateCoins(ctx, addr1, delCoins)
 	require.NoError(t, err)
 
 	vacc = input.ak.GetAccount(ctx, addr1).(*auth.ContinuousVestingAccount)
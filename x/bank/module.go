@@ -0,0 +1,99 @@
+package bank
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var (
+	_ sdk.AppModule            = AppModule{}
+	_ sdk.AppModuleBasic       = AppModuleBasic{}
+	_ sdk.AppModuleMsgHandlers = AppModule{}
+)
+
+// AppModuleBasic defines the basic elements of the bank module.
+type AppModuleBasic struct{}
+
+// Name returns the bank module's name.
+func (AppModuleBasic) Name() string { return ModuleName }
+
+// RegisterCodec registers the bank module's types on the codec.
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) { RegisterCodec(cdc) }
+
+// Msgs returns the bank module's messages.
+func (AppModuleBasic) Msgs() []sdk.Msg {
+	return []sdk.Msg{MsgSend{}, MsgMultiSend{}}
+}
+
+// DefaultGenesis returns the default genesis state of the bank module.
+func (AppModuleBasic) DefaultGenesis(cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// ValidateGenesis validates a genesis state of the bank module.
+func (AppModuleBasic) ValidateGenesis(cdc *codec.Codec, bz json.RawMessage) error {
+	var data GenesisState
+	if err := cdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+	return ValidateGenesis(data)
+}
+
+// AppModule implements the sdk.AppModule interface for the bank module.
+type AppModule struct {
+	AppModuleBasic
+	keeper        Keeper
+	accountKeeper auth.AccountKeeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(keeper Keeper, accountKeeper auth.AccountKeeper) AppModule {
+	return AppModule{
+		keeper:        keeper,
+		accountKeeper: accountKeeper,
+	}
+}
+
+// RegisterInvariants registers the bank module's invariants.
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRouter) {
+	RegisterInvariants(ir, am.accountKeeper)
+}
+
+// Route returns the bank module's message route.
+func (AppModule) Route() string { return RouterKey }
+
+// NewHandler returns the bank module's message handler.
+func (am AppModule) NewHandler() sdk.Handler { return NewHandler(am.keeper) }
+
+// RegisterMsgHandlers registers the bank module's message handlers.
+func (am AppModule) RegisterMsgHandlers(router sdk.MsgRouter) { RegisterMsgHandlers(router, am.keeper) }
+
+// QuerierRoute returns the bank module's querier route, as it has no querier.
+func (AppModule) QuerierRoute() string { return "" }
+
+// NewQuerierHandler returns no querier, as the bank module has none.
+func (AppModule) NewQuerierHandler() sdk.Querier { return nil }
+
+// InitGenesis initializes the bank module's genesis state.
+func (am AppModule) InitGenesis(ctx sdk.Context, cdc *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	cdc.MustUnmarshalJSON(bz, &data)
+	InitGenesis(ctx, am.keeper, data)
+	return nil
+}
+
+// ExportGenesis exports the bank module's genesis state.
+func (am AppModule) ExportGenesis(ctx sdk.Context, cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(ExportGenesis(ctx, am.keeper))
+}
+
+// BeginBlock runs nothing at the start of a block.
+func (AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {}
+
+// EndBlock runs nothing at the end of a block.
+func (AppModule) EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate { return nil }
@@ -4,8 +4,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// RouterKey is they name of the bank module
-const RouterKey = "bank"
+const (
+	// ModuleName is the name of the bank module
+	ModuleName = "bank"
+
+	// RouterKey is they name of the bank module
+	RouterKey = ModuleName
+)
 
 // MsgSend - high level transaction of the coin module
 type MsgSend struct {
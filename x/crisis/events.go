@@ -0,0 +1,10 @@
+package crisis
+
+// crisis module event types
+const (
+	EventTypeInvariant = "invariant"
+
+	AttributeKeyRoute = "route"
+
+	AttributeValueCategory = ModuleName
+)
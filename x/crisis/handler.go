@@ -2,7 +2,6 @@ package crisis
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/crisis/tags"
 )
 
 // ModuleName is the module name for this module
@@ -11,16 +10,19 @@ const (
 	RouterKey  = ModuleName
 )
 
+// NewHandler returns a handler for "crisis" type messages.
 func NewHandler(k Keeper) sdk.Handler {
-	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+	router := sdk.NewMsgRouter()
+	RegisterMsgHandlers(router, k)
+	return sdk.NewMsgRouterHandler(router)
+}
 
-		switch msg := msg.(type) {
-		case MsgVerifyInvariant:
-			return handleMsgVerifyInvariant(ctx, msg, k)
-		default:
-			return sdk.ErrTxDecode("invalid message parse in crisis module").Result()
-		}
-	}
+// RegisterMsgHandlers registers the handlers of the crisis messages.
+func RegisterMsgHandlers(router sdk.MsgRouter, k Keeper) {
+	router.
+		AddMsgHandler(MsgVerifyInvariant{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgVerifyInvariant(ctx, msg.(MsgVerifyInvariant), k)
+		})
 }
 
 func handleMsgVerifyInvariant(ctx sdk.Context, msg MsgVerifyInvariant, k Keeper) sdk.Result {
@@ -71,11 +73,17 @@ func handleMsgVerifyInvariant(ctx sdk.Context, msg MsgVerifyInvariant, k Keeper)
 		panic(invarianceErr)
 	}
 
-	resTags := sdk.NewTags(
-		tags.Sender, msg.Sender.String(),
-		tags.Invariant, msg.InvariantRoute,
-	)
-	return sdk.Result{
-		Tags: resTags,
-	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeInvariant,
+			sdk.NewAttribute(AttributeKeyRoute, msg.InvariantRoute),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
@@ -1,11 +0,0 @@
-package tags
-
-import (
-	sdk "github.com/cosmos/cosmos-sdk/types"
-)
-
-// Crisis module tags
-var (
-	Sender    = sdk.TagSender
-	Invariant = "invariant"
-)
@@ -3,24 +3,28 @@ package distribution
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/distribution/keeper"
-	"github.com/cosmos/cosmos-sdk/x/distribution/tags"
 	"github.com/cosmos/cosmos-sdk/x/distribution/types"
 )
 
+// NewHandler returns a handler for "distribution" type messages.
 func NewHandler(k keeper.Keeper) sdk.Handler {
-	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
-		// NOTE msg already has validate basic run
-		switch msg := msg.(type) {
-		case types.MsgSetWithdrawAddress:
-			return handleMsgModifyWithdrawAddress(ctx, msg, k)
-		case types.MsgWithdrawDelegatorReward:
-			return handleMsgWithdrawDelegatorReward(ctx, msg, k)
-		case types.MsgWithdrawValidatorCommission:
-			return handleMsgWithdrawValidatorCommission(ctx, msg, k)
-		default:
-			return sdk.ErrTxDecode("invalid message parse in distribution module").Result()
-		}
-	}
+	router := sdk.NewMsgRouter()
+	RegisterMsgHandlers(router, k)
+	return sdk.NewMsgRouterHandler(router)
+}
+
+// RegisterMsgHandlers registers the handlers of the distribution messages.
+func RegisterMsgHandlers(router sdk.MsgRouter, k keeper.Keeper) {
+	router.
+		AddMsgHandler(types.MsgSetWithdrawAddress{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgModifyWithdrawAddress(ctx, msg.(types.MsgSetWithdrawAddress), k)
+		}).
+		AddMsgHandler(types.MsgWithdrawDelegatorReward{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgWithdrawDelegatorReward(ctx, msg.(types.MsgWithdrawDelegatorReward), k)
+		}).
+		AddMsgHandler(types.MsgWithdrawValidatorCommission{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgWithdrawValidatorCommission(ctx, msg.(types.MsgWithdrawValidatorCommission), k)
+		})
 }
 
 // These functions assume everything has been authenticated (ValidateBasic passed, and signatures checked)
@@ -32,13 +36,15 @@ func handleMsgModifyWithdrawAddress(ctx sdk.Context, msg types.MsgSetWithdrawAdd
 		return err.Result()
 	}
 
-	resTags := sdk.NewTags(
-		tags.Category, tags.TxCategory,
-		tags.Sender, msg.DelegatorAddress.String(),
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
 	)
-	return sdk.Result{
-		Tags: resTags,
-	}
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 func handleMsgWithdrawDelegatorReward(ctx sdk.Context, msg types.MsgWithdrawDelegatorReward, k keeper.Keeper) sdk.Result {
@@ -48,14 +54,15 @@ func handleMsgWithdrawDelegatorReward(ctx sdk.Context, msg types.MsgWithdrawDele
 		return err.Result()
 	}
 
-	resTags := sdk.NewTags(
-		tags.Category, tags.TxCategory,
-		tags.Sender, msg.DelegatorAddress.String(),
-		tags.Validator, msg.ValidatorAddress.String(),
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
 	)
-	return sdk.Result{
-		Tags: resTags,
-	}
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 func handleMsgWithdrawValidatorCommission(ctx sdk.Context, msg types.MsgWithdrawValidatorCommission, k keeper.Keeper) sdk.Result {
@@ -65,11 +72,13 @@ func handleMsgWithdrawValidatorCommission(ctx sdk.Context, msg types.MsgWithdraw
 		return err.Result()
 	}
 
-	resTags := sdk.NewTags(
-		tags.Category, tags.TxCategory,
-		tags.Sender, msg.ValidatorAddress.String(),
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.ValidatorAddress.String()),
+		),
 	)
-	return sdk.Result{
-		Tags: resTags,
-	}
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
@@ -0,0 +1,103 @@
+package distribution
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ sdk.AppModule            = AppModule{}
+	_ sdk.AppModuleBasic       = AppModuleBasic{}
+	_ sdk.AppModuleMsgHandlers = AppModule{}
+)
+
+// AppModuleBasic defines the basic elements of the distribution module.
+type AppModuleBasic struct{}
+
+// Name returns the distribution module's name.
+func (AppModuleBasic) Name() string { return ModuleName }
+
+// RegisterCodec registers the distribution module's types on the codec.
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) { RegisterCodec(cdc) }
+
+// Msgs returns the distribution module's messages.
+func (AppModuleBasic) Msgs() []sdk.Msg {
+	return []sdk.Msg{MsgSetWithdrawAddress{}, MsgWithdrawDelegatorReward{}, MsgWithdrawValidatorCommission{}}
+}
+
+// DefaultGenesis returns the default genesis state of the distribution module.
+func (AppModuleBasic) DefaultGenesis(cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// ValidateGenesis validates a genesis state of the distribution module.
+func (AppModuleBasic) ValidateGenesis(cdc *codec.Codec, bz json.RawMessage) error {
+	var data GenesisState
+	if err := cdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+	return ValidateGenesis(data)
+}
+
+// AppModule implements the sdk.AppModule interface for the distribution
+// module.
+type AppModule struct {
+	AppModuleBasic
+	keeper        Keeper
+	stakingKeeper StakingKeeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(keeper Keeper, stakingKeeper StakingKeeper) AppModule {
+	return AppModule{
+		keeper:        keeper,
+		stakingKeeper: stakingKeeper,
+	}
+}
+
+// RegisterInvariants registers the distribution module's invariants.
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRouter) {
+	RegisterInvariants(ir, am.keeper, am.stakingKeeper)
+}
+
+// Route returns the distribution module's message route.
+func (AppModule) Route() string { return RouterKey }
+
+// NewHandler returns the distribution module's message handler.
+func (am AppModule) NewHandler() sdk.Handler { return NewHandler(am.keeper) }
+
+// RegisterMsgHandlers registers the distribution module's message handlers.
+func (am AppModule) RegisterMsgHandlers(router sdk.MsgRouter) { RegisterMsgHandlers(router, am.keeper) }
+
+// QuerierRoute returns the distribution module's querier route.
+func (AppModule) QuerierRoute() string { return QuerierRoute }
+
+// NewQuerierHandler returns the distribution module's querier.
+func (am AppModule) NewQuerierHandler() sdk.Querier { return NewQuerier(am.keeper) }
+
+// InitGenesis initializes the distribution module's genesis state. It must run
+// before the staking module's, as the staking hooks update the distribution
+// state of the genesis validators.
+func (am AppModule) InitGenesis(ctx sdk.Context, cdc *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	cdc.MustUnmarshalJSON(bz, &data)
+	InitGenesis(ctx, am.keeper, data)
+	return nil
+}
+
+// ExportGenesis exports the distribution module's genesis state.
+func (am AppModule) ExportGenesis(ctx sdk.Context, cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(ExportGenesis(ctx, am.keeper))
+}
+
+// BeginBlock distributes the fees of the previous block.
+func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
+	BeginBlocker(ctx, req, am.keeper)
+}
+
+// EndBlock runs nothing at the end of a block.
+func (AppModule) EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate { return nil }
@@ -0,0 +1,13 @@
+package types
+
+// distribution module event types
+const (
+	EventTypeSetWithdrawAddress = "set_withdraw_address"
+	EventTypeWithdrawRewards    = "withdraw_rewards"
+	EventTypeWithdrawCommission = "withdraw_commission"
+
+	AttributeKeyWithdrawAddress = "withdraw_address"
+	AttributeKeyValidator       = "validator"
+
+	AttributeValueCategory = ModuleName
+)
@@ -0,0 +1,192 @@
+package feegrant
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeAllowance defines the interface of a fee allowance a granter issues to a
+// grantee. Implementations must be registered on the module codec.
+type FeeAllowance interface {
+	// Accept checks whether the fee can be paid out of the allowance at the
+	// given block time and, if so, updates the allowance to reflect the spent
+	// fee. If remove is true the allowance is used up (or expired) and should
+	// be deleted from state.
+	Accept(fee sdk.Coins, blockTime time.Time) (remove bool, err sdk.Error)
+
+	// ExpiresAt returns the time from which the allowance is expired, or a
+	// zero time if it never expires.
+	ExpiresAt() time.Time
+
+	// ValidateBasic performs stateless validation of the allowance.
+	ValidateBasic() sdk.Error
+}
+
+var (
+	_ FeeAllowance = (*BasicFeeAllowance)(nil)
+	_ FeeAllowance = (*PeriodicFeeAllowance)(nil)
+)
+
+// BasicFeeAllowance allows a grantee to spend up to SpendLimit in fees until
+// Expiration. A nil SpendLimit means there is no limit on the fees spent and a
+// zero Expiration means the allowance never expires.
+type BasicFeeAllowance struct {
+	SpendLimit sdk.Coins `json:"spend_limit"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// NewBasicFeeAllowance returns a new BasicFeeAllowance.
+func NewBasicFeeAllowance(spendLimit sdk.Coins, expiration time.Time) *BasicFeeAllowance {
+	return &BasicFeeAllowance{
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// Accept implements FeeAllowance.
+func (a *BasicFeeAllowance) Accept(fee sdk.Coins, blockTime time.Time) (bool, sdk.Error) {
+	if a.isExpired(blockTime) {
+		return true, ErrFeeLimitExpired(DefaultCodespace)
+	}
+
+	if a.SpendLimit == nil {
+		return false, nil
+	}
+
+	left, hasNeg := a.SpendLimit.SafeSub(fee)
+	if hasNeg {
+		return false, ErrFeeLimitExceeded(DefaultCodespace, fee, a.SpendLimit)
+	}
+
+	a.SpendLimit = left
+	return left.IsZero(), nil
+}
+
+// ValidateBasic implements FeeAllowance.
+func (a *BasicFeeAllowance) ValidateBasic() sdk.Error {
+	if a.SpendLimit != nil && !a.SpendLimit.IsValid() {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("invalid spend limit %s", a.SpendLimit))
+	}
+	return nil
+}
+
+// ExpiresAt implements FeeAllowance.
+func (a BasicFeeAllowance) ExpiresAt() time.Time {
+	return a.Expiration
+}
+
+func (a BasicFeeAllowance) isExpired(blockTime time.Time) bool {
+	return !a.Expiration.IsZero() && !blockTime.Before(a.Expiration)
+}
+
+func (a BasicFeeAllowance) String() string {
+	return fmt.Sprintf(`Basic Fee Allowance:
+  Spend Limit: %s
+  Expiration:  %s`, a.SpendLimit, a.Expiration)
+}
+
+// PeriodicFeeAllowance extends a BasicFeeAllowance with a limit on the fees
+// that can be spent in every Period. PeriodCanSpend is reset to
+// PeriodSpendLimit at PeriodReset, which then moves forward by Period. A zero
+// PeriodReset starts the first period with the first fee paid.
+type PeriodicFeeAllowance struct {
+	Basic            BasicFeeAllowance `json:"basic"`
+	Period           time.Duration     `json:"period"`
+	PeriodSpendLimit sdk.Coins         `json:"period_spend_limit"`
+	PeriodCanSpend   sdk.Coins         `json:"period_can_spend"`
+	PeriodReset      time.Time         `json:"period_reset"`
+}
+
+// NewPeriodicFeeAllowance returns a new PeriodicFeeAllowance.
+func NewPeriodicFeeAllowance(
+	basic BasicFeeAllowance, period time.Duration, periodSpendLimit sdk.Coins,
+) *PeriodicFeeAllowance {
+
+	return &PeriodicFeeAllowance{
+		Basic:            basic,
+		Period:           period,
+		PeriodSpendLimit: periodSpendLimit,
+	}
+}
+
+// Accept implements FeeAllowance.
+func (a *PeriodicFeeAllowance) Accept(fee sdk.Coins, blockTime time.Time) (bool, sdk.Error) {
+	if a.Basic.isExpired(blockTime) {
+		return true, ErrFeeLimitExpired(DefaultCodespace)
+	}
+
+	a.tryResetPeriod(blockTime)
+
+	left, hasNeg := a.PeriodCanSpend.SafeSub(fee)
+	if hasNeg {
+		return false, ErrFeeLimitExceeded(DefaultCodespace, fee, a.PeriodCanSpend)
+	}
+	a.PeriodCanSpend = left
+
+	if a.Basic.SpendLimit == nil {
+		return false, nil
+	}
+
+	left, hasNeg = a.Basic.SpendLimit.SafeSub(fee)
+	if hasNeg {
+		return false, ErrFeeLimitExceeded(DefaultCodespace, fee, a.Basic.SpendLimit)
+	}
+
+	a.Basic.SpendLimit = left
+	return left.IsZero(), nil
+}
+
+// tryResetPeriod refills PeriodCanSpend and moves PeriodReset forward if the
+// current period is over. If more than one period has elapsed, the next period
+// starts at the given block time.
+func (a *PeriodicFeeAllowance) tryResetPeriod(blockTime time.Time) {
+	if blockTime.Before(a.PeriodReset) {
+		return
+	}
+
+	a.PeriodCanSpend = a.PeriodSpendLimit
+
+	a.PeriodReset = a.PeriodReset.Add(a.Period)
+	if blockTime.After(a.PeriodReset) {
+		a.PeriodReset = blockTime.Add(a.Period)
+	}
+}
+
+// ValidateBasic implements FeeAllowance.
+func (a *PeriodicFeeAllowance) ValidateBasic() sdk.Error {
+	if err := a.Basic.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if a.Period <= 0 {
+		return ErrInvalidPeriod(DefaultCodespace, a.Period)
+	}
+	if a.PeriodSpendLimit.Empty() || !a.PeriodSpendLimit.IsValid() {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("invalid period spend limit %s", a.PeriodSpendLimit))
+	}
+	if a.Basic.SpendLimit != nil && !a.PeriodSpendLimit.DenomsSubsetOf(a.Basic.SpendLimit) {
+		return sdk.ErrInvalidCoins("period spend limit has denoms not covered by the spend limit")
+	}
+
+	return nil
+}
+
+// ExpiresAt implements FeeAllowance.
+func (a PeriodicFeeAllowance) ExpiresAt() time.Time {
+	return a.Basic.Expiration
+}
+
+func (a PeriodicFeeAllowance) String() string {
+	return fmt.Sprintf(`Periodic Fee Allowance:
+  Spend Limit:        %s
+  Expiration:         %s
+  Period:             %s
+  Period Spend Limit: %s
+  Period Can Spend:   %s
+  Period Reset:       %s`,
+		a.Basic.SpendLimit, a.Basic.Expiration, a.Period,
+		a.PeriodSpendLimit, a.PeriodCanSpend, a.PeriodReset,
+	)
+}
@@ -0,0 +1,122 @@
+package feegrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBasicFeeAllowance(t *testing.T) {
+	now := time.Now().UTC()
+	atom := sdk.NewCoins(sdk.NewInt64Coin("atom", 555))
+	smallAtom := sdk.NewCoins(sdk.NewInt64Coin("atom", 43))
+	leftAtom := sdk.NewCoins(sdk.NewInt64Coin("atom", 512))
+
+	cases := map[string]struct {
+		allowance BasicFeeAllowance
+		fee       sdk.Coins
+		blockTime time.Time
+		accept    bool
+		remove    bool
+		remains   sdk.Coins
+	}{
+		"unlimited": {
+			allowance: BasicFeeAllowance{},
+			fee:       atom,
+			blockTime: now,
+			accept:    true,
+		},
+		"small fee": {
+			allowance: BasicFeeAllowance{SpendLimit: atom},
+			fee:       smallAtom,
+			blockTime: now,
+			accept:    true,
+			remains:   leftAtom,
+		},
+		"all fee": {
+			allowance: BasicFeeAllowance{SpendLimit: smallAtom},
+			fee:       smallAtom,
+			blockTime: now,
+			accept:    true,
+			remove:    true,
+			remains:   sdk.Coins{},
+		},
+		"wrong fee": {
+			allowance: BasicFeeAllowance{SpendLimit: smallAtom},
+			fee:       atom,
+			blockTime: now,
+			accept:    false,
+		},
+		"non-expired": {
+			allowance: BasicFeeAllowance{SpendLimit: atom, Expiration: now.Add(time.Hour)},
+			fee:       smallAtom,
+			blockTime: now,
+			accept:    true,
+			remains:   leftAtom,
+		},
+		"expired": {
+			allowance: BasicFeeAllowance{SpendLimit: atom, Expiration: now},
+			fee:       smallAtom,
+			blockTime: now,
+			accept:    false,
+			remove:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, tc.allowance.ValidateBasic())
+
+			remove, err := tc.allowance.Accept(tc.fee, tc.blockTime)
+			require.Equal(t, tc.accept, err == nil)
+			require.Equal(t, tc.remove, remove)
+			if tc.accept && tc.remains != nil {
+				require.True(t, tc.remains.IsEqual(tc.allowance.SpendLimit))
+			}
+		})
+	}
+}
+
+func TestPeriodicFeeAllowance(t *testing.T) {
+	now := time.Now().UTC()
+	atom := sdk.NewCoins(sdk.NewInt64Coin("atom", 555))
+	smallAtom := sdk.NewCoins(sdk.NewInt64Coin("atom", 43))
+	leftAtom := sdk.NewCoins(sdk.NewInt64Coin("atom", 512))
+	periodAtom := sdk.NewCoins(sdk.NewInt64Coin("atom", 50))
+
+	allowance := NewPeriodicFeeAllowance(BasicFeeAllowance{SpendLimit: atom}, time.Hour, periodAtom)
+	require.NoError(t, allowance.ValidateBasic())
+
+	// the first fee starts the period
+	remove, err := allowance.Accept(smallAtom, now)
+	require.NoError(t, err)
+	require.False(t, remove)
+	require.True(t, leftAtom.IsEqual(allowance.Basic.SpendLimit))
+	require.Equal(t, now.Add(time.Hour), allowance.PeriodReset)
+
+	// the period limit is exceeded
+	_, err = allowance.Accept(smallAtom, now.Add(time.Minute))
+	require.Error(t, err)
+
+	// the period limit is restored once the period is over
+	_, err = allowance.Accept(smallAtom, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, now.Add(2*time.Hour), allowance.PeriodReset)
+
+	// skipped periods start a new period at the block time
+	later := now.Add(10 * time.Hour)
+	_, err = allowance.Accept(smallAtom, later)
+	require.NoError(t, err)
+	require.Equal(t, later.Add(time.Hour), allowance.PeriodReset)
+
+	// invalid allowances
+	require.Error(t, NewPeriodicFeeAllowance(BasicFeeAllowance{}, 0, periodAtom).ValidateBasic())
+	require.Error(t, NewPeriodicFeeAllowance(BasicFeeAllowance{}, time.Hour, nil).ValidateBasic())
+	require.Error(t, NewPeriodicFeeAllowance(
+		BasicFeeAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("stake", 1))}, time.Hour, periodAtom,
+	).ValidateBasic())
+}
@@ -0,0 +1,107 @@
+package feegrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+)
+
+var (
+	granterPriv = secp256k1.GenPrivKey()
+	granterAddr = sdk.AccAddress(granterPriv.PubKey().Address())
+	granteePriv = secp256k1.GenPrivKey()
+	granteeAddr = sdk.AccAddress(granteePriv.PubKey().Address())
+	appCoins    = sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+)
+
+// initialize the mock application for this module, whose ante handler charges
+// the fees against the fee allowances
+func getMockApp(t *testing.T) (*mock.App, Keeper) {
+	mapp := mock.NewApp()
+
+	RegisterCodec(mapp.Cdc)
+
+	keyFeeGrant := sdk.NewKVStoreKey(StoreKey)
+	keeper := NewKeeper(mapp.Cdc, keyFeeGrant)
+	mapp.Router().AddRoute(RouterKey, NewHandler(keeper))
+	mapp.SetAnteHandler(auth.NewAnteHandlerWithFeeGrants(mapp.AccountKeeper, mapp.FeeCollectionKeeper, keeper))
+
+	module := NewAppModule(keeper)
+	mapp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+		module.BeginBlock(ctx, req)
+		return abci.ResponseBeginBlock{}
+	})
+
+	require.NoError(t, mapp.CompleteSetup(keyFeeGrant))
+
+	accs := []auth.Account{
+		&auth.BaseAccount{Address: granterAddr, Coins: appCoins},
+		&auth.BaseAccount{Address: granteeAddr, Coins: appCoins},
+	}
+	mock.SetGenesis(mapp, accs)
+	return mapp, keeper
+}
+
+// genGrantedTx generates a tx signed by the grantee whose fee is paid by the
+// granter.
+func genGrantedTx(msgs []sdk.Msg, accNum, seq uint64, priv crypto.PrivKey) auth.StdTx {
+	fee := auth.StdFee{
+		Amount:  sdk.NewCoins(sdk.NewInt64Coin("foocoin", 1)),
+		Gas:     100000,
+		Granter: granterAddr,
+	}
+
+	sig, err := priv.Sign(auth.StdSignBytes("", accNum, seq, 0, false, fee, msgs, ""))
+	if err != nil {
+		panic(err)
+	}
+	return auth.NewStdTx(msgs, fee, []auth.StdSignature{{PubKey: priv.PubKey(), Signature: sig}}, "")
+}
+
+func deliverBlock(mapp *mock.App, header abci.Header, tx auth.StdTx) sdk.Result {
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	res := mapp.Deliver(tx)
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+	return res
+}
+
+func TestExpiredFeeAllowanceRemoved(t *testing.T) {
+	mapp, keeper := getMockApp(t)
+	start := time.Now().UTC()
+	expiration := start.Add(time.Hour)
+
+	grant := NewMsgGrantFeeAllowance(granterAddr, granteeAddr, NewBasicFeeAllowance(nil, expiration))
+	mock.SignCheckDeliver(t, mapp.Cdc, mapp.BaseApp, abci.Header{Height: 2, Time: start},
+		[]sdk.Msg{grant}, []uint64{0}, []uint64{0}, true, true, granterPriv)
+
+	// the grantee pays its fees with the allowance until it expires
+	msg := NewMsgGrantFeeAllowance(granteeAddr, granterAddr, NewBasicFeeAllowance(nil, time.Time{}))
+	res := deliverBlock(mapp, abci.Header{Height: 3, Time: start.Add(time.Minute)}, genGrantedTx([]sdk.Msg{msg}, 1, 0, granteePriv))
+	require.True(t, res.IsOK(), res.Log)
+	mock.CheckBalance(t, mapp, granterAddr, sdk.Coins{sdk.NewInt64Coin("foocoin", 9)})
+	mock.CheckBalance(t, mapp, granteeAddr, appCoins)
+
+	// the expired allowance is rejected and removed, although the tx using it
+	// is aborted
+	res = deliverBlock(mapp, abci.Header{Height: 4, Time: expiration}, genGrantedTx([]sdk.Msg{msg}, 1, 1, granteePriv))
+	require.False(t, res.IsOK())
+	mock.CheckBalance(t, mapp, granterAddr, sdk.Coins{sdk.NewInt64Coin("foocoin", 9)})
+
+	ctx := mapp.BaseApp.NewContext(true, abci.Header{})
+	_, found := keeper.GetFeeGrant(ctx, granterAddr, granteeAddr)
+	require.False(t, found)
+
+	store := ctx.KVStore(keeper.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, FeeAllowanceQueueKeyPrefix)
+	defer iter.Close()
+	require.False(t, iter.Valid())
+}
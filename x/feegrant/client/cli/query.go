@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// GetCmdQueryFeeAllowance implements a command to return the fee allowance a
+// granter issued to a grantee.
+func GetCmdQueryFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "allowance [granter-addr] [grantee-addr]",
+		Short: "Query the fee allowance a granter issued to a grantee",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(feegrant.NewQueryFeeAllowanceParams(granter, grantee))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", feegrant.QuerierRoute, feegrant.QueryFeeAllowance)
+			res, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var grant feegrant.FeeAllowanceGrant
+			if err := cdc.UnmarshalJSON(res, &grant); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(grant)
+		},
+	}
+}
+
+// GetCmdQueryFeeAllowances implements a command to return all the fee
+// allowances issued to a grantee.
+func GetCmdQueryFeeAllowances(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "allowances [grantee-addr]",
+		Short: "Query all the fee allowances issued to a grantee",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(feegrant.NewQueryFeeAllowancesParams(grantee))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", feegrant.QuerierRoute, feegrant.QueryFeeAllowances)
+			res, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var grants feegrant.FeeAllowanceGrants
+			if err := cdc.UnmarshalJSON(res, &grants); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(grants)
+		},
+	}
+}
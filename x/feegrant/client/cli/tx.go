@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+const (
+	flagSpendLimit  = "spend-limit"
+	flagExpiration  = "expiration"
+	flagPeriod      = "period"
+	flagPeriodLimit = "period-limit"
+)
+
+// GetCmdGrantFeeAllowance implements the command to grant a fee allowance.
+func GetCmdGrantFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee-addr]",
+		Short: "Grant an account an allowance to pay transaction fees from your account",
+		Long: strings.TrimSpace(`Grant an account an allowance to pay transaction fees from your account.
+Without a spend limit the allowance is unlimited; without an expiration it
+never expires. If a period is given, at most period-limit can be spent per period:
+
+$ gaiacli tx feegrant grant cosmos1... --spend-limit=1000uatom --expiration=2020-01-01T00:00:00Z --from mykey
+$ gaiacli tx feegrant grant cosmos1... --period=24h --period-limit=10uatom --from mykey
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			allowance, err := allowanceFromFlags()
+			if err != nil {
+				return err
+			}
+
+			msg := feegrant.NewMsgGrantFeeAllowance(cliCtx.GetFromAddress(), grantee, allowance)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, false)
+		},
+	}
+
+	cmd.Flags().String(flagSpendLimit, "", "The maximum amount of fees that can be spent in total")
+	cmd.Flags().String(flagExpiration, "", "The time (RFC3339) at which the allowance expires")
+	cmd.Flags().Duration(flagPeriod, 0, "The duration of a spending period")
+	cmd.Flags().String(flagPeriodLimit, "", "The maximum amount of fees that can be spent per period")
+	return cmd
+}
+
+func allowanceFromFlags() (feegrant.FeeAllowance, error) {
+	var basic feegrant.BasicFeeAllowance
+
+	if limit := viper.GetString(flagSpendLimit); limit != "" {
+		spendLimit, err := sdk.ParseCoins(limit)
+		if err != nil {
+			return nil, err
+		}
+		basic.SpendLimit = spendLimit
+	}
+
+	if exp := viper.GetString(flagExpiration); exp != "" {
+		expiration, err := time.Parse(time.RFC3339, exp)
+		if err != nil {
+			return nil, err
+		}
+		basic.Expiration = expiration
+	}
+
+	period := viper.GetDuration(flagPeriod)
+	if period == 0 {
+		return &basic, nil
+	}
+
+	periodLimit, err := sdk.ParseCoins(viper.GetString(flagPeriodLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	return feegrant.NewPeriodicFeeAllowance(basic, period, periodLimit), nil
+}
+
+// GetCmdRevokeFeeAllowance implements the command to revoke a fee allowance.
+func GetCmdRevokeFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke [grantee-addr]",
+		Short: "Revoke the fee allowance you granted to an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := feegrant.NewMsgRevokeFeeAllowance(cliCtx.GetFromAddress(), grantee)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, false)
+		},
+	}
+}
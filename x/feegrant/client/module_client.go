@@ -0,0 +1,54 @@
+package client
+
+import (
+	"github.com/spf13/cobra"
+	amino "github.com/tendermint/go-amino"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/x/feegrant/client/cli"
+)
+
+// ModuleClient exports all client functionality from this module
+type ModuleClient struct {
+	storeKey string
+	cdc      *amino.Codec
+}
+
+// NewModuleClient creates a new ModuleClient object
+func NewModuleClient(storeKey string, cdc *amino.Codec) ModuleClient {
+	return ModuleClient{
+		storeKey: storeKey,
+		cdc:      cdc,
+	}
+}
+
+// GetQueryCmd returns the cli query commands for this module
+func (mc ModuleClient) GetQueryCmd() *cobra.Command {
+	queryCmd := &cobra.Command{
+		Use:   feegrant.ModuleName,
+		Short: "Querying commands for the fee grant module",
+	}
+
+	queryCmd.AddCommand(sdkclient.GetCommands(
+		cli.GetCmdQueryFeeAllowance(mc.cdc),
+		cli.GetCmdQueryFeeAllowances(mc.cdc),
+	)...)
+
+	return queryCmd
+}
+
+// GetTxCmd returns the transaction commands for this module
+func (mc ModuleClient) GetTxCmd() *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   feegrant.ModuleName,
+		Short: "Fee grant transactions subcommands",
+	}
+
+	txCmd.AddCommand(sdkclient.PostCommands(
+		cli.GetCmdGrantFeeAllowance(mc.cdc),
+		cli.GetCmdRevokeFeeAllowance(mc.cdc),
+	)...)
+
+	return txCmd
+}
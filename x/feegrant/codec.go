@@ -0,0 +1,25 @@
+package feegrant
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterInterface((*FeeAllowance)(nil), nil)
+	cdc.RegisterConcrete(&BasicFeeAllowance{}, "cosmos-sdk/BasicFeeAllowance", nil)
+	cdc.RegisterConcrete(&PeriodicFeeAllowance{}, "cosmos-sdk/PeriodicFeeAllowance", nil)
+
+	cdc.RegisterConcrete(MsgGrantFeeAllowance{}, "cosmos-sdk/MsgGrantFeeAllowance", nil)
+	cdc.RegisterConcrete(MsgRevokeFeeAllowance{}, "cosmos-sdk/MsgRevokeFeeAllowance", nil)
+}
+
+// generic sealed codec to be used throughout module
+var MsgCdc *codec.Codec
+
+func init() {
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	MsgCdc = cdc.Seal()
+}
@@ -0,0 +1,44 @@
+package feegrant
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Fee grant errors reserve 100 ~ 199.
+const (
+	DefaultCodespace sdk.CodespaceType = ModuleName
+
+	CodeFeeLimitExceeded sdk.CodeType = 101
+	CodeFeeLimitExpired  sdk.CodeType = 102
+	CodeInvalidPeriod    sdk.CodeType = 103
+	CodeNoAllowance      sdk.CodeType = 104
+	CodeSelfGrant        sdk.CodeType = 105
+)
+
+// ErrFeeLimitExceeded is returned if the fee is larger than the allowance
+func ErrFeeLimitExceeded(codespace sdk.CodespaceType, fee, limit sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeFeeLimitExceeded, fmt.Sprintf("fee %s exceeds the allowance limit %s", fee, limit))
+}
+
+// ErrFeeLimitExpired is returned if the allowance has expired
+func ErrFeeLimitExpired(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeFeeLimitExpired, "fee allowance has expired")
+}
+
+// ErrInvalidPeriod is returned if a periodic allowance has an invalid period
+func ErrInvalidPeriod(codespace sdk.CodespaceType, period time.Duration) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPeriod, fmt.Sprintf("invalid allowance period %s", period))
+}
+
+// ErrNoAllowance is returned if there is no fee allowance
+func ErrNoAllowance(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoAllowance, "no fee allowance")
+}
+
+// ErrSelfGrant is returned if the granter and grantee are the same account
+func ErrSelfGrant(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeSelfGrant, "cannot grant a fee allowance to oneself")
+}
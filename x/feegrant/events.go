@@ -0,0 +1,12 @@
+package feegrant
+
+// feegrant module event types
+const (
+	EventTypeGrantFeeAllowance  = "grant_fee_allowance"
+	EventTypeRevokeFeeAllowance = "revoke_fee_allowance"
+
+	AttributeKeyGranter = "granter"
+	AttributeKeyGrantee = "grantee"
+
+	AttributeValueCategory = ModuleName
+)
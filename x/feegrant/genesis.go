@@ -0,0 +1,50 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState contains the fee allowances in state
+type GenesisState struct {
+	FeeAllowances []FeeAllowanceGrant `json:"fee_allowances"`
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(feeAllowances []FeeAllowanceGrant) GenesisState {
+	return GenesisState{
+		FeeAllowances: feeAllowances,
+	}
+}
+
+// DefaultGenesisState returns a GenesisState without any fee allowances
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// ValidateGenesis ensures all grants in the genesis state are valid
+func ValidateGenesis(data GenesisState) error {
+	for _, grant := range data.FeeAllowances {
+		if err := grant.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InitGenesis stores the fee allowances of the genesis state
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.FeeAllowances {
+		k.GrantFeeAllowance(ctx, grant)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var grants []FeeAllowanceGrant
+	k.IterateAllFeeAllowances(ctx, func(grant FeeAllowanceGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+
+	return NewGenesisState(grants)
+}
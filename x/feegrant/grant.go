@@ -0,0 +1,64 @@
+package feegrant
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeAllowanceGrant is stored in the KVStore to record a fee allowance the
+// Granter has issued to the Grantee.
+type FeeAllowanceGrant struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance FeeAllowance   `json:"allowance"`
+}
+
+// NewFeeAllowanceGrant returns a new FeeAllowanceGrant.
+func NewFeeAllowanceGrant(granter, grantee sdk.AccAddress, allowance FeeAllowance) FeeAllowanceGrant {
+	return FeeAllowanceGrant{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+// ValidateBasic performs stateless validation of the grant.
+func (g FeeAllowanceGrant) ValidateBasic() sdk.Error {
+	if g.Granter.Empty() {
+		return sdk.ErrInvalidAddress("missing granter address")
+	}
+	if g.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("missing grantee address")
+	}
+	if g.Grantee.Equals(g.Granter) {
+		return ErrSelfGrant(DefaultCodespace)
+	}
+	if g.Allowance == nil {
+		return ErrNoAllowance(DefaultCodespace)
+	}
+
+	return g.Allowance.ValidateBasic()
+}
+
+func (g FeeAllowanceGrant) String() string {
+	return fmt.Sprintf(`Granter: %s
+Grantee: %s
+%s`, g.Granter, g.Grantee, g.Allowance)
+}
+
+// FeeAllowanceGrants is a collection of FeeAllowanceGrant
+type FeeAllowanceGrants []FeeAllowanceGrant
+
+func (gs FeeAllowanceGrants) String() string {
+	if len(gs) == 0 {
+		return "[]"
+	}
+
+	out := make([]string, len(gs))
+	for i, g := range gs {
+		out[i] = g.String()
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,66 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "feegrant" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	router := sdk.NewMsgRouter()
+	RegisterMsgHandlers(router, k)
+	return sdk.NewMsgRouterHandler(router)
+}
+
+// RegisterMsgHandlers registers the handlers of the feegrant messages.
+func RegisterMsgHandlers(router sdk.MsgRouter, k Keeper) {
+	router.
+		AddMsgHandler(MsgGrantFeeAllowance{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgGrantFeeAllowance(ctx, k, msg.(MsgGrantFeeAllowance))
+		}).
+		AddMsgHandler(MsgRevokeFeeAllowance{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgRevokeFeeAllowance(ctx, k, msg.(MsgRevokeFeeAllowance))
+		})
+}
+
+func handleMsgGrantFeeAllowance(ctx sdk.Context, k Keeper, msg MsgGrantFeeAllowance) sdk.Result {
+	grant := NewFeeAllowanceGrant(msg.Granter, msg.Grantee, msg.Allowance)
+	k.GrantFeeAllowance(ctx, grant)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeGrantFeeAllowance,
+			sdk.NewAttribute(AttributeKeyGranter, msg.Granter.String()),
+			sdk.NewAttribute(AttributeKeyGrantee, msg.Grantee.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRevokeFeeAllowance(ctx sdk.Context, k Keeper, msg MsgRevokeFeeAllowance) sdk.Result {
+	if _, found := k.GetFeeGrant(ctx, msg.Granter, msg.Grantee); !found {
+		return ErrNoAllowance(DefaultCodespace).Result()
+	}
+
+	k.RevokeFeeAllowance(ctx, msg.Granter, msg.Grantee)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeRevokeFeeAllowance,
+			sdk.NewAttribute(AttributeKeyGranter, msg.Granter.String()),
+			sdk.NewAttribute(AttributeKeyGrantee, msg.Grantee.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
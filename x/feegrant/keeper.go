@@ -0,0 +1,209 @@
+package feegrant
+
+import (
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "feegrant"
+
+	// StoreKey is the store key string for the fee grant module
+	StoreKey = ModuleName
+
+	// RouterKey is the message route for the fee grant module
+	RouterKey = ModuleName
+
+	// QuerierRoute is the querier route for the fee grant module
+	QuerierRoute = ModuleName
+)
+
+var (
+	// FeeAllowanceKeyPrefix is the prefix of the keys storing fee allowances
+	FeeAllowanceKeyPrefix = []byte{0x00}
+
+	// FeeAllowanceQueueKeyPrefix is the prefix of the queue of the expiring
+	// allowances
+	FeeAllowanceQueueKeyPrefix = []byte{0x01}
+)
+
+// FeeAllowanceKey is the key under which the allowance granter issued to
+// grantee is stored: 0x00<grantee_bytes><granter_bytes>. Keying by the
+// grantee first allows iterating over all allowances of a grantee.
+func FeeAllowanceKey(granter, grantee sdk.AccAddress) []byte {
+	return append(FeeAllowancePrefixByGrantee(grantee), granter.Bytes()...)
+}
+
+// FeeAllowancePrefixByGrantee returns the prefix of all allowances issued to
+// the grantee.
+func FeeAllowancePrefixByGrantee(grantee sdk.AccAddress) []byte {
+	return append(FeeAllowanceKeyPrefix, grantee.Bytes()...)
+}
+
+// FeeAllowanceQueueKey is the key under which the allowance granter issued to
+// grantee is queued until its expiration:
+// 0x01<expiration_bytes><grantee_bytes><granter_bytes>.
+func FeeAllowanceQueueKey(expiration time.Time, granter, grantee sdk.AccAddress) []byte {
+	key := append(FeeAllowanceQueueTimePrefix(expiration), grantee.Bytes()...)
+	return append(key, granter.Bytes()...)
+}
+
+// FeeAllowanceQueueTimePrefix returns the prefix of the allowances queued
+// until the given expiration.
+func FeeAllowanceQueueTimePrefix(expiration time.Time) []byte {
+	return append(FeeAllowanceQueueKeyPrefix, sdk.FormatTimeBytes(expiration)...)
+}
+
+var _ auth.FeeGrantKeeper = Keeper{}
+
+// Keeper manages the fee allowances granters issue to grantees.
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+}
+
+// NewKeeper creates a new fee grant Keeper.
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey) Keeper {
+	return Keeper{
+		storeKey: storeKey,
+		cdc:      cdc,
+	}
+}
+
+// GrantFeeAllowance stores the grant, replacing any existing allowance from
+// the same granter to the same grantee. An expiring allowance is queued to be
+// removed at the start of the first block from its expiration.
+func (k Keeper) GrantFeeAllowance(ctx sdk.Context, grant FeeAllowanceGrant) {
+	k.RevokeFeeAllowance(ctx, grant.Granter, grant.Grantee)
+	k.setFeeGrant(ctx, grant)
+
+	if expiration := grant.Allowance.ExpiresAt(); !expiration.IsZero() {
+		store := ctx.KVStore(k.storeKey)
+		store.Set(
+			FeeAllowanceQueueKey(expiration, grant.Granter, grant.Grantee),
+			FeeAllowanceKey(grant.Granter, grant.Grantee),
+		)
+	}
+}
+
+// RevokeFeeAllowance removes any existing allowance from granter to grantee.
+func (k Keeper) RevokeFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) {
+	grant, found := k.GetFeeGrant(ctx, granter, grantee)
+	if found {
+		k.deleteFeeGrant(ctx, grant)
+	}
+}
+
+// RemoveExpiredFeeAllowances removes the allowances expired at the given block
+// time. The expired allowances are removed at the start of each block, as they
+// cannot be removed by the txs using them, which are rejected.
+func (k Keeper) RemoveExpiredFeeAllowances(ctx sdk.Context, blockTime time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(FeeAllowanceQueueKeyPrefix, sdk.PrefixEndBytes(FeeAllowanceQueueTimePrefix(blockTime)))
+
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key(), iter.Value())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) setFeeGrant(ctx sdk.Context, grant FeeAllowanceGrant) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(grant)
+	store.Set(FeeAllowanceKey(grant.Granter, grant.Grantee), bz)
+}
+
+func (k Keeper) deleteFeeGrant(ctx sdk.Context, grant FeeAllowanceGrant) {
+	store := ctx.KVStore(k.storeKey)
+	if expiration := grant.Allowance.ExpiresAt(); !expiration.IsZero() {
+		store.Delete(FeeAllowanceQueueKey(expiration, grant.Granter, grant.Grantee))
+	}
+	store.Delete(FeeAllowanceKey(grant.Granter, grant.Grantee))
+}
+
+// GetFeeAllowance returns the allowance granter issued to grantee, or nil if
+// there is none.
+func (k Keeper) GetFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) FeeAllowance {
+	grant, found := k.GetFeeGrant(ctx, granter, grantee)
+	if !found {
+		return nil
+	}
+	return grant.Allowance
+}
+
+// GetFeeGrant returns the full grant from granter to grantee.
+func (k Keeper) GetFeeGrant(ctx sdk.Context, granter, grantee sdk.AccAddress) (grant FeeAllowanceGrant, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(FeeAllowanceKey(granter, grantee))
+	if bz == nil {
+		return grant, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &grant)
+	return grant, true
+}
+
+// IterateAllGranteeFeeAllowances iterates over all the allowances issued to
+// the grantee. The iteration stops if cb returns true.
+func (k Keeper) IterateAllGranteeFeeAllowances(
+	ctx sdk.Context, grantee sdk.AccAddress, cb func(FeeAllowanceGrant) (stop bool),
+) {
+	k.iterateFeeAllowances(ctx, FeeAllowancePrefixByGrantee(grantee), cb)
+}
+
+// IterateAllFeeAllowances iterates over all the allowances in state. The
+// iteration stops if cb returns true.
+func (k Keeper) IterateAllFeeAllowances(ctx sdk.Context, cb func(FeeAllowanceGrant) (stop bool)) {
+	k.iterateFeeAllowances(ctx, FeeAllowanceKeyPrefix, cb)
+}
+
+func (k Keeper) iterateFeeAllowances(ctx sdk.Context, prefix []byte, cb func(FeeAllowanceGrant) bool) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var grant FeeAllowanceGrant
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &grant)
+		if cb(grant) {
+			break
+		}
+	}
+}
+
+// UseGrantedFees charges the fee against the allowance granter issued to
+// grantee. The allowance is updated, or removed once it is used up. It
+// implements the auth.FeeGrantKeeper interface.
+//
+// NOTE: an expired allowance is removed along with the error, but the removal
+// is discarded with the writes of the rejected tx, so the expired allowances
+// are removed by RemoveExpiredFeeAllowances.
+func (k Keeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins) sdk.Error {
+	grant, found := k.GetFeeGrant(ctx, granter, grantee)
+	if !found {
+		return ErrNoAllowance(DefaultCodespace)
+	}
+
+	remove, err := grant.Allowance.Accept(fee, ctx.BlockHeader().Time)
+	if remove {
+		k.deleteFeeGrant(ctx, grant)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the expiration is unchanged, so the queue is left as is
+	if !remove {
+		k.setFeeGrant(ctx, grant)
+	}
+	return nil
+}
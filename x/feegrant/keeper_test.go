@@ -0,0 +1,129 @@
+package feegrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	addr1 = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr2 = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr3 = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+)
+
+func createTestInput() (sdk.Context, Keeper) {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey(StoreKey)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		panic(err)
+	}
+
+	cdc := codec.New()
+	RegisterCodec(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Now().UTC()}, false, log.NewNopLogger())
+	return ctx, NewKeeper(cdc, key)
+}
+
+func TestKeeperGrantRevoke(t *testing.T) {
+	ctx, k := createTestInput()
+	limit := sdk.NewCoins(sdk.NewInt64Coin("atom", 100))
+
+	require.Nil(t, k.GetFeeAllowance(ctx, addr1, addr2))
+
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(addr1, addr2, NewBasicFeeAllowance(limit, time.Time{})))
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(addr3, addr2, NewBasicFeeAllowance(nil, time.Time{})))
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(addr2, addr1, NewBasicFeeAllowance(limit, time.Time{})))
+
+	require.Equal(t, NewBasicFeeAllowance(limit, time.Time{}), k.GetFeeAllowance(ctx, addr1, addr2))
+	require.Nil(t, k.GetFeeAllowance(ctx, addr2, addr3))
+
+	var grants []FeeAllowanceGrant
+	k.IterateAllGranteeFeeAllowances(ctx, addr2, func(grant FeeAllowanceGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	require.Len(t, grants, 2)
+
+	k.RevokeFeeAllowance(ctx, addr1, addr2)
+	require.Nil(t, k.GetFeeAllowance(ctx, addr1, addr2))
+
+	exported := ExportGenesis(ctx, k)
+	require.Len(t, exported.FeeAllowances, 2)
+	require.NoError(t, ValidateGenesis(exported))
+}
+
+func TestKeeperUseGrantedFees(t *testing.T) {
+	ctx, k := createTestInput()
+	limit := sdk.NewCoins(sdk.NewInt64Coin("atom", 100))
+	fee := sdk.NewCoins(sdk.NewInt64Coin("atom", 60))
+
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(addr1, addr2, NewBasicFeeAllowance(limit, time.Time{})))
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(
+		addr1, addr3, NewBasicFeeAllowance(nil, ctx.BlockHeader().Time.Add(-time.Hour)),
+	))
+
+	// no allowance
+	require.Error(t, k.UseGrantedFees(ctx, addr2, addr1, fee))
+
+	// the allowance is decremented
+	require.NoError(t, k.UseGrantedFees(ctx, addr1, addr2, fee))
+	left := sdk.NewCoins(sdk.NewInt64Coin("atom", 40))
+	require.Equal(t, NewBasicFeeAllowance(left, time.Time{}), k.GetFeeAllowance(ctx, addr1, addr2))
+
+	// the allowance is exceeded and left untouched
+	require.Error(t, k.UseGrantedFees(ctx, addr1, addr2, fee))
+	require.Equal(t, NewBasicFeeAllowance(left, time.Time{}), k.GetFeeAllowance(ctx, addr1, addr2))
+
+	// the allowance is removed once used up
+	require.NoError(t, k.UseGrantedFees(ctx, addr1, addr2, left))
+	require.Nil(t, k.GetFeeAllowance(ctx, addr1, addr2))
+
+	// expired allowances are removed
+	require.Error(t, k.UseGrantedFees(ctx, addr1, addr3, fee))
+	require.Nil(t, k.GetFeeAllowance(ctx, addr1, addr3))
+}
+
+func TestKeeperRemoveExpiredFeeAllowances(t *testing.T) {
+	ctx, k := createTestInput()
+	now := ctx.BlockHeader().Time
+
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(addr1, addr2, NewBasicFeeAllowance(nil, now.Add(time.Hour))))
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(addr1, addr3, NewBasicFeeAllowance(nil, now.Add(time.Hour))))
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(addr2, addr3, NewBasicFeeAllowance(nil, now.Add(2*time.Hour))))
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(addr3, addr1, NewBasicFeeAllowance(nil, time.Time{})))
+
+	// a replaced allowance is queued with its new expiration, and a revoked
+	// one is not queued anymore
+	k.GrantFeeAllowance(ctx, NewFeeAllowanceGrant(addr1, addr3, NewBasicFeeAllowance(nil, now.Add(3*time.Hour))))
+	k.RevokeFeeAllowance(ctx, addr2, addr3)
+
+	k.RemoveExpiredFeeAllowances(ctx, now.Add(time.Hour-time.Second))
+	require.NotNil(t, k.GetFeeAllowance(ctx, addr1, addr2))
+
+	k.RemoveExpiredFeeAllowances(ctx, now.Add(2*time.Hour))
+	require.Nil(t, k.GetFeeAllowance(ctx, addr1, addr2))
+	require.NotNil(t, k.GetFeeAllowance(ctx, addr1, addr3))
+
+	k.RemoveExpiredFeeAllowances(ctx, now.Add(3*time.Hour))
+	require.Nil(t, k.GetFeeAllowance(ctx, addr1, addr3))
+	require.NotNil(t, k.GetFeeAllowance(ctx, addr3, addr1))
+
+	// the queue is empty
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), FeeAllowanceQueueKeyPrefix)
+	defer iter.Close()
+	require.False(t, iter.Valid())
+}
@@ -0,0 +1,96 @@
+package feegrant
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ sdk.AppModule            = AppModule{}
+	_ sdk.AppModuleBasic       = AppModuleBasic{}
+	_ sdk.AppModuleMsgHandlers = AppModule{}
+)
+
+// AppModuleBasic defines the basic elements of the fee grant module.
+type AppModuleBasic struct{}
+
+// Name returns the fee grant module's name.
+func (AppModuleBasic) Name() string { return ModuleName }
+
+// RegisterCodec registers the fee grant module's types on the codec.
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) { RegisterCodec(cdc) }
+
+// Msgs returns the fee grant module's messages.
+func (AppModuleBasic) Msgs() []sdk.Msg {
+	return []sdk.Msg{MsgGrantFeeAllowance{}, MsgRevokeFeeAllowance{}}
+}
+
+// DefaultGenesis returns the default genesis state of the fee grant module.
+func (AppModuleBasic) DefaultGenesis(cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// ValidateGenesis validates a genesis state of the fee grant module.
+func (AppModuleBasic) ValidateGenesis(cdc *codec.Codec, bz json.RawMessage) error {
+	var data GenesisState
+	if err := cdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+	return ValidateGenesis(data)
+}
+
+// AppModule implements the sdk.AppModule interface for the fee grant module.
+type AppModule struct {
+	AppModuleBasic
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{
+		keeper: keeper,
+	}
+}
+
+// RegisterInvariants registers no invariants, as the fee grant module has none.
+func (AppModule) RegisterInvariants(sdk.InvariantRouter) {}
+
+// Route returns the fee grant module's message route.
+func (AppModule) Route() string { return RouterKey }
+
+// NewHandler returns the fee grant module's message handler.
+func (am AppModule) NewHandler() sdk.Handler { return NewHandler(am.keeper) }
+
+// RegisterMsgHandlers registers the fee grant module's message handlers.
+func (am AppModule) RegisterMsgHandlers(router sdk.MsgRouter) { RegisterMsgHandlers(router, am.keeper) }
+
+// QuerierRoute returns the fee grant module's querier route.
+func (AppModule) QuerierRoute() string { return QuerierRoute }
+
+// NewQuerierHandler returns the fee grant module's querier.
+func (am AppModule) NewQuerierHandler() sdk.Querier { return NewQuerier(am.keeper) }
+
+// InitGenesis initializes the fee grant module's genesis state.
+func (am AppModule) InitGenesis(ctx sdk.Context, cdc *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	cdc.MustUnmarshalJSON(bz, &data)
+	InitGenesis(ctx, am.keeper, data)
+	return nil
+}
+
+// ExportGenesis exports the fee grant module's genesis state.
+func (am AppModule) ExportGenesis(ctx sdk.Context, cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(ExportGenesis(ctx, am.keeper))
+}
+
+// BeginBlock removes the allowances expired at the start of the block.
+func (am AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
+	am.keeper.RemoveExpiredFeeAllowances(ctx, ctx.BlockHeader().Time)
+}
+
+// EndBlock runs nothing at the end of a block.
+func (AppModule) EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate { return nil }
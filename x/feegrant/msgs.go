@@ -0,0 +1,88 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ensure Msg interface compliance at compile time
+var (
+	_ sdk.Msg = MsgGrantFeeAllowance{}
+	_ sdk.Msg = MsgRevokeFeeAllowance{}
+)
+
+// MsgGrantFeeAllowance adds permission for Grantee to spend up to Allowance
+// of fees from the account of Granter. If there was already an allowance for
+// the pair, it is replaced.
+type MsgGrantFeeAllowance struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance FeeAllowance   `json:"allowance"`
+}
+
+// NewMsgGrantFeeAllowance creates a new MsgGrantFeeAllowance.
+func NewMsgGrantFeeAllowance(granter, grantee sdk.AccAddress, allowance FeeAllowance) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+//nolint
+func (msg MsgGrantFeeAllowance) Route() string { return RouterKey }
+func (msg MsgGrantFeeAllowance) Type() string  { return "grant_fee_allowance" }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgGrantFeeAllowance) ValidateBasic() sdk.Error {
+	return NewFeeAllowanceGrant(msg.Granter, msg.Grantee, msg.Allowance).ValidateBasic()
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(MsgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgGrantFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgRevokeFeeAllowance removes any existing fee allowance from Granter to
+// Grantee.
+type MsgRevokeFeeAllowance struct {
+	Granter sdk.AccAddress `json:"granter"`
+	Grantee sdk.AccAddress `json:"grantee"`
+}
+
+// NewMsgRevokeFeeAllowance creates a new MsgRevokeFeeAllowance.
+func NewMsgRevokeFeeAllowance(granter, grantee sdk.AccAddress) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+//nolint
+func (msg MsgRevokeFeeAllowance) Route() string { return RouterKey }
+func (msg MsgRevokeFeeAllowance) Type() string  { return "revoke_fee_allowance" }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgRevokeFeeAllowance) ValidateBasic() sdk.Error {
+	if msg.Granter.Empty() {
+		return sdk.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("missing grantee address")
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(MsgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgRevokeFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
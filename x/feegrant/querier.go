@@ -0,0 +1,99 @@
+package feegrant
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the fee grant Querier
+const (
+	QueryFeeAllowance  = "allowance"
+	QueryFeeAllowances = "allowances"
+)
+
+// QueryFeeAllowanceParams defines the params for querying the allowance a
+// granter issued to a grantee
+type QueryFeeAllowanceParams struct {
+	Granter sdk.AccAddress
+	Grantee sdk.AccAddress
+}
+
+// NewQueryFeeAllowanceParams creates a new instance of QueryFeeAllowanceParams
+func NewQueryFeeAllowanceParams(granter, grantee sdk.AccAddress) QueryFeeAllowanceParams {
+	return QueryFeeAllowanceParams{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+// QueryFeeAllowancesParams defines the params for querying all the allowances
+// issued to a grantee
+type QueryFeeAllowancesParams struct {
+	Grantee sdk.AccAddress
+}
+
+// NewQueryFeeAllowancesParams creates a new instance of QueryFeeAllowancesParams
+func NewQueryFeeAllowancesParams(grantee sdk.AccAddress) QueryFeeAllowancesParams {
+	return QueryFeeAllowancesParams{
+		Grantee: grantee,
+	}
+}
+
+// NewQuerier returns a fee grant Querier handler.
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryFeeAllowance:
+			return queryFeeAllowance(ctx, req, k)
+
+		case QueryFeeAllowances:
+			return queryFeeAllowances(ctx, req, k)
+
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown feegrant query endpoint: %s", path[0]))
+		}
+	}
+}
+
+func queryFeeAllowance(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params QueryFeeAllowanceParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	grant, found := k.GetFeeGrant(ctx, params.Granter, params.Grantee)
+	if !found {
+		return nil, ErrNoAllowance(DefaultCodespace)
+	}
+
+	res, err := codec.MarshalJSONIndent(k.cdc, grant)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal JSON", err.Error()))
+	}
+
+	return res, nil
+}
+
+func queryFeeAllowances(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params QueryFeeAllowancesParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	grants := FeeAllowanceGrants{}
+	k.IterateAllGranteeFeeAllowances(ctx, params.Grantee, func(grant FeeAllowanceGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+
+	res, err := codec.MarshalJSONIndent(k.cdc, grants)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal JSON", err.Error()))
+	}
+
+	return res, nil
+}
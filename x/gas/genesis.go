@@ -0,0 +1,38 @@
+package gas
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - gas genesis state
+type GenesisState struct {
+	Params Params `json:"params"`
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(params Params) GenesisState {
+	return GenesisState{
+		Params: params,
+	}
+}
+
+// DefaultGenesisState creates a default GenesisState object
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(DefaultParams())
+}
+
+// InitGenesis sets the gas schedule in the params.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper.
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	return NewGenesisState(keeper.GetParams(ctx))
+}
+
+// ValidateGenesis performs basic validation of the gas genesis data returning
+// an error for any failed validation criteria.
+func ValidateGenesis(data GenesisState) error {
+	return data.Params.Validate()
+}
@@ -0,0 +1,90 @@
+package gas
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// ModuleName is the name of the gas module
+const ModuleName = "gas"
+
+// Keeper of the gas schedule of the stores
+type Keeper struct {
+	paramSpace params.Subspace
+
+	// gas configs declared by the modules for their stores, by store name
+	storeGasConfigs map[string]sdk.GasConfig
+}
+
+// NewKeeper creates a new Keeper object
+func NewKeeper(paramSpace params.Subspace) Keeper {
+	return Keeper{
+		paramSpace:      paramSpace.WithKeyTable(ParamKeyTable()),
+		storeGasConfigs: make(map[string]sdk.GasConfig),
+	}
+}
+
+// RegisterStoreGasConfig declares a discounted or surcharged gas config for
+// the accesses to the store of a module. It applies until governance sets
+// another one for the store in the params.
+func (k *Keeper) RegisterStoreGasConfig(key sdk.StoreKey, config sdk.GasConfig) {
+	if _, ok := k.storeGasConfigs[key.Name()]; ok {
+		panic(fmt.Sprintf("gas config already registered for store %s", key.Name()))
+	}
+	if err := validateGasConfig(config); err != nil {
+		panic(fmt.Sprintf("invalid gas config for store %s: %s", key.Name(), err))
+	}
+	k.storeGasConfigs[key.Name()] = config
+}
+
+// GetParams returns the gas params, defaulting to DefaultParams for the ones
+// not set yet.
+func (k Keeper) GetParams(ctx sdk.Context) Params {
+	p := DefaultParams()
+	for _, pair := range p.ParamSetPairs() {
+		k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return p
+}
+
+// SetParams sets the gas params.
+func (k Keeper) SetParams(ctx sdk.Context, p Params) {
+	k.paramSpace.SetParamSet(ctx, &p)
+}
+
+// GasSchedule returns the gas schedule charged for the store accesses. It is
+// meant to be set as the gas scheduler of the app, so that it is read at the
+// start of each block.
+func (k Keeper) GasSchedule(ctx sdk.Context) sdk.GasSchedule {
+	p := k.GetParams(ctx)
+	schedule := sdk.GasSchedule{
+		KV:        p.KVGasConfig,
+		Transient: p.TransientGasConfig,
+	}
+	if len(k.storeGasConfigs) == 0 && len(p.StoreGasConfigs) == 0 {
+		return schedule
+	}
+
+	schedule.Stores = make(map[string]sdk.GasConfig)
+	for store, config := range k.storeGasConfigs {
+		schedule.Stores[store] = config
+	}
+	for _, sgc := range p.StoreGasConfigs {
+		schedule.Stores[sgc.Store] = sgc.GasConfig
+	}
+	return schedule
+}
+
+// BlockGasCaps returns the block gas caps of the modules, by module name. It
+// is meant to be set as the block gas capper of the module manager, so that
+// the caps are read from the params at the start of each block.
+func (k Keeper) BlockGasCaps(ctx sdk.Context) map[string]sdk.Gas {
+	p := k.GetParams(ctx)
+	caps := make(map[string]sdk.Gas, len(p.BlockGasCaps))
+	for _, bgc := range p.BlockGasCaps {
+		caps[bgc.Module] = bgc.GasCap
+	}
+	return caps
+}
@@ -0,0 +1,121 @@
+package gas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper, params.Subspace) {
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	pk := params.NewKeeper(codec.New(), keyParams, tkeyParams)
+	keeper := NewKeeper(pk.Subspace(DefaultParamspace))
+	space, _ := pk.GetSubspace(DefaultParamspace)
+	return ctx, keeper, space
+}
+
+func TestGasSchedule(t *testing.T) {
+	ctx, keeper, space := createTestInput(t)
+
+	// the default schedule applies until the params are set
+	require.Equal(t, DefaultParams(), keeper.GetParams(ctx))
+	require.Equal(t, sdk.DefaultGasSchedule(), keeper.GasSchedule(ctx))
+
+	discount := sdk.GasConfig{HasCost: 1, DeleteCost: 1, ReadCostFlat: 1, WriteCostFlat: 1, IterNextCostFlat: 1}
+	surcharge := sdk.DefaultGasSchedule().KV
+	surcharge.WriteCostFlat *= 2
+	moduleKey, otherKey := sdk.NewKVStoreKey("module"), sdk.NewKVStoreKey("other")
+	keeper.RegisterStoreGasConfig(moduleKey, discount)
+	keeper.RegisterStoreGasConfig(otherKey, surcharge)
+	require.Panics(t, func() { keeper.RegisterStoreGasConfig(moduleKey, surcharge) })
+	require.Panics(t, func() { keeper.RegisterStoreGasConfig(sdk.NewKVStoreKey("free"), sdk.GasConfig{}) })
+
+	InitGenesis(ctx, keeper, DefaultGenesisState())
+	schedule := keeper.GasSchedule(ctx)
+	require.Equal(t, discount, schedule.KVStoreGasConfig(moduleKey.Name()))
+	require.Equal(t, surcharge, schedule.KVStoreGasConfig(otherKey.Name()))
+	require.Equal(t, DefaultParams().KVGasConfig, schedule.KVStoreGasConfig("bank"))
+
+	// governance retunes the schedule and overrides the config of a module
+	require.NoError(t, space.Update(ctx, KeyKVGasConfig, []byte(`{"has_cost":"2","delete_cost":"2","read_cost_flat":"2","read_cost_per_byte":"2","write_cost_flat":"2","write_cost_per_byte":"2","iter_next_cost_flat":"2"}`)))
+	require.NoError(t, space.Update(ctx, KeyStoreGasConfigs, []byte(`[{"store":"other","gas_config":{"has_cost":"3","delete_cost":"3","read_cost_flat":"3","read_cost_per_byte":"3","write_cost_flat":"3","write_cost_per_byte":"3","iter_next_cost_flat":"3"}}]`)))
+	schedule = keeper.GasSchedule(ctx)
+	require.Equal(t, discount, schedule.KVStoreGasConfig(moduleKey.Name()))
+	require.Equal(t, sdk.Gas(3), schedule.KVStoreGasConfig(otherKey.Name()).WriteCostFlat)
+	require.Equal(t, sdk.Gas(2), schedule.KVStoreGasConfig("bank").WriteCostFlat)
+	require.Equal(t, DefaultParams().TransientGasConfig, schedule.TransientStoreGasConfig("transient_bank"))
+
+	// invalid configs are rejected
+	require.Error(t, space.Update(ctx, KeyKVGasConfig, []byte(`{"has_cost":"0","delete_cost":"2","read_cost_flat":"2","read_cost_per_byte":"2","write_cost_flat":"2","write_cost_per_byte":"2","iter_next_cost_flat":"2"}`)))
+	require.Error(t, space.Update(ctx, KeyTransientGasConfig, []byte(`{}`)))
+	require.Error(t, space.Update(ctx, KeyStoreGasConfigs, []byte(`[{"store":"other","gas_config":{"has_cost":"3","delete_cost":"3","read_cost_flat":"3","read_cost_per_byte":"3","write_cost_flat":"3","write_cost_per_byte":"3","iter_next_cost_flat":"3"}},{"store":"other","gas_config":{"has_cost":"4","delete_cost":"4","read_cost_flat":"4","read_cost_per_byte":"4","write_cost_flat":"4","write_cost_per_byte":"4","iter_next_cost_flat":"4"}}]`)))
+	require.Equal(t, schedule, keeper.GasSchedule(ctx))
+
+	exported := ExportGenesis(ctx, keeper)
+	require.NoError(t, ValidateGenesis(exported))
+	require.Equal(t, sdk.Gas(2), exported.Params.KVGasConfig.HasCost)
+}
+
+func TestValidateParams(t *testing.T) {
+	require.NoError(t, DefaultParams().Validate())
+
+	p := DefaultParams()
+	p.KVGasConfig.WriteCostFlat = 0
+	require.Error(t, p.Validate())
+
+	p = DefaultParams()
+	p.StoreGasConfigs = []StoreGasConfig{NewStoreGasConfig("", p.KVGasConfig)}
+	require.Error(t, p.Validate())
+
+	p.StoreGasConfigs = []StoreGasConfig{
+		NewStoreGasConfig("bank", p.KVGasConfig),
+		NewStoreGasConfig("bank", p.KVGasConfig),
+	}
+	require.Error(t, p.Validate())
+	p.StoreGasConfigs = p.StoreGasConfigs[:1]
+	require.NoError(t, p.Validate())
+
+	p.BlockGasCaps = []BlockGasCap{NewBlockGasCap("gov", 0)}
+	require.Error(t, p.Validate())
+	p.BlockGasCaps = []BlockGasCap{NewBlockGasCap("gov", 1000), NewBlockGasCap("gov", 2000)}
+	require.Error(t, p.Validate())
+	p.BlockGasCaps = p.BlockGasCaps[:1]
+	require.NoError(t, p.Validate())
+}
+
+func TestBlockGasCaps(t *testing.T) {
+	ctx, keeper, space := createTestInput(t)
+
+	// no module is capped by default
+	require.Empty(t, keeper.BlockGasCaps(ctx))
+
+	genesis := DefaultGenesisState()
+	genesis.Params.BlockGasCaps = []BlockGasCap{NewBlockGasCap("gov", 1000)}
+	InitGenesis(ctx, keeper, genesis)
+	require.Equal(t, map[string]sdk.Gas{"gov": 1000}, keeper.BlockGasCaps(ctx))
+
+	// governance changes the caps, which must be positive and set once per module
+	require.NoError(t, space.Update(ctx, KeyBlockGasCaps, []byte(`[{"module":"gov","gas_cap":"2000"},{"module":"staking","gas_cap":"3000"}]`)))
+	require.Equal(t, map[string]sdk.Gas{"gov": 2000, "staking": 3000}, keeper.BlockGasCaps(ctx))
+	require.Error(t, space.Update(ctx, KeyBlockGasCaps, []byte(`[{"module":"gov","gas_cap":"0"}]`)))
+	require.Error(t, space.Update(ctx, KeyBlockGasCaps, []byte(`[{"module":"gov","gas_cap":"1"},{"module":"gov","gas_cap":"2"}]`)))
+	require.Equal(t, map[string]sdk.Gas{"gov": 2000, "staking": 3000}, keeper.BlockGasCaps(ctx))
+}
@@ -0,0 +1,85 @@
+package gas
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ sdk.AppModule      = AppModule{}
+	_ sdk.AppModuleBasic = AppModuleBasic{}
+)
+
+// AppModuleBasic defines the basic elements of the gas module.
+type AppModuleBasic struct{}
+
+// Name returns the gas module's name.
+func (AppModuleBasic) Name() string { return ModuleName }
+
+// RegisterCodec registers nothing, as the gas module has no types to register.
+func (AppModuleBasic) RegisterCodec(*codec.Codec) {}
+
+// DefaultGenesis returns the default genesis state of the gas module.
+func (AppModuleBasic) DefaultGenesis(cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// ValidateGenesis validates a genesis state of the gas module.
+func (AppModuleBasic) ValidateGenesis(cdc *codec.Codec, bz json.RawMessage) error {
+	var data GenesisState
+	if err := cdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+	return ValidateGenesis(data)
+}
+
+// AppModule implements the sdk.AppModule interface for the gas module.
+type AppModule struct {
+	AppModuleBasic
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{
+		keeper: keeper,
+	}
+}
+
+// RegisterInvariants registers no invariants, as the gas module has none.
+func (AppModule) RegisterInvariants(sdk.InvariantRouter) {}
+
+// Route returns no message route, as the gas module has no messages.
+func (AppModule) Route() string { return "" }
+
+// NewHandler returns no handler, as the gas module has no messages.
+func (AppModule) NewHandler() sdk.Handler { return nil }
+
+// QuerierRoute returns no querier route, as the gas module has no querier.
+func (AppModule) QuerierRoute() string { return "" }
+
+// NewQuerierHandler returns no querier, as the gas module has none.
+func (AppModule) NewQuerierHandler() sdk.Querier { return nil }
+
+// InitGenesis initializes the gas module's genesis state.
+func (am AppModule) InitGenesis(ctx sdk.Context, cdc *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	cdc.MustUnmarshalJSON(bz, &data)
+	InitGenesis(ctx, am.keeper, data)
+	return nil
+}
+
+// ExportGenesis exports the gas module's genesis state.
+func (am AppModule) ExportGenesis(ctx sdk.Context, cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(ExportGenesis(ctx, am.keeper))
+}
+
+// BeginBlock runs nothing at the start of a block.
+func (AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {}
+
+// EndBlock runs nothing at the end of a block.
+func (AppModule) EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate { return nil }
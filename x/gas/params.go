@@ -0,0 +1,219 @@
+package gas
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default gas module parameter subspace
+const DefaultParamspace = ModuleName
+
+// Parameter keys
+var (
+	KeyKVGasConfig        = []byte("KVGasConfig")
+	KeyTransientGasConfig = []byte("TransientGasConfig")
+	KeyStoreGasConfigs    = []byte("StoreGasConfigs")
+	KeyBlockGasCaps       = []byte("BlockGasCaps")
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the gas schedule charged for the store accesses.
+type Params struct {
+	// KVGasConfig is charged for the accesses to the KVStores.
+	KVGasConfig sdk.GasConfig `json:"kv_gas_config"`
+	// TransientGasConfig is charged for the accesses to the TransientStores.
+	TransientGasConfig sdk.GasConfig `json:"transient_gas_config"`
+	// StoreGasConfigs are charged instead for the accesses to the given
+	// stores, overriding the configs declared by their modules.
+	StoreGasConfigs []StoreGasConfig `json:"store_gas_configs"`
+	// BlockGasCaps limit the gas the blockers of the given modules may each
+	// consume in a block, past which they defer their work to the next block.
+	BlockGasCaps []BlockGasCap `json:"block_gas_caps"`
+}
+
+// StoreGasConfig defines the gas config charged for the accesses to a store.
+type StoreGasConfig struct {
+	Store     string        `json:"store"`
+	GasConfig sdk.GasConfig `json:"gas_config"`
+}
+
+// NewStoreGasConfig returns a new StoreGasConfig.
+func NewStoreGasConfig(store string, config sdk.GasConfig) StoreGasConfig {
+	return StoreGasConfig{
+		Store:     store,
+		GasConfig: config,
+	}
+}
+
+// String implements the stringer interface.
+func (sgc StoreGasConfig) String() string {
+	return fmt.Sprintf("%s: %+v", sgc.Store, sgc.GasConfig)
+}
+
+// BlockGasCap defines the gas the BeginBlock and EndBlock of a module may each
+// consume in a block.
+type BlockGasCap struct {
+	Module string  `json:"module"`
+	GasCap sdk.Gas `json:"gas_cap"`
+}
+
+// NewBlockGasCap returns a new BlockGasCap.
+func NewBlockGasCap(module string, gasCap sdk.Gas) BlockGasCap {
+	return BlockGasCap{
+		Module: module,
+		GasCap: gasCap,
+	}
+}
+
+// String implements the stringer interface.
+func (bgc BlockGasCap) String() string {
+	return fmt.Sprintf("%s: %d", bgc.Module, bgc.GasCap)
+}
+
+// ParamKeyTable for gas module, with the params validated when they are
+// updated, e.g. by governance
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().
+		RegisterTypeWithValidator(KeyKVGasConfig, sdk.GasConfig{}, validateKVGasConfig).
+		RegisterTypeWithValidator(KeyTransientGasConfig, sdk.GasConfig{}, validateTransientGasConfig).
+		RegisterTypeWithValidator(KeyStoreGasConfigs, []StoreGasConfig{}, validateStoreGasConfigs).
+		RegisterTypeWithValidator(KeyBlockGasCaps, []BlockGasCap{}, validateBlockGasCaps)
+}
+
+// ParamSetPairs implements the ParamSet interface and returns all the key/value pairs
+// pairs of gas module's parameters.
+// nolint
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyKVGasConfig, &p.KVGasConfig},
+		{KeyTransientGasConfig, &p.TransientGasConfig},
+		{KeyStoreGasConfigs, &p.StoreGasConfigs},
+		{KeyBlockGasCaps, &p.BlockGasCaps},
+	}
+}
+
+// DefaultParams returns the default gas schedule of the stores.
+func DefaultParams() Params {
+	schedule := sdk.DefaultGasSchedule()
+	return Params{
+		KVGasConfig:        schedule.KV,
+		TransientGasConfig: schedule.Transient,
+	}
+}
+
+// Validate checks that no store access is free, so that a block can't be
+// filled with an unbounded number of them, and that a store is only given one
+// config, and that the block gas caps are positive and set once per module.
+func (p Params) Validate() error {
+	if err := validateKVGasConfig(p.KVGasConfig); err != nil {
+		return err
+	}
+	if err := validateTransientGasConfig(p.TransientGasConfig); err != nil {
+		return err
+	}
+	if err := validateStoreGasConfigs(p.StoreGasConfigs); err != nil {
+		return err
+	}
+	return validateBlockGasCaps(p.BlockGasCaps)
+}
+
+func validateKVGasConfig(i interface{}) error {
+	config, ok := i.(sdk.GasConfig)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if err := validateGasConfig(config); err != nil {
+		return fmt.Errorf("invalid kv gas config: %s", err)
+	}
+	return nil
+}
+
+func validateTransientGasConfig(i interface{}) error {
+	config, ok := i.(sdk.GasConfig)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if err := validateGasConfig(config); err != nil {
+		return fmt.Errorf("invalid transient gas config: %s", err)
+	}
+	return nil
+}
+
+func validateStoreGasConfigs(i interface{}) error {
+	sgcs, ok := i.([]StoreGasConfig)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool)
+	for _, sgc := range sgcs {
+		if sgc.Store == "" {
+			return fmt.Errorf("store gas config without a store name")
+		}
+		if seen[sgc.Store] {
+			return fmt.Errorf("duplicate gas config for store %s", sgc.Store)
+		}
+		seen[sgc.Store] = true
+		if err := validateGasConfig(sgc.GasConfig); err != nil {
+			return fmt.Errorf("invalid gas config for store %s: %s", sgc.Store, err)
+		}
+	}
+	return nil
+}
+
+func validateBlockGasCaps(i interface{}) error {
+	caps, ok := i.([]BlockGasCap)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool)
+	for _, bgc := range caps {
+		if bgc.Module == "" {
+			return fmt.Errorf("block gas cap without a module name")
+		}
+		if seen[bgc.Module] {
+			return fmt.Errorf("duplicate block gas cap for module %s", bgc.Module)
+		}
+		seen[bgc.Module] = true
+		if bgc.GasCap == 0 {
+			return fmt.Errorf("block gas cap of module %s must be positive", bgc.Module)
+		}
+	}
+	return nil
+}
+
+func validateGasConfig(config sdk.GasConfig) error {
+	switch {
+	case config.HasCost == 0:
+		return fmt.Errorf("has cost must be positive")
+	case config.DeleteCost == 0:
+		return fmt.Errorf("delete cost must be positive")
+	case config.ReadCostFlat == 0:
+		return fmt.Errorf("flat read cost must be positive")
+	case config.WriteCostFlat == 0:
+		return fmt.Errorf("flat write cost must be positive")
+	case config.IterNextCostFlat == 0:
+		return fmt.Errorf("flat iteration cost must be positive")
+	}
+	return nil
+}
+
+// String implements the stringer interface.
+func (p Params) String() string {
+	var sb strings.Builder
+	sb.WriteString("Params: \n")
+	sb.WriteString(fmt.Sprintf("KVGasConfig: %+v\n", p.KVGasConfig))
+	sb.WriteString(fmt.Sprintf("TransientGasConfig: %+v\n", p.TransientGasConfig))
+	sb.WriteString("StoreGasConfigs:\n")
+	for _, sgc := range p.StoreGasConfigs {
+		sb.WriteString(fmt.Sprintf("  %s\n", sgc))
+	}
+	sb.WriteString("BlockGasCaps:\n")
+	for _, bgc := range p.BlockGasCaps {
+		sb.WriteString(fmt.Sprintf("  %s\n", bgc))
+	}
+	return sb.String()
+}
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"github.com/spf13/viper"
 
+	"github.com/cosmos/cosmos-sdk/x/gov"
+
 	govClientUtils "github.com/cosmos/cosmos-sdk/x/gov/client/utils"
 )
 
@@ -40,3 +43,20 @@ func parseSubmitProposalFlags() (*proposal, error) {
 
 	return proposal, nil
 }
+
+// parseParamChanges parses parameter changes formatted as subspace/key=value
+func parseParamChanges(changes []string) ([]gov.ParamChange, error) {
+	var res []gov.ParamChange
+	for _, change := range changes {
+		kv := strings.SplitN(change, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid parameter change %q, expected subspace/key=value", change)
+		}
+		path := strings.SplitN(kv[0], "/", 2)
+		if len(path) != 2 {
+			return nil, fmt.Errorf("invalid parameter change %q, expected subspace/key=value", change)
+		}
+		res = append(res, gov.NewParamChange(path[0], path[1], kv[1]))
+	}
+	return res, nil
+}
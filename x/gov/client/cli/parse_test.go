@@ -6,6 +6,8 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/x/gov"
 )
 
 func TestParseSubmitProposalFlags(t *testing.T) {
@@ -69,3 +71,20 @@ func TestParseSubmitProposalFlags(t *testing.T) {
 	err = badJSON.Close()
 	require.Nil(t, err, "unexpected error")
 }
+
+func TestParseParamChanges(t *testing.T) {
+	changes, err := parseParamChanges([]string{
+		`auth/MaxMemoCharacters="512"`,
+		`gov/depositparams={"min_deposit":[{"denom":"stake","amount":"10"}],"max_deposit_period":"10"}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []gov.ParamChange{
+		gov.NewParamChange("auth", "MaxMemoCharacters", `"512"`),
+		gov.NewParamChange("gov", "depositparams", `{"min_deposit":[{"denom":"stake","amount":"10"}],"max_deposit_period":"10"}`),
+	}, changes)
+
+	_, err = parseParamChanges([]string{`auth/MaxMemoCharacters`})
+	require.Error(t, err)
+	_, err = parseParamChanges([]string{`MaxMemoCharacters="512"`})
+	require.Error(t, err)
+}
@@ -58,7 +58,7 @@ Query for a all proposals. You can filter the returns with the following flags:
 
 $ gaiacli query gov proposals --depositor cosmos1skjwj5whet0lpe65qaq4rpq03hjxlwd9nf39lk
 $ gaiacli query gov proposals --voter cosmos1skjwj5whet0lpe65qaq4rpq03hjxlwd9nf39lk
-$ gaiacli query gov proposals --status (DepositPeriod|VotingPeriod|Passed|Rejected)
+$ gaiacli query gov proposals --status (DepositPeriod|VotingPeriod|Passed|Rejected|Failed)
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			bechDepositorAddr := viper.GetString(flagDepositor)
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	govClientUtils "github.com/cosmos/cosmos-sdk/x/gov/client/utils"
 )
@@ -29,13 +30,15 @@ const (
 	flagStatus       = "status"
 	flagNumLimit     = "limit"
 	flagProposal     = "proposal"
+	flagParamChange  = "param-change"
 )
 
 type proposal struct {
-	Title       string
-	Description string
-	Type        string
-	Deposit     string
+	Title        string
+	Description  string
+	Type         string
+	Deposit      string
+	ParamChanges []gov.ParamChange `json:"param_changes"`
 }
 
 var proposalFlags = []string{
@@ -67,6 +70,12 @@ where proposal.json contains:
 is equivalent to
 
 $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome proposal" --type="Text" --deposit="10test" --from mykey
+
+Parameter change proposals list the JSON encoded new value of each changed parameter, either
+in the "param_changes" field of the proposal file or through repeated --param-change flags:
+
+$ gaiacli gov submit-proposal --title="Raise memo limit" --description="Allow longer memos" \
+	--type="ParameterChange" --param-change='auth/MaxMemoCharacters="512"' --deposit="10test" --from mykey
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			proposal, err := parseSubmitProposalFlags()
@@ -74,6 +83,20 @@ $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome
 				return err
 			}
 
+			// read as a string array as values are JSON which may contain commas
+			paramChanges, err := cmd.Flags().GetStringArray(flagParamChange)
+			if err != nil {
+				return err
+			}
+			if len(paramChanges) != 0 {
+				if viper.GetString(flagProposal) != "" {
+					return fmt.Errorf("--%s flag provided alongside --proposal, which is a noop", flagParamChange)
+				}
+				if proposal.ParamChanges, err = parseParamChanges(paramChanges); err != nil {
+					return err
+				}
+			}
+
 			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
 			cliCtx := context.NewCLIContext().
 				WithCodec(cdc).
@@ -94,6 +117,9 @@ $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome
 			}
 
 			msg := gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, from, amount)
+			if proposalType == gov.ProposalTypeParameterChange {
+				msg = gov.NewMsgSubmitParameterChangeProposal(proposal.Title, proposal.Description, proposal.ParamChanges, from, amount)
+			}
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
@@ -107,6 +133,7 @@ $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome
 	cmd.Flags().String(flagDescription, "", "description of proposal")
 	cmd.Flags().String(flagProposalType, "", "proposalType of proposal, types: text/parameter_change/software_upgrade")
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
+	cmd.Flags().StringArray(flagParamChange, nil, "parameter change of a parameter_change proposal, formatted as subspace/key=value")
 	cmd.Flags().String(flagProposal, "", "proposal file path (if this path is given, other proposal flags are ignored)")
 
 	return cmd
@@ -54,12 +54,13 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 
 // PostProposalReq defines the properties of a proposal request's body.
 type PostProposalReq struct {
-	BaseReq        rest.BaseReq   `json:"base_req"`
-	Title          string         `json:"title"`           // Title of the proposal
-	Description    string         `json:"description"`     // Description of the proposal
-	ProposalType   string         `json:"proposal_type"`   // Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress `json:"proposer"`        // Address of the proposer
-	InitialDeposit sdk.Coins      `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	BaseReq        rest.BaseReq      `json:"base_req"`
+	Title          string            `json:"title"`           // Title of the proposal
+	Description    string            `json:"description"`     // Description of the proposal
+	ProposalType   string            `json:"proposal_type"`   // Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress    `json:"proposer"`        // Address of the proposer
+	InitialDeposit sdk.Coins         `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	ParamChanges   []gov.ParamChange `json:"param_changes"`   // Parameter changes of a ParameterChange proposal
 }
 
 // DepositReq defines the properties of a deposit request's body.
@@ -96,6 +97,9 @@ func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, proposalType, req.Proposer, req.InitialDeposit)
+		if proposalType == gov.ProposalTypeParameterChange {
+			msg = gov.NewMsgSubmitParameterChangeProposal(req.Title, req.Description, req.ParamChanges, req.Proposer, req.InitialDeposit)
+		}
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
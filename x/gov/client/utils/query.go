@@ -6,8 +6,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
-	"github.com/cosmos/cosmos-sdk/x/gov/tags"
 )
 
 const (
@@ -31,7 +31,7 @@ func (p Proposer) String() string {
 	return fmt.Sprintf("Proposal with ID %d was proposed by %s", p.ProposalID, p.Proposer)
 }
 
-// QueryDepositsByTxQuery will query for deposits via a direct txs tags query. It
+// QueryDepositsByTxQuery will query for deposits via a direct txs events query. It
 // will fetch and build deposits directly from the returned txs and return a
 // JSON marshalled result or any error that occurred.
 //
@@ -41,14 +41,14 @@ func QueryDepositsByTxQuery(
 	cdc *codec.Codec, cliCtx context.CLIContext, params gov.QueryProposalParams,
 ) ([]byte, error) {
 
-	tags := []string{
-		fmt.Sprintf("%s='%s'", tags.Action, gov.MsgDeposit{}.Type()),
-		fmt.Sprintf("%s='%s'", tags.ProposalID, []byte(fmt.Sprintf("%d", params.ProposalID))),
+	events := []string{
+		fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeyAction, gov.TypeMsgDeposit),
+		fmt.Sprintf("%s.%s='%d'", gov.EventTypeProposalDeposit, gov.AttributeKeyProposalID, params.ProposalID),
 	}
 
 	// NOTE: SearchTxs is used to facilitate the txs query which does not currently
 	// support configurable pagination.
-	infos, err := tx.SearchTxs(cliCtx, cdc, tags, defaultPage, defaultLimit)
+	infos, err := tx.SearchTxs(cliCtx, cdc, events, defaultPage, defaultLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +76,7 @@ func QueryDepositsByTxQuery(
 	return cdc.MarshalJSON(deposits)
 }
 
-// QueryVotesByTxQuery will query for votes via a direct txs tags query. It
+// QueryVotesByTxQuery will query for votes via a direct txs events query. It
 // will fetch and build votes directly from the returned txs and return a JSON
 // marshalled result or any error that occurred.
 //
@@ -86,14 +86,14 @@ func QueryVotesByTxQuery(
 	cdc *codec.Codec, cliCtx context.CLIContext, params gov.QueryProposalParams,
 ) ([]byte, error) {
 
-	tags := []string{
-		fmt.Sprintf("%s='%s'", tags.Action, gov.MsgVote{}.Type()),
-		fmt.Sprintf("%s='%s'", tags.ProposalID, []byte(fmt.Sprintf("%d", params.ProposalID))),
+	events := []string{
+		fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeyAction, gov.TypeMsgVote),
+		fmt.Sprintf("%s.%s='%d'", gov.EventTypeProposalVote, gov.AttributeKeyProposalID, params.ProposalID),
 	}
 
 	// NOTE: SearchTxs is used to facilitate the txs query which does not currently
 	// support configurable pagination.
-	infos, err := tx.SearchTxs(cliCtx, cdc, tags, defaultPage, defaultLimit)
+	infos, err := tx.SearchTxs(cliCtx, cdc, events, defaultPage, defaultLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -121,20 +121,20 @@ func QueryVotesByTxQuery(
 	return cdc.MarshalJSON(votes)
 }
 
-// QueryVoteByTxQuery will query for a single vote via a direct txs tags query.
+// QueryVoteByTxQuery will query for a single vote via a direct txs events query.
 func QueryVoteByTxQuery(
 	cdc *codec.Codec, cliCtx context.CLIContext, params gov.QueryVoteParams,
 ) ([]byte, error) {
 
-	tags := []string{
-		fmt.Sprintf("%s='%s'", tags.Action, gov.MsgVote{}.Type()),
-		fmt.Sprintf("%s='%s'", tags.ProposalID, []byte(fmt.Sprintf("%d", params.ProposalID))),
-		fmt.Sprintf("%s='%s'", tags.Sender, []byte(params.Voter.String())),
+	events := []string{
+		fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeyAction, gov.TypeMsgVote),
+		fmt.Sprintf("%s.%s='%d'", gov.EventTypeProposalVote, gov.AttributeKeyProposalID, params.ProposalID),
+		fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeySender, params.Voter),
 	}
 
 	// NOTE: SearchTxs is used to facilitate the txs query which does not currently
 	// support configurable pagination.
-	infos, err := tx.SearchTxs(cliCtx, cdc, tags, defaultPage, defaultLimit)
+	infos, err := tx.SearchTxs(cliCtx, cdc, events, defaultPage, defaultLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -163,21 +163,21 @@ func QueryVoteByTxQuery(
 	return nil, fmt.Errorf("address '%s' did not vote on proposalID %d", params.Voter, params.ProposalID)
 }
 
-// QueryDepositByTxQuery will query for a single deposit via a direct txs tags
+// QueryDepositByTxQuery will query for a single deposit via a direct txs events
 // query.
 func QueryDepositByTxQuery(
 	cdc *codec.Codec, cliCtx context.CLIContext, params gov.QueryDepositParams,
 ) ([]byte, error) {
 
-	tags := []string{
-		fmt.Sprintf("%s='%s'", tags.Action, gov.MsgDeposit{}.Type()),
-		fmt.Sprintf("%s='%s'", tags.ProposalID, []byte(fmt.Sprintf("%d", params.ProposalID))),
-		fmt.Sprintf("%s='%s'", tags.Sender, []byte(params.Depositor.String())),
+	events := []string{
+		fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeyAction, gov.TypeMsgDeposit),
+		fmt.Sprintf("%s.%s='%d'", gov.EventTypeProposalDeposit, gov.AttributeKeyProposalID, params.ProposalID),
+		fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeySender, params.Depositor),
 	}
 
 	// NOTE: SearchTxs is used to facilitate the txs query which does not currently
 	// support configurable pagination.
-	infos, err := tx.SearchTxs(cliCtx, cdc, tags, defaultPage, defaultLimit)
+	infos, err := tx.SearchTxs(cliCtx, cdc, events, defaultPage, defaultLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -212,14 +212,14 @@ func QueryProposerByTxQuery(
 	cdc *codec.Codec, cliCtx context.CLIContext, proposalID uint64,
 ) (Proposer, error) {
 
-	tags := []string{
-		fmt.Sprintf("%s='%s'", tags.Action, gov.MsgSubmitProposal{}.Type()),
-		fmt.Sprintf("%s='%s'", tags.ProposalID, []byte(fmt.Sprintf("%d", proposalID))),
+	events := []string{
+		fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeyAction, gov.TypeMsgSubmitProposal),
+		fmt.Sprintf("%s.%s='%d'", gov.EventTypeSubmitProposal, gov.AttributeKeyProposalID, proposalID),
 	}
 
 	// NOTE: SearchTxs is used to facilitate the txs query which does not currently
 	// support configurable pagination.
-	infos, err := tx.SearchTxs(cliCtx, cdc, tags, defaultPage, defaultLimit)
+	infos, err := tx.SearchTxs(cliCtx, cdc, events, defaultPage, defaultLimit)
 	if err != nil {
 		return Proposer{}, err
 	}
@@ -15,7 +15,7 @@ func NormalizeVoteOption(option string) string {
 	return ""
 }
 
-//NormalizeProposalType - normalize user specified proposal type
+// NormalizeProposalType - normalize user specified proposal type
 func NormalizeProposalType(proposalType string) string {
 	switch proposalType {
 	case "Text", "text":
@@ -28,7 +28,7 @@ func NormalizeProposalType(proposalType string) string {
 	return ""
 }
 
-//NormalizeProposalStatus - normalize user specified proposal status
+// NormalizeProposalStatus - normalize user specified proposal status
 func NormalizeProposalStatus(status string) string {
 	switch status {
 	case "DepositPeriod", "deposit_period":
@@ -39,6 +39,8 @@ func NormalizeProposalStatus(status string) string {
 		return "Passed"
 	case "Rejected", "rejected":
 		return "Rejected"
+	case "Failed", "failed":
+		return "Failed"
 	}
 	return ""
 }
@@ -3,14 +3,15 @@ package gov
 import (
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/gov/tags"
 )
 
-// Called every block, process inflation, update validator set
-func EndBlocker(ctx sdk.Context, keeper Keeper) sdk.Tags {
+// Called every block, process inflation, update validator set. Once the gas
+// meter of the context is out of gas, the remaining proposals of a queue are
+// left to the next block, at least one proposal of each queue being processed.
+func EndBlocker(ctx sdk.Context, keeper Keeper) {
 	logger := ctx.Logger().With("module", "x/gov")
-	resTags := sdk.NewTags()
 
 	inactiveIterator := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
 	defer inactiveIterator.Close()
@@ -26,8 +27,13 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) sdk.Tags {
 		keeper.DeleteProposal(ctx, proposalID)
 		keeper.DeleteDeposits(ctx, proposalID) // delete any associated deposits (burned)
 
-		resTags = resTags.AppendTag(tags.ProposalID, fmt.Sprintf("%d", proposalID))
-		resTags = resTags.AppendTag(tags.ProposalResult, tags.ActionProposalDropped)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				EventTypeInactiveProposal,
+				sdk.NewAttribute(AttributeKeyProposalID, fmt.Sprintf("%d", proposalID)),
+				sdk.NewAttribute(AttributeKeyProposalResult, AttributeValueProposalDropped),
+			),
+		)
 
 		logger.Info(
 			fmt.Sprintf("proposal %d (%s) didn't meet minimum deposit of %s (had only %s); deleted",
@@ -37,6 +43,10 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) sdk.Tags {
 				inactiveProposal.TotalDeposit,
 			),
 		)
+
+		if ctx.GasMeter().IsOutOfGas() {
+			break
+		}
 	}
 
 	// fetch active proposals whose voting periods have ended (are passed the block time)
@@ -52,15 +62,26 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) sdk.Tags {
 		}
 		passes, tallyResults := tally(ctx, keeper, activeProposal)
 
-		var tagValue string
+		var result string
 		if passes {
 			keeper.RefundDeposits(ctx, activeProposal.ProposalID)
 			activeProposal.Status = StatusPassed
-			tagValue = tags.ActionProposalPassed
+			result = AttributeValueProposalPassed
+
+			if err := executeProposal(ctx, keeper, activeProposal); err != nil {
+				activeProposal.Status = StatusFailed
+				result = AttributeValueProposalFailed
+
+				logger.Info(
+					fmt.Sprintf("proposal %d (%s) passed but failed on execution: %s",
+						activeProposal.ProposalID, activeProposal.GetTitle(), err.Error(),
+					),
+				)
+			}
 		} else {
 			keeper.DeleteDeposits(ctx, activeProposal.ProposalID)
 			activeProposal.Status = StatusRejected
-			tagValue = tags.ActionProposalRejected
+			result = AttributeValueProposalRejected
 		}
 
 		activeProposal.FinalTallyResult = tallyResults
@@ -74,9 +95,46 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) sdk.Tags {
 			),
 		)
 
-		resTags = resTags.AppendTag(tags.ProposalID, fmt.Sprintf("%d", proposalID))
-		resTags = resTags.AppendTag(tags.ProposalResult, tagValue)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				EventTypeActiveProposal,
+				sdk.NewAttribute(AttributeKeyProposalID, fmt.Sprintf("%d", proposalID)),
+				sdk.NewAttribute(AttributeKeyProposalResult, result),
+			),
+		)
+
+		if ctx.GasMeter().IsOutOfGas() {
+			break
+		}
 	}
 
-	return resTags
+	if telemetry.Enabled() {
+		recordQueueSizes(ctx, keeper)
+	}
+}
+
+// recordQueueSizes records the sizes of the proposal queues. It iterates over
+// the queues, hence it is only called when telemetry is enabled, and does not
+// consume the gas of the block.
+func recordQueueSizes(ctx sdk.Context, keeper Keeper) {
+	inactive, active := keeper.GetProposalQueueSizes(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()))
+	queueSize := telemetry.Default().QueueSize
+	queueSize.With("module", ModuleName, "queue", "inactive_proposal").Set(float64(inactive))
+	queueSize.With("module", ModuleName, "queue", "active_proposal").Set(float64(active))
+}
+
+// executeProposal applies the content of a passed proposal, either all of its
+// changes are written or none of them
+func executeProposal(ctx sdk.Context, keeper Keeper, proposal Proposal) sdk.Error {
+	content, ok := proposal.ProposalContent.(ParameterChangeProposal)
+	if !ok {
+		return nil
+	}
+
+	cacheCtx, writeCache := ctx.CacheContext()
+	if err := keeper.ApplyParamChanges(cacheCtx, content.Changes); err != nil {
+		return err
+	}
+	writeCache()
+	return nil
 }
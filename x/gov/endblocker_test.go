@@ -9,6 +9,8 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/staking"
 )
 
 func TestTickExpiredDepositPeriod(t *testing.T) {
@@ -117,6 +119,39 @@ func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
 	inactiveQueue.Close()
 }
 
+func TestTickExpiredDepositPeriodOutOfGas(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 10, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.ck.SetSendEnabled(ctx, true)
+	govHandler := NewHandler(keeper)
+
+	for i := 0; i < 2; i++ {
+		newProposalMsg := NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[i], sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)})
+		res := govHandler(ctx, newProposalMsg)
+		require.True(t, res.IsOK())
+	}
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetDepositParams(ctx).MaxDepositPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+
+	// a single proposal is processed per block once out of gas
+	EndBlocker(ctx.WithGasMeter(sdk.NewSoftGasMeter(1)), keeper)
+	_, ok := keeper.GetProposal(ctx, 1)
+	require.False(t, ok)
+	_, ok = keeper.GetProposal(ctx, 2)
+	require.True(t, ok)
+
+	EndBlocker(ctx.WithGasMeter(sdk.NewSoftGasMeter(1)), keeper)
+	inactiveQueue := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, inactiveQueue.Valid())
+	inactiveQueue.Close()
+}
+
 func TestTickPassedDepositPeriod(t *testing.T) {
 	mapp, keeper, _, addrs, _, _ := getMockApp(t, 10, GenesisState{}, nil)
 
@@ -222,3 +257,93 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	require.False(t, activeQueue.Valid())
 	activeQueue.Close()
 }
+
+func TestParameterChangeProposalExecution(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	stakingHandler := staking.NewHandler(sk)
+
+	valAddrs := []sdk.ValAddress{sdk.ValAddress(addrs[0]), sdk.ValAddress(addrs[1])}
+	createValidators(t, stakingHandler, ctx, valAddrs, []int64{5, 5})
+	staking.EndBlocker(ctx, sk)
+
+	passProposal := func(content ProposalContent) Proposal {
+		proposal, err := keeper.SubmitProposal(ctx, content)
+		require.NoError(t, err)
+		proposal.Status = StatusVotingPeriod
+		proposal.VotingEndTime = ctx.BlockHeader().Time
+		keeper.SetProposal(ctx, proposal)
+		keeper.InsertActiveProposalQueue(ctx, proposal.VotingEndTime, proposal.ProposalID)
+
+		require.NoError(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+		require.NoError(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionYes))
+
+		EndBlocker(ctx, keeper)
+
+		proposal, ok := keeper.GetProposal(ctx, proposal.ProposalID)
+		require.True(t, ok)
+		return proposal
+	}
+
+	// a passed proposal sets the new parameter values
+	proposal := passProposal(NewParameterChangeProposal("Test", "description", []ParamChange{
+		NewParamChange(auth.DefaultParamspace, string(auth.KeyMaxMemoCharacters), `"500"`),
+	}))
+	require.Equal(t, StatusPassed, proposal.Status)
+	require.Equal(t, uint64(500), mapp.AccountKeeper.GetParams(ctx).MaxMemoCharacters)
+
+	// a proposal failing on execution does not apply any of its changes
+	proposal = passProposal(NewParameterChangeProposal("Test", "description", []ParamChange{
+		NewParamChange(auth.DefaultParamspace, string(auth.KeyMaxMemoCharacters), `"700"`),
+		NewParamChange(auth.DefaultParamspace, "UnknownKey", `"1"`),
+	}))
+	require.Equal(t, StatusFailed, proposal.Status)
+	require.Equal(t, uint64(500), mapp.AccountKeeper.GetParams(ctx).MaxMemoCharacters)
+}
+
+func TestSubmitParameterChangeProposal(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 10, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	deposit := sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)}
+
+	msg := NewMsgSubmitParameterChangeProposal("Test", "test", []ParamChange{
+		NewParamChange(auth.DefaultParamspace, string(auth.KeyTxSigLimit), `"10"`),
+	}, addrs[0], deposit)
+	res := govHandler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	var proposalID uint64
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(res.Data, &proposalID)
+	proposal, ok := keeper.GetProposal(ctx, proposalID)
+	require.True(t, ok)
+	require.Equal(t, ProposalTypeParameterChange, proposal.ProposalType())
+	require.Equal(t, msg.ParamChanges, proposal.ProposalContent.(ParameterChangeProposal).Changes)
+
+	// submitting does not change the parameter
+	require.Equal(t, auth.DefaultTxSigLimit, mapp.AccountKeeper.GetParams(ctx).TxSigLimit)
+
+	// proposals without changes and changes that cannot be applied are
+	// rejected on submission
+	msg = NewMsgSubmitParameterChangeProposal("Test", "test", nil, addrs[0], deposit)
+	res = govHandler(ctx, msg)
+	require.Equal(t, CodeInvalidParamChange, res.Code)
+
+	for _, change := range []ParamChange{
+		NewParamChange("unknown", string(auth.KeyTxSigLimit), `"10"`),
+		NewParamChange(auth.DefaultParamspace, "UnknownKey", `"10"`),
+		NewParamChange(auth.DefaultParamspace, string(auth.KeyTxSigLimit), `"ten"`),
+	} {
+		msg := NewMsgSubmitParameterChangeProposal("Test", "test", []ParamChange{change}, addrs[0], deposit)
+		res := govHandler(ctx, msg)
+		require.Equal(t, CodeInvalidParamChange, res.Code, change.String())
+	}
+}
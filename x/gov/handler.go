@@ -4,24 +4,27 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/gov/tags"
 )
 
-// Handle all "gov" type messages.
+// NewHandler returns a handler for "gov" type messages.
 func NewHandler(keeper Keeper) sdk.Handler {
-	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
-		switch msg := msg.(type) {
-		case MsgDeposit:
-			return handleMsgDeposit(ctx, keeper, msg)
-		case MsgSubmitProposal:
-			return handleMsgSubmitProposal(ctx, keeper, msg)
-		case MsgVote:
-			return handleMsgVote(ctx, keeper, msg)
-		default:
-			errMsg := fmt.Sprintf("Unrecognized gov msg type: %T", msg)
-			return sdk.ErrUnknownRequest(errMsg).Result()
-		}
-	}
+	router := sdk.NewMsgRouter()
+	RegisterMsgHandlers(router, keeper)
+	return sdk.NewMsgRouterHandler(router)
+}
+
+// RegisterMsgHandlers registers the handlers of the gov messages.
+func RegisterMsgHandlers(router sdk.MsgRouter, keeper Keeper) {
+	router.
+		AddMsgHandler(MsgDeposit{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgDeposit(ctx, keeper, msg.(MsgDeposit))
+		}).
+		AddMsgHandler(MsgSubmitProposal{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgSubmitProposal(ctx, keeper, msg.(MsgSubmitProposal))
+		}).
+		AddMsgHandler(MsgVote{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgVote(ctx, keeper, msg.(MsgVote))
+		})
 }
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
@@ -31,6 +34,11 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitPropos
 		content = NewTextProposal(msg.Title, msg.Description)
 	case ProposalTypeSoftwareUpgrade:
 		content = NewSoftwareUpgradeProposal(msg.Title, msg.Description)
+	case ProposalTypeParameterChange:
+		if err := keeper.validateParamChanges(ctx, msg.ParamChanges); err != nil {
+			return err.Result()
+		}
+		content = NewParameterChangeProposal(msg.Title, msg.Description, msg.ParamChanges)
 	default:
 		return ErrInvalidProposalType(keeper.codespace, msg.ProposalType).Result()
 	}
@@ -46,19 +54,23 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitPropos
 		return err.Result()
 	}
 
-	resTags := sdk.NewTags(
-		tags.ProposalID, proposalIDStr,
-		tags.Category, tags.TxCategory,
-		tags.Sender, msg.Proposer.String(),
-	)
-
+	submitEvent := sdk.NewEvent(EventTypeSubmitProposal, sdk.NewAttribute(AttributeKeyProposalID, proposalIDStr))
 	if votingStarted {
-		resTags = resTags.AppendTag(tags.VotingPeriodStart, proposalIDStr)
+		submitEvent = submitEvent.AppendAttributes(sdk.NewAttribute(AttributeKeyVotingPeriodStart, proposalIDStr))
 	}
 
+	ctx.EventManager().EmitEvents(sdk.Events{
+		submitEvent,
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Proposer.String()),
+		),
+	})
+
 	return sdk.Result{
-		Data: keeper.cdc.MustMarshalBinaryLengthPrefixed(proposalID),
-		Tags: resTags,
+		Data:   keeper.cdc.MustMarshalBinaryLengthPrefixed(proposalID),
+		Events: ctx.EventManager().Events(),
 	}
 }
 
@@ -70,19 +82,25 @@ func handleMsgDeposit(ctx sdk.Context, keeper Keeper, msg MsgDeposit) sdk.Result
 
 	proposalIDStr := fmt.Sprintf("%d", msg.ProposalID)
 
-	resTags := sdk.NewTags(
-		tags.ProposalID, proposalIDStr,
-		tags.Category, tags.TxCategory,
-		tags.Sender, msg.Depositor.String(),
+	depositEvent := sdk.NewEvent(
+		EventTypeProposalDeposit,
+		sdk.NewAttribute(AttributeKeyProposalID, proposalIDStr),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
 	)
-
 	if votingStarted {
-		resTags = resTags.AppendTag(tags.VotingPeriodStart, proposalIDStr)
+		depositEvent = depositEvent.AppendAttributes(sdk.NewAttribute(AttributeKeyVotingPeriodStart, proposalIDStr))
 	}
 
-	return sdk.Result{
-		Tags: resTags,
-	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		depositEvent,
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Depositor.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
@@ -93,11 +111,18 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 
 	proposalIDStr := fmt.Sprintf("%d", msg.ProposalID)
 
-	return sdk.Result{
-		Tags: sdk.NewTags(
-			tags.ProposalID, proposalIDStr,
-			tags.Category, tags.TxCategory,
-			tags.Sender, msg.Voter.String(),
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeProposalVote,
+			sdk.NewAttribute(AttributeKeyProposalID, proposalIDStr),
+			sdk.NewAttribute(AttributeKeyOption, msg.Option.String()),
 		),
-	}
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Voter.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
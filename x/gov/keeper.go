@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"fmt"
 	"time"
 
 	codec "github.com/cosmos/cosmos-sdk/codec"
@@ -120,6 +121,31 @@ func (keeper Keeper) SubmitProposal(ctx sdk.Context, content ProposalContent) (p
 	return
 }
 
+// ApplyParamChanges sets the new values of the changed parameters in their
+// subspaces, the caller is responsible for discarding partial changes on error
+func (keeper Keeper) ApplyParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	for _, change := range changes {
+		space, ok := keeper.paramsKeeper.GetSubspace(change.Subspace)
+		if !ok {
+			return ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("Unknown parameter subspace %s", change.Subspace))
+		}
+		if err := space.Update(ctx, []byte(change.Key), []byte(change.Value)); err != nil {
+			return ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("Invalid parameter change %s: %s", change, err))
+		}
+	}
+	return nil
+}
+
+// validateParamChanges checks the changes can be applied on the current state
+// without persisting them
+func (keeper Keeper) validateParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	if len(changes) == 0 {
+		return ErrInvalidParamChange(keeper.codespace, "No parameter changes present in proposal")
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	return keeper.ApplyParamChanges(cacheCtx, changes)
+}
+
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID uint64) (proposal Proposal, ok bool) {
 	store := ctx.KVStore(keeper.storeKey)
@@ -493,3 +519,24 @@ func (keeper Keeper) RemoveFromInactiveProposalQueue(ctx sdk.Context, endTime ti
 	store := ctx.KVStore(keeper.storeKey)
 	store.Delete(KeyInactiveProposalQueueProposal(endTime, proposalID))
 }
+
+// GetProposalQueueSizes returns the number of proposals in the inactive
+// proposal queue, i.e. in their deposit period, and in the active proposal
+// queue, i.e. in their voting period.
+func (keeper Keeper) GetProposalQueueSizes(ctx sdk.Context) (inactive, active int) {
+	store := ctx.KVStore(keeper.storeKey)
+
+	inactiveIterator := sdk.KVStorePrefixIterator(store, PrefixInactiveProposalQueue)
+	defer inactiveIterator.Close()
+	for ; inactiveIterator.Valid(); inactiveIterator.Next() {
+		inactive++
+	}
+
+	activeIterator := sdk.KVStorePrefixIterator(store, PrefixActiveProposalQueue)
+	defer activeIterator.Close()
+	for ; activeIterator.Valid(); activeIterator.Next() {
+		active++
+	}
+
+	return inactive, active
+}
@@ -254,6 +254,12 @@ func TestProposalQueues(t *testing.T) {
 	tp := testProposal()
 	proposal, err := keeper.SubmitProposal(ctx, tp)
 	require.NoError(t, err)
+	_, err = keeper.SubmitProposal(ctx, tp)
+	require.NoError(t, err)
+
+	inactive, active := keeper.GetProposalQueueSizes(ctx)
+	require.Equal(t, 2, inactive)
+	require.Equal(t, 0, active)
 
 	inactiveIterator := keeper.InactiveProposalQueueIterator(ctx, proposal.DepositEndTime)
 	require.True(t, inactiveIterator.Valid())
@@ -272,4 +278,8 @@ func TestProposalQueues(t *testing.T) {
 	keeper.cdc.UnmarshalBinaryLengthPrefixed(activeIterator.Value(), &proposalID)
 	require.Equal(t, proposalID, proposal.ProposalID)
 	activeIterator.Close()
+
+	inactive, active = keeper.GetProposalQueueSizes(ctx)
+	require.Equal(t, 1, inactive)
+	require.Equal(t, 1, active)
 }
@@ -0,0 +1,98 @@
+package gov
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ sdk.AppModule            = AppModule{}
+	_ sdk.AppModuleBasic       = AppModuleBasic{}
+	_ sdk.AppModuleMsgHandlers = AppModule{}
+)
+
+// AppModuleBasic defines the basic elements of the gov module.
+type AppModuleBasic struct{}
+
+// Name returns the gov module's name.
+func (AppModuleBasic) Name() string { return ModuleName }
+
+// RegisterCodec registers the gov module's types on the codec.
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) { RegisterCodec(cdc) }
+
+// Msgs returns the gov module's messages.
+func (AppModuleBasic) Msgs() []sdk.Msg {
+	return []sdk.Msg{MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}}
+}
+
+// DefaultGenesis returns the default genesis state of the gov module.
+func (AppModuleBasic) DefaultGenesis(cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// ValidateGenesis validates a genesis state of the gov module.
+func (AppModuleBasic) ValidateGenesis(cdc *codec.Codec, bz json.RawMessage) error {
+	var data GenesisState
+	if err := cdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+	return ValidateGenesis(data)
+}
+
+// AppModule implements the sdk.AppModule interface for the gov module.
+type AppModule struct {
+	AppModuleBasic
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{
+		keeper: keeper,
+	}
+}
+
+// RegisterInvariants registers no invariants, as the gov module has none.
+func (AppModule) RegisterInvariants(sdk.InvariantRouter) {}
+
+// Route returns the gov module's message route.
+func (AppModule) Route() string { return RouterKey }
+
+// NewHandler returns the gov module's message handler.
+func (am AppModule) NewHandler() sdk.Handler { return NewHandler(am.keeper) }
+
+// RegisterMsgHandlers registers the gov module's message handlers.
+func (am AppModule) RegisterMsgHandlers(router sdk.MsgRouter) { RegisterMsgHandlers(router, am.keeper) }
+
+// QuerierRoute returns the gov module's querier route.
+func (AppModule) QuerierRoute() string { return QuerierRoute }
+
+// NewQuerierHandler returns the gov module's querier.
+func (am AppModule) NewQuerierHandler() sdk.Querier { return NewQuerier(am.keeper) }
+
+// InitGenesis initializes the gov module's genesis state.
+func (am AppModule) InitGenesis(ctx sdk.Context, cdc *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	cdc.MustUnmarshalJSON(bz, &data)
+	InitGenesis(ctx, am.keeper, data)
+	return nil
+}
+
+// ExportGenesis exports the gov module's genesis state.
+func (am AppModule) ExportGenesis(ctx sdk.Context, cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(ExportGenesis(ctx, am.keeper))
+}
+
+// BeginBlock runs nothing at the start of a block.
+func (AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {}
+
+// EndBlock ends the voting and deposit periods of the proposals which are
+// due, and executes the passed proposals.
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	EndBlocker(ctx, am.keeper)
+	return nil
+}
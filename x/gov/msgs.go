@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"encoding/json"
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -20,11 +21,12 @@ var _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}
 
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
-	Title          string         `json:"title"`           //  Title of the proposal
-	Description    string         `json:"description"`     //  Description of the proposal
-	ProposalType   ProposalKind   `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins      `json:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive.
+	Title          string         `json:"title"`                   //  Title of the proposal
+	Description    string         `json:"description"`             //  Description of the proposal
+	ProposalType   ProposalKind   `json:"proposal_type"`           //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress `json:"proposer"`                //  Address of the proposer
+	InitialDeposit sdk.Coins      `json:"initial_deposit"`         //  Initial deposit paid by sender. Must be strictly positive.
+	ParamChanges   []ParamChange  `json:"param_changes,omitempty"` //  Parameter changes, only set for ParameterChange proposals
 }
 
 func NewMsgSubmitProposal(title, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
@@ -37,7 +39,13 @@ func NewMsgSubmitProposal(title, description string, proposalType ProposalKind,
 	}
 }
 
-//nolint
+func NewMsgSubmitParameterChangeProposal(title, description string, changes []ParamChange, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
+	msg := NewMsgSubmitProposal(title, description, ProposalTypeParameterChange, proposer, initialDeposit)
+	msg.ParamChanges = changes
+	return msg
+}
+
+// nolint
 func (msg MsgSubmitProposal) Route() string { return RouterKey }
 func (msg MsgSubmitProposal) Type() string  { return TypeMsgSubmitProposal }
 
@@ -58,6 +66,9 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if !validProposalType(msg.ProposalType) {
 		return ErrInvalidProposalType(DefaultCodespace, msg.ProposalType)
 	}
+	if err := validateParamChanges(msg.ProposalType, msg.ParamChanges); err != nil {
+		return err
+	}
 	if msg.Proposer.Empty() {
 		return sdk.ErrInvalidAddress(msg.Proposer.String())
 	}
@@ -80,6 +91,31 @@ func (msg MsgSubmitProposal) GetSignBytes() []byte {
 	return sdk.MustSortJSON(bz)
 }
 
+// parameter changes must be set for, and only for, ParameterChange proposals
+func validateParamChanges(proposalType ProposalKind, changes []ParamChange) sdk.Error {
+	if proposalType != ProposalTypeParameterChange {
+		if len(changes) != 0 {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("Proposal Type '%s' cannot change parameters", proposalType))
+		}
+		return nil
+	}
+
+	// a proposal without changes is valid as before, and is rejected on
+	// submission as it has nothing to execute
+	for _, change := range changes {
+		if len(change.Subspace) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "Parameter change subspace cannot be empty")
+		}
+		if len(change.Key) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "Parameter change key cannot be empty")
+		}
+		if !json.Valid([]byte(change.Value)) {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("Parameter change value for %s/%s is not valid JSON", change.Subspace, change.Key))
+		}
+	}
+	return nil
+}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Proposer}
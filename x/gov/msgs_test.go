@@ -54,6 +54,35 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+func TestMsgSubmitParameterChangeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.NewCoins())
+	tests := []struct {
+		changes    []ParamChange
+		expectPass bool
+	}{
+		{[]ParamChange{NewParamChange("auth", "TxSigLimit", `"10"`)}, true},
+		{[]ParamChange{NewParamChange("auth", "TxSigLimit", `"10"`), NewParamChange("gov", "depositparams", `{"max_deposit_period":"10"}`)}, true},
+		{nil, true},
+		{[]ParamChange{NewParamChange("", "TxSigLimit", `"10"`)}, false},
+		{[]ParamChange{NewParamChange("auth", "", `"10"`)}, false},
+		{[]ParamChange{NewParamChange("auth", "TxSigLimit", `ten`)}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitParameterChangeProposal("Test Proposal", "the purpose of this proposal is to test", tc.changes, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.NoError(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.Error(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// other proposal types cannot carry parameter changes
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.ParamChanges = []ParamChange{NewParamChange("auth", "TxSigLimit", `"10"`)}
+	require.Error(t, msg.ValidateBasic())
+}
+
 func TestMsgDepositGetSignBytes(t *testing.T) {
 	addr := sdk.AccAddress("addr1")
 	msg := NewMsgDeposit(addr, 0, coinsPos)
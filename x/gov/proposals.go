@@ -107,6 +107,45 @@ var _ ProposalContent = SoftwareUpgradeProposal{}
 // nolint
 func (sup SoftwareUpgradeProposal) ProposalType() ProposalKind { return ProposalTypeSoftwareUpgrade }
 
+// ParamChange defines a single parameter change, the value is the JSON encoding
+// of the new parameter value as stored in the params subspace
+type ParamChange struct {
+	Subspace string `json:"subspace"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+}
+
+func NewParamChange(subspace, key, value string) ParamChange {
+	return ParamChange{
+		Subspace: subspace,
+		Key:      key,
+		Value:    value,
+	}
+}
+
+func (pc ParamChange) String() string {
+	return fmt.Sprintf("%s/%s: %s", pc.Subspace, pc.Key, pc.Value)
+}
+
+// Parameter Change Proposals, the changes are applied atomically once the proposal passes
+type ParameterChangeProposal struct {
+	TextProposal
+	Changes []ParamChange `json:"changes"`
+}
+
+func NewParameterChangeProposal(title, description string, changes []ParamChange) ParameterChangeProposal {
+	return ParameterChangeProposal{
+		TextProposal: NewTextProposal(title, description),
+		Changes:      changes,
+	}
+}
+
+// Implements Proposal Interface
+var _ ProposalContent = ParameterChangeProposal{}
+
+// nolint
+func (pcp ParameterChangeProposal) ProposalType() ProposalKind { return ProposalTypeParameterChange }
+
 // ProposalQueue
 type ProposalQueue []uint64
 
@@ -217,6 +256,7 @@ const (
 	StatusVotingPeriod  ProposalStatus = 0x02
 	StatusPassed        ProposalStatus = 0x03
 	StatusRejected      ProposalStatus = 0x04
+	StatusFailed        ProposalStatus = 0x05
 )
 
 // ProposalStatusToString turns a string into a ProposalStatus
@@ -230,6 +270,8 @@ func ProposalStatusFromString(str string) (ProposalStatus, error) {
 		return StatusPassed, nil
 	case "Rejected":
 		return StatusRejected, nil
+	case "Failed":
+		return StatusFailed, nil
 	case "":
 		return StatusNil, nil
 	default:
@@ -242,7 +284,8 @@ func validProposalStatus(status ProposalStatus) bool {
 	if status == StatusDepositPeriod ||
 		status == StatusVotingPeriod ||
 		status == StatusPassed ||
-		status == StatusRejected {
+		status == StatusRejected ||
+		status == StatusFailed {
 		return true
 	}
 	return false
@@ -291,6 +334,8 @@ func (status ProposalStatus) String() string {
 		return "Passed"
 	case StatusRejected:
 		return "Rejected"
+	case StatusFailed:
+		return "Failed"
 	default:
 		return ""
 	}
@@ -214,7 +214,7 @@ func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 
 	if proposal.Status == StatusDepositPeriod {
 		tallyResult = EmptyTallyResult()
-	} else if proposal.Status == StatusPassed || proposal.Status == StatusRejected {
+	} else if proposal.Status == StatusPassed || proposal.Status == StatusRejected || proposal.Status == StatusFailed {
 		tallyResult = proposal.FinalTallyResult
 	} else {
 		// proposal is in voting period
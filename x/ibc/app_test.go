@@ -23,7 +23,7 @@ func getMockApp(t *testing.T) *mock.App {
 	ibcMapper := NewMapper(mapp.Cdc, keyIBC, DefaultCodespace)
 	bankKeeper := bank.NewBaseKeeper(mapp.AccountKeeper,
 		mapp.ParamsKeeper.Subspace(bank.DefaultParamspace),
-		bank.DefaultCodespace)
+		bank.DefaultCodespace, nil)
 	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper, bankKeeper))
 
 	require.NoError(t, mapp.CompleteSetup(keyIBC))
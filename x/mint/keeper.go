@@ -8,7 +8,7 @@ import (
 
 const (
 	// ModuleName is the name of the module
-	ModuleName = "minting"
+	ModuleName = "mint"
 
 	// default paramspace for params keeper
 	DefaultParamspace = "mint"
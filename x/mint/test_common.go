@@ -59,7 +59,7 @@ func newTestInput(t *testing.T) testInput {
 	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams)
 	feeCollectionKeeper := auth.NewFeeCollectionKeeper(cdc, keyFeeCollection)
 	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc, paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
-	bankKeeper := bank.NewBaseKeeper(accountKeeper, paramsKeeper.Subspace(bank.DefaultParamspace), bank.DefaultCodespace)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, paramsKeeper.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, nil)
 	stakingKeeper := staking.NewKeeper(
 		cdc, keyStaking, tkeyStaking, bankKeeper, paramsKeeper.Subspace(staking.DefaultParamspace), staking.DefaultCodespace,
 	)
@@ -1,6 +1,7 @@
 package params
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -216,3 +217,51 @@ func TestSubspace(t *testing.T) {
 		require.Equal(t, kv.param, indirect(kv.ptr), "stored param not equal, tc #%d", i)
 	}
 }
+
+func TestSubspaceUpdate(t *testing.T) {
+	cdc := createTestCodec()
+	key := sdk.NewKVStoreKey("test")
+	tkey := sdk.NewTransientStoreKey("transient_test")
+	ctx := defaultContext(key, tkey)
+	keeper := NewKeeper(cdc, key, tkey)
+
+	table := NewKeyTable(
+		[]byte("uint64"), uint64(0),
+		[]byte("dec"), sdk.Dec{},
+	).RegisterTypeWithValidator([]byte("bounded"), uint64(0), func(value interface{}) error {
+		if value.(uint64) > 100 {
+			return errors.New("value too large")
+		}
+		return nil
+	})
+	space := keeper.Subspace("test").WithKeyTable(table)
+
+	require.NoError(t, space.Update(ctx, []byte("uint64"), []byte(`"10"`)))
+	var u uint64
+	space.Get(ctx, []byte("uint64"), &u)
+	require.Equal(t, uint64(10), u)
+	require.True(t, space.Modified(ctx, []byte("uint64")))
+
+	require.NoError(t, space.Update(ctx, []byte("dec"), []byte(`"0.5"`)))
+	var d sdk.Dec
+	space.Get(ctx, []byte("dec"), &d)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), d)
+
+	// the subspace returned by the keeper shares the registered key table
+	fetched, ok := keeper.GetSubspace("test")
+	require.True(t, ok)
+	require.NoError(t, fetched.Update(ctx, []byte("uint64"), []byte(`"20"`)))
+	space.Get(ctx, []byte("uint64"), &u)
+	require.Equal(t, uint64(20), u)
+
+	require.Error(t, space.Update(ctx, []byte("invalid"), []byte(`"10"`)))
+	require.Error(t, space.Update(ctx, []byte("uint64"), []byte(`"ten"`)))
+	space.Get(ctx, []byte("uint64"), &u)
+	require.Equal(t, uint64(20), u)
+
+	// the values rejected by the validator are not stored
+	require.NoError(t, space.Update(ctx, []byte("bounded"), []byte(`"50"`)))
+	require.Error(t, space.Update(ctx, []byte("bounded"), []byte(`"200"`)))
+	space.Get(ctx, []byte("bounded"), &u)
+	require.Equal(t, uint64(50), u)
+}
@@ -1,6 +1,7 @@
 package subspace
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -195,6 +196,31 @@ func (s Subspace) SetWithSubkey(ctx sdk.Context, key []byte, subkey []byte, para
 	tstore.Set(newkey, []byte{})
 }
 
+// Update decodes a JSON encoded value into the type registered for the key,
+// validates it if the key has a validator and stores it. It returns an error
+// instead of panicking so it can be used on values provided by users, e.g. in
+// governance proposals.
+func (s Subspace) Update(ctx sdk.Context, key, value []byte) error {
+	attr, ok := s.table.m[string(key)]
+	if !ok {
+		return fmt.Errorf("parameter %s not registered in subspace %s", key, s.Name())
+	}
+
+	ptr := reflect.New(attr.ty).Interface()
+	if err := s.cdc.UnmarshalJSON(value, ptr); err != nil {
+		return err
+	}
+
+	if attr.vfn != nil {
+		if err := attr.vfn(reflect.Indirect(reflect.ValueOf(ptr)).Interface()); err != nil {
+			return err
+		}
+	}
+
+	s.Set(ctx, key, ptr)
+	return nil
+}
+
 // Get to ParamSet
 func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
 	for _, pair := range ps.ParamSetPairs() {
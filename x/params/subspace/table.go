@@ -5,9 +5,14 @@ import (
 )
 
 type attribute struct {
-	ty reflect.Type
+	ty  reflect.Type
+	vfn ValueValidatorFn
 }
 
+// ValueValidatorFn validates a parameter value before it is updated, it is
+// given the value itself rather than a pointer to it
+type ValueValidatorFn func(value interface{}) error
+
 // KeyTable subspaces appropriate type for each parameter key
 type KeyTable struct {
 	m map[string]attribute
@@ -43,6 +48,12 @@ func isAlphaNumeric(key []byte) bool {
 
 // Register single key-type pair
 func (t KeyTable) RegisterType(key []byte, ty interface{}) KeyTable {
+	return t.RegisterTypeWithValidator(key, ty, nil)
+}
+
+// RegisterTypeWithValidator registers a key-type pair whose values are checked
+// by the validator when they are updated
+func (t KeyTable) RegisterTypeWithValidator(key []byte, ty interface{}, vfn ValueValidatorFn) KeyTable {
 	if len(key) == 0 {
 		panic("cannot register empty key")
 	}
@@ -62,7 +73,8 @@ func (t KeyTable) RegisterType(key []byte, ty interface{}) KeyTable {
 	}
 
 	t.m[keystr] = attribute{
-		ty: rty,
+		ty:  rty,
+		vfn: vfn,
 	}
 
 	return t
@@ -2,19 +2,21 @@ package slashing
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/slashing/tags"
 )
 
+// NewHandler returns a handler for "slashing" type messages.
 func NewHandler(k Keeper) sdk.Handler {
-	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
-		// NOTE msg already has validate basic run
-		switch msg := msg.(type) {
-		case MsgUnjail:
-			return handleMsgUnjail(ctx, msg, k)
-		default:
-			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
-		}
-	}
+	router := sdk.NewMsgRouter()
+	RegisterMsgHandlers(router, k)
+	return sdk.NewMsgRouterHandler(router)
+}
+
+// RegisterMsgHandlers registers the handlers of the slashing messages.
+func RegisterMsgHandlers(router sdk.MsgRouter, k Keeper) {
+	router.
+		AddMsgHandler(MsgUnjail{}, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return handleMsgUnjail(ctx, msg.(MsgUnjail), k)
+		})
 }
 
 // Validators must submit a transaction to unjail itself after
@@ -60,12 +62,13 @@ func handleMsgUnjail(ctx sdk.Context, msg MsgUnjail, k Keeper) sdk.Result {
 	// unjail the validator
 	k.validatorSet.Unjail(ctx, consAddr)
 
-	tags := sdk.NewTags(
-		tags.Category, tags.TxCategory,
-		tags.Sender, msg.ValidatorAddr.String(),
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.ValidatorAddr.String()),
+		),
 	)
 
-	return sdk.Result{
-		Tags: tags,
-	}
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
@@ -106,6 +106,15 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 	// The fraction is passed in to separately to slash unbonding and rebonding delegations.
 	k.validatorSet.Slash(ctx, consAddr, distributionHeight, power, fraction)
 
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			EventTypeSlash,
+			sdk.NewAttribute(AttributeKeyAddress, consAddr.String()),
+			sdk.NewAttribute(AttributeKeyPower, fmt.Sprintf("%d", power)),
+			sdk.NewAttribute(AttributeKeyReason, AttributeValueDoubleSign),
+		),
+	)
+
 	// Jail validator if not already jailed
 	// begin unbonding validator if not already unbonding (tombstone)
 	if !validator.IsJailed() {
@@ -163,6 +172,15 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 	}
 
 	if missed {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				EventTypeLiveness,
+				sdk.NewAttribute(AttributeKeyAddress, consAddr.String()),
+				sdk.NewAttribute(AttributeKeyMissedBlocks, fmt.Sprintf("%d", signInfo.MissedBlocksCounter)),
+				sdk.NewAttribute(AttributeKeyHeight, fmt.Sprintf("%d", height)),
+			),
+		)
+
 		logger.Info(fmt.Sprintf("Absent validator %s (%v) at height %d, %d missed, threshold %d", addr, pubkey, height, signInfo.MissedBlocksCounter, k.MinSignedPerWindow(ctx)))
 	}
 
@@ -184,6 +202,17 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 			// i.e. at the end of the pre-genesis block (none) = at the beginning of the genesis block.
 			// That's fine since this is just used to filter unbonding delegations & redelegations.
 			distributionHeight := height - sdk.ValidatorUpdateDelay - 1
+
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					EventTypeSlash,
+					sdk.NewAttribute(AttributeKeyAddress, consAddr.String()),
+					sdk.NewAttribute(AttributeKeyPower, fmt.Sprintf("%d", power)),
+					sdk.NewAttribute(AttributeKeyReason, AttributeValueMissingSignature),
+					sdk.NewAttribute(AttributeKeyJailed, consAddr.String()),
+				),
+			)
+
 			k.validatorSet.Slash(ctx, consAddr, distributionHeight, power, k.SlashFractionDowntime(ctx))
 			k.validatorSet.Jail(ctx, consAddr)
 			signInfo.JailedUntil = ctx.BlockHeader().Time.Add(k.DowntimeJailDuration(ctx))
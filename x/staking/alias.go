@@ -120,6 +120,7 @@ const (
 )
 
 const (
+	ModuleName            = types.ModuleName
 	StoreKey              = types.StoreKey
 	TStoreKey             = types.TStoreKey
 	QuerierRoute          = types.QuerierRoute
@@ -132,6 +133,22 @@ const (
 	CodeUnauthorized      = types.CodeUnauthorized
 	CodeInternal          = types.CodeInternal
 	CodeUnknownRequest    = types.CodeUnknownRequest
+
+	EventTypeCompleteUnbonding    = types.EventTypeCompleteUnbonding
+	EventTypeCompleteRedelegation = types.EventTypeCompleteRedelegation
+	EventTypeCreateValidator      = types.EventTypeCreateValidator
+	EventTypeEditValidator        = types.EventTypeEditValidator
+	EventTypeDelegate             = types.EventTypeDelegate
+	EventTypeUnbond               = types.EventTypeUnbond
+	EventTypeRedelegate           = types.EventTypeRedelegate
+	AttributeKeyValidator         = types.AttributeKeyValidator
+	AttributeKeyCommissionRate    = types.AttributeKeyCommissionRate
+	AttributeKeyMinSelfDelegation = types.AttributeKeyMinSelfDelegation
+	AttributeKeySrcValidator      = types.AttributeKeySrcValidator
+	AttributeKeyDstValidator      = types.AttributeKeyDstValidator
+	AttributeKeyDelegator         = types.AttributeKeyDelegator
+	AttributeKeyCompletionTime    = types.AttributeKeyCompletionTime
+	AttributeValueCategory        = types.AttributeValueCategory
 )
 
 var (
@@ -1117,6 +1117,64 @@ func TestMultipleUnbondingDelegationAtUniqueTimes(t *testing.T) {
 	require.False(t, found)
 }
 
+func TestMatureQueuesOutOfGas(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	valAddr := sdk.ValAddress(keep.Addrs[0])
+	valAddr2 := sdk.ValAddress(keep.Addrs[1])
+
+	// set the unbonding time
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 1 * time.Second
+	keeper.SetParams(ctx, params)
+
+	// create the validators
+	valTokens := sdk.TokensFromTendermintPower(10)
+	msgCreateValidator := NewTestMsgCreateValidator(valAddr, keep.PKs[0], valTokens)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	msgCreateValidator = NewTestMsgCreateValidator(valAddr2, keep.PKs[1], valTokens)
+	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	// end block to bond them
+	EndBlocker(ctx, keeper)
+
+	// both validators unbond and redelegate at the same time
+	amt := sdk.NewCoin(sdk.DefaultBondDenom, valTokens.QuoRaw(4))
+	for _, pair := range [][2]sdk.ValAddress{{valAddr, valAddr2}, {valAddr2, valAddr}} {
+		selfDelAddr := sdk.AccAddress(pair[0]) // (the validator is it's own delegator)
+		got = handleMsgUndelegate(ctx, NewMsgUndelegate(selfDelAddr, pair[0], amt), keeper)
+		require.True(t, got.IsOK(), "expected no error, %v", got)
+		got = handleMsgBeginRedelegate(ctx, NewMsgBeginRedelegate(selfDelAddr, pair[0], pair[1], amt), keeper)
+		require.True(t, got.IsOK(), "expected no error, %v", got)
+	}
+	require.Equal(t, 2, keeper.GetUBDQueueSize(ctx))
+	require.Equal(t, 2, keeper.GetRedelegationQueueSize(ctx))
+
+	// once out of gas, a single entry of each queue is completed per block
+	ctx = ctx.WithBlockTime(ctx.BlockHeader().Time.Add(1 * time.Second))
+	EndBlocker(ctx.WithGasMeter(sdk.NewSoftGasMeter(1)), keeper)
+	require.Equal(t, 1, keeper.GetUBDQueueSize(ctx))
+	require.Equal(t, 1, keeper.GetRedelegationQueueSize(ctx))
+	_, found := keeper.GetUnbondingDelegation(ctx, sdk.AccAddress(valAddr), valAddr)
+	require.False(t, found)
+	_, found = keeper.GetUnbondingDelegation(ctx, sdk.AccAddress(valAddr2), valAddr2)
+	require.True(t, found)
+	_, found = keeper.GetRedelegation(ctx, sdk.AccAddress(valAddr), valAddr, valAddr2)
+	require.False(t, found)
+	_, found = keeper.GetRedelegation(ctx, sdk.AccAddress(valAddr2), valAddr2, valAddr)
+	require.True(t, found)
+
+	EndBlocker(ctx.WithGasMeter(sdk.NewSoftGasMeter(1)), keeper)
+	require.Equal(t, 0, keeper.GetUBDQueueSize(ctx))
+	require.Equal(t, 0, keeper.GetRedelegationQueueSize(ctx))
+	_, found = keeper.GetUnbondingDelegation(ctx, sdk.AccAddress(valAddr2), valAddr2)
+	require.False(t, found)
+	_, found = keeper.GetRedelegation(ctx, sdk.AccAddress(valAddr2), valAddr2, valAddr)
+	require.False(t, found)
+}
+
 func TestUnbondingWhenExcessValidators(t *testing.T) {
 	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
 	validatorAddr1 := sdk.ValAddress(keep.Addrs[0])
@@ -238,22 +238,44 @@ func (k Keeper) UBDQueueIterator(ctx sdk.Context, endTime time.Time) sdk.Iterato
 		sdk.InclusiveEndBytes(GetUnbondingDelegationTimeKey(endTime)))
 }
 
-// Returns a concatenated list of all the timeslices inclusively previous to
-// currTime, and deletes the timeslices from the queue
-func (k Keeper) DequeueAllMatureUBDQueue(ctx sdk.Context,
-	currTime time.Time) (matureUnbonds []types.DVPair) {
-
+// Calls fn on the unbonding delegations of the timeslices inclusively previous
+// to currTime, in order, removing them from the queue. It stops once the gas
+// meter of the context is out of gas, the remaining unbonding delegations
+// being left in the queue.
+func (k Keeper) DequeueMatureUBDQueue(ctx sdk.Context, currTime time.Time, fn func(types.DVPair)) {
 	store := ctx.KVStore(k.storeKey)
-	// gets an iterator for all timeslices from time 0 until the current Blockheader time
-	unbondingTimesliceIterator := k.UBDQueueIterator(ctx, ctx.BlockHeader().Time)
+	unbondingTimesliceIterator := k.UBDQueueIterator(ctx, currTime)
+	defer unbondingTimesliceIterator.Close()
 	for ; unbondingTimesliceIterator.Valid(); unbondingTimesliceIterator.Next() {
 		timeslice := []types.DVPair{}
 		value := unbondingTimesliceIterator.Value()
 		k.cdc.MustUnmarshalBinaryLengthPrefixed(value, &timeslice)
-		matureUnbonds = append(matureUnbonds, timeslice...)
+		for i, dvPair := range timeslice {
+			fn(dvPair)
+			if ctx.GasMeter().IsOutOfGas() && i+1 < len(timeslice) {
+				store.Set(unbondingTimesliceIterator.Key(), k.cdc.MustMarshalBinaryLengthPrefixed(timeslice[i+1:]))
+				return
+			}
+		}
 		store.Delete(unbondingTimesliceIterator.Key())
+		if ctx.GasMeter().IsOutOfGas() {
+			return
+		}
+	}
+}
+
+// GetUBDQueueSize returns the number of unbonding delegations in the unbonding
+// queue.
+func (k Keeper) GetUBDQueueSize(ctx sdk.Context) (size int) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, UnbondingQueueKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		timeslice := []types.DVPair{}
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &timeslice)
+		size += len(timeslice)
 	}
-	return matureUnbonds
+	return size
 }
 
 // return a given amount of all the delegator redelegations
@@ -427,20 +449,44 @@ func (k Keeper) RedelegationQueueIterator(ctx sdk.Context, endTime time.Time) sd
 	return store.Iterator(RedelegationQueueKey, sdk.InclusiveEndBytes(GetRedelegationTimeKey(endTime)))
 }
 
-// Returns a concatenated list of all the timeslices inclusively previous to
-// currTime, and deletes the timeslices from the queue
-func (k Keeper) DequeueAllMatureRedelegationQueue(ctx sdk.Context, currTime time.Time) (matureRedelegations []types.DVVTriplet) {
+// Calls fn on the redelegations of the timeslices inclusively previous to
+// currTime, in order, removing them from the queue. It stops once the gas
+// meter of the context is out of gas, the remaining redelegations being left
+// in the queue.
+func (k Keeper) DequeueMatureRedelegationQueue(ctx sdk.Context, currTime time.Time, fn func(types.DVVTriplet)) {
 	store := ctx.KVStore(k.storeKey)
-	// gets an iterator for all timeslices from time 0 until the current Blockheader time
-	redelegationTimesliceIterator := k.RedelegationQueueIterator(ctx, ctx.BlockHeader().Time)
+	redelegationTimesliceIterator := k.RedelegationQueueIterator(ctx, currTime)
+	defer redelegationTimesliceIterator.Close()
 	for ; redelegationTimesliceIterator.Valid(); redelegationTimesliceIterator.Next() {
 		timeslice := []types.DVVTriplet{}
 		value := redelegationTimesliceIterator.Value()
 		k.cdc.MustUnmarshalBinaryLengthPrefixed(value, &timeslice)
-		matureRedelegations = append(matureRedelegations, timeslice...)
+		for i, dvvTriplet := range timeslice {
+			fn(dvvTriplet)
+			if ctx.GasMeter().IsOutOfGas() && i+1 < len(timeslice) {
+				store.Set(redelegationTimesliceIterator.Key(), k.cdc.MustMarshalBinaryLengthPrefixed(timeslice[i+1:]))
+				return
+			}
+		}
 		store.Delete(redelegationTimesliceIterator.Key())
+		if ctx.GasMeter().IsOutOfGas() {
+			return
+		}
+	}
+}
+
+// GetRedelegationQueueSize returns the number of redelegations in the
+// redelegation queue.
+func (k Keeper) GetRedelegationQueueSize(ctx sdk.Context) (size int) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, RedelegationQueueKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		timeslice := []types.DVVTriplet{}
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &timeslice)
+		size += len(timeslice)
 	}
-	return matureRedelegations
+	return size
 }
 
 // Perform a delegation, set/update everything necessary within the store.
@@ -468,7 +514,7 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.In
 	}
 
 	if subtractAccount {
-		_, err := k.bankKeeper.DelegateCoins(ctx, delegation.DelegatorAddress, sdk.Coins{sdk.NewCoin(k.GetParams(ctx).BondDenom, bondAmt)})
+		err := k.bankKeeper.DelegateCoins(ctx, delegation.DelegatorAddress, sdk.Coins{sdk.NewCoin(k.GetParams(ctx).BondDenom, bondAmt)})
 		if err != nil {
 			return sdk.Dec{}, err
 		}
@@ -589,7 +635,7 @@ func (k Keeper) Undelegate(ctx sdk.Context, delAddr sdk.AccAddress,
 	if completeNow {
 		// track undelegation only when remaining or truncated shares are non-zero
 		if !balance.IsZero() {
-			if _, err := k.bankKeeper.UndelegateCoins(ctx, delAddr, sdk.Coins{balance}); err != nil {
+			if err := k.bankKeeper.UndelegateCoins(ctx, delAddr, sdk.Coins{balance}); err != nil {
 				return completionTime, err
 			}
 		}
@@ -629,7 +675,7 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delAddr sdk.AccAddress,
 
 			// track undelegation only when remaining or truncated shares are non-zero
 			if !entry.Balance.IsZero() {
-				_, err := k.bankKeeper.UndelegateCoins(ctx, ubd.DelegatorAddress, sdk.Coins{sdk.NewCoin(k.GetParams(ctx).BondDenom, entry.Balance)})
+				err := k.bankKeeper.UndelegateCoins(ctx, ubd.DelegatorAddress, sdk.Coins{sdk.NewCoin(k.GetParams(ctx).BondDenom, entry.Balance)})
 				if err != nil {
 					return err
 				}
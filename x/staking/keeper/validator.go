@@ -420,6 +420,20 @@ func (k Keeper) GetAllMatureValidatorQueue(ctx sdk.Context, currTime time.Time)
 	return matureValsAddrs
 }
 
+// GetValidatorQueueSize returns the number of unbonding validators in the
+// validator queue.
+func (k Keeper) GetValidatorQueueSize(ctx sdk.Context) (size int) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorQueueKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		timeslice := []sdk.ValAddress{}
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &timeslice)
+		size += len(timeslice)
+	}
+	return size
+}
+
 // Unbonds all the unbonding validators that have finished their unbonding period
 func (k Keeper) UnbondAllMatureValidatorQueue(ctx sdk.Context) {
 	store := ctx.KVStore(k.storeKey)
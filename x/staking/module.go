@@ -0,0 +1,116 @@
+package staking
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+var (
+	_ sdk.AppModule            = AppModule{}
+	_ sdk.AppModuleBasic       = AppModuleBasic{}
+	_ sdk.AppModuleMsgHandlers = AppModule{}
+)
+
+// AppModuleBasic defines the basic elements of the staking module.
+type AppModuleBasic struct{}
+
+// Name returns the staking module's name.
+func (AppModuleBasic) Name() string { return ModuleName }
+
+// RegisterCodec registers the staking module's types on the codec.
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) { RegisterCodec(cdc) }
+
+// Msgs returns the staking module's messages.
+func (AppModuleBasic) Msgs() []sdk.Msg {
+	return []sdk.Msg{types.MsgCreateValidator{}, types.MsgEditValidator{}, types.MsgDelegate{}, types.MsgBeginRedelegate{}, types.MsgUndelegate{}}
+}
+
+// DefaultGenesis returns the default genesis state of the staking module.
+func (AppModuleBasic) DefaultGenesis(cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// ValidateGenesis validates a genesis state of the staking module.
+func (AppModuleBasic) ValidateGenesis(cdc *codec.Codec, bz json.RawMessage) error {
+	var data GenesisState
+	if err := cdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+	return ValidateGenesis(data)
+}
+
+// AppModule implements the sdk.AppModule interface for the staking module.
+type AppModule struct {
+	AppModuleBasic
+	keeper Keeper
+
+	// keepers only used by the invariants
+	feeCollectionKeeper types.FeeCollectionKeeper
+	distrKeeper         types.DistributionKeeper
+	accountKeeper       auth.AccountKeeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(keeper Keeper, feeCollectionKeeper types.FeeCollectionKeeper,
+	distrKeeper types.DistributionKeeper, accountKeeper auth.AccountKeeper) AppModule {
+
+	return AppModule{
+		keeper:              keeper,
+		feeCollectionKeeper: feeCollectionKeeper,
+		distrKeeper:         distrKeeper,
+		accountKeeper:       accountKeeper,
+	}
+}
+
+// RegisterInvariants registers the staking module's invariants.
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRouter) {
+	RegisterInvariants(ir, am.keeper, am.feeCollectionKeeper, am.distrKeeper, am.accountKeeper)
+}
+
+// Route returns the staking module's message route.
+func (AppModule) Route() string { return RouterKey }
+
+// NewHandler returns the staking module's message handler.
+func (am AppModule) NewHandler() sdk.Handler { return NewHandler(am.keeper) }
+
+// RegisterMsgHandlers registers the staking module's message handlers.
+func (am AppModule) RegisterMsgHandlers(router sdk.MsgRouter) { RegisterMsgHandlers(router, am.keeper) }
+
+// QuerierRoute returns the staking module's querier route.
+func (AppModule) QuerierRoute() string { return QuerierRoute }
+
+// NewQuerierHandler returns the staking module's querier.
+func (am AppModule) NewQuerierHandler() sdk.Querier {
+	return NewQuerier(am.keeper, types.MsgCdc)
+}
+
+// InitGenesis initializes the staking module's genesis state and returns the
+// initial validator set.
+func (am AppModule) InitGenesis(ctx sdk.Context, cdc *codec.Codec, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	cdc.MustUnmarshalJSON(bz, &data)
+	validators, err := InitGenesis(ctx, am.keeper, data)
+	if err != nil {
+		panic(err)
+	}
+	return validators
+}
+
+// ExportGenesis exports the staking module's genesis state.
+func (am AppModule) ExportGenesis(ctx sdk.Context, cdc *codec.Codec) json.RawMessage {
+	return cdc.MustMarshalJSON(ExportGenesis(ctx, am.keeper))
+}
+
+// BeginBlock runs nothing at the start of a block.
+func (AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {}
+
+// EndBlock returns the validator set updates of the block.
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	return EndBlocker(ctx, am.keeper)
+}
@@ -112,6 +112,12 @@ func (msg *MsgCreateValidator) UnmarshalJSON(bz []byte) error {
 	return nil
 }
 
+// JSONRepr implements the codec.JSONRepr interface, as the JSON schema of the
+// MsgCreateValidator type is the one of its custom JSON serialization.
+func (msg MsgCreateValidator) JSONRepr() interface{} {
+	return msgCreateValidatorJSON{}
+}
+
 // GetSignBytes returns the message bytes to sign over.
 func (msg MsgCreateValidator) GetSignBytes() []byte {
 	bz := MsgCdc.MustMarshalJSON(msg)